@@ -66,118 +66,108 @@ const docTemplate = `{
                 }
             }
         },
-        "/tasks": {
+        "/caldav/calendars/{workspaceID}/{name}": {
             "get": {
                 "security": [
                     {
                         "BasicAuth": []
                     }
                 ],
+                "description": "The task as an iCalendar VTODO, for CalDAV clients",
+                "produces": [
+                    "text/calendar"
+                ],
                 "tags": [
-                    "Tasks"
+                    "CalDAV"
                 ],
-                "summary": "Get Tasks",
-                "operationId": "GetTasks",
+                "summary": "Get CalDAV Task",
+                "operationId": "GetCalendarObject",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "cursor for forward pagination",
-                        "name": "cursor",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "maximum number of tasks to return",
-                        "name": "per_page",
-                        "in": "query"
+                        "description": "workspace id",
+                        "name": "workspaceID",
+                        "in": "path",
+                        "required": true
                     },
                     {
-                        "enum": [
-                            "completed",
-                            "pending"
-                        ],
                         "type": "string",
-                        "description": "filter by task status",
-                        "name": "status",
-                        "in": "query"
+                        "description": "resource name, the task's UID followed by .ics",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/app.SuccessResponse"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/app.CreateTaskResponse"
-                                        },
-                                        "paging": {
-                                            "$ref": "#/definitions/app.PaginationData"
-                                        }
-                                    }
-                                }
-                            ]
+                            "type": "string"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/app.ErrorResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/app.ErrorResponse"
                         }
                     }
                 }
             },
-            "post": {
+            "put": {
                 "security": [
                     {
-                        "ApiKeyAuth": []
+                        "BasicAuth": []
                     }
                 ],
+                "description": "Creates or replaces a task from an iCalendar object with a single VTODO. The summary, description, due\ndate, priority, categories, recurrence rule and status are kept, categories become tags. Tasks are created\noutside of projects, the resource name of new tasks must be their UID followed by .ics. A task in the trash\nwith the same UID is restored and replaced.",
+                "consumes": [
+                    "text/calendar"
+                ],
                 "tags": [
-                    "Tasks"
+                    "CalDAV"
                 ],
-                "summary": "Create Task",
-                "operationId": "CreateTasks",
+                "summary": "Put CalDAV Task",
+                "operationId": "PutCalendarObject",
                 "parameters": [
                     {
-                        "description": "request body",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/app.CreateTaskRequest"
-                        }
+                        "type": "integer",
+                        "description": "workspace id",
+                        "name": "workspaceID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "resource name, the task's UID followed by .ics",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "only replace the task if it is still at this ETag",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "* to only create the task",
+                        "name": "If-None-Match",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "201": {
-                        "description": "Created",
-                        "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/app.SuccessResponse"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/app.CreateTaskResponse"
-                                        }
-                                    }
-                                }
-                            ]
-                        }
+                        "description": "Created"
+                    },
+                    "204": {
+                        "description": "No Content"
                     },
                     "400": {
                         "description": "Bad Request",
@@ -190,29 +180,65 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/app.ErrorResponse"
                         }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
                     }
                 }
-            }
-        },
-        "/tasks/{id}": {
+            },
             "delete": {
                 "security": [
                     {
                         "BasicAuth": []
                     }
                 ],
+                "description": "Moves the task to the trash",
                 "tags": [
-                    "Tasks"
+                    "CalDAV"
                 ],
-                "summary": "Delete Tasks",
-                "operationId": "DeleteTasks",
+                "summary": "Delete CalDAV Task",
+                "operationId": "DeleteCalendarObject",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "task id",
-                        "name": "id",
+                        "description": "workspace id",
+                        "name": "workspaceID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "resource name, the task's UID followed by .ics",
+                        "name": "name",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "only delete the task if it is still at this ETag",
+                        "name": "If-Match",
+                        "in": "header"
                     }
                 ],
                 "responses": {
@@ -225,46 +251,51 @@ const docTemplate = `{
                             "$ref": "#/definitions/app.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/app.ErrorResponse"
                         }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
                     }
                 }
-            },
-            "patch": {
+            }
+        },
+        "/calendar/feed": {
+            "post": {
                 "security": [
                     {
                         "BasicAuth": []
                     }
                 ],
+                "description": "Returns a secret url that calendar apps can subscribe to, listing the tasks with due dates the user can\nsee in the workspace. Creating a feed again replaces the url, the old one stops working. Tasks are\nVTODO items, add ?components=vevent to the url to get them as events instead, for calendar apps without\nsupport for todos.",
                 "tags": [
-                    "Tasks"
+                    "Calendar"
                 ],
-                "summary": "Edit Tasks",
-                "operationId": "EditTasks",
+                "summary": "Create Calendar Feed",
+                "operationId": "CreateCalendarFeed",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "task id",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "request body",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/app.EditTaskResponse"
-                        }
+                        "description": "workspace to act in, defaults to the personal workspace",
+                        "name": "X-Workspace-ID",
+                        "in": "header"
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
                             "allOf": [
                                 {
@@ -274,18 +305,56 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/app.EditTaskResponse"
+                                            "$ref": "#/definitions/app.CalendarFeedResponse"
                                         }
                                     }
                                 }
                             ]
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/app.ErrorResponse"
                         }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Stops the user's feed for the workspace from working",
+                "tags": [
+                    "Calendar"
+                ],
+                "summary": "Delete Calendar Feed",
+                "operationId": "DeleteCalendarFeed",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "workspace to act in, defaults to the personal workspace",
+                        "name": "X-Workspace-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
                     },
                     "401": {
                         "description": "Unauthorized",
@@ -293,6 +362,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/app.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -301,122 +376,6866 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "app.CreateTaskRequest": {
-            "type": "object",
-            "properties": {
-                "description": {
-                    "type": "string"
-                },
-                "title": {
-                    "type": "string"
+        },
+        "/calendar/{token}.ics": {
+            "get": {
+                "description": "The iCalendar feed at the url returned when the feed was created, it needs no other authentication",
+                "produces": [
+                    "text/calendar"
+                ],
+                "tags": [
+                    "Calendar"
+                ],
+                "summary": "Get Calendar Feed",
+                "operationId": "GetCalendarFeed",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "feed token",
+                        "name": "token",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "vtodo",
+                            "vevent"
+                        ],
+                        "type": "string",
+                        "description": "component tasks are listed as",
+                        "name": "components",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    }
                 }
             }
         },
-        "app.CreateTaskResponse": {
-            "type": "object",
-            "properties": {
+        "/imports": {
+            "post": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Imports a todo.txt file (todotxt), a Todoist project CSV (todoist_csv) or backup (todoist_json), or a\nTrello board (trello_json). The file is parsed right away and its tasks are created in the background,\nalong with a project for each of the file's projects. Poll the import to follow its progress.\nPriorities, tags and due dates are kept, Todoist sections, todo.txt contexts and Trello lists and labels\nbecome tags. Tags that aren't valid are dropped and long titles are cut short.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "tags": [
+                    "Imports"
+                ],
+                "summary": "Import from another app",
+                "operationId": "CreateImport",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "workspace to act in, defaults to the personal workspace",
+                        "name": "X-Workspace-ID",
+                        "in": "header"
+                    },
+                    {
+                        "type": "file",
+                        "description": "exported file",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "todotxt",
+                            "todoist_csv",
+                            "todoist_json",
+                            "trello_json"
+                        ],
+                        "type": "string",
+                        "description": "format of the file",
+                        "name": "format",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/app.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/app.ImportResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/imports/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "tags": [
+                    "Imports"
+                ],
+                "summary": "Get Import",
+                "operationId": "GetImport",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "import id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/app.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/app.ImportResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/notifications": {
+            "get": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "tags": [
+                    "Notifications"
+                ],
+                "summary": "Get Notifications",
+                "operationId": "GetNotifications",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "only return unread notifications",
+                        "name": "unread",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "cursor for forward pagination",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "maximum number of notifications to return",
+                        "name": "per_page",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/app.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/app.GetNotificationsResponse"
+                                        },
+                                        "paging": {
+                                            "$ref": "#/definitions/app.PaginationData"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/notifications/preferences": {
+            "get": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "tags": [
+                    "Notifications"
+                ],
+                "summary": "Get Notification Preferences",
+                "operationId": "GetNotificationPreferences",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/app.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/app.NotificationPreferencesResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Turns types of notifications on or off per channel. Preferences that aren't sent are left unchanged, every channel is enabled until it is turned off.",
+                "tags": [
+                    "Notifications"
+                ],
+                "summary": "Edit Notification Preferences",
+                "operationId": "EditNotificationPreferences",
+                "parameters": [
+                    {
+                        "description": "request body",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/app.EditNotificationPreferencesRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/app.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/app.NotificationPreferencesResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/notifications/read": {
+            "post": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "tags": [
+                    "Notifications"
+                ],
+                "summary": "Mark All Notifications Read",
+                "operationId": "MarkAllNotificationsRead",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/app.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/app.MarkAllNotificationsReadResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/notifications/{id}/read": {
+            "post": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "tags": [
+                    "Notifications"
+                ],
+                "summary": "Mark Notification Read",
+                "operationId": "MarkNotificationRead",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "notification id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/app.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/app.MarkNotificationReadResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/projects": {
+            "get": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "tags": [
+                    "Projects"
+                ],
+                "summary": "Get Projects",
+                "operationId": "GetProjects",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "workspace to act in, defaults to the personal workspace",
+                        "name": "X-Workspace-ID",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "cursor for forward pagination",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "maximum number of projects to return",
+                        "name": "per_page",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/app.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/app.GetProjectsResponse"
+                                        },
+                                        "paging": {
+                                            "$ref": "#/definitions/app.PaginationData"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "tags": [
+                    "Projects"
+                ],
+                "summary": "Create Project",
+                "operationId": "CreateProject",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "workspace to act in, defaults to the personal workspace",
+                        "name": "X-Workspace-ID",
+                        "in": "header"
+                    },
+                    {
+                        "description": "request body",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/app.CreateProjectRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/app.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/app.CreateProjectResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/projects/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Deletes a project. Its tasks are kept without a project and go back to the members who created them. Only owners can delete a project.",
+                "tags": [
+                    "Projects"
+                ],
+                "summary": "Delete Project",
+                "operationId": "DeleteProject",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "workspace to act in, defaults to the personal workspace",
+                        "name": "X-Workspace-ID",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "project id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "tags": [
+                    "Projects"
+                ],
+                "summary": "Edit Project",
+                "operationId": "EditProject",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "workspace to act in, defaults to the personal workspace",
+                        "name": "X-Workspace-ID",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "project id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "request body",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/app.EditProjectRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/app.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/app.EditProjectResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/projects/{id}/board": {
+            "get": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Returns the project's tasks grouped into a column per status and ordered by position. Each column holds the first page of its tasks; later pages of a column are fetched by passing its status_id with the column's next cursor. Tasks are moved between columns with the move task endpoint.",
+                "tags": [
+                    "Projects"
+                ],
+                "summary": "Get Project Board",
+                "operationId": "GetProjectBoard",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "workspace to act in, defaults to the personal workspace",
+                        "name": "X-Workspace-ID",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "project id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "only return the column of this status",
+                        "name": "status_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "cursor for the column selected by status_id",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor_position of the column's previous page",
+                        "name": "cursor_position",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "maximum number of tasks to return per column",
+                        "name": "per_page",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/app.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/app.GetBoardResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/projects/{id}/members": {
+            "get": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "tags": [
+                    "Projects"
+                ],
+                "summary": "Get Project Members",
+                "operationId": "GetProjectMembers",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "workspace to act in, defaults to the personal workspace",
+                        "name": "X-Workspace-ID",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "project id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/app.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/app.GetProjectMembersResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Adds a member of the project's workspace to the project by email. Viewers can read the project's tasks, editors can also change them and owners can also manage the project and its members.",
+                "tags": [
+                    "Projects"
+                ],
+                "summary": "Add Project Member",
+                "operationId": "AddProjectMember",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "workspace to act in, defaults to the personal workspace",
+                        "name": "X-Workspace-ID",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "project id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "request body",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/app.AddProjectMemberRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/app.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/app.AddProjectMemberResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/projects/{id}/members/{userID}": {
+            "delete": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Removes a member from the project. Owners can remove anyone and other members can only remove themselves. The last owner of a project can't be removed.",
+                "tags": [
+                    "Projects"
+                ],
+                "summary": "Remove Project Member",
+                "operationId": "RemoveProjectMember",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "workspace to act in, defaults to the personal workspace",
+                        "name": "X-Workspace-ID",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "project id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "user id of the member",
+                        "name": "userID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/statuses": {
+            "get": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Returns the user's statuses, or with project_id the statuses of the project's tasks, which are those of\nthe project's owner",
+                "tags": [
+                    "Statuses"
+                ],
+                "summary": "Get Statuses",
+                "operationId": "GetStatuses",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "workspace to act in, defaults to the personal workspace",
+                        "name": "X-Workspace-ID",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "project whose statuses to return",
+                        "name": "project_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/app.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/app.GetStatusesResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Creates a status in the workspace, each workspace has its own statuses",
+                "tags": [
+                    "Statuses"
+                ],
+                "summary": "Create Status",
+                "operationId": "CreateStatus",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "workspace to act in, defaults to the personal workspace",
+                        "name": "X-Workspace-ID",
+                        "in": "header"
+                    },
+                    {
+                        "description": "request body",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/app.CreateStatusRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/app.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/app.CreateStatusResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/statuses/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Deletes a status that no task uses. The last todo and done statuses can't be deleted.",
+                "tags": [
+                    "Statuses"
+                ],
+                "summary": "Delete Status",
+                "operationId": "DeleteStatus",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "workspace to act in, defaults to the personal workspace",
+                        "name": "X-Workspace-ID",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "status id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Renames or reorders a status. A status' category can't be changed.",
+                "tags": [
+                    "Statuses"
+                ],
+                "summary": "Edit Status",
+                "operationId": "EditStatus",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "workspace to act in, defaults to the personal workspace",
+                        "name": "X-Workspace-ID",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "status id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "request body",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/app.EditStatusRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/app.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/app.EditStatusResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/sync": {
+            "get": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Without since, returns a snapshot of the user's tasks, pulled in pages while has_more is set. With since,\nreturns the tasks created or changed since the token was issued, and tombstones for those deleted since.\nA task is only returned once per page, in its current state.",
+                "tags": [
+                    "sync"
+                ],
+                "summary": "Pull task changes for offline sync",
+                "operationId": "GetSyncChanges",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "workspace to act in, defaults to the personal workspace",
+                        "name": "X-Workspace-ID",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_token of the previous pull",
+                        "name": "since",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/app.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/app.GetSyncChangesResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Applies the mutations in order and reports the outcome of each. Updates and deletes are checked against\nbase_version, when the task has changed since then the change made last, by modified_at and the task's\nupdated_at, wins. A winning update only overwrites the fields in its data.",
+                "tags": [
+                    "sync"
+                ],
+                "summary": "Push offline changes",
+                "operationId": "UploadSyncMutations",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "workspace to act in, defaults to the personal workspace",
+                        "name": "X-Workspace-ID",
+                        "in": "header"
+                    },
+                    {
+                        "description": "request body",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/app.UploadSyncMutationsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/app.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/app.UploadSyncMutationsResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks": {
+            "get": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "tags": [
+                    "Tasks"
+                ],
+                "summary": "Get Tasks",
+                "operationId": "GetTasks",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "workspace to act in, defaults to the personal workspace",
+                        "name": "X-Workspace-ID",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "cursor for forward pagination",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor_position of the previous page when sorted by position",
+                        "name": "cursor_position",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "maximum number of tasks to return",
+                        "name": "per_page",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "completed",
+                            "pending",
+                            "archived",
+                            "todo",
+                            "in_progress",
+                            "done",
+                            "cancelled"
+                        ],
+                        "type": "string",
+                        "description": "filter by task status or status category",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "filter by workflow status",
+                        "name": "status_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "filter by project",
+                        "name": "project_id",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "me"
+                        ],
+                        "type": "string",
+                        "description": "only return tasks assigned to the authenticated user",
+                        "name": "assignee",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "filter by assignee",
+                        "name": "assignee_id",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "me"
+                        ],
+                        "type": "string",
+                        "description": "only return tasks mentioning the authenticated user",
+                        "name": "mentioned",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "filter by tag",
+                        "name": "tag",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "newest",
+                            "position"
+                        ],
+                        "type": "string",
+                        "description": "order tasks newest first or by their manual position",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/app.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/app.CreateTaskResponse"
+                                        },
+                                        "paging": {
+                                            "$ref": "#/definitions/app.PaginationData"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "tags": [
+                    "Tasks"
+                ],
+                "summary": "Create Task",
+                "operationId": "CreateTasks",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "workspace to act in, defaults to the personal workspace",
+                        "name": "X-Workspace-ID",
+                        "in": "header"
+                    },
+                    {
+                        "description": "request body",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/app.CreateTaskRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/app.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/app.CreateTaskResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/events": {
+            "get": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Streams changes to the tasks the user can see in the workspace as server-sent events named task.created, task.updated and task.deleted, with the position of the change as the event id. Reconnecting with the Last-Event-ID header, or the last_event_id query parameter, resumes after that change, otherwise only new changes are sent. A comment is sent as a heartbeat while there are no changes. Browsers can't set headers on an EventSource, so the workspace can also be given as the workspace_id query parameter.",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "Tasks"
+                ],
+                "summary": "Stream Task Events",
+                "operationId": "StreamTaskEvents",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "workspace to act in, defaults to the personal workspace",
+                        "name": "X-Workspace-ID",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "workspace to act in, used when the X-Workspace-ID header isn't set",
+                        "name": "workspace_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "id of the last event received",
+                        "name": "Last-Event-ID",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "id of the last event received, used when the Last-Event-ID header isn't set",
+                        "name": "last_event_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/app.TaskStreamEvent"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/export": {
+            "get": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Streams every task matching the filters, not just one page. The markdown format is a checklist with\nsubtasks nested under their parent tasks, and the tasks of each project under a heading of their own.",
+                "produces": [
+                    "text/csv",
+                    "text/markdown"
+                ],
+                "tags": [
+                    "Tasks"
+                ],
+                "summary": "Export Tasks",
+                "operationId": "ExportTasks",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "workspace to act in, defaults to the personal workspace",
+                        "name": "X-Workspace-ID",
+                        "in": "header"
+                    },
+                    {
+                        "enum": [
+                            "csv",
+                            "markdown"
+                        ],
+                        "type": "string",
+                        "description": "export format",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "completed",
+                            "pending",
+                            "archived",
+                            "todo",
+                            "in_progress",
+                            "done",
+                            "cancelled"
+                        ],
+                        "type": "string",
+                        "description": "filter by task status or status category",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "filter by workflow status",
+                        "name": "status_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "filter by project",
+                        "name": "project_id",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "me"
+                        ],
+                        "type": "string",
+                        "description": "only return tasks assigned to the authenticated user",
+                        "name": "assignee",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "filter by assignee",
+                        "name": "assignee_id",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "me"
+                        ],
+                        "type": "string",
+                        "description": "only return tasks mentioning the authenticated user",
+                        "name": "mentioned",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "filter by tag",
+                        "name": "tag",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "newest",
+                            "position"
+                        ],
+                        "type": "string",
+                        "description": "order tasks newest first or by their manual position",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/import": {
+            "post": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Creates a task for every row of a CSV file, all at once or, if any row is invalid, not at all.\nmapping maps task fields (title, description, is_completed, status_id, project_id, assignee_id, due_at,\npriority, tags, recurrence) to the names of the file's columns, by default the columns named after the fields are used.\nTags are separated by commas and due dates are RFC 3339 timestamps or dates. A dry run only validates the\nrows, returning the tasks that would be created without ids.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "tags": [
+                    "Tasks"
+                ],
+                "summary": "Import Tasks",
+                "operationId": "ImportTasks",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "workspace to act in, defaults to the personal workspace",
+                        "name": "X-Workspace-ID",
+                        "in": "header"
+                    },
+                    {
+                        "type": "file",
+                        "description": "CSV file with a header row",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "JSON object of task fields to column names",
+                        "name": "mapping",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "only validate the rows",
+                        "name": "dry_run",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/app.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/app.ImportTasksResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/app.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/app.ImportTasksResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/app.ImportTasksErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/import/markdown": {
+            "post": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Creates a task for every item of the document's checklists (\"- [ ] task\" or \"- [x] done\"), all at once\nor, if any item is invalid, not at all. Items indented under an item become its subtasks, and other text\nindented under it its description. #tags and due:2006-01-02 dates in an item are taken out of its\ntitle. Headings and other text are skipped, so meeting notes can be imported as they are. Errors are\nreported by line. A dry run only validates the items, returning the tasks that would be created without ids.",
+                "consumes": [
+                    "text/markdown"
+                ],
+                "tags": [
+                    "Tasks"
+                ],
+                "summary": "Import Markdown Tasks",
+                "operationId": "ImportMarkdownTasks",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "workspace to act in, defaults to the personal workspace",
+                        "name": "X-Workspace-ID",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "project to add the tasks to",
+                        "name": "project_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "only validate the items",
+                        "name": "dry_run",
+                        "in": "query"
+                    },
+                    {
+                        "description": "Markdown document",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/app.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/app.ImportTasksResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/app.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/app.ImportTasksResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/app.ImportTasksErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/quick": {
+            "post": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Creates a task from a line of text such as \"Pay rent every month on the 1st #finance !high tomorrow 9am\".\n#tags and priorities (!urgent, !high, !medium, !low or !1 to !4) are taken out of the text, along with the\nfirst due date (today, friday, next monday, in 3 days, oct 30th, 2026-10-30), time of day (9am, 17:30,\nnoon) and recurrence (daily, every 2 weeks, every mon and thu, every month on the 1st). The rest is the\ntask's title. The interpretation is returned along with the task, or on its own for previews.",
+                "tags": [
+                    "Tasks"
+                ],
+                "summary": "Quick Add Task",
+                "operationId": "QuickAddTask",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "workspace to act in, defaults to the personal workspace",
+                        "name": "X-Workspace-ID",
+                        "in": "header"
+                    },
+                    {
+                        "description": "request body",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/app.QuickAddTaskRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/app.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/app.QuickAddTaskResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/app.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/app.QuickAddTaskResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/trash": {
+            "get": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "tags": [
+                    "Tasks"
+                ],
+                "summary": "Get Trashed Tasks",
+                "operationId": "GetTrashedTasks",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "workspace to act in, defaults to the personal workspace",
+                        "name": "X-Workspace-ID",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "cursor for forward pagination",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "maximum number of tasks to return",
+                        "name": "per_page",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/app.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/app.GetTasksResponse"
+                                        },
+                                        "paging": {
+                                            "$ref": "#/definitions/app.PaginationData"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/trash/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Permanently deletes a task from the trash",
+                "tags": [
+                    "Tasks"
+                ],
+                "summary": "Purge Task",
+                "operationId": "PurgeTask",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "workspace to act in, defaults to the personal workspace",
+                        "name": "X-Workspace-ID",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "task id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "only change the task if it is still at this version",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/undo": {
+            "get": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "tags": [
+                    "Tasks"
+                ],
+                "summary": "Get Undo Stack",
+                "operationId": "GetUndoStack",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "workspace to act in, defaults to the personal workspace",
+                        "name": "X-Workspace-ID",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "cursor for forward pagination",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "maximum number of events to return",
+                        "name": "per_page",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/app.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/app.GetUndoStackResponse"
+                                        },
+                                        "paging": {
+                                            "$ref": "#/definitions/app.PaginationData"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Reverts the most recent task change made by the authenticated user",
+                "tags": [
+                    "Tasks"
+                ],
+                "summary": "Undo Last Change",
+                "operationId": "UndoLastChange",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "workspace to act in, defaults to the personal workspace",
+                        "name": "X-Workspace-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/app.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/app.UndoResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Moves the task to the trash. Trashed tasks can be restored until they are purged.",
+                "tags": [
+                    "Tasks"
+                ],
+                "summary": "Delete Tasks",
+                "operationId": "DeleteTasks",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "workspace to act in, defaults to the personal workspace",
+                        "name": "X-Workspace-ID",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "task id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "only change the task if it is still at this version",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "tags": [
+                    "Tasks"
+                ],
+                "summary": "Edit Tasks",
+                "operationId": "EditTasks",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "workspace to act in, defaults to the personal workspace",
+                        "name": "X-Workspace-ID",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "task id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "only change the task if it is still at this version",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "description": "request body",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/app.EditTaskResponse"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/app.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/app.EditTaskResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/{id}/archive": {
+            "post": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "tags": [
+                    "Tasks"
+                ],
+                "summary": "Archive Task",
+                "operationId": "ArchiveTask",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "workspace to act in, defaults to the personal workspace",
+                        "name": "X-Workspace-ID",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "task id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "only change the task if it is still at this version",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/app.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/app.ArchiveTaskResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/{id}/attachments": {
+            "get": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "tags": [
+                    "Attachments"
+                ],
+                "summary": "Get Task Attachments",
+                "operationId": "GetTaskAttachments",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "workspace to act in, defaults to the personal workspace",
+                        "name": "X-Workspace-ID",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "task id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "cursor for forward pagination",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "maximum number of attachments to return",
+                        "name": "per_page",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/app.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/app.GetAttachmentsResponse"
+                                        },
+                                        "paging": {
+                                            "$ref": "#/definitions/app.PaginationData"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Uploads a file to a task. Files are limited in size and type, and count towards the uploader's storage quota.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "tags": [
+                    "Attachments"
+                ],
+                "summary": "Upload Task Attachment",
+                "operationId": "UploadTaskAttachment",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "workspace to act in, defaults to the personal workspace",
+                        "name": "X-Workspace-ID",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "task id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "file to attach",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/app.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/app.UploadAttachmentResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/{id}/attachments/{attachmentID}": {
+            "get": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/octet-stream"
+                ],
+                "tags": [
+                    "Attachments"
+                ],
+                "summary": "Download Task Attachment",
+                "operationId": "DownloadTaskAttachment",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "workspace to act in, defaults to the personal workspace",
+                        "name": "X-Workspace-ID",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "task id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "attachment id",
+                        "name": "attachmentID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Deletes an attachment. Its file is removed from storage in the background.",
+                "tags": [
+                    "Attachments"
+                ],
+                "summary": "Delete Task Attachment",
+                "operationId": "DeleteTaskAttachment",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "workspace to act in, defaults to the personal workspace",
+                        "name": "X-Workspace-ID",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "task id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "attachment id",
+                        "name": "attachmentID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/{id}/comments": {
+            "get": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "tags": [
+                    "Comments"
+                ],
+                "summary": "Get Task Comments",
+                "operationId": "GetTaskComments",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "workspace to act in, defaults to the personal workspace",
+                        "name": "X-Workspace-ID",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "task id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "cursor for forward pagination",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "maximum number of comments to return",
+                        "name": "per_page",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/app.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/app.GetCommentsResponse"
+                                        },
+                                        "paging": {
+                                            "$ref": "#/definitions/app.PaginationData"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "tags": [
+                    "Comments"
+                ],
+                "summary": "Create Task Comment",
+                "operationId": "CreateTaskComment",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "workspace to act in, defaults to the personal workspace",
+                        "name": "X-Workspace-ID",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "task id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "request body",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/app.CreateCommentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/app.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/app.CreateCommentResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/{id}/comments/{commentID}": {
+            "delete": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Deletes a comment. Only the author of a comment can delete it.",
+                "tags": [
+                    "Comments"
+                ],
+                "summary": "Delete Task Comment",
+                "operationId": "DeleteTaskComment",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "workspace to act in, defaults to the personal workspace",
+                        "name": "X-Workspace-ID",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "task id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "comment id",
+                        "name": "commentID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Changes the body of a comment. Only the author of a comment can edit it.",
+                "tags": [
+                    "Comments"
+                ],
+                "summary": "Edit Task Comment",
+                "operationId": "EditTaskComment",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "workspace to act in, defaults to the personal workspace",
+                        "name": "X-Workspace-ID",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "task id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "comment id",
+                        "name": "commentID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "request body",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/app.EditCommentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/app.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/app.EditCommentResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/{id}/history": {
+            "get": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "tags": [
+                    "Tasks"
+                ],
+                "summary": "Get Task History",
+                "operationId": "GetTaskHistory",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "workspace to act in, defaults to the personal workspace",
+                        "name": "X-Workspace-ID",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "task id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "cursor for forward pagination",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "maximum number of events to return",
+                        "name": "per_page",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/app.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/app.GetTaskHistoryResponse"
+                                        },
+                                        "paging": {
+                                            "$ref": "#/definitions/app.PaginationData"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/{id}/move": {
+            "post": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Places a task between two neighbours in the manual order. Either neighbour can be omitted to place the task directly after or before the other one, and omitting both moves it to the end. A status_id moves the task into that status in the same change, e.g. between board columns.",
+                "tags": [
+                    "Tasks"
+                ],
+                "summary": "Move Task",
+                "operationId": "MoveTask",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "workspace to act in, defaults to the personal workspace",
+                        "name": "X-Workspace-ID",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "task id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "only change the task if it is still at this version",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "description": "request body",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/app.MoveTaskRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/app.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/app.MoveTaskResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/{id}/restore": {
+            "post": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "tags": [
+                    "Tasks"
+                ],
+                "summary": "Restore Task",
+                "operationId": "RestoreTask",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "workspace to act in, defaults to the personal workspace",
+                        "name": "X-Workspace-ID",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "task id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "only change the task if it is still at this version",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/app.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/app.RestoreTaskResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/{id}/unarchive": {
+            "post": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "tags": [
+                    "Tasks"
+                ],
+                "summary": "Unarchive Task",
+                "operationId": "UnarchiveTask",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "workspace to act in, defaults to the personal workspace",
+                        "name": "X-Workspace-ID",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "task id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "only change the task if it is still at this version",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/app.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/app.ArchiveTaskResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/{id}/undo": {
+            "post": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Reverts the most recent update to a task or restores it if it was deleted",
+                "tags": [
+                    "Tasks"
+                ],
+                "summary": "Undo Task Change",
+                "operationId": "UndoTask",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "workspace to act in, defaults to the personal workspace",
+                        "name": "X-Workspace-ID",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "task id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/app.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/app.UndoResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/templates": {
+            "get": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "tags": [
+                    "Templates"
+                ],
+                "summary": "Get Templates",
+                "operationId": "GetTemplates",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "workspace to act in, defaults to the personal workspace",
+                        "name": "X-Workspace-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/app.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/app.GetTemplatesResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Saves a task and its subtasks as a template, either as given or, with task_id, copied from a task the user\ncan see along with its subtasks in the same project. Due dates are saved as days after the date the template\nis instantiated for, for saved tasks counted from the task's due date or, if it has none, today.",
+                "tags": [
+                    "Templates"
+                ],
+                "summary": "Create Template",
+                "operationId": "CreateTemplate",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "workspace to act in, defaults to the personal workspace",
+                        "name": "X-Workspace-ID",
+                        "in": "header"
+                    },
+                    {
+                        "description": "request body",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/app.CreateTemplateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/app.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/app.TemplateResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/templates/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "tags": [
+                    "Templates"
+                ],
+                "summary": "Get Template",
+                "operationId": "GetTemplate",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "workspace to act in, defaults to the personal workspace",
+                        "name": "X-Workspace-ID",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "template id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/app.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/app.TemplateResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "tags": [
+                    "Templates"
+                ],
+                "summary": "Delete Template",
+                "operationId": "DeleteTemplate",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "workspace to act in, defaults to the personal workspace",
+                        "name": "X-Workspace-ID",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "template id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "A task replaces the template's task along with all of its subtasks.",
+                "tags": [
+                    "Templates"
+                ],
+                "summary": "Edit Template",
+                "operationId": "EditTemplate",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "workspace to act in, defaults to the personal workspace",
+                        "name": "X-Workspace-ID",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "template id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "request body",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/app.EditTemplateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/app.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/app.TemplateResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/templates/{id}/instantiate": {
+            "post": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Creates the template's task and its subtasks all at once. Tasks are due due_offset days after the anchor\ndate, at their due_time in the time zone if they have one and otherwise all day. A task's checklist is\nadded to the end of its description as unchecked \"- [ ] \" items.",
+                "tags": [
+                    "Templates"
+                ],
+                "summary": "Instantiate Template",
+                "operationId": "InstantiateTemplate",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "workspace to act in, defaults to the personal workspace",
+                        "name": "X-Workspace-ID",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "template id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "request body",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/app.InstantiateTemplateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/app.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/app.InstantiateTemplateResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks": {
+            "get": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Get Webhooks",
+                "operationId": "GetWebhooks",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "workspace to act in, defaults to the personal workspace",
+                        "name": "X-Workspace-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/app.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/app.GetWebhooksResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Registers a url that is sent the chosen events for every task the user can see in the workspace. Deliveries are POSTed as json with the X-Webhook-Event, X-Webhook-Delivery and X-Webhook-Timestamp headers, and signed in X-Webhook-Signature as \"sha256=\" followed by the hex encoded HMAC-SHA256 of the timestamp, a \".\", and the body, keyed with the secret. A secret is generated if none is given, it is only shown once. Changes made by background jobs, such as auto archiving, aren't sent.",
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Create Webhook",
+                "operationId": "CreateWebhook",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "workspace to act in, defaults to the personal workspace",
+                        "name": "X-Workspace-ID",
+                        "in": "header"
+                    },
+                    {
+                        "description": "request body",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/app.CreateWebhookRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/app.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/app.CreateWebhookResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Delete Webhook",
+                "operationId": "DeleteWebhook",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "workspace to act in, defaults to the personal workspace",
+                        "name": "X-Workspace-ID",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "webhook id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Deliveries of an inactive webhook are kept pending until it is activated again.",
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Edit Webhook",
+                "operationId": "EditWebhook",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "workspace to act in, defaults to the personal workspace",
+                        "name": "X-Workspace-ID",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "webhook id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "request body",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/app.EditWebhookRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/app.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/app.EditWebhookResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/{id}/deliveries": {
+            "get": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Get Webhook Deliveries",
+                "operationId": "GetWebhookDeliveries",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "workspace to act in, defaults to the personal workspace",
+                        "name": "X-Workspace-ID",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "webhook id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "cursor for forward pagination",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "maximum number of deliveries to return",
+                        "name": "per_page",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/app.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/app.GetWebhookDeliveriesResponse"
+                                        },
+                                        "paging": {
+                                            "$ref": "#/definitions/app.PaginationData"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/{id}/deliveries/{deliveryID}/redeliver": {
+            "post": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Queues a new delivery with the same payload as an earlier one. The earlier delivery is left as it is in the log.",
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Redeliver Webhook Delivery",
+                "operationId": "RedeliverWebhookDelivery",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "workspace to act in, defaults to the personal workspace",
+                        "name": "X-Workspace-ID",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "webhook id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "delivery id",
+                        "name": "deliveryID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/app.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/app.RedeliverWebhookResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/workspaces": {
+            "get": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "tags": [
+                    "Workspaces"
+                ],
+                "summary": "Get Workspaces",
+                "operationId": "GetWorkspaces",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "cursor for forward pagination",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "maximum number of workspaces to return",
+                        "name": "per_page",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/app.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/app.GetWorkspacesResponse"
+                                        },
+                                        "paging": {
+                                            "$ref": "#/definitions/app.PaginationData"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Creates a workspace owned by the authenticated user. Requests act in a workspace by passing its id in the X-Workspace-ID header.",
+                "tags": [
+                    "Workspaces"
+                ],
+                "summary": "Create Workspace",
+                "operationId": "CreateWorkspace",
+                "parameters": [
+                    {
+                        "description": "request body",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/app.CreateWorkspaceRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/app.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/app.CreateWorkspaceResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/workspaces/invitations/{token}/accept": {
+            "post": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Joins the workspace the invitation is for. Invitations can only be accepted once, before they expire, by the user they were sent to.",
+                "tags": [
+                    "Workspaces"
+                ],
+                "summary": "Accept Workspace Invitation",
+                "operationId": "AcceptWorkspaceInvitation",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "invitation token",
+                        "name": "token",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/app.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/app.AcceptInvitationResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/workspaces/{id}": {
+            "patch": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "tags": [
+                    "Workspaces"
+                ],
+                "summary": "Edit Workspace",
+                "operationId": "EditWorkspace",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "workspace id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "request body",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/app.EditWorkspaceRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/app.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/app.EditWorkspaceResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/workspaces/{id}/invitations": {
+            "get": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "tags": [
+                    "Workspaces"
+                ],
+                "summary": "Get Workspace Invitations",
+                "operationId": "GetWorkspaceInvitations",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "workspace id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/app.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/app.GetInvitationsResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Invites someone to the workspace by email. The returned token is only shown once, whoever signs in with the invited email can join the workspace by accepting it. Only owners can invite owners.",
+                "tags": [
+                    "Workspaces"
+                ],
+                "summary": "Create Workspace Invitation",
+                "operationId": "CreateWorkspaceInvitation",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "workspace id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "request body",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/app.CreateInvitationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/app.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/app.CreateInvitationResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/workspaces/{id}/invitations/{invitationID}": {
+            "delete": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "tags": [
+                    "Workspaces"
+                ],
+                "summary": "Revoke Workspace Invitation",
+                "operationId": "RevokeWorkspaceInvitation",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "workspace id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "invitation id",
+                        "name": "invitationID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/workspaces/{id}/members": {
+            "get": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "tags": [
+                    "Workspaces"
+                ],
+                "summary": "Get Workspace Members",
+                "operationId": "GetWorkspaceMembers",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "workspace id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/app.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/app.GetWorkspaceMembersResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/workspaces/{id}/members/{userID}": {
+            "delete": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Removes a member from the workspace, along with their membership of the workspace's projects, and unassigns them from its tasks. Admins can remove members and admins, only owners can remove owners, and anyone can leave. The last owner of a workspace can't be removed.",
+                "tags": [
+                    "Workspaces"
+                ],
+                "summary": "Remove Workspace Member",
+                "operationId": "RemoveWorkspaceMember",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "workspace id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "user id of the member",
+                        "name": "userID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/ws": {
+            "get": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Clients send JSON messages with a type and an id that is echoed back in the reply.\n\"subscribe\" and \"unsubscribe\" take a project_id, subscribing requires viewer access and marks the user as viewing the project.\n\"mutate\" takes an op (create_task, update_task, delete_task, restore_task or move_task), a task_id, the base_version the change was made\nagainst and data with the same body as the REST endpoint. It is acknowledged with an \"ack\" holding the status, the task's\nnew version and the REST response body, or an \"error\" with a status of 412 if the task changed since base_version.\nThe server sends \"task\" messages with a TaskStreamEvent for changes to tasks in subscribed projects, and \"presence\"\nmessages with the users viewing a subscribed project whenever they change.",
+                "tags": [
+                    "tasks"
+                ],
+                "summary": "Open a websocket for live task changes and presence",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "workspace to act in, defaults to the personal workspace",
+                        "name": "X-Workspace-ID",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "workspace to act in, used when the X-Workspace-ID header isn't set",
+                        "name": "workspace_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "101": {
+                        "description": "Switching Protocols"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/app.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "app.AcceptInvitationResponse": {
+            "type": "object",
+            "properties": {
+                "member": {
+                    "$ref": "#/definitions/app.WorkspaceMember"
+                }
+            }
+        },
+        "app.AddProjectMemberRequest": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "role": {
+                    "$ref": "#/definitions/app.ProjectRole"
+                }
+            }
+        },
+        "app.AddProjectMemberResponse": {
+            "type": "object",
+            "properties": {
+                "member": {
+                    "$ref": "#/definitions/app.ProjectMember"
+                }
+            }
+        },
+        "app.ArchiveTaskResponse": {
+            "type": "object",
+            "properties": {
+                "task": {
+                    "$ref": "#/definitions/app.Task"
+                }
+            }
+        },
+        "app.Attachment": {
+            "type": "object",
+            "properties": {
+                "content_type": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "filename": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "size": {
+                    "type": "integer"
+                },
+                "task_id": {
+                    "type": "integer"
+                },
+                "uploader_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "app.BoardColumn": {
+            "type": "object",
+            "properties": {
+                "paging": {
+                    "$ref": "#/definitions/app.PaginationData"
+                },
+                "status": {
+                    "$ref": "#/definitions/app.Status"
+                },
+                "tasks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/app.Task"
+                    }
+                }
+            }
+        },
+        "app.CalendarFeedResponse": {
+            "type": "object",
+            "properties": {
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "app.Comment": {
+            "type": "object",
+            "properties": {
+                "author_id": {
+                    "type": "integer"
+                },
+                "body": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "edited_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "task_id": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "app.CreateCommentRequest": {
+            "type": "object",
+            "properties": {
+                "body": {
+                    "type": "string"
+                }
+            }
+        },
+        "app.CreateCommentResponse": {
+            "type": "object",
+            "properties": {
+                "comment": {
+                    "$ref": "#/definitions/app.Comment"
+                }
+            }
+        },
+        "app.CreateInvitationRequest": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "role": {
+                    "$ref": "#/definitions/app.WorkspaceRole"
+                }
+            }
+        },
+        "app.CreateInvitationResponse": {
+            "type": "object",
+            "properties": {
+                "invitation": {
+                    "$ref": "#/definitions/app.WorkspaceInvitation"
+                },
+                "token": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "app.CreateProjectRequest": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "app.CreateProjectResponse": {
+            "type": "object",
+            "properties": {
+                "project": {
+                    "$ref": "#/definitions/app.Project"
+                }
+            }
+        },
+        "app.CreateStatusRequest": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "$ref": "#/definitions/app.StatusCategory"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "position": {
+                    "type": "integer"
+                }
+            }
+        },
+        "app.CreateStatusResponse": {
+            "type": "object",
+            "properties": {
+                "status": {
+                    "$ref": "#/definitions/app.Status"
+                }
+            }
+        },
+        "app.CreateTaskRequest": {
+            "type": "object",
+            "properties": {
+                "assignee_id": {
+                    "type": "integer"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "due_at": {
+                    "type": "string"
+                },
+                "parent_id": {
+                    "description": "ParentID makes the task a subtask, it must be in the parent's project",
+                    "type": "integer"
+                },
+                "priority": {
+                    "$ref": "#/definitions/app.TaskPriority"
+                },
+                "project_id": {
+                    "type": "integer"
+                },
+                "recurrence": {
+                    "description": "Recurrence is an iCalendar RRULE such as FREQ=WEEKLY;BYDAY=MO",
+                    "type": "string"
+                },
+                "status_id": {
+                    "type": "integer"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "title": {
+                    "type": "string"
+                },
+                "uid": {
+                    "description": "UID is the task's iCalendar UID, for tasks created by calendar clients",
+                    "type": "string"
+                }
+            }
+        },
+        "app.CreateTaskResponse": {
+            "type": "object",
+            "properties": {
+                "task": {
+                    "$ref": "#/definitions/app.Task"
+                }
+            }
+        },
+        "app.CreateTemplateRequest": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string"
+                },
+                "task": {
+                    "$ref": "#/definitions/app.TemplateTask"
+                },
+                "task_id": {
+                    "description": "TaskID saves the task and its subtasks as the template instead, with due\ndates as days after the task's due date",
+                    "type": "integer"
+                },
+                "timezone": {
+                    "description": "Timezone is the IANA time zone the due times of the saved task are in,\ndefaults to UTC",
+                    "type": "string"
+                }
+            }
+        },
+        "app.CreateWebhookRequest": {
+            "type": "object",
+            "properties": {
+                "event_types": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/app.WebhookEventType"
+                    }
+                },
+                "secret": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "app.CreateWebhookResponse": {
+            "type": "object",
+            "properties": {
+                "secret": {
+                    "type": "string"
+                },
+                "webhook": {
+                    "$ref": "#/definitions/app.Webhook"
+                }
+            }
+        },
+        "app.CreateWorkspaceRequest": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "app.CreateWorkspaceResponse": {
+            "type": "object",
+            "properties": {
+                "workspace": {
+                    "$ref": "#/definitions/app.Workspace"
+                }
+            }
+        },
+        "app.EditCommentRequest": {
+            "type": "object",
+            "properties": {
+                "body": {
+                    "type": "string"
+                }
+            }
+        },
+        "app.EditCommentResponse": {
+            "type": "object",
+            "properties": {
+                "comment": {
+                    "$ref": "#/definitions/app.Comment"
+                }
+            }
+        },
+        "app.EditNotificationPreferencesRequest": {
+            "type": "object",
+            "properties": {
+                "preferences": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/app.NotificationPreference"
+                    }
+                }
+            }
+        },
+        "app.EditProjectRequest": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "app.EditProjectResponse": {
+            "type": "object",
+            "properties": {
+                "project": {
+                    "$ref": "#/definitions/app.Project"
+                }
+            }
+        },
+        "app.EditStatusRequest": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string"
+                },
+                "position": {
+                    "type": "integer"
+                }
+            }
+        },
+        "app.EditStatusResponse": {
+            "type": "object",
+            "properties": {
+                "status": {
+                    "$ref": "#/definitions/app.Status"
+                }
+            }
+        },
+        "app.EditTaskResponse": {
+            "type": "object",
+            "properties": {
+                "task": {
+                    "$ref": "#/definitions/app.Task"
+                }
+            }
+        },
+        "app.EditTemplateRequest": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string"
+                },
+                "task": {
+                    "$ref": "#/definitions/app.TemplateTask"
+                }
+            }
+        },
+        "app.EditWebhookRequest": {
+            "type": "object",
+            "properties": {
+                "event_types": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/app.WebhookEventType"
+                    }
+                },
+                "is_active": {
+                    "type": "boolean"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "app.EditWebhookResponse": {
+            "type": "object",
+            "properties": {
+                "webhook": {
+                    "$ref": "#/definitions/app.Webhook"
+                }
+            }
+        },
+        "app.EditWorkspaceRequest": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "app.EditWorkspaceResponse": {
+            "type": "object",
+            "properties": {
+                "workspace": {
+                    "$ref": "#/definitions/app.Workspace"
+                }
+            }
+        },
+        "app.ErrorResponse": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "app.FieldChange": {
+            "type": "object",
+            "properties": {
+                "from": {
+                    "type": "object"
+                },
+                "to": {
+                    "type": "object"
+                }
+            }
+        },
+        "app.GetAttachmentsResponse": {
+            "type": "object",
+            "properties": {
+                "attachments": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/app.Attachment"
+                    }
+                }
+            }
+        },
+        "app.GetBoardResponse": {
+            "type": "object",
+            "properties": {
+                "columns": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/app.BoardColumn"
+                    }
+                },
+                "project": {
+                    "$ref": "#/definitions/app.Project"
+                }
+            }
+        },
+        "app.GetCommentsResponse": {
+            "type": "object",
+            "properties": {
+                "comments": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/app.Comment"
+                    }
+                }
+            }
+        },
+        "app.GetInvitationsResponse": {
+            "type": "object",
+            "properties": {
+                "invitations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/app.WorkspaceInvitation"
+                    }
+                }
+            }
+        },
+        "app.GetNotificationsResponse": {
+            "type": "object",
+            "properties": {
+                "notifications": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/app.Notification"
+                    }
+                },
+                "unread_count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "app.GetProjectMembersResponse": {
+            "type": "object",
+            "properties": {
+                "members": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/app.ProjectMember"
+                    }
+                }
+            }
+        },
+        "app.GetProjectsResponse": {
+            "type": "object",
+            "properties": {
+                "projects": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/app.Project"
+                    }
+                }
+            }
+        },
+        "app.GetStatusesResponse": {
+            "type": "object",
+            "properties": {
+                "statuses": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/app.Status"
+                    }
+                }
+            }
+        },
+        "app.GetSyncChangesResponse": {
+            "type": "object",
+            "properties": {
+                "deleted": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/app.SyncTombstone"
+                    }
+                },
+                "has_more": {
+                    "description": "HasMore is set when there are more changes to pull right away",
+                    "type": "boolean"
+                },
+                "next_token": {
+                    "description": "NextToken is passed as since on the next pull",
+                    "type": "string"
+                },
+                "tasks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/app.Task"
+                    }
+                }
+            }
+        },
+        "app.GetTaskHistoryResponse": {
+            "type": "object",
+            "properties": {
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/app.TaskEvent"
+                    }
+                }
+            }
+        },
+        "app.GetTasksResponse": {
+            "type": "object",
+            "properties": {
+                "tasks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/app.Task"
+                    }
+                }
+            }
+        },
+        "app.GetTemplatesResponse": {
+            "type": "object",
+            "properties": {
+                "templates": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/app.TaskTemplate"
+                    }
+                }
+            }
+        },
+        "app.GetUndoStackResponse": {
+            "type": "object",
+            "properties": {
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/app.TaskEvent"
+                    }
+                }
+            }
+        },
+        "app.GetWebhookDeliveriesResponse": {
+            "type": "object",
+            "properties": {
+                "deliveries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/app.WebhookDelivery"
+                    }
+                }
+            }
+        },
+        "app.GetWebhooksResponse": {
+            "type": "object",
+            "properties": {
+                "webhooks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/app.Webhook"
+                    }
+                }
+            }
+        },
+        "app.GetWorkspaceMembersResponse": {
+            "type": "object",
+            "properties": {
+                "members": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/app.WorkspaceMember"
+                    }
+                }
+            }
+        },
+        "app.GetWorkspacesResponse": {
+            "type": "object",
+            "properties": {
+                "workspaces": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/app.Workspace"
+                    }
+                }
+            }
+        },
+        "app.Import": {
+            "type": "object",
+            "properties": {
+                "completed_at": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "format": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "processed": {
+                    "type": "integer"
+                },
+                "status": {
+                    "$ref": "#/definitions/app.ImportStatus"
+                },
+                "total": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                },
+                "workspace_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "app.ImportResponse": {
+            "type": "object",
+            "properties": {
+                "import": {
+                    "$ref": "#/definitions/app.Import"
+                }
+            }
+        },
+        "app.ImportRowError": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string"
+                },
+                "row": {
+                    "type": "integer"
+                }
+            }
+        },
+        "app.ImportStatus": {
+            "type": "string",
+            "enum": [
+                "pending",
+                "running",
+                "completed",
+                "failed"
+            ],
+            "x-enum-varnames": [
+                "ImportPending",
+                "ImportRunning",
+                "ImportCompleted",
+                "ImportFailed"
+            ]
+        },
+        "app.ImportTasksErrorResponse": {
+            "type": "object",
+            "properties": {
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/app.ImportRowError"
+                    }
+                },
+                "message": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "app.ImportTasksResponse": {
+            "type": "object",
+            "properties": {
+                "dry_run": {
+                    "type": "boolean"
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/app.ImportRowError"
+                    }
+                },
+                "tasks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/app.Task"
+                    }
+                }
+            }
+        },
+        "app.InstantiateTemplateRequest": {
+            "type": "object",
+            "properties": {
+                "anchor_date": {
+                    "description": "AnchorDate is the date due offsets are counted from, such as 2026-10-19,\ndefaults to today",
+                    "type": "string"
+                },
+                "project_id": {
+                    "type": "integer"
+                },
+                "timezone": {
+                    "description": "Timezone is the IANA time zone due times and today are in, defaults to UTC",
+                    "type": "string"
+                }
+            }
+        },
+        "app.InstantiateTemplateResponse": {
+            "type": "object",
+            "properties": {
+                "tasks": {
+                    "description": "Tasks are listed parents first, with subtasks after their parent",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/app.Task"
+                    }
+                }
+            }
+        },
+        "app.MarkAllNotificationsReadResponse": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "app.MarkNotificationReadResponse": {
+            "type": "object",
+            "properties": {
+                "notification": {
+                    "$ref": "#/definitions/app.Notification"
+                }
+            }
+        },
+        "app.MoveTaskRequest": {
+            "type": "object",
+            "properties": {
+                "after_id": {
+                    "type": "integer"
+                },
+                "before_id": {
+                    "type": "integer"
+                },
+                "status_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "app.MoveTaskResponse": {
+            "type": "object",
+            "properties": {
+                "task": {
+                    "$ref": "#/definitions/app.Task"
+                }
+            }
+        },
+        "app.Notification": {
+            "type": "object",
+            "properties": {
+                "actor_id": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "read_at": {
+                    "type": "string"
+                },
+                "task_id": {
+                    "type": "integer"
+                },
+                "task_title": {
+                    "type": "string"
+                },
+                "type": {
+                    "$ref": "#/definitions/app.NotificationType"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "app.NotificationChannel": {
+            "type": "string",
+            "enum": [
+                "in_app",
+                "email"
+            ],
+            "x-enum-varnames": [
+                "NotificationChannelInApp",
+                "NotificationChannelEmail"
+            ]
+        },
+        "app.NotificationPreference": {
+            "type": "object",
+            "properties": {
+                "channel": {
+                    "$ref": "#/definitions/app.NotificationChannel"
+                },
+                "enabled": {
+                    "type": "boolean"
+                },
+                "type": {
+                    "$ref": "#/definitions/app.NotificationType"
+                }
+            }
+        },
+        "app.NotificationPreferencesResponse": {
+            "type": "object",
+            "properties": {
+                "preferences": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/app.NotificationPreference"
+                    }
+                }
+            }
+        },
+        "app.NotificationType": {
+            "type": "string",
+            "enum": [
+                "task_assigned",
+                "task_commented",
+                "task_mentioned",
+                "task_due"
+            ],
+            "x-enum-varnames": [
+                "NotificationTaskAssigned",
+                "NotificationTaskCommented",
+                "NotificationTaskMentioned",
+                "NotificationTaskDue"
+            ]
+        },
+        "app.PaginationData": {
+            "type": "object",
+            "properties": {
+                "item_count": {
+                    "type": "integer"
+                },
+                "next_cursor": {
+                    "type": "integer"
+                },
+                "next_cursor_position": {
+                    "description": "NextCursorPosition is set on lists ordered by position, whose cursor is\nthe next task's position together with its id",
+                    "type": "string"
+                },
+                "per_page": {
+                    "type": "integer"
+                }
+            }
+        },
+        "app.Project": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                },
+                "workspace_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "app.ProjectMember": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "first_name": {
+                    "type": "string"
+                },
+                "last_name": {
+                    "type": "string"
+                },
+                "project_id": {
+                    "type": "integer"
+                },
+                "role": {
+                    "$ref": "#/definitions/app.ProjectRole"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "app.ProjectRole": {
+            "type": "string",
+            "enum": [
+                "viewer",
+                "editor",
+                "owner"
+            ],
+            "x-enum-varnames": [
+                "ProjectRoleViewer",
+                "ProjectRoleEditor",
+                "ProjectRoleOwner"
+            ]
+        },
+        "app.QuickAddInterpretation": {
+            "type": "object",
+            "properties": {
+                "all_day": {
+                    "type": "boolean"
+                },
+                "due_at": {
+                    "type": "string"
+                },
+                "matches": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/app.QuickAddMatch"
+                    }
+                },
+                "priority": {
+                    "$ref": "#/definitions/app.TaskPriority"
+                },
+                "recurrence": {
+                    "type": "string"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "app.QuickAddMatch": {
+            "type": "object",
+            "properties": {
+                "kind": {
+                    "type": "string",
+                    "enum": [
+                        "tag",
+                        "priority",
+                        "date",
+                        "time",
+                        "recurrence"
+                    ]
+                },
+                "text": {
+                    "type": "string"
+                }
+            }
+        },
+        "app.QuickAddTaskRequest": {
+            "type": "object",
+            "properties": {
+                "preview": {
+                    "description": "Preview only reads the text, without creating the task",
+                    "type": "boolean"
+                },
+                "project_id": {
+                    "type": "integer"
+                },
+                "text": {
+                    "description": "Text is read into the task, such as \"Pay rent every month on the 1st #finance !high tomorrow 9am\"",
+                    "type": "string"
+                },
+                "timezone": {
+                    "description": "Timezone is the IANA time zone times of day and relative dates are in, defaults to UTC",
+                    "type": "string"
+                }
+            }
+        },
+        "app.QuickAddTaskResponse": {
+            "type": "object",
+            "properties": {
+                "interpretation": {
+                    "$ref": "#/definitions/app.QuickAddInterpretation"
+                },
+                "task": {
+                    "description": "Task is null for previews",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/app.Task"
+                        }
+                    ]
+                }
+            }
+        },
+        "app.RedeliverWebhookResponse": {
+            "type": "object",
+            "properties": {
+                "delivery": {
+                    "$ref": "#/definitions/app.WebhookDelivery"
+                }
+            }
+        },
+        "app.RegisterUserRequest": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "first_name": {
+                    "type": "string"
+                },
+                "last_name": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                }
+            }
+        },
+        "app.RegisterUserResponse": {
+            "type": "object",
+            "properties": {
+                "user": {
+                    "$ref": "#/definitions/app.User"
+                }
+            }
+        },
+        "app.RestoreTaskResponse": {
+            "type": "object",
+            "properties": {
+                "task": {
+                    "$ref": "#/definitions/app.Task"
+                }
+            }
+        },
+        "app.Status": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "$ref": "#/definitions/app.StatusCategory"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "position": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                },
+                "workspace_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "app.StatusCategory": {
+            "type": "string",
+            "enum": [
+                "todo",
+                "in_progress",
+                "done",
+                "cancelled"
+            ],
+            "x-enum-varnames": [
+                "StatusCategoryTodo",
+                "StatusCategoryInProgress",
+                "StatusCategoryDone",
+                "StatusCategoryCancelled"
+            ]
+        },
+        "app.SuccessResponse": {
+            "type": "object",
+            "properties": {
+                "data": {},
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "app.SyncMutation": {
+            "type": "object",
+            "properties": {
+                "base_version": {
+                    "type": "integer"
+                },
+                "client_id": {
+                    "type": "string"
+                },
+                "data": {
+                    "type": "object"
+                },
+                "modified_at": {
+                    "type": "string"
+                },
+                "op": {
+                    "$ref": "#/definitions/app.SyncOp"
+                },
+                "task_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "app.SyncOp": {
+            "type": "string",
+            "enum": [
+                "create",
+                "update",
+                "delete"
+            ],
+            "x-enum-varnames": [
+                "SyncCreate",
+                "SyncUpdate",
+                "SyncDelete"
+            ]
+        },
+        "app.SyncResolution": {
+            "type": "string",
+            "enum": [
+                "client_wins",
+                "server_wins"
+            ],
+            "x-enum-varnames": [
+                "SyncClientWins",
+                "SyncServerWins"
+            ]
+        },
+        "app.SyncResult": {
+            "type": "object",
+            "properties": {
+                "client_id": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "resolution": {
+                    "$ref": "#/definitions/app.SyncResolution"
+                },
+                "status": {
+                    "$ref": "#/definitions/app.SyncResultStatus"
+                },
+                "status_code": {
+                    "type": "integer"
+                },
                 "task": {
                     "$ref": "#/definitions/app.Task"
                 }
             }
         },
-        "app.EditTaskResponse": {
+        "app.SyncResultStatus": {
+            "type": "string",
+            "enum": [
+                "applied",
+                "conflict",
+                "failed"
+            ],
+            "x-enum-varnames": [
+                "SyncApplied",
+                "SyncConflict",
+                "SyncFailed"
+            ]
+        },
+        "app.SyncTombstone": {
+            "type": "object",
+            "properties": {
+                "deleted_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "app.Task": {
+            "type": "object",
+            "properties": {
+                "archived_at": {
+                    "type": "string"
+                },
+                "assignee_id": {
+                    "type": "integer"
+                },
+                "comment_count": {
+                    "type": "integer"
+                },
+                "completed_at": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "deleted_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "due_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "is_completed": {
+                    "type": "boolean"
+                },
+                "parent_id": {
+                    "description": "ParentID is the task this is a subtask of, it's set when the task is\ncreated",
+                    "type": "integer"
+                },
+                "position": {
+                    "type": "string"
+                },
+                "priority": {
+                    "$ref": "#/definitions/app.TaskPriority"
+                },
+                "project_id": {
+                    "type": "integer"
+                },
+                "recurrence": {
+                    "description": "Recurrence is an iCalendar RRULE, empty if the task doesn't repeat",
+                    "type": "string"
+                },
+                "status_id": {
+                    "type": "integer"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "title": {
+                    "type": "string"
+                },
+                "uid": {
+                    "description": "UID is the iCalendar UID of tasks created over CalDAV",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                },
+                "version": {
+                    "type": "integer"
+                },
+                "workspace_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "app.TaskAction": {
+            "type": "string",
+            "enum": [
+                "created",
+                "updated",
+                "deleted",
+                "restored",
+                "purged",
+                "archived",
+                "unarchived",
+                "reverted",
+                "moved",
+                "access_changed"
+            ],
+            "x-enum-varnames": [
+                "TaskCreated",
+                "TaskUpdated",
+                "TaskDeleted",
+                "TaskRestored",
+                "TaskPurged",
+                "TaskArchived",
+                "TaskUnarchived",
+                "TaskReverted",
+                "TaskMoved",
+                "TaskAccessChanged"
+            ]
+        },
+        "app.TaskEvent": {
             "type": "object",
             "properties": {
-                "task": {
-                    "$ref": "#/definitions/app.Task"
+                "action": {
+                    "$ref": "#/definitions/app.TaskAction"
+                },
+                "actor_id": {
+                    "type": "integer"
+                },
+                "changes": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/app.FieldChange"
+                    }
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "request_id": {
+                    "type": "string"
+                },
+                "reverted_by": {
+                    "type": "integer"
+                },
+                "task_id": {
+                    "type": "integer"
                 }
             }
         },
-        "app.ErrorResponse": {
+        "app.TaskPriority": {
+            "type": "string",
+            "enum": [
+                "none",
+                "low",
+                "medium",
+                "high",
+                "urgent"
+            ],
+            "x-enum-varnames": [
+                "PriorityNone",
+                "PriorityLow",
+                "PriorityMedium",
+                "PriorityHigh",
+                "PriorityUrgent"
+            ]
+        },
+        "app.TaskStreamEvent": {
             "type": "object",
             "properties": {
-                "message": {
+                "action": {
+                    "$ref": "#/definitions/app.TaskAction"
+                },
+                "actor_id": {
+                    "type": "integer"
+                },
+                "changes": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/app.FieldChange"
+                    }
+                },
+                "created_at": {
                     "type": "string"
                 },
-                "status": {
+                "id": {
+                    "type": "integer"
+                },
+                "task": {
+                    "$ref": "#/definitions/app.Task"
+                },
+                "task_id": {
+                    "type": "integer"
+                },
+                "type": {
                     "type": "string"
                 }
             }
         },
-        "app.PaginationData": {
+        "app.TaskTemplate": {
             "type": "object",
             "properties": {
-                "item_count": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
                     "type": "integer"
                 },
-                "next_cursor": {
+                "name": {
+                    "type": "string"
+                },
+                "task": {
+                    "$ref": "#/definitions/app.TemplateTask"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
                     "type": "integer"
                 },
-                "per_page": {
+                "workspace_id": {
                     "type": "integer"
                 }
             }
         },
-        "app.RegisterUserRequest": {
+        "app.TemplateResponse": {
             "type": "object",
             "properties": {
-                "email": {
+                "template": {
+                    "$ref": "#/definitions/app.TaskTemplate"
+                }
+            }
+        },
+        "app.TemplateTask": {
+            "type": "object",
+            "properties": {
+                "checklist": {
+                    "description": "Checklist is added to the description of the tasks made from the\ntemplate, as unchecked Markdown task list items",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "description": {
                     "type": "string"
                 },
-                "first_name": {
+                "due_offset": {
+                    "type": "integer"
+                },
+                "due_time": {
+                    "description": "DueTime is a time of day such as 17:30",
                     "type": "string"
                 },
-                "last_name": {
+                "priority": {
+                    "$ref": "#/definitions/app.TaskPriority"
+                },
+                "recurrence": {
                     "type": "string"
                 },
-                "password": {
+                "subtasks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/app.TemplateTask"
+                    }
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "title": {
                     "type": "string"
                 }
             }
         },
-        "app.RegisterUserResponse": {
+        "app.UndoResponse": {
             "type": "object",
             "properties": {
-                "user": {
-                    "$ref": "#/definitions/app.User"
+                "task": {
+                    "$ref": "#/definitions/app.Task"
+                },
+                "undone": {
+                    "$ref": "#/definitions/app.TaskEvent"
                 }
             }
         },
-        "app.SuccessResponse": {
+        "app.UploadAttachmentResponse": {
             "type": "object",
             "properties": {
-                "data": {},
-                "status": {
-                    "type": "string"
+                "attachment": {
+                    "$ref": "#/definitions/app.Attachment"
                 }
             }
         },
-        "app.Task": {
+        "app.UploadSyncMutationsRequest": {
+            "type": "object",
+            "properties": {
+                "mutations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/app.SyncMutation"
+                    }
+                }
+            }
+        },
+        "app.UploadSyncMutationsResponse": {
+            "type": "object",
+            "properties": {
+                "results": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/app.SyncResult"
+                    }
+                }
+            }
+        },
+        "app.User": {
             "type": "object",
             "properties": {
                 "created_at": {
                     "type": "string"
                 },
-                "description": {
+                "email": {
+                    "type": "string"
+                },
+                "first_name": {
                     "type": "string"
                 },
                 "id": {
                     "type": "integer"
                 },
-                "is_completed": {
-                    "type": "boolean"
+                "last_name": {
+                    "type": "string"
                 },
-                "title": {
+                "update_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "app.Webhook": {
+            "type": "object",
+            "properties": {
+                "created_at": {
                     "type": "string"
                 },
+                "event_types": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/app.WebhookEventType"
+                    }
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "is_active": {
+                    "type": "boolean"
+                },
                 "updated_at": {
                     "type": "string"
                 },
+                "url": {
+                    "type": "string"
+                },
                 "user_id": {
                     "type": "integer"
+                },
+                "workspace_id": {
+                    "type": "integer"
                 }
             }
         },
-        "app.User": {
+        "app.WebhookDelivery": {
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "event_type": {
+                    "$ref": "#/definitions/app.WebhookEventType"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "last_attempt_at": {
+                    "type": "string"
+                },
+                "next_attempt_at": {
+                    "type": "string"
+                },
+                "payload": {
+                    "type": "object"
+                },
+                "response_body": {
+                    "type": "string"
+                },
+                "response_status": {
+                    "type": "integer"
+                },
+                "status": {
+                    "$ref": "#/definitions/app.WebhookDeliveryStatus"
+                },
+                "webhook_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "app.WebhookDeliveryStatus": {
+            "type": "string",
+            "enum": [
+                "pending",
+                "succeeded",
+                "failed"
+            ],
+            "x-enum-varnames": [
+                "DeliveryPending",
+                "DeliverySucceeded",
+                "DeliveryFailed"
+            ]
+        },
+        "app.WebhookEventType": {
+            "type": "string",
+            "enum": [
+                "task.created",
+                "task.updated",
+                "task.completed",
+                "task.deleted"
+            ],
+            "x-enum-varnames": [
+                "WebhookTaskCreated",
+                "WebhookTaskUpdated",
+                "WebhookTaskCompleted",
+                "WebhookTaskDeleted"
+            ]
+        },
+        "app.Workspace": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "is_personal": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "role": {
+                    "$ref": "#/definitions/app.WorkspaceRole"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "app.WorkspaceInvitation": {
             "type": "object",
             "properties": {
                 "created_at": {
@@ -425,19 +7244,61 @@ const docTemplate = `{
                 "email": {
                     "type": "string"
                 },
-                "first_name": {
+                "expires_at": {
                     "type": "string"
                 },
                 "id": {
                     "type": "integer"
                 },
-                "last_name": {
+                "invited_by": {
+                    "type": "integer"
+                },
+                "role": {
+                    "$ref": "#/definitions/app.WorkspaceRole"
+                },
+                "workspace_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "app.WorkspaceMember": {
+            "type": "object",
+            "properties": {
+                "created_at": {
                     "type": "string"
                 },
-                "update_at": {
+                "email": {
+                    "type": "string"
+                },
+                "first_name": {
+                    "type": "string"
+                },
+                "last_name": {
                     "type": "string"
+                },
+                "role": {
+                    "$ref": "#/definitions/app.WorkspaceRole"
+                },
+                "user_id": {
+                    "type": "integer"
+                },
+                "workspace_id": {
+                    "type": "integer"
                 }
             }
+        },
+        "app.WorkspaceRole": {
+            "type": "string",
+            "enum": [
+                "member",
+                "admin",
+                "owner"
+            ],
+            "x-enum-varnames": [
+                "WorkspaceRoleMember",
+                "WorkspaceRoleAdmin",
+                "WorkspaceRoleOwner"
+            ]
         }
     },
     "securityDefinitions": {
@@ -12,23 +12,44 @@ import (
 
 	_ "github.com/ayo-awe/golang_todo_api/docs"
 	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
 	httpSwagger "github.com/swaggo/http-swagger/v2"
 )
 
 type Application struct {
-	config *Config
-	store  Store
+	config        *Config
+	store         Store
+	blobs         BlobStore
+	notifier      Notifier
+	webhookSender WebhookSender
+	feed          ChangeFeed
+	// shutdown is closed when the server starts shutting down, to end long
+	// lived requests such as event streams
+	shutdown chan struct{}
 }
 
-func NewApplication(config *Config, store Store) *Application {
-	return &Application{config: config, store: store}
+func NewApplication(config *Config, store Store, blobs BlobStore, notifier Notifier, webhookSender WebhookSender, feed ChangeFeed) *Application {
+	return &Application{
+		config:        config,
+		store:         store,
+		blobs:         blobs,
+		notifier:      notifier,
+		webhookSender: webhookSender,
+		feed:          feed,
+		shutdown:      make(chan struct{}),
+	}
 }
 
 func (a *Application) buildRoutes() http.Handler {
 	r := chi.NewRouter()
 	api := chi.NewRouter()
 
+	r.Use(middleware.RequestID)
+
 	r.Get("/swagger/*", httpSwagger.Handler())
+	r.HandleFunc("/.well-known/caldav", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, davRoot, http.StatusMovedPermanently)
+	})
 
 	api.Route("/auth", func(r chi.Router) {
 		r.Post("/signup", a.RegisterUser)
@@ -36,10 +57,149 @@ func (a *Application) buildRoutes() http.Handler {
 
 	api.Route("/tasks", func(r chi.Router) {
 		r.Use(a.basicAuthMiddleware)
+		r.Use(a.workspaceMiddleware)
 		r.Post("/", a.CreateTask)
+		r.Post("/quick", a.QuickAddTask)
 		r.With(a.Paginate).Get("/", a.GetTasks)
+		r.Get("/events", a.StreamTaskEvents)
+		r.Get("/export", a.ExportTasks)
+		r.Post("/import", a.ImportTasks)
+		r.Post("/import/markdown", a.ImportMarkdownTasks)
+		r.With(a.Paginate).Get("/trash", a.GetTrashedTasks)
+		r.Delete("/trash/{id}", a.PurgeTask)
+		r.With(a.Paginate).Get("/undo", a.GetUndoStack)
+		r.Post("/undo", a.UndoLastChange)
 		r.Patch("/{id}", a.EditTask)
 		r.Delete("/{id}", a.DeleteTask)
+		r.Post("/{id}/restore", a.RestoreTask)
+		r.Post("/{id}/archive", a.ArchiveTask)
+		r.Post("/{id}/unarchive", a.UnarchiveTask)
+		r.Post("/{id}/move", a.MoveTask)
+		r.With(a.Paginate).Get("/{id}/history", a.GetTaskHistory)
+		r.Post("/{id}/undo", a.UndoTask)
+		r.With(a.Paginate).Get("/{id}/comments", a.GetTaskComments)
+		r.Post("/{id}/comments", a.CreateTaskComment)
+		r.Patch("/{id}/comments/{commentID}", a.EditTaskComment)
+		r.Delete("/{id}/comments/{commentID}", a.DeleteTaskComment)
+		r.With(a.Paginate).Get("/{id}/attachments", a.GetTaskAttachments)
+		r.Post("/{id}/attachments", a.UploadTaskAttachment)
+		r.Get("/{id}/attachments/{attachmentID}", a.DownloadTaskAttachment)
+		r.Delete("/{id}/attachments/{attachmentID}", a.DeleteTaskAttachment)
+	})
+
+	api.Route("/statuses", func(r chi.Router) {
+		r.Use(a.basicAuthMiddleware)
+		r.Use(a.workspaceMiddleware)
+		r.Get("/", a.GetStatuses)
+		r.Post("/", a.CreateStatus)
+		r.Patch("/{id}", a.EditStatus)
+		r.Delete("/{id}", a.DeleteStatus)
+	})
+
+	api.Route("/projects", func(r chi.Router) {
+		r.Use(a.basicAuthMiddleware)
+		r.Use(a.workspaceMiddleware)
+		r.With(a.Paginate).Get("/", a.GetProjects)
+		r.Post("/", a.CreateProject)
+		r.Patch("/{id}", a.EditProject)
+		r.Delete("/{id}", a.DeleteProject)
+		r.With(a.Paginate).Get("/{id}/board", a.GetProjectBoard)
+		r.Get("/{id}/members", a.GetProjectMembers)
+		r.Post("/{id}/members", a.AddProjectMember)
+		r.Delete("/{id}/members/{userID}", a.RemoveProjectMember)
+	})
+
+	api.Route("/workspaces", func(r chi.Router) {
+		r.Use(a.basicAuthMiddleware)
+		r.With(a.Paginate).Get("/", a.GetWorkspaces)
+		r.Post("/", a.CreateWorkspace)
+		r.Post("/invitations/{token}/accept", a.AcceptWorkspaceInvitation)
+		r.Patch("/{id}", a.EditWorkspace)
+		r.Get("/{id}/members", a.GetWorkspaceMembers)
+		r.Delete("/{id}/members/{userID}", a.RemoveWorkspaceMember)
+		r.Get("/{id}/invitations", a.GetWorkspaceInvitations)
+		r.Post("/{id}/invitations", a.CreateWorkspaceInvitation)
+		r.Delete("/{id}/invitations/{invitationID}", a.RevokeWorkspaceInvitation)
+	})
+
+	api.Route("/notifications", func(r chi.Router) {
+		r.Use(a.basicAuthMiddleware)
+		r.With(a.Paginate).Get("/", a.GetNotifications)
+		r.Post("/read", a.MarkAllNotificationsRead)
+		r.Get("/preferences", a.GetNotificationPreferences)
+		r.Put("/preferences", a.EditNotificationPreferences)
+		r.Post("/{id}/read", a.MarkNotificationRead)
+	})
+
+	api.Route("/templates", func(r chi.Router) {
+		r.Use(a.basicAuthMiddleware)
+		r.Use(a.workspaceMiddleware)
+		r.Get("/", a.GetTemplates)
+		r.Post("/", a.CreateTemplate)
+		r.Get("/{id}", a.GetTemplate)
+		r.Patch("/{id}", a.EditTemplate)
+		r.Delete("/{id}", a.DeleteTemplate)
+		r.Post("/{id}/instantiate", a.InstantiateTemplate)
+	})
+
+	api.Route("/webhooks", func(r chi.Router) {
+		r.Use(a.basicAuthMiddleware)
+		r.Use(a.workspaceMiddleware)
+		r.Get("/", a.GetWebhooks)
+		r.Post("/", a.CreateWebhook)
+		r.Patch("/{id}", a.EditWebhook)
+		r.Delete("/{id}", a.DeleteWebhook)
+		r.With(a.Paginate).Get("/{id}/deliveries", a.GetWebhookDeliveries)
+		r.Post("/{id}/deliveries/{deliveryID}/redeliver", a.RedeliverWebhookDelivery)
+	})
+
+	api.Route("/calendar", func(r chi.Router) {
+		// feeds are authenticated by their secret url, calendar apps can't log in
+		r.Get("/{token}.ics", a.GetCalendarFeed)
+
+		r.Group(func(r chi.Router) {
+			r.Use(a.basicAuthMiddleware)
+			r.Use(a.workspaceMiddleware)
+			r.Post("/feed", a.CreateCalendarFeed)
+			r.Delete("/feed", a.DeleteCalendarFeed)
+		})
+	})
+
+	api.Route("/caldav", func(r chi.Router) {
+		r.Use(a.davAuthMiddleware)
+		r.MethodFunc("PROPFIND", "/", a.DavPrincipal)
+		r.MethodFunc("PROPFIND", "/principal/", a.DavPrincipal)
+		r.MethodFunc("PROPFIND", "/calendars/", a.DavCalendarHome)
+
+		r.Route("/calendars/{workspaceID}", func(r chi.Router) {
+			r.Use(a.davWorkspaceMiddleware)
+			r.MethodFunc("PROPFIND", "/", a.DavCalendar)
+			r.MethodFunc("REPORT", "/", a.DavCalendarReport)
+			r.MethodFunc("PROPFIND", "/{name}", a.DavCalendarObjectProps)
+			r.Get("/{name}", a.GetCalendarObject)
+			r.Put("/{name}", a.PutCalendarObject)
+			r.Delete("/{name}", a.DeleteCalendarObject)
+		})
+	})
+
+	api.Route("/imports", func(r chi.Router) {
+		r.Use(a.basicAuthMiddleware)
+		r.Use(a.workspaceMiddleware)
+		r.Post("/", a.CreateImport)
+		r.Get("/{id}", a.GetImport)
+	})
+
+	api.Route("/sync", func(r chi.Router) {
+		r.Use(a.basicAuthMiddleware)
+		r.Use(a.workspaceMiddleware)
+		r.Get("/", a.GetSyncChanges)
+		r.Post("/", a.UploadSyncMutations)
+	})
+
+	api.Route("/ws", func(r chi.Router) {
+		r.Use(a.basicAuthMiddleware)
+		r.Use(a.workspaceMiddleware)
+		r.Get("/", a.ServeWebSocket)
 	})
 
 	r.Mount("/api", api)
@@ -52,6 +212,12 @@ func (a *Application) Start() error {
 		Handler: a.buildRoutes(),
 		Addr:    fmt.Sprintf(":%d", a.config.PORT),
 	}
+	srv.RegisterOnShutdown(func() { close(a.shutdown) })
+
+	jobsCtx, stopJobs := context.WithCancel(context.Background())
+	defer stopJobs()
+
+	a.startBackgroundJobs(jobsCtx)
 
 	go func() {
 		fmt.Printf("Starting server on port %d\n", a.config.PORT)
@@ -65,6 +231,7 @@ func (a *Application) Start() error {
 
 	<-gracefulShutdown
 	fmt.Println("Starting graceful shutdown...")
+	stopJobs()
 
 	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
 	defer cancel()
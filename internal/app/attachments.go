@@ -0,0 +1,399 @@
+package app
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+)
+
+// multipartOverhead is the room left in an upload's body for the multipart
+// boundaries and headers around the file
+const multipartOverhead = 1 << 20
+
+var errUploadTooLarge = errors.New("upload exceeds the maximum attachment size")
+
+// @Summary	Get Task Attachments
+// @Tags		Attachments
+// @Id			GetTaskAttachments
+// @Param		X-Workspace-ID	header		int	false	"workspace to act in, defaults to the personal workspace"
+// @Param		id				path		int	true	"task id"
+// @Param		cursor			query		int	false	"cursor for forward pagination"
+// @Param		per_page		query		int	false	"maximum number of attachments to return"
+// @Success	200				{object}	SuccessResponse{data=GetAttachmentsResponse,paging=PaginationData}
+// @Failure	400,401,404		{object}	ErrorResponse
+// @Security	BasicAuth
+// @Router		/tasks/{id}/attachments [get]
+func (a *Application) GetTaskAttachments(w http.ResponseWriter, r *http.Request) {
+	paging := a.getCtxPaging(r)
+	rawID := chi.URLParam(r, "id")
+
+	id, err := strconv.Atoi(rawID)
+	if err != nil {
+		render.Render(w, r, ErrResourceNotFound("Task not found"))
+		return
+	}
+
+	task, err := a.authorizeTask(r, id, ProjectRoleViewer, taskLive)
+	if err != nil {
+		a.renderTaskAccessError(w, r, err)
+		return
+	}
+
+	attachments, paginationData, err := a.store.Attachments().GetAttachments(r.Context(), task.ID, paging)
+	if err != nil {
+		render.Render(w, r, ErrInternalServerError("An unexpected error occured"))
+		slog.Error(err.Error())
+		return
+	}
+
+	payload := NewSuccessResponse(GetAttachmentsResponse{attachments}).WithPaginationData(paginationData)
+	render.Render(w, r, payload)
+}
+
+// @Summary		Upload Task Attachment
+// @Description	Uploads a file to a task. Files are limited in size and type, and count towards the uploader's storage quota.
+// @Tags			Attachments
+// @Id				UploadTaskAttachment
+// @Param			X-Workspace-ID	header	int	false	"workspace to act in, defaults to the personal workspace"
+// @Accept			multipart/form-data
+// @Param			id						path		int		true	"task id"
+// @Param			file					formData	file	true	"file to attach"
+// @Success		201						{object}	SuccessResponse{data=UploadAttachmentResponse}
+// @Failure		400,401,403,404,413,415	{object}	ErrorResponse
+// @Security		BasicAuth
+// @Router			/tasks/{id}/attachments [post]
+func (a *Application) UploadTaskAttachment(w http.ResponseWriter, r *http.Request) {
+	user := a.getCtxUser(r)
+	rawID := chi.URLParam(r, "id")
+
+	id, err := strconv.Atoi(rawID)
+	if err != nil {
+		render.Render(w, r, ErrResourceNotFound("Task not found"))
+		return
+	}
+
+	task, err := a.authorizeTask(r, id, ProjectRoleEditor, taskLive)
+	if err != nil {
+		a.renderTaskAccessError(w, r, err)
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, a.config.MAX_ATTACHMENT_SIZE+multipartOverhead)
+
+	reader, err := r.MultipartReader()
+	if err != nil {
+		render.Render(w, r, ErrBadRequest("Expected a multipart/form-data request body"))
+		return
+	}
+
+	var file *os.File
+	var size int64
+	var contentType, filename string
+	for file == nil {
+		part, err := reader.NextPart()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				render.Render(w, r, ErrBadRequest("Missing file"))
+			} else {
+				render.Render(w, r, ErrBadRequest("Invalid request body"))
+			}
+			return
+		}
+
+		if part.FormName() != "file" {
+			continue
+		}
+
+		filename = part.FileName()
+		file, size, contentType, err = spoolUpload(part, a.config.MAX_ATTACHMENT_SIZE)
+		if err != nil {
+			var maxBytesErr *http.MaxBytesError
+			if errors.Is(err, errUploadTooLarge) || errors.As(err, &maxBytesErr) {
+				render.Render(w, r, ErrPayloadTooLarge(fmt.Sprintf("Attachments can't be larger than %d bytes", a.config.MAX_ATTACHMENT_SIZE)))
+				return
+			}
+
+			render.Render(w, r, ErrInternalServerError("An unexpected error occured"))
+			slog.Error(err.Error())
+			return
+		}
+	}
+	defer os.Remove(file.Name())
+	defer file.Close()
+
+	if size == 0 {
+		render.Render(w, r, ErrBadRequest("File is empty"))
+		return
+	}
+
+	if !a.isAllowedAttachmentType(contentType) {
+		render.Render(w, r, ErrUnsupportedMediaType("Files of this type can't be attached"))
+		return
+	}
+
+	// check the quota before uploading so a full quota fails fast, it's checked
+	// again when the attachment is saved
+	usage, err := a.store.Attachments().GetStorageUsage(r.Context(), user.ID)
+	if err != nil {
+		render.Render(w, r, ErrInternalServerError("An unexpected error occured"))
+		slog.Error(err.Error())
+		return
+	}
+
+	if usage+size > a.config.STORAGE_QUOTA {
+		render.Render(w, r, ErrPayloadTooLarge("Storage quota exceeded"))
+		return
+	}
+
+	key, err := newBlobKey(user.ID)
+	if err != nil {
+		render.Render(w, r, ErrInternalServerError("An unexpected error occured"))
+		slog.Error(err.Error())
+		return
+	}
+
+	if err := a.blobs.Put(r.Context(), key, file, size, contentType); err != nil {
+		render.Render(w, r, ErrInternalServerError("An unexpected error occured"))
+		slog.Error(err.Error())
+		return
+	}
+
+	attachmentPayload := &Attachment{
+		TaskID:      task.ID,
+		UploaderID:  user.ID,
+		Filename:    sanitizeFilename(filename),
+		ContentType: contentType,
+		Size:        size,
+		BlobKey:     key,
+	}
+
+	attachment, err := a.store.Attachments().CreateAttachment(r.Context(), attachmentPayload, a.config.STORAGE_QUOTA)
+	if err != nil {
+		if err := a.blobs.Delete(r.Context(), key); err != nil {
+			slog.Error(err.Error())
+		}
+
+		if errors.Is(err, ErrQuotaExceeded) {
+			render.Render(w, r, ErrPayloadTooLarge("Storage quota exceeded"))
+			return
+		}
+
+		render.Render(w, r, ErrInternalServerError("An unexpected error occured"))
+		slog.Error(err.Error())
+		return
+	}
+
+	render.Status(r, http.StatusCreated)
+	render.Render(w, r, NewSuccessResponse(UploadAttachmentResponse{*attachment}))
+}
+
+// @Summary	Download Task Attachment
+// @Tags		Attachments
+// @Id			DownloadTaskAttachment
+// @Param		X-Workspace-ID	header	int	false	"workspace to act in, defaults to the personal workspace"
+// @Produce	octet-stream
+// @Param		id				path		int	true	"task id"
+// @Param		attachmentID	path		int	true	"attachment id"
+// @Success	200				{file}		file
+// @Failure	401,404			{object}	ErrorResponse
+// @Security	BasicAuth
+// @Router		/tasks/{id}/attachments/{attachmentID} [get]
+func (a *Application) DownloadTaskAttachment(w http.ResponseWriter, r *http.Request) {
+	rawID := chi.URLParam(r, "id")
+	rawAttachmentID := chi.URLParam(r, "attachmentID")
+
+	id, err := strconv.Atoi(rawID)
+	if err != nil {
+		render.Render(w, r, ErrResourceNotFound("Task not found"))
+		return
+	}
+
+	attachmentID, err := strconv.Atoi(rawAttachmentID)
+	if err != nil {
+		render.Render(w, r, ErrResourceNotFound("Attachment not found"))
+		return
+	}
+
+	task, err := a.authorizeTask(r, id, ProjectRoleViewer, taskLive)
+	if err != nil {
+		a.renderTaskAccessError(w, r, err)
+		return
+	}
+
+	attachment, err := a.store.Attachments().GetAttachmentByID(r.Context(), task.ID, attachmentID)
+	if err != nil {
+		if errors.Is(err, ErrAttachmentNotFound) {
+			render.Render(w, r, ErrResourceNotFound("Attachment not found"))
+			return
+		}
+
+		render.Render(w, r, ErrInternalServerError("An unexpected error occured"))
+		slog.Error(err.Error())
+		return
+	}
+
+	body, err := a.blobs.Get(r.Context(), attachment.BlobKey)
+	if err != nil {
+		if errors.Is(err, ErrBlobNotFound) {
+			render.Render(w, r, ErrResourceNotFound("Attachment not found"))
+			return
+		}
+
+		render.Render(w, r, ErrInternalServerError("An unexpected error occured"))
+		slog.Error(err.Error())
+		return
+	}
+	defer body.Close()
+
+	w.Header().Set("Content-Type", attachment.ContentType)
+	w.Header().Set("Content-Length", strconv.FormatInt(attachment.Size, 10))
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": attachment.Filename}))
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+
+	if _, err := io.Copy(w, body); err != nil {
+		slog.Error(err.Error())
+	}
+}
+
+// @Summary		Delete Task Attachment
+// @Description	Deletes an attachment. Its file is removed from storage in the background.
+// @Tags			Attachments
+// @Id				DeleteTaskAttachment
+// @Param			X-Workspace-ID	header	int	false	"workspace to act in, defaults to the personal workspace"
+// @Param			id				path	int	true	"task id"
+// @Param			attachmentID	path	int	true	"attachment id"
+// @Success		204
+// @Failure		401,403,404	{object}	ErrorResponse
+// @Security		BasicAuth
+// @Router			/tasks/{id}/attachments/{attachmentID} [delete]
+func (a *Application) DeleteTaskAttachment(w http.ResponseWriter, r *http.Request) {
+	rawID := chi.URLParam(r, "id")
+	rawAttachmentID := chi.URLParam(r, "attachmentID")
+
+	id, err := strconv.Atoi(rawID)
+	if err != nil {
+		render.Render(w, r, ErrResourceNotFound("Task not found"))
+		return
+	}
+
+	attachmentID, err := strconv.Atoi(rawAttachmentID)
+	if err != nil {
+		render.Render(w, r, ErrResourceNotFound("Attachment not found"))
+		return
+	}
+
+	task, err := a.authorizeTask(r, id, ProjectRoleEditor, taskLive)
+	if err != nil {
+		a.renderTaskAccessError(w, r, err)
+		return
+	}
+
+	if err := a.store.Attachments().DeleteAttachment(r.Context(), task.ID, attachmentID); err != nil {
+		if errors.Is(err, ErrAttachmentNotFound) {
+			render.Render(w, r, ErrResourceNotFound("Attachment not found"))
+			return
+		}
+
+		render.Render(w, r, ErrInternalServerError("An unexpected error occured"))
+		slog.Error(err.Error())
+		return
+	}
+
+	render.NoContent(w, r)
+}
+
+func (a *Application) isAllowedAttachmentType(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+
+	for _, allowed := range a.config.ATTACHMENT_TYPES {
+		allowed = strings.TrimSpace(allowed)
+		if allowed == mediaType {
+			return true
+		}
+
+		// a trailing wildcard allows every subtype, e.g. image/*
+		if prefix, ok := strings.CutSuffix(allowed, "*"); ok && strings.HasSuffix(prefix, "/") && strings.HasPrefix(mediaType, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// spoolUpload copies an uploaded file into a temporary file so its size is known
+// before it's stored. The content type is sniffed from the file's first bytes
+// rather than trusted from the request.
+func spoolUpload(body io.Reader, maxSize int64) (*os.File, int64, string, error) {
+	file, err := os.CreateTemp("", "attachment-*")
+	if err != nil {
+		return nil, 0, "", err
+	}
+
+	cleanup := func() {
+		file.Close()
+		os.Remove(file.Name())
+	}
+
+	size, err := io.Copy(file, io.LimitReader(body, maxSize+1))
+	if err != nil {
+		cleanup()
+		return nil, 0, "", err
+	}
+
+	if size > maxSize {
+		cleanup()
+		return nil, 0, "", errUploadTooLarge
+	}
+
+	head := make([]byte, 512)
+	n, err := file.ReadAt(head, 0)
+	if err != nil && !errors.Is(err, io.EOF) {
+		cleanup()
+		return nil, 0, "", err
+	}
+
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		cleanup()
+		return nil, 0, "", err
+	}
+
+	return file, size, http.DetectContentType(head[:n]), nil
+}
+
+func sanitizeFilename(filename string) string {
+	filename = strings.TrimSpace(filename)
+	if filename == "" {
+		return "attachment"
+	}
+
+	runes := []rune(filename)
+	if len(runes) > 255 {
+		filename = string(runes[:255])
+	}
+
+	return filename
+}
+
+// newBlobKey returns a random, unguessable key for a blob uploaded by the user
+func newBlobKey(userID int) (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("attachments/%d/%s", userID, hex.EncodeToString(buf)), nil
+}
@@ -0,0 +1,174 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/render"
+)
+
+type taskState int
+
+const (
+	taskLive taskState = iota
+	taskTrashed
+	taskAnyState
+)
+
+// authorizeTask returns the task if the request's user has at least role's access
+// to it. Only tasks in the request's workspace are considered. Tasks outside of
+// projects are private to the user who created them, tasks in a project are
+// shared with its members according to their role. Tasks the user can't see,
+// or that are not in the given state, are not found.
+func (a *Application) authorizeTask(r *http.Request, taskID int, role ProjectRole, state taskState) (*Task, error) {
+	user := a.getCtxUser(r)
+	workspace := a.getCtxWorkspace(r)
+
+	task, err := a.store.Tasks().GetTaskByID(r.Context(), workspace.ID, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	if (state == taskLive && task.DeletedAt.Valid) || (state == taskTrashed && !task.DeletedAt.Valid) {
+		return nil, ErrTaskNotFound
+	}
+
+	if !task.ProjectID.Valid {
+		if task.UserID != user.ID {
+			return nil, ErrTaskNotFound
+		}
+
+		return task, nil
+	}
+
+	if _, err := a.authorizeProject(r, int(task.ProjectID.Int64), role); err != nil {
+		if errors.Is(err, ErrProjectNotFound) {
+			return nil, ErrTaskNotFound
+		}
+		return nil, err
+	}
+
+	return task, nil
+}
+
+// authorizeProject returns the project if the request's user is a member with at
+// least role's access to it. Only projects in the request's workspace are
+// considered, and the workspace's admins have owner access to all of them.
+// Other projects the user is not a member of are not found.
+func (a *Application) authorizeProject(r *http.Request, projectID int, role ProjectRole) (*Project, error) {
+	user := a.getCtxUser(r)
+	workspace := a.getCtxWorkspace(r)
+
+	project, err := a.store.Projects().GetProjectByID(r.Context(), workspace.ID, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	if workspace.Role.AtLeast(WorkspaceRoleAdmin) {
+		return project, nil
+	}
+
+	memberRole, err := a.store.Projects().GetProjectRole(r.Context(), projectID, user.ID)
+	if err != nil {
+		if errors.Is(err, ErrMemberNotFound) {
+			return nil, ErrProjectNotFound
+		}
+		return nil, err
+	}
+
+	if !memberRole.AtLeast(role) {
+		return nil, ErrPermissionDenied
+	}
+
+	return project, nil
+}
+
+// authorizeWorkspace returns the workspace if the request's user is a member
+// with at least role's access to it
+func (a *Application) authorizeWorkspace(r *http.Request, workspaceID int, role WorkspaceRole) (*Workspace, error) {
+	user := a.getCtxUser(r)
+
+	workspace, err := a.store.Workspaces().GetWorkspace(r.Context(), workspaceID, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !workspace.Role.AtLeast(role) {
+		return nil, ErrPermissionDenied
+	}
+
+	return workspace, nil
+}
+
+// checkAssignee fails with ErrInvalidAssignee if the user can't be assigned
+// to the task. Tasks in a project can be assigned to any of its members, other
+// tasks only to the user who created them.
+func (a *Application) checkAssignee(ctx context.Context, task *Task, assigneeID int) error {
+	if !task.ProjectID.Valid {
+		if assigneeID != task.UserID {
+			return ErrInvalidAssignee
+		}
+		return nil
+	}
+
+	_, err := a.store.Projects().GetProjectRole(ctx, int(task.ProjectID.Int64), assigneeID)
+	if errors.Is(err, ErrMemberNotFound) {
+		return ErrInvalidAssignee
+	}
+	return err
+}
+
+// statusOwnerID returns the user whose workflow statuses the task uses.
+// Tasks in a project share the statuses of the project's creator.
+func (a *Application) statusOwnerID(ctx context.Context, task *Task) (int, error) {
+	if !task.ProjectID.Valid {
+		return task.UserID, nil
+	}
+
+	project, err := a.store.Projects().GetProjectByID(ctx, task.WorkspaceID, int(task.ProjectID.Int64))
+	if err != nil {
+		return 0, err
+	}
+
+	return project.UserID, nil
+}
+
+func (a *Application) renderTaskAccessError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, ErrTaskNotFound):
+		render.Render(w, r, ErrResourceNotFound("Task not found"))
+	case errors.Is(err, ErrPermissionDenied):
+		render.Render(w, r, ErrForbidden("You do not have permission to change this task"))
+	case errors.Is(err, ErrVersionConflict):
+		render.Render(w, r, ErrPreconditionFailed("Task was changed since the version in If-Match"))
+	default:
+		render.Render(w, r, ErrInternalServerError("An unexpected error occured"))
+		slog.Error(err.Error())
+	}
+}
+
+func (a *Application) renderWorkspaceAccessError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, ErrWorkspaceNotFound):
+		render.Render(w, r, ErrResourceNotFound("Workspace not found"))
+	case errors.Is(err, ErrPermissionDenied):
+		render.Render(w, r, ErrForbidden("You do not have permission to change this workspace"))
+	default:
+		render.Render(w, r, ErrInternalServerError("An unexpected error occured"))
+		slog.Error(err.Error())
+	}
+}
+
+func (a *Application) renderProjectAccessError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, ErrProjectNotFound):
+		render.Render(w, r, ErrResourceNotFound("Project not found"))
+	case errors.Is(err, ErrPermissionDenied):
+		render.Render(w, r, ErrForbidden("You do not have permission to change this project"))
+	default:
+		render.Render(w, r, ErrInternalServerError("An unexpected error occured"))
+		slog.Error(err.Error())
+	}
+}
@@ -0,0 +1,1154 @@
+package app
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"path"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/ayo-awe/golang_todo_api/internal/ical"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"gopkg.in/guregu/null.v4"
+)
+
+// CalDAV (RFC 4791) lets calendar clients such as Apple Reminders and
+// Thunderbird read and edit tasks. Each workspace is a calendar of VTODOs with
+// the tasks the user can see in it, changes made by clients run through the
+// same handlers as the REST API.
+
+const (
+	davNamespace    = "DAV:"
+	caldavNamespace = "urn:ietf:params:xml:ns:caldav"
+	// calendarserverNamespace has getctag, which clients that predate
+	// sync-collection poll for changes
+	calendarserverNamespace = "http://calendarserver.org/ns/"
+
+	davRoot = "/api/caldav/"
+	// maxDavRequestSize is the size of the largest request body, both XML
+	// requests and iCalendar objects
+	maxDavRequestSize = 1 << 20
+)
+
+var (
+	davResourceType              = xml.Name{Space: davNamespace, Local: "resourcetype"}
+	davDisplayName               = xml.Name{Space: davNamespace, Local: "displayname"}
+	davCurrentUserPrincipal      = xml.Name{Space: davNamespace, Local: "current-user-principal"}
+	davPrincipalURL              = xml.Name{Space: davNamespace, Local: "principal-URL"}
+	davSupportedReportSet        = xml.Name{Space: davNamespace, Local: "supported-report-set"}
+	davSyncToken                 = xml.Name{Space: davNamespace, Local: "sync-token"}
+	davGetETag                   = xml.Name{Space: davNamespace, Local: "getetag"}
+	davGetContentType            = xml.Name{Space: davNamespace, Local: "getcontenttype"}
+	davGetLastModified           = xml.Name{Space: davNamespace, Local: "getlastmodified"}
+	caldavCalendarHomeSet        = xml.Name{Space: caldavNamespace, Local: "calendar-home-set"}
+	caldavSupportedComponentSet  = xml.Name{Space: caldavNamespace, Local: "supported-calendar-component-set"}
+	caldavCalendarData           = xml.Name{Space: caldavNamespace, Local: "calendar-data"}
+	calendarserverGetCTag        = xml.Name{Space: calendarserverNamespace, Local: "getctag"}
+	davSyncCollectionReport      = xml.Name{Space: davNamespace, Local: "sync-collection"}
+	caldavCalendarQueryReport    = xml.Name{Space: caldavNamespace, Local: "calendar-query"}
+	caldavCalendarMultigetReport = xml.Name{Space: caldavNamespace, Local: "calendar-multiget"}
+)
+
+// taskResourcePattern is the name of the resources of tasks without a UID
+var taskResourcePattern = regexp.MustCompile(`^task-(\d+)$`)
+
+func init() {
+	chi.RegisterMethod("PROPFIND")
+	chi.RegisterMethod("REPORT")
+}
+
+// davRequest is the body of a PROPFIND or REPORT request. Only the parts of
+// the requests this server understands are read.
+type davRequest struct {
+	XMLName   xml.Name
+	Prop      *davPropNames  `xml:"DAV: prop"`
+	Hrefs     []string       `xml:"DAV: href"`
+	SyncToken string         `xml:"DAV: sync-token"`
+	Filter    *davCompFilter `xml:"urn:ietf:params:xml:ns:caldav filter>comp-filter"`
+}
+
+type davPropNames struct {
+	Names []struct {
+		XMLName xml.Name
+	} `xml:",any"`
+}
+
+type davCompFilter struct {
+	Name    string          `xml:"name,attr"`
+	Filters []davCompFilter `xml:"urn:ietf:params:xml:ns:caldav comp-filter"`
+}
+
+// davPropRequest is the properties a request asks for
+type davPropRequest struct {
+	all   bool
+	names []xml.Name
+}
+
+// wants reports whether the property should be in the response. Like other
+// servers, calendar data is only returned when asked for by name.
+func (p davPropRequest) wants(name xml.Name) bool {
+	if p.all {
+		return name != caldavCalendarData
+	}
+	return slices.Contains(p.names, name)
+}
+
+func (req *davRequest) props() davPropRequest {
+	if req.Prop == nil {
+		return davPropRequest{all: true}
+	}
+
+	names := make([]xml.Name, len(req.Prop.Names))
+	for i, name := range req.Prop.Names {
+		names[i] = name.XMLName
+	}
+	return davPropRequest{names: names}
+}
+
+type davMultistatus struct {
+	XMLName   xml.Name      `xml:"DAV: multistatus"`
+	Responses []davResponse `xml:"response"`
+	SyncToken string        `xml:"sync-token,omitempty"`
+}
+
+type davResponse struct {
+	Href      string        `xml:"href"`
+	Status    string        `xml:"status,omitempty"`
+	Propstats []davPropstat `xml:"propstat"`
+}
+
+type davPropstat struct {
+	Prop   davPropList `xml:"prop"`
+	Status string      `xml:"status"`
+}
+
+// davPropList is marshalled with each property named after its XMLName
+type davPropList struct {
+	Props []davProp
+}
+
+// davProp is a property with its value as XML
+type davProp struct {
+	XMLName xml.Name
+	Value   string `xml:",innerxml"`
+}
+
+func davStatus(status int) string {
+	return fmt.Sprintf("HTTP/1.1 %d %s", status, http.StatusText(status))
+}
+
+func davText(text string) string {
+	var buf bytes.Buffer
+	xml.EscapeText(&buf, []byte(text))
+	return buf.String()
+}
+
+func davHref(href string) string {
+	return fmt.Sprintf(`<href xmlns="DAV:">%s</href>`, davText(href))
+}
+
+// newDavResponse lists the properties that were asked for under 200, and
+// those that were asked for but the resource doesn't have under 404
+func newDavResponse(href string, props []davProp, req davPropRequest) davResponse {
+	response := davResponse{Href: href}
+
+	var found []davProp
+	for _, prop := range props {
+		if req.wants(prop.XMLName) {
+			found = append(found, prop)
+		}
+	}
+	if len(found) > 0 || req.all {
+		response.Propstats = append(response.Propstats, davPropstat{Prop: davPropList{found}, Status: davStatus(http.StatusOK)})
+	}
+
+	var missing []davProp
+	for _, name := range req.names {
+		if !slices.ContainsFunc(props, func(prop davProp) bool { return prop.XMLName == name }) {
+			missing = append(missing, davProp{XMLName: name})
+		}
+	}
+	if len(missing) > 0 {
+		response.Propstats = append(response.Propstats, davPropstat{Prop: davPropList{missing}, Status: davStatus(http.StatusNotFound)})
+	}
+
+	return response
+}
+
+func renderMultistatus(w http.ResponseWriter, multistatus davMultistatus) {
+	w.Header().Set("Content-Type", "application/xml; charset=utf-8")
+	w.WriteHeader(http.StatusMultiStatus)
+	io.WriteString(w, xml.Header)
+	if err := xml.NewEncoder(w).Encode(multistatus); err != nil {
+		slog.Error(err.Error())
+	}
+}
+
+// parseDavRequest reads the request's XML body, requests without one ask for
+// all properties
+func parseDavRequest(w http.ResponseWriter, r *http.Request) (*davRequest, error) {
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxDavRequestSize))
+	if err != nil {
+		return nil, err
+	}
+
+	var req davRequest
+	if len(bytes.TrimSpace(body)) == 0 {
+		return &req, nil
+	}
+
+	if err := xml.Unmarshal(body, &req); err != nil {
+		return nil, err
+	}
+
+	return &req, nil
+}
+
+// davDepthOne reports whether a PROPFIND should list the collection's members.
+// Infinite depth is treated as one, there are no deeper collections.
+func davDepthOne(r *http.Request) bool {
+	return r.Header.Get("Depth") != "0"
+}
+
+// davAuthMiddleware is basicAuthMiddleware with a WWW-Authenticate challenge,
+// which calendar clients wait for before sending credentials. OPTIONS requests,
+// which clients discover CalDAV support with, need no credentials.
+func (a *Application) davAuthMiddleware(next http.Handler) http.Handler {
+	auth := a.basicAuthMiddleware(next)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodOptions {
+			a.DavOptions(w, r)
+			return
+		}
+		auth.ServeHTTP(&challengeWriter{w}, r)
+	})
+}
+
+type challengeWriter struct {
+	http.ResponseWriter
+}
+
+func (cw *challengeWriter) WriteHeader(status int) {
+	if status == http.StatusUnauthorized {
+		cw.Header().Set("WWW-Authenticate", `Basic realm="Tasks", charset="UTF-8"`)
+	}
+	cw.ResponseWriter.WriteHeader(status)
+}
+
+// davWorkspaceMiddleware acts in the workspace of the calendar in the url
+func (a *Application) davWorkspaceMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user := a.getCtxUser(r)
+
+		workspaceID, err := strconv.Atoi(chi.URLParam(r, "workspaceID"))
+		if err != nil {
+			render.Render(w, r, ErrResourceNotFound("Workspace not found"))
+			return
+		}
+
+		workspace, err := a.store.Workspaces().GetWorkspace(r.Context(), workspaceID, user.ID)
+		if err != nil {
+			a.renderWorkspaceAccessError(w, r, err)
+			return
+		}
+
+		next.ServeHTTP(w, a.setCtxWorkspace(r, workspace))
+	})
+}
+
+// DavOptions advertises CalDAV support
+func (a *Application) DavOptions(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("DAV", "1, 3, calendar-access")
+	w.Header().Set("Allow", "OPTIONS, GET, PUT, DELETE, PROPFIND, REPORT")
+	w.WriteHeader(http.StatusOK)
+}
+
+// DavPrincipal describes the user, it's where clients find their calendars
+func (a *Application) DavPrincipal(w http.ResponseWriter, r *http.Request) {
+	user := a.getCtxUser(r)
+
+	req, err := parseDavRequest(w, r)
+	if err != nil {
+		render.Render(w, r, ErrBadRequest("Invalid request body"))
+		return
+	}
+
+	name := strings.TrimSpace(user.Firstname + " " + user.Lastname)
+	if name == "" {
+		name = user.Email
+	}
+
+	props := []davProp{
+		{davResourceType, `<principal xmlns="DAV:"/>`},
+		{davDisplayName, davText(name)},
+		{davCurrentUserPrincipal, davHref(davRoot + "principal/")},
+		{davPrincipalURL, davHref(davRoot + "principal/")},
+		{caldavCalendarHomeSet, davHref(davRoot + "calendars/")},
+	}
+
+	renderMultistatus(w, davMultistatus{Responses: []davResponse{
+		newDavResponse(r.URL.Path, props, req.props()),
+	}})
+}
+
+// DavCalendarHome lists a calendar for each of the user's workspaces
+func (a *Application) DavCalendarHome(w http.ResponseWriter, r *http.Request) {
+	user := a.getCtxUser(r)
+
+	req, err := parseDavRequest(w, r)
+	if err != nil {
+		render.Render(w, r, ErrBadRequest("Invalid request body"))
+		return
+	}
+
+	props := []davProp{
+		{davResourceType, `<collection xmlns="DAV:"/>`},
+		{davDisplayName, "Calendars"},
+		{davCurrentUserPrincipal, davHref(davRoot + "principal/")},
+	}
+
+	multistatus := davMultistatus{Responses: []davResponse{
+		newDavResponse(davRoot+"calendars/", props, req.props()),
+	}}
+
+	if davDepthOne(r) {
+		watermark, err := a.store.Tasks().GetTaskEventWatermark(r.Context())
+		if err != nil {
+			render.Render(w, r, ErrInternalServerError("An unexpected error occured"))
+			slog.Error(err.Error())
+			return
+		}
+
+		paging := Paging{Cursor: defaultCursor, PerPage: maxPerPage}
+		for {
+			workspaces, paginationData, err := a.store.Workspaces().GetWorkspaces(r.Context(), user.ID, paging)
+			if err != nil {
+				render.Render(w, r, ErrInternalServerError("An unexpected error occured"))
+				slog.Error(err.Error())
+				return
+			}
+
+			for _, workspace := range workspaces {
+				multistatus.Responses = append(multistatus.Responses, newDavResponse(
+					calendarHref(workspace.ID), a.calendarProps(workspace, watermark), req.props(),
+				))
+			}
+
+			if !paginationData.NextCursor.Valid {
+				break
+			}
+			paging.Cursor = int(paginationData.NextCursor.Int64)
+		}
+	}
+
+	renderMultistatus(w, multistatus)
+}
+
+// DavCalendar describes the workspace's calendar and lists its tasks
+func (a *Application) DavCalendar(w http.ResponseWriter, r *http.Request) {
+	workspace := a.getCtxWorkspace(r)
+
+	req, err := parseDavRequest(w, r)
+	if err != nil {
+		render.Render(w, r, ErrBadRequest("Invalid request body"))
+		return
+	}
+
+	watermark, err := a.store.Tasks().GetTaskEventWatermark(r.Context())
+	if err != nil {
+		render.Render(w, r, ErrInternalServerError("An unexpected error occured"))
+		slog.Error(err.Error())
+		return
+	}
+
+	multistatus := davMultistatus{Responses: []davResponse{
+		newDavResponse(calendarHref(workspace.ID), a.calendarProps(*workspace, watermark), req.props()),
+	}}
+
+	if davDepthOne(r) {
+		tasks, err := a.getCalendarObjects(r)
+		if err != nil {
+			render.Render(w, r, ErrInternalServerError("An unexpected error occured"))
+			slog.Error(err.Error())
+			return
+		}
+
+		responses, err := a.taskResponses(r, tasks, req.props())
+		if err != nil {
+			render.Render(w, r, ErrInternalServerError("An unexpected error occured"))
+			slog.Error(err.Error())
+			return
+		}
+		multistatus.Responses = append(multistatus.Responses, responses...)
+	}
+
+	renderMultistatus(w, multistatus)
+}
+
+// DavCalendarReport runs calendar-query, calendar-multiget and sync-collection
+// reports. Queries return every task unless they only ask for components other
+// than VTODOs, their other filters are left to the client.
+func (a *Application) DavCalendarReport(w http.ResponseWriter, r *http.Request) {
+	req, err := parseDavRequest(w, r)
+	if err != nil {
+		render.Render(w, r, ErrBadRequest("Invalid request body"))
+		return
+	}
+
+	switch req.XMLName {
+	case caldavCalendarQueryReport:
+		if req.Filter != nil && len(req.Filter.Filters) > 0 &&
+			!slices.ContainsFunc(req.Filter.Filters, func(f davCompFilter) bool { return strings.EqualFold(f.Name, "VTODO") }) {
+			renderMultistatus(w, davMultistatus{})
+			return
+		}
+
+		tasks, err := a.getCalendarObjects(r)
+		if err != nil {
+			render.Render(w, r, ErrInternalServerError("An unexpected error occured"))
+			slog.Error(err.Error())
+			return
+		}
+
+		responses, err := a.taskResponses(r, tasks, req.props())
+		if err != nil {
+			render.Render(w, r, ErrInternalServerError("An unexpected error occured"))
+			slog.Error(err.Error())
+			return
+		}
+
+		renderMultistatus(w, davMultistatus{Responses: responses})
+	case caldavCalendarMultigetReport:
+		a.calendarMultiget(w, r, req)
+	case davSyncCollectionReport:
+		a.syncCollection(w, r, req)
+	default:
+		render.Render(w, r, ErrBadRequest("Unsupported report"))
+	}
+}
+
+func (a *Application) calendarMultiget(w http.ResponseWriter, r *http.Request, req *davRequest) {
+	var (
+		tasks    []Task
+		hrefs    []string
+		notFound []davResponse
+	)
+	for _, href := range req.Hrefs {
+		task, err := a.getCalendarObject(r, path.Base(href), true)
+		if err != nil {
+			if errors.Is(err, ErrTaskNotFound) {
+				notFound = append(notFound, davResponse{Href: href, Status: davStatus(http.StatusNotFound)})
+				continue
+			}
+			render.Render(w, r, ErrInternalServerError("An unexpected error occured"))
+			slog.Error(err.Error())
+			return
+		}
+		tasks = append(tasks, *task)
+		hrefs = append(hrefs, href)
+	}
+
+	responses, err := a.taskResponses(r, tasks, req.props())
+	if err != nil {
+		render.Render(w, r, ErrInternalServerError("An unexpected error occured"))
+		slog.Error(err.Error())
+		return
+	}
+
+	// tasks are listed under the hrefs the client asked for
+	for i := range responses {
+		responses[i].Href = hrefs[i]
+	}
+
+	renderMultistatus(w, davMultistatus{Responses: append(responses, notFound...)})
+}
+
+// syncCollection lists the tasks changed since the sync token, and those
+// deleted since as not found. Without a token every task is listed.
+func (a *Application) syncCollection(w http.ResponseWriter, r *http.Request, req *davRequest) {
+	user := a.getCtxUser(r)
+	workspace := a.getCtxWorkspace(r)
+
+	upTo, err := a.store.Tasks().GetTaskEventWatermark(r.Context())
+	if err != nil {
+		render.Render(w, r, ErrInternalServerError("An unexpected error occured"))
+		slog.Error(err.Error())
+		return
+	}
+
+	multistatus := davMultistatus{SyncToken: a.davSyncToken(upTo)}
+
+	if req.SyncToken == "" {
+		tasks, err := a.getCalendarObjects(r)
+		if err != nil {
+			render.Render(w, r, ErrInternalServerError("An unexpected error occured"))
+			slog.Error(err.Error())
+			return
+		}
+
+		multistatus.Responses, err = a.taskResponses(r, tasks, req.props())
+		if err != nil {
+			render.Render(w, r, ErrInternalServerError("An unexpected error occured"))
+			slog.Error(err.Error())
+			return
+		}
+
+		renderMultistatus(w, multistatus)
+		return
+	}
+
+	after, err := a.parseDavSyncToken(req.SyncToken)
+	if err != nil || upTo.Before(after) {
+		w.Header().Set("Content-Type", "application/xml; charset=utf-8")
+		w.WriteHeader(http.StatusForbidden)
+		io.WriteString(w, xml.Header+`<error xmlns="DAV:"><valid-sync-token/></error>`)
+		return
+	}
+
+	var (
+		changed []Task
+		deleted []davResponse
+		seen    = make(map[int]bool)
+	)
+	for after.Before(upTo) {
+		changes, err := a.store.Tasks().GetTaskChanges(r.Context(), workspace.ID, user.ID, after, upTo, syncPageSize)
+		if err != nil {
+			render.Render(w, r, ErrInternalServerError("An unexpected error occured"))
+			slog.Error(err.Error())
+			return
+		}
+
+		// changes have the current state of the task, so only the first change
+		// of each task is needed
+		for _, change := range changes {
+			if seen[change.Event.TaskID] {
+				continue
+			}
+			seen[change.Event.TaskID] = true
+
+			switch {
+			case change.Task == nil:
+				// the UID of a purged task is gone, it's only known by its id
+				href := calendarHref(workspace.ID) + fmt.Sprintf("task-%d.ics", change.Event.TaskID)
+				deleted = append(deleted, davResponse{Href: href, Status: davStatus(http.StatusNotFound)})
+			case change.Task.DeletedAt.Valid:
+				href := calendarHref(workspace.ID) + taskResourceName(*change.Task)
+				deleted = append(deleted, davResponse{Href: href, Status: davStatus(http.StatusNotFound)})
+			default:
+				changed = append(changed, *change.Task)
+			}
+		}
+
+		if len(changes) < syncPageSize {
+			break
+		}
+		after = changes[len(changes)-1].Event.Position
+	}
+
+	multistatus.Responses, err = a.taskResponses(r, changed, req.props())
+	if err != nil {
+		render.Render(w, r, ErrInternalServerError("An unexpected error occured"))
+		slog.Error(err.Error())
+		return
+	}
+	multistatus.Responses = append(multistatus.Responses, deleted...)
+
+	renderMultistatus(w, multistatus)
+}
+
+// DavCalendarObjectProps describes a single task
+func (a *Application) DavCalendarObjectProps(w http.ResponseWriter, r *http.Request) {
+	req, err := parseDavRequest(w, r)
+	if err != nil {
+		render.Render(w, r, ErrBadRequest("Invalid request body"))
+		return
+	}
+
+	task, err := a.getCalendarObject(r, chi.URLParam(r, "name"), r.URL.RawPath != "")
+	if err != nil {
+		a.renderTaskAccessError(w, r, err)
+		return
+	}
+
+	responses, err := a.taskResponses(r, []Task{*task}, req.props())
+	if err != nil {
+		render.Render(w, r, ErrInternalServerError("An unexpected error occured"))
+		slog.Error(err.Error())
+		return
+	}
+
+	renderMultistatus(w, davMultistatus{Responses: responses})
+}
+
+// @Summary		Get CalDAV Task
+// @Description	The task as an iCalendar VTODO, for CalDAV clients
+// @Tags			CalDAV
+// @Id				GetCalendarObject
+// @Produce		text/calendar
+// @Param			workspaceID	path		int		true	"workspace id"
+// @Param			name		path		string	true	"resource name, the task's UID followed by .ics"
+// @Success		200			{string}	string
+// @Failure		401,404		{object}	ErrorResponse
+// @Security		BasicAuth
+// @Router			/caldav/calendars/{workspaceID}/{name} [get]
+func (a *Application) GetCalendarObject(w http.ResponseWriter, r *http.Request) {
+	workspace := a.getCtxWorkspace(r)
+
+	task, err := a.getCalendarObject(r, chi.URLParam(r, "name"), r.URL.RawPath != "")
+	if err != nil {
+		a.renderTaskAccessError(w, r, err)
+		return
+	}
+
+	categories, err := a.statusCategories(r, workspace.ID, []Task{*task})
+	if err != nil {
+		render.Render(w, r, ErrInternalServerError("An unexpected error occured"))
+		slog.Error(err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Header().Set("ETag", taskETag(*task))
+	io.WriteString(w, a.taskCalendarData(workspace.Name, *task, categories[task.StatusID]))
+}
+
+// @Summary		Put CalDAV Task
+// @Description	Creates or replaces a task from an iCalendar object with a single VTODO. The summary, description, due
+// @Description	date, priority, categories, recurrence rule and status are kept, categories become tags. Tasks are created
+// @Description	outside of projects, the resource name of new tasks must be their UID followed by .ics. A task in the trash
+// @Description	with the same UID is restored and replaced.
+// @Tags			CalDAV
+// @Id				PutCalendarObject
+// @Accept			text/calendar
+// @Param			workspaceID		path	int		true	"workspace id"
+// @Param			name			path	string	true	"resource name, the task's UID followed by .ics"
+// @Param			If-Match		header	string	false	"only replace the task if it is still at this ETag"
+// @Param			If-None-Match	header	string	false	"* to only create the task"
+// @Success		201
+// @Success		204
+// @Failure		400,401,403,404,409,412	{object}	ErrorResponse
+// @Security		BasicAuth
+// @Router			/caldav/calendars/{workspaceID}/{name} [put]
+func (a *Application) PutCalendarObject(w http.ResponseWriter, r *http.Request) {
+	user := a.getCtxUser(r)
+
+	name := chi.URLParam(r, "name")
+	if r.URL.RawPath != "" {
+		unescaped, err := url.PathUnescape(name)
+		if err != nil {
+			render.Render(w, r, ErrBadRequest("Invalid resource name"))
+			return
+		}
+		name = unescaped
+	}
+
+	uid, ok := strings.CutSuffix(name, ".ics")
+	if !ok || uid == "" {
+		render.Render(w, r, ErrBadRequest("Resource names must end with .ics"))
+		return
+	}
+
+	calendar, err := ical.Parse(http.MaxBytesReader(w, r.Body, maxDavRequestSize))
+	if err != nil || calendar.Name != "VCALENDAR" {
+		render.Render(w, r, ErrBadRequest("Expected an iCalendar object"))
+		return
+	}
+
+	todos := calendar.Children("VTODO")
+	if len(todos) != 1 || len(todos)+len(calendar.Children("VTIMEZONE")) != len(calendar.Components) {
+		render.Render(w, r, ErrBadRequest("Expected a single VTODO"))
+		return
+	}
+
+	todo, err := parseCalendarTodo(todos[0])
+	if err != nil {
+		render.Render(w, r, ErrBadRequest(err.Error()))
+		return
+	}
+
+	version := expectedVersion(r)
+	mutation := taskMutation{RequestID: middleware.GetReqID(r.Context())}
+
+	task, err := a.getCalendarObject(r, name, false)
+	created := err != nil
+	if errors.Is(err, ErrTaskNotFound) && version == 0 && todo.UID == uid {
+		// tasks in the trash keep their UID, so they are restored and updated
+		// rather than created again under a UID that's taken
+		restored, result, restoreErr := a.restoreCalendarObject(r, uid)
+		switch {
+		case errors.Is(restoreErr, ErrTaskNotFound):
+		case restoreErr != nil:
+			render.Render(w, r, ErrInternalServerError("An unexpected error occured"))
+			slog.Error(restoreErr.Error())
+			return
+		case result.Failed():
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(result.Status)
+			w.Write(result.Body)
+			return
+		default:
+			task, err = restored, nil
+		}
+	}
+
+	switch {
+	case errors.Is(err, ErrTaskNotFound):
+		if version != 0 {
+			render.Render(w, r, ErrPreconditionFailed("Task not found"))
+			return
+		}
+
+		if todo.UID != uid {
+			render.Render(w, r, ErrBadRequest("The resource name must be the VTODO's UID followed by .ics"))
+			return
+		}
+
+		category := StatusCategoryTodo
+		if todo.Category != nil {
+			category = *todo.Category
+		}
+
+		status, err := a.getTaskStatus(r.Context(), a.getCtxWorkspace(r).ID, user.ID, nil, category)
+		if err != nil {
+			render.Render(w, r, ErrInternalServerError("An unexpected error occured"))
+			slog.Error(err.Error())
+			return
+		}
+
+		mutation.Op = "create_task"
+		mutation.Body, err = json.Marshal(CreateTaskRequest{
+			Title:       todo.Title,
+			Description: todo.Description,
+			StatusID:    &status.ID,
+			DueAt:       todo.DueAt,
+			Priority:    todo.Priority,
+			Tags:        todo.Tags,
+			Recurrence:  todo.Recurrence,
+			UID:         todo.UID,
+		})
+		if err != nil {
+			render.Render(w, r, ErrInternalServerError("An unexpected error occured"))
+			slog.Error(err.Error())
+			return
+		}
+	case err != nil:
+		a.renderTaskAccessError(w, r, err)
+		return
+	default:
+		if !created && r.Header.Get("If-None-Match") == "*" {
+			render.Render(w, r, ErrPreconditionFailed("Task already exists"))
+			return
+		}
+
+		if version < 0 {
+			render.Render(w, r, ErrPreconditionFailed("Task was changed since the version in If-Match"))
+			return
+		}
+
+		fields := map[string]any{
+			"title":       todo.Title,
+			"description": todo.Description,
+			"due_at":      todo.DueAt,
+			"priority":    todo.Priority,
+			"tags":        todo.Tags,
+			"recurrence":  todo.Recurrence,
+		}
+
+		// the status is only changed when the todo's status is in a different
+		// category, so tasks keep their custom statuses
+		if todo.Category != nil {
+			statusID, err := a.categoryStatusID(r, task, *todo.Category)
+			if err != nil {
+				render.Render(w, r, ErrInternalServerError("An unexpected error occured"))
+				slog.Error(err.Error())
+				return
+			}
+			if statusID != task.StatusID {
+				fields["status_id"] = statusID
+			}
+		}
+
+		mutation.Op = "update_task"
+		mutation.TaskID = task.ID
+		mutation.BaseVersion = version
+		mutation.Body, err = json.Marshal(fields)
+		if err != nil {
+			render.Render(w, r, ErrInternalServerError("An unexpected error occured"))
+			slog.Error(err.Error())
+			return
+		}
+	}
+
+	result, _ := a.dispatchTaskMutation(r, mutation)
+	if result.Failed() {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(result.Status)
+		w.Write(result.Body)
+		return
+	}
+
+	if result.Task != nil {
+		w.Header().Set("ETag", taskETag(*result.Task))
+	}
+
+	if created {
+		w.WriteHeader(http.StatusCreated)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// restoreCalendarObject restores the task in the trash with the UID, it
+// returns ErrTaskNotFound if there is none
+func (a *Application) restoreCalendarObject(r *http.Request, uid string) (*Task, *taskMutationResult, error) {
+	workspace := a.getCtxWorkspace(r)
+
+	task, err := a.store.Tasks().GetTaskByUID(r.Context(), workspace.ID, uid)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if !task.DeletedAt.Valid {
+		return nil, nil, ErrTaskNotFound
+	}
+
+	result, _ := a.dispatchTaskMutation(r, taskMutation{
+		Op:        "restore_task",
+		TaskID:    task.ID,
+		RequestID: middleware.GetReqID(r.Context()),
+	})
+
+	return result.Task, result, nil
+}
+
+// @Summary		Delete CalDAV Task
+// @Description	Moves the task to the trash
+// @Tags			CalDAV
+// @Id				DeleteCalendarObject
+// @Param			workspaceID	path	int		true	"workspace id"
+// @Param			name		path	string	true	"resource name, the task's UID followed by .ics"
+// @Param			If-Match	header	string	false	"only delete the task if it is still at this ETag"
+// @Success		204
+// @Failure		401,403,404,412	{object}	ErrorResponse
+// @Security		BasicAuth
+// @Router			/caldav/calendars/{workspaceID}/{name} [delete]
+func (a *Application) DeleteCalendarObject(w http.ResponseWriter, r *http.Request) {
+	task, err := a.getCalendarObject(r, chi.URLParam(r, "name"), r.URL.RawPath != "")
+	if err != nil {
+		a.renderTaskAccessError(w, r, err)
+		return
+	}
+
+	version := expectedVersion(r)
+	if version < 0 {
+		render.Render(w, r, ErrPreconditionFailed("Task was changed since the version in If-Match"))
+		return
+	}
+
+	result, _ := a.dispatchTaskMutation(r, taskMutation{
+		Op:          "delete_task",
+		TaskID:      task.ID,
+		BaseVersion: version,
+		RequestID:   middleware.GetReqID(r.Context()),
+	})
+	if result.Failed() {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(result.Status)
+		w.Write(result.Body)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// getCalendarObjects returns the tasks in the request's workspace calendar,
+// which are the same as those pulled in an offline sync
+func (a *Application) getCalendarObjects(r *http.Request) ([]Task, error) {
+	user := a.getCtxUser(r)
+	workspace := a.getCtxWorkspace(r)
+
+	var tasks []Task
+	afterID := 0
+	for {
+		page, err := a.store.Tasks().GetSyncTasks(r.Context(), workspace.ID, user.ID, afterID, syncPageSize)
+		if err != nil {
+			return nil, err
+		}
+
+		tasks = append(tasks, page...)
+		if len(page) < syncPageSize {
+			return tasks, nil
+		}
+		afterID = page[len(page)-1].ID
+	}
+}
+
+// getCalendarObject returns the task with the resource name, which is its UID
+// or, for tasks without one, its id. escaped is set when name is still path
+// escaped.
+func (a *Application) getCalendarObject(r *http.Request, name string, escaped bool) (*Task, error) {
+	workspace := a.getCtxWorkspace(r)
+
+	if escaped {
+		unescaped, err := url.PathUnescape(name)
+		if err != nil {
+			return nil, ErrTaskNotFound
+		}
+		name = unescaped
+	}
+
+	uid, ok := strings.CutSuffix(name, ".ics")
+	if !ok {
+		return nil, ErrTaskNotFound
+	}
+
+	task, err := a.store.Tasks().GetTaskByUID(r.Context(), workspace.ID, uid)
+	if err == nil {
+		return a.authorizeTask(r, task.ID, ProjectRoleViewer, taskLive)
+	}
+	if !errors.Is(err, ErrTaskNotFound) {
+		return nil, err
+	}
+
+	match := taskResourcePattern.FindStringSubmatch(uid)
+	if match == nil {
+		return nil, ErrTaskNotFound
+	}
+
+	taskID, err := strconv.Atoi(match[1])
+	if err != nil {
+		return nil, ErrTaskNotFound
+	}
+
+	task, err = a.authorizeTask(r, taskID, ProjectRoleViewer, taskLive)
+	if err != nil {
+		return nil, err
+	}
+
+	// tasks with a UID are only found by it
+	if task.UID.Valid {
+		return nil, ErrTaskNotFound
+	}
+
+	return task, nil
+}
+
+// categoryStatusID returns the task's status if it's in category, and
+// otherwise the default status of category in the task's workflow
+func (a *Application) categoryStatusID(r *http.Request, task *Task, category StatusCategory) (int, error) {
+	ownerID, err := a.statusOwnerID(r.Context(), task)
+	if err != nil {
+		return 0, err
+	}
+
+	current, err := a.store.Statuses().GetStatusByID(r.Context(), task.WorkspaceID, ownerID, task.StatusID)
+	if err != nil {
+		return 0, err
+	}
+
+	if current.Category == category {
+		return current.ID, nil
+	}
+
+	status, err := a.getTaskStatus(r.Context(), task.WorkspaceID, ownerID, nil, category)
+	if err != nil {
+		return 0, err
+	}
+
+	return status.ID, nil
+}
+
+func (a *Application) taskResponses(r *http.Request, tasks []Task, req davPropRequest) ([]davResponse, error) {
+	workspace := a.getCtxWorkspace(r)
+
+	categories, err := a.statusCategories(r, workspace.ID, tasks)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]davResponse, len(tasks))
+	for i, task := range tasks {
+		props := []davProp{
+			{davResourceType, ""},
+			{davGetETag, davText(taskETag(task))},
+			{davGetContentType, "text/calendar; charset=utf-8; component=VTODO"},
+			{davGetLastModified, task.UpdatedAt.UTC().Format(http.TimeFormat)},
+		}
+
+		if req.wants(caldavCalendarData) {
+			data := a.taskCalendarData(workspace.Name, task, categories[task.StatusID])
+			props = append(props, davProp{caldavCalendarData, davText(data)})
+		}
+
+		responses[i] = newDavResponse(calendarHref(workspace.ID)+taskResourceName(task), props, req)
+	}
+
+	return responses, nil
+}
+
+func (a *Application) calendarProps(workspace Workspace, watermark EventPosition) []davProp {
+	syncToken := davText(a.davSyncToken(watermark))
+
+	return []davProp{
+		{davResourceType, fmt.Sprintf(`<collection xmlns="DAV:"/><calendar xmlns="%s"/>`, caldavNamespace)},
+		{davDisplayName, davText(workspace.Name)},
+		{davCurrentUserPrincipal, davHref(davRoot + "principal/")},
+		{caldavSupportedComponentSet, fmt.Sprintf(`<comp xmlns="%s" name="VTODO"/>`, caldavNamespace)},
+		{davSupportedReportSet, fmt.Sprintf(
+			`<supported-report xmlns="DAV:"><report><calendar-query xmlns="%[1]s"/></report></supported-report>`+
+				`<supported-report xmlns="DAV:"><report><calendar-multiget xmlns="%[1]s"/></report></supported-report>`+
+				`<supported-report xmlns="DAV:"><report><sync-collection/></report></supported-report>`,
+			caldavNamespace,
+		)},
+		// the tag changes whenever the watermark moves, even for changes in
+		// other workspaces
+		{calendarserverGetCTag, syncToken},
+		{davSyncToken, syncToken},
+	}
+}
+
+func (a *Application) taskCalendarData(calendarName string, task Task, category StatusCategory) string {
+	calendar := newCalendar(calendarName)
+	calendar.Add(a.taskTodo(task, category))
+
+	var buf bytes.Buffer
+	calendar.WriteTo(&buf)
+	return buf.String()
+}
+
+// davSyncToken is the sync token of the task events up to position, sync
+// tokens must be URIs
+func (a *Application) davSyncToken(position EventPosition) string {
+	return fmt.Sprintf("%s/ns/sync/%s", strings.TrimSuffix(a.config.BASE_URL, "/"), position)
+}
+
+func (a *Application) parseDavSyncToken(token string) (EventPosition, error) {
+	raw, ok := strings.CutPrefix(token, strings.TrimSuffix(a.config.BASE_URL, "/")+"/ns/sync/")
+	if !ok {
+		return EventPosition{}, errInvalidSyncToken
+	}
+
+	position, err := parseEventPosition(raw)
+	if err != nil {
+		return EventPosition{}, errInvalidSyncToken
+	}
+
+	return position, nil
+}
+
+func calendarHref(workspaceID int) string {
+	return fmt.Sprintf("%scalendars/%d/", davRoot, workspaceID)
+}
+
+// taskResourceName is the name of the task's resource in its calendar
+func taskResourceName(task Task) string {
+	if task.UID.Valid {
+		return url.PathEscape(task.UID.String) + ".ics"
+	}
+	return fmt.Sprintf("task-%d.ics", task.ID)
+}
+
+func taskETag(task Task) string {
+	return strconv.Quote(strconv.Itoa(task.Version))
+}
+
+// calendarTodo is what's kept of a VTODO put by a client
+type calendarTodo struct {
+	UID         string
+	Title       string
+	Description string
+	DueAt       null.Time
+	Priority    TaskPriority
+	Tags        []string
+	Recurrence  string
+	// Category is nil when the todo has no status
+	Category *StatusCategory
+}
+
+// parseCalendarTodo reads a VTODO. Tags and recurrence rules that can't be
+// represented are dropped rather than failing the request, clients have no
+// way of showing why it failed.
+func parseCalendarTodo(component *ical.Component) (calendarTodo, error) {
+	todo := calendarTodo{Title: "Untitled", Priority: PriorityNone, Tags: []string{}}
+
+	if uid := component.Get("UID"); uid != nil {
+		todo.UID = uid.Text()
+	}
+
+	if summary := component.Get("SUMMARY"); summary != nil && strings.TrimSpace(summary.Text()) != "" {
+		todo.Title = truncateName(strings.TrimSpace(summary.Text()))
+	}
+
+	if description := component.Get("DESCRIPTION"); description != nil {
+		todo.Description = description.Text()
+	}
+
+	if due := component.Get("DUE"); due != nil {
+		dueAt, _, err := due.Time()
+		if err != nil {
+			return calendarTodo{}, errors.New("Invalid DUE")
+		}
+		todo.DueAt = null.TimeFrom(dueAt)
+	}
+
+	if priority := component.Get("PRIORITY"); priority != nil {
+		level, err := strconv.Atoi(strings.TrimSpace(priority.Value))
+		if err != nil || level < 0 || level > 9 {
+			return calendarTodo{}, errors.New("Invalid PRIORITY")
+		}
+		todo.Priority = todoPriority(level)
+	}
+
+	var tags []string
+	for _, categories := range component.GetAll("CATEGORIES") {
+		tags = append(tags, categories.TextValues()...)
+	}
+	todo.Tags = importedTags(tags)
+
+	if rrule := component.Get("RRULE"); rrule != nil {
+		if rule, err := ical.NormalizeRRule(rrule.Value); err == nil {
+			todo.Recurrence = rule
+		}
+	}
+
+	if status := component.Get("STATUS"); status != nil {
+		for category, todoStatus := range statusCategoryTodoStatuses {
+			if strings.EqualFold(strings.TrimSpace(status.Value), todoStatus) {
+				todo.Category = &category
+			}
+		}
+	}
+	if todo.Category == nil && component.Get("COMPLETED") != nil {
+		done := StatusCategoryDone
+		todo.Category = &done
+	}
+
+	return todo, nil
+}
+
+// todoPriority is the task priority closest to an iCalendar priority level
+func todoPriority(level int) TaskPriority {
+	switch {
+	case level == 0:
+		return PriorityNone
+	case level == 1:
+		return PriorityUrgent
+	case level <= 4:
+		return PriorityHigh
+	case level == 5:
+		return PriorityMedium
+	default:
+		return PriorityLow
+	}
+}
@@ -0,0 +1,295 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/ayo-awe/golang_todo_api/internal/ical"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+)
+
+const (
+	// maxCalendarTasks is how many tasks a feed has, those due first are kept
+	maxCalendarTasks = 1000
+	// calendarCompletedHistory is how long completed tasks stay in feeds after
+	// they were due, so that old ones don't take up the feed. Tasks that
+	// aren't completed stay however overdue they are.
+	calendarCompletedHistory = 30 * 24 * time.Hour
+)
+
+const calendarProductID = "-//golang_todo_api//Tasks//EN"
+
+var statusCategoryTodoStatuses = map[StatusCategory]string{
+	StatusCategoryTodo:       "NEEDS-ACTION",
+	StatusCategoryInProgress: "IN-PROCESS",
+	StatusCategoryDone:       "COMPLETED",
+	StatusCategoryCancelled:  "CANCELLED",
+}
+
+// iCalendar priorities go from 1, the highest, to 9, the lowest, with 0
+// meaning no priority
+var taskPriorityLevels = map[TaskPriority]int{
+	PriorityUrgent: 1,
+	PriorityHigh:   3,
+	PriorityMedium: 5,
+	PriorityLow:    7,
+}
+
+// @Summary		Create Calendar Feed
+// @Description	Returns a secret url that calendar apps can subscribe to, listing the tasks with due dates the user can
+// @Description	see in the workspace. Creating a feed again replaces the url, the old one stops working. Tasks are
+// @Description	VTODO items, add ?components=vevent to the url to get them as events instead, for calendar apps without
+// @Description	support for todos.
+// @Tags			Calendar
+// @Id				CreateCalendarFeed
+// @Param			X-Workspace-ID	header		int	false	"workspace to act in, defaults to the personal workspace"
+// @Success		201				{object}	SuccessResponse{data=CalendarFeedResponse}
+// @Failure		401,403,404		{object}	ErrorResponse
+// @Security		BasicAuth
+// @Router			/calendar/feed [post]
+func (a *Application) CreateCalendarFeed(w http.ResponseWriter, r *http.Request) {
+	user := a.getCtxUser(r)
+	workspace := a.getCtxWorkspace(r)
+
+	token, err := newSecretToken()
+	if err != nil {
+		render.Render(w, r, ErrInternalServerError("An unexpected error occured"))
+		slog.Error(err.Error())
+		return
+	}
+
+	if err := a.store.Calendars().SetFeedToken(r.Context(), user.ID, workspace.ID, hashSecretToken(token)); err != nil {
+		render.Render(w, r, ErrInternalServerError("An unexpected error occured"))
+		slog.Error(err.Error())
+		return
+	}
+
+	feedURL := fmt.Sprintf("%s/api/calendar/%s.ics", strings.TrimSuffix(a.config.BASE_URL, "/"), token)
+
+	render.Status(r, http.StatusCreated)
+	render.Render(w, r, NewSuccessResponse(CalendarFeedResponse{feedURL}))
+}
+
+// @Summary		Delete Calendar Feed
+// @Description	Stops the user's feed for the workspace from working
+// @Tags			Calendar
+// @Id				DeleteCalendarFeed
+// @Param			X-Workspace-ID	header	int	false	"workspace to act in, defaults to the personal workspace"
+// @Success		204
+// @Failure		401,403,404	{object}	ErrorResponse
+// @Security		BasicAuth
+// @Router			/calendar/feed [delete]
+func (a *Application) DeleteCalendarFeed(w http.ResponseWriter, r *http.Request) {
+	user := a.getCtxUser(r)
+	workspace := a.getCtxWorkspace(r)
+
+	if err := a.store.Calendars().DeleteFeed(r.Context(), user.ID, workspace.ID); err != nil {
+		if errors.Is(err, ErrCalendarFeedNotFound) {
+			render.Render(w, r, ErrResourceNotFound("Calendar feed not found"))
+			return
+		}
+		render.Render(w, r, ErrInternalServerError("An unexpected error occured"))
+		slog.Error(err.Error())
+		return
+	}
+
+	render.NoContent(w, r)
+}
+
+// @Summary		Get Calendar Feed
+// @Description	The iCalendar feed at the url returned when the feed was created, it needs no other authentication
+// @Tags			Calendar
+// @Id				GetCalendarFeed
+// @Produce		text/calendar
+// @Param			token		path		string	true	"feed token"
+// @Param			components	query		string	false	"component tasks are listed as"	Enums(vtodo, vevent)
+// @Success		200			{string}	string
+// @Failure		400,404		{object}	ErrorResponse
+// @Router			/calendar/{token}.ics [get]
+func (a *Application) GetCalendarFeed(w http.ResponseWriter, r *http.Request) {
+	token := chi.URLParam(r, "token")
+
+	asEvents := false
+	switch r.URL.Query().Get("components") {
+	case "", "vtodo":
+	case "vevent":
+		asEvents = true
+	default:
+		render.Render(w, r, ErrBadRequest("Invalid components"))
+		return
+	}
+
+	feed, err := a.store.Calendars().GetFeedByTokenHash(r.Context(), hashSecretToken(token))
+	if err != nil {
+		if errors.Is(err, ErrCalendarFeedNotFound) {
+			render.Render(w, r, ErrResourceNotFound("Calendar feed not found"))
+			return
+		}
+		render.Render(w, r, ErrInternalServerError("An unexpected error occured"))
+		slog.Error(err.Error())
+		return
+	}
+
+	// feeds outlive the user's membership of the workspace
+	workspace, err := a.store.Workspaces().GetWorkspace(r.Context(), feed.WorkspaceID, feed.UserID)
+	if err != nil {
+		if errors.Is(err, ErrWorkspaceNotFound) {
+			render.Render(w, r, ErrResourceNotFound("Calendar feed not found"))
+			return
+		}
+		render.Render(w, r, ErrInternalServerError("An unexpected error occured"))
+		slog.Error(err.Error())
+		return
+	}
+
+	tasks, err := a.store.Tasks().GetCalendarTasks(r.Context(), feed.WorkspaceID, feed.UserID, time.Now().Add(-calendarCompletedHistory), maxCalendarTasks)
+	if err != nil {
+		render.Render(w, r, ErrInternalServerError("An unexpected error occured"))
+		slog.Error(err.Error())
+		return
+	}
+
+	categories, err := a.statusCategories(r, feed.WorkspaceID, tasks)
+	if err != nil {
+		render.Render(w, r, ErrInternalServerError("An unexpected error occured"))
+		slog.Error(err.Error())
+		return
+	}
+
+	calendar := newCalendar(workspace.Name)
+	for _, task := range tasks {
+		if asEvents {
+			calendar.Add(a.taskEvent(task, categories[task.StatusID]))
+		} else {
+			calendar.Add(a.taskTodo(task, categories[task.StatusID]))
+		}
+	}
+
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	if _, err := calendar.WriteTo(w); err != nil {
+		slog.Error(err.Error())
+	}
+}
+
+// statusCategories returns the category of each of the tasks' statuses, by
+// status id
+func (a *Application) statusCategories(r *http.Request, workspaceID int, tasks []Task) (map[int]StatusCategory, error) {
+	var statusIDs []int
+	seen := make(map[int]bool)
+	for _, task := range tasks {
+		if !seen[task.StatusID] {
+			seen[task.StatusID] = true
+			statusIDs = append(statusIDs, task.StatusID)
+		}
+	}
+
+	statuses, err := a.store.Statuses().GetStatusesByIDs(r.Context(), workspaceID, statusIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	categories := make(map[int]StatusCategory, len(statuses))
+	for _, status := range statuses {
+		categories[status.ID] = status.Category
+	}
+
+	return categories, nil
+}
+
+func newCalendar(name string) *ical.Component {
+	calendar := ical.NewComponent("VCALENDAR")
+	calendar.Set("VERSION", "2.0")
+	calendar.Set("PRODID", calendarProductID)
+	calendar.Set("CALSCALE", "GREGORIAN")
+	calendar.SetText("X-WR-CALNAME", name)
+	calendar.Set("REFRESH-INTERVAL", "PT15M", "VALUE=DURATION")
+	calendar.Set("X-PUBLISHED-TTL", "PT15M")
+	return calendar
+}
+
+// taskUID is the task's UID in calendars, which is the same in every feed.
+// Tasks created by calendar clients keep the UID the client gave them.
+func (a *Application) taskUID(task Task) string {
+	if task.UID.Valid {
+		return task.UID.String
+	}
+
+	host := "localhost"
+	if baseURL, err := url.Parse(a.config.BASE_URL); err == nil && baseURL.Hostname() != "" {
+		host = baseURL.Hostname()
+	}
+
+	return fmt.Sprintf("task-%d@%s", task.ID, host)
+}
+
+// setTaskProperties sets the properties todos and events share
+func (a *Application) setTaskProperties(component *ical.Component, task Task) {
+	component.SetText("UID", a.taskUID(task))
+	component.SetDateTime("DTSTAMP", task.UpdatedAt)
+	component.SetDateTime("CREATED", task.CreatedAt)
+	component.SetDateTime("LAST-MODIFIED", task.UpdatedAt)
+	component.Set("SEQUENCE", fmt.Sprint(max(task.Version-1, 0)))
+	component.SetText("SUMMARY", task.Title)
+
+	if task.Description != "" {
+		component.SetText("DESCRIPTION", task.Description)
+	}
+
+	if level, ok := taskPriorityLevels[task.Priority]; ok {
+		component.Set("PRIORITY", fmt.Sprint(level))
+	}
+
+	if len(task.Tags) > 0 {
+		component.SetText("CATEGORIES", task.Tags...)
+	}
+
+	if task.Recurrence != "" {
+		component.Set("RRULE", task.Recurrence)
+	}
+}
+
+func (a *Application) taskTodo(task Task, category StatusCategory) *ical.Component {
+	todo := ical.NewComponent("VTODO")
+	a.setTaskProperties(todo, task)
+
+	if task.DueAt.Valid {
+		todo.SetDateTime("DUE", task.DueAt.Time)
+	}
+
+	status, ok := statusCategoryTodoStatuses[category]
+	if !ok {
+		status = "NEEDS-ACTION"
+		if task.IsCompleted {
+			status = "COMPLETED"
+		}
+	}
+	todo.Set("STATUS", status)
+
+	if task.CompletedAt.Valid {
+		todo.SetDateTime("COMPLETED", task.CompletedAt.Time)
+		todo.Set("PERCENT-COMPLETE", "100")
+	}
+
+	return todo
+}
+
+// taskEvent lists the task as an event that starts, and ends, when it's due
+func (a *Application) taskEvent(task Task, category StatusCategory) *ical.Component {
+	event := ical.NewComponent("VEVENT")
+	a.setTaskProperties(event, task)
+	event.SetDateTime("DTSTART", task.DueAt.Time)
+
+	status := "CONFIRMED"
+	if category == StatusCategoryCancelled {
+		status = "CANCELLED"
+	}
+	event.Set("STATUS", status)
+
+	return event
+}
@@ -0,0 +1,278 @@
+package app
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"gopkg.in/guregu/null.v4"
+)
+
+// @Summary	Get Task Comments
+// @Tags		Comments
+// @Id			GetTaskComments
+// @Param		X-Workspace-ID	header		int	false	"workspace to act in, defaults to the personal workspace"
+// @Param		id				path		int	true	"task id"
+// @Param		cursor			query		int	false	"cursor for forward pagination"
+// @Param		per_page		query		int	false	"maximum number of comments to return"
+// @Success	200				{object}	SuccessResponse{data=GetCommentsResponse,paging=PaginationData}
+// @Failure	400,401,404		{object}	ErrorResponse
+// @Security	BasicAuth
+// @Router		/tasks/{id}/comments [get]
+func (a *Application) GetTaskComments(w http.ResponseWriter, r *http.Request) {
+	paging := a.getCtxPaging(r)
+	rawID := chi.URLParam(r, "id")
+
+	id, err := strconv.Atoi(rawID)
+	if err != nil {
+		render.Render(w, r, ErrResourceNotFound("Task not found"))
+		return
+	}
+
+	task, err := a.authorizeTask(r, id, ProjectRoleViewer, taskLive)
+	if err != nil {
+		a.renderTaskAccessError(w, r, err)
+		return
+	}
+
+	comments, paginationData, err := a.store.Comments().GetComments(r.Context(), task.ID, paging)
+	if err != nil {
+		render.Render(w, r, ErrInternalServerError("An unexpected error occured"))
+		slog.Error(err.Error())
+		return
+	}
+
+	payload := NewSuccessResponse(GetCommentsResponse{comments}).WithPaginationData(paginationData)
+	render.Render(w, r, payload)
+}
+
+// @Summary	Create Task Comment
+// @Tags		Comments
+// @Id			CreateTaskComment
+// @Param		X-Workspace-ID	header		int						false	"workspace to act in, defaults to the personal workspace"
+// @Param		id				path		int						true	"task id"
+// @Param		request			body		CreateCommentRequest	true	"request body"
+// @Success	201				{object}	SuccessResponse{data=CreateCommentResponse}
+// @Failure	400,401,403,404	{object}	ErrorResponse
+// @Security	BasicAuth
+// @Router		/tasks/{id}/comments [post]
+func (a *Application) CreateTaskComment(w http.ResponseWriter, r *http.Request) {
+	user := a.getCtxUser(r)
+	rawID := chi.URLParam(r, "id")
+
+	id, err := strconv.Atoi(rawID)
+	if err != nil {
+		render.Render(w, r, ErrResourceNotFound("Task not found"))
+		return
+	}
+
+	task, err := a.authorizeTask(r, id, ProjectRoleEditor, taskLive)
+	if err != nil {
+		a.renderTaskAccessError(w, r, err)
+		return
+	}
+
+	var requestBody CreateCommentRequest
+	if err := render.Bind(r, &requestBody); err != nil {
+		render.Render(w, r, ErrBadRequest("Invalid request body"))
+		return
+	}
+
+	if err := requestBody.Validate(); err != nil {
+		render.Render(w, r, ErrBadRequest(err.Error()))
+		return
+	}
+
+	mentionedIDs, invalidMentions, err := a.resolveMentions(r.Context(), task, requestBody.Body)
+	if err != nil {
+		render.Render(w, r, ErrInternalServerError("An unexpected error occured"))
+		slog.Error(err.Error())
+		return
+	}
+
+	if len(invalidMentions) > 0 {
+		a.renderInvalidMentions(w, r, invalidMentions)
+		return
+	}
+
+	commentPayload := &Comment{
+		TaskID:   task.ID,
+		AuthorID: user.ID,
+		Body:     requestBody.Body,
+	}
+
+	comment, err := a.store.Comments().CreateComment(r.Context(), commentPayload)
+	if err != nil {
+		render.Render(w, r, ErrInternalServerError("An unexpected error occured"))
+		slog.Error(err.Error())
+		return
+	}
+
+	a.notifyCommented(r.Context(), task, user.ID)
+	a.saveMentions(r.Context(), task, null.IntFrom(int64(comment.ID)), mentionedIDs, user.ID)
+
+	render.Status(r, http.StatusCreated)
+	render.Render(w, r, NewSuccessResponse(CreateCommentResponse{*comment}))
+}
+
+// @Summary		Edit Task Comment
+// @Description	Changes the body of a comment. Only the author of a comment can edit it.
+// @Tags			Comments
+// @Id				EditTaskComment
+// @Param			X-Workspace-ID	header		int					false	"workspace to act in, defaults to the personal workspace"
+// @Param			id				path		int					true	"task id"
+// @Param			commentID		path		int					true	"comment id"
+// @Param			request			body		EditCommentRequest	true	"request body"
+// @Success		200				{object}	SuccessResponse{data=EditCommentResponse}
+// @Failure		400,401,403,404	{object}	ErrorResponse
+// @Security		BasicAuth
+// @Router			/tasks/{id}/comments/{commentID} [patch]
+func (a *Application) EditTaskComment(w http.ResponseWriter, r *http.Request) {
+	user := a.getCtxUser(r)
+	rawID := chi.URLParam(r, "id")
+	rawCommentID := chi.URLParam(r, "commentID")
+
+	id, err := strconv.Atoi(rawID)
+	if err != nil {
+		render.Render(w, r, ErrResourceNotFound("Task not found"))
+		return
+	}
+
+	commentID, err := strconv.Atoi(rawCommentID)
+	if err != nil {
+		render.Render(w, r, ErrResourceNotFound("Comment not found"))
+		return
+	}
+
+	task, err := a.authorizeTask(r, id, ProjectRoleEditor, taskLive)
+	if err != nil {
+		a.renderTaskAccessError(w, r, err)
+		return
+	}
+
+	comment, err := a.store.Comments().GetCommentByID(r.Context(), task.ID, commentID)
+	if err != nil {
+		if errors.Is(err, ErrCommentNotFound) {
+			render.Render(w, r, ErrResourceNotFound("Comment not found"))
+			return
+		}
+
+		render.Render(w, r, ErrInternalServerError("An unexpected error occured"))
+		slog.Error(err.Error())
+		return
+	}
+
+	if comment.AuthorID != user.ID {
+		render.Render(w, r, ErrForbidden("Only the author can edit a comment"))
+		return
+	}
+
+	var requestBody EditCommentRequest
+	if err := render.Bind(r, &requestBody); err != nil {
+		render.Render(w, r, ErrBadRequest("Invalid request body"))
+		return
+	}
+
+	if err := requestBody.Validate(); err != nil {
+		render.Render(w, r, ErrBadRequest(err.Error()))
+		return
+	}
+
+	comment.Body = requestBody.Body
+
+	mentionedIDs, invalidMentions, err := a.resolveMentions(r.Context(), task, comment.Body)
+	if err != nil {
+		render.Render(w, r, ErrInternalServerError("An unexpected error occured"))
+		slog.Error(err.Error())
+		return
+	}
+
+	if len(invalidMentions) > 0 {
+		a.renderInvalidMentions(w, r, invalidMentions)
+		return
+	}
+
+	updatedComment, err := a.store.Comments().UpdateComment(r.Context(), comment)
+	if err != nil {
+		if errors.Is(err, ErrCommentNotFound) {
+			render.Render(w, r, ErrResourceNotFound("Comment not found"))
+			return
+		}
+
+		render.Render(w, r, ErrInternalServerError("An unexpected error occured"))
+		slog.Error(err.Error())
+		return
+	}
+
+	a.saveMentions(r.Context(), task, null.IntFrom(int64(updatedComment.ID)), mentionedIDs, user.ID)
+
+	render.Render(w, r, NewSuccessResponse(EditCommentResponse{*updatedComment}))
+}
+
+// @Summary		Delete Task Comment
+// @Description	Deletes a comment. Only the author of a comment can delete it.
+// @Tags			Comments
+// @Id				DeleteTaskComment
+// @Param			X-Workspace-ID	header	int	false	"workspace to act in, defaults to the personal workspace"
+// @Param			id				path	int	true	"task id"
+// @Param			commentID		path	int	true	"comment id"
+// @Success		204
+// @Failure		401,403,404	{object}	ErrorResponse
+// @Security		BasicAuth
+// @Router			/tasks/{id}/comments/{commentID} [delete]
+func (a *Application) DeleteTaskComment(w http.ResponseWriter, r *http.Request) {
+	user := a.getCtxUser(r)
+	rawID := chi.URLParam(r, "id")
+	rawCommentID := chi.URLParam(r, "commentID")
+
+	id, err := strconv.Atoi(rawID)
+	if err != nil {
+		render.Render(w, r, ErrResourceNotFound("Task not found"))
+		return
+	}
+
+	commentID, err := strconv.Atoi(rawCommentID)
+	if err != nil {
+		render.Render(w, r, ErrResourceNotFound("Comment not found"))
+		return
+	}
+
+	task, err := a.authorizeTask(r, id, ProjectRoleEditor, taskLive)
+	if err != nil {
+		a.renderTaskAccessError(w, r, err)
+		return
+	}
+
+	comment, err := a.store.Comments().GetCommentByID(r.Context(), task.ID, commentID)
+	if err != nil {
+		if errors.Is(err, ErrCommentNotFound) {
+			render.Render(w, r, ErrResourceNotFound("Comment not found"))
+			return
+		}
+
+		render.Render(w, r, ErrInternalServerError("An unexpected error occured"))
+		slog.Error(err.Error())
+		return
+	}
+
+	if comment.AuthorID != user.ID {
+		render.Render(w, r, ErrForbidden("Only the author can delete a comment"))
+		return
+	}
+
+	if err := a.store.Comments().DeleteComment(r.Context(), task.ID, comment.ID); err != nil {
+		if errors.Is(err, ErrCommentNotFound) {
+			render.Render(w, r, ErrResourceNotFound("Comment not found"))
+			return
+		}
+
+		render.Render(w, r, ErrInternalServerError("An unexpected error occured"))
+		slog.Error(err.Error())
+		return
+	}
+
+	render.NoContent(w, r)
+}
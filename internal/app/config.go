@@ -1,13 +1,31 @@
 package app
 
 import (
+	"time"
+
 	"github.com/joho/godotenv"
 	"github.com/kelseyhightower/envconfig"
 )
 
 type Config struct {
-	PORT   int    `envconfig:"PORT" default:"8080"`
-	DB_URL string `envconfig:"DATABASE_URL" required:"true"`
+	PORT                 int           `envconfig:"PORT" default:"8080"`
+	DB_URL               string        `envconfig:"DATABASE_URL" required:"true"`
+	TRASH_RETENTION      time.Duration `envconfig:"TRASH_RETENTION" default:"720h"`
+	AUTO_ARCHIVE_AFTER   time.Duration `envconfig:"AUTO_ARCHIVE_AFTER" default:"0"`
+	BLOB_STORE           string        `envconfig:"BLOB_STORE" default:"local"`
+	BLOB_DIR             string        `envconfig:"BLOB_DIR" default:"data/attachments"`
+	S3_ENDPOINT          string        `envconfig:"S3_ENDPOINT"`
+	S3_REGION            string        `envconfig:"S3_REGION" default:"us-east-1"`
+	S3_BUCKET            string        `envconfig:"S3_BUCKET"`
+	S3_ACCESS_KEY        string        `envconfig:"S3_ACCESS_KEY"`
+	S3_SECRET_KEY        string        `envconfig:"S3_SECRET_KEY"`
+	MAX_ATTACHMENT_SIZE  int64         `envconfig:"MAX_ATTACHMENT_SIZE" default:"10485760"`
+	STORAGE_QUOTA        int64         `envconfig:"STORAGE_QUOTA" default:"104857600"`
+	ATTACHMENT_TYPES     []string      `envconfig:"ATTACHMENT_TYPES" default:"image/*,application/pdf,text/plain"`
+	BASE_URL             string        `envconfig:"BASE_URL" default:"http://localhost:8080"`
+	INVITATION_TTL       time.Duration `envconfig:"INVITATION_TTL" default:"168h"`
+	WEBHOOK_TIMEOUT      time.Duration `envconfig:"WEBHOOK_TIMEOUT" default:"10s"`
+	WEBHOOK_MAX_ATTEMPTS int           `envconfig:"WEBHOOK_MAX_ATTEMPTS" default:"8"`
 }
 
 func LoadConfig() (*Config, error) {
@@ -0,0 +1,440 @@
+package app
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/render"
+	"gopkg.in/guregu/null.v4"
+)
+
+const (
+	maxImportSize = 10 << 20
+	maxImportRows = 1000
+)
+
+var taskCSVColumns = []string{
+	"id", "title", "description", "is_completed", "status_id", "project_id",
+	"assignee_id", "due_at", "priority", "tags", "recurrence", "created_at",
+	"updated_at", "completed_at", "archived_at",
+}
+
+// importableTaskFields are the task fields CSV columns can be mapped to
+var importableTaskFields = []string{
+	"title", "description", "is_completed", "status_id", "project_id",
+	"assignee_id", "due_at", "priority", "tags", "recurrence",
+}
+
+func taskCSVRecord(task Task) []string {
+	formatInt := func(i null.Int) string {
+		if !i.Valid {
+			return ""
+		}
+		return strconv.FormatInt(i.Int64, 10)
+	}
+
+	formatTime := func(t null.Time) string {
+		if !t.Valid {
+			return ""
+		}
+		return t.Time.Format(time.RFC3339)
+	}
+
+	return []string{
+		strconv.Itoa(task.ID),
+		escapeCSVCell(task.Title),
+		escapeCSVCell(task.Description),
+		strconv.FormatBool(task.IsCompleted),
+		strconv.Itoa(task.StatusID),
+		formatInt(task.ProjectID),
+		formatInt(task.AssigneeID),
+		formatTime(task.DueAt),
+		string(task.Priority),
+		escapeCSVCell(strings.Join(task.Tags, ",")),
+		task.Recurrence,
+		task.CreatedAt.Format(time.RFC3339),
+		task.UpdatedAt.Format(time.RFC3339),
+		formatTime(task.CompletedAt),
+		formatTime(task.ArchivedAt),
+	}
+}
+
+// csvFormulaPrefixes start cells spreadsheets evaluate as formulas
+const csvFormulaPrefixes = "=+-@\t\r"
+
+// escapeCSVCell quotes cells spreadsheets would evaluate as formulas, so
+// exported task text can't run in whoever opens the file
+func escapeCSVCell(cell string) string {
+	if cell != "" && strings.ContainsRune(csvFormulaPrefixes, rune(cell[0])) {
+		return "'" + cell
+	}
+	return cell
+}
+
+// unescapeCSVCell undoes escapeCSVCell, so exported files import as they were
+func unescapeCSVCell(cell string) string {
+	if len(cell) > 1 && cell[0] == '\'' && strings.ContainsRune(csvFormulaPrefixes, rune(cell[1])) {
+		return cell[1:]
+	}
+	return cell
+}
+
+// @Summary		Export Tasks
+// @Description	Streams every task matching the filters, not just one page. The markdown format is a checklist with
+// @Description	subtasks nested under their parent tasks, and the tasks of each project under a heading of their own.
+// @Tags			Tasks
+// @Id				ExportTasks
+// @Produce		text/csv,text/markdown
+// @Param			X-Workspace-ID	header		int		false	"workspace to act in, defaults to the personal workspace"
+// @Param			format			query		string	false	"export format"								Enums(csv, markdown)
+// @Param			status			query		string	false	"filter by task status or status category"	Enums(completed, pending, archived, todo, in_progress, done, cancelled)
+// @Param			status_id		query		int		false	"filter by workflow status"
+// @Param			project_id		query		int		false	"filter by project"
+// @Param			assignee		query		string	false	"only return tasks assigned to the authenticated user"	Enums(me)
+// @Param			assignee_id		query		int		false	"filter by assignee"
+// @Param			mentioned		query		string	false	"only return tasks mentioning the authenticated user"	Enums(me)
+// @Param			tag				query		string	false	"filter by tag"
+// @Param			sort			query		string	false	"order tasks newest first or by their manual position"	Enums(newest, position)
+// @Success		200				{file}		file
+// @Failure		400,401			{object}	ErrorResponse
+// @Security		BasicAuth
+// @Router			/tasks/export [get]
+func (a *Application) ExportTasks(w http.ResponseWriter, r *http.Request) {
+	user := a.getCtxUser(r)
+	workspace := a.getCtxWorkspace(r)
+
+	filter := taskFilterFromQuery(r, user)
+
+	switch r.URL.Query().Get("format") {
+	case "", "csv":
+	case "markdown":
+		a.exportMarkdownTasks(w, r, filter)
+		return
+	default:
+		render.Render(w, r, ErrBadRequest("Unsupported export format"))
+		return
+	}
+
+	paging := Paging{Cursor: defaultCursor, PerPage: maxPerPage}
+
+	var writer *csv.Writer
+	for {
+		tasks, paginationData, err := a.store.Tasks().GetTasks(r.Context(), workspace.ID, user.ID, filter, paging)
+		if err != nil {
+			// once the export has started the error can only cut it short
+			if writer == nil {
+				render.Render(w, r, ErrInternalServerError("An unexpected error occured"))
+			}
+			slog.Error(err.Error())
+			return
+		}
+
+		if writer == nil {
+			w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+			w.Header().Set("Content-Disposition", `attachment; filename="tasks.csv"`)
+			writer = csv.NewWriter(w)
+			writer.Write(taskCSVColumns)
+		}
+
+		for _, task := range tasks {
+			writer.Write(taskCSVRecord(task))
+		}
+
+		writer.Flush()
+		if err := writer.Error(); err != nil {
+			return
+		}
+
+		if !paginationData.NextCursor.Valid {
+			return
+		}
+		paging.Cursor = int(paginationData.NextCursor.Int64)
+	}
+}
+
+// @Summary		Import Tasks
+// @Description	Creates a task for every row of a CSV file, all at once or, if any row is invalid, not at all.
+// @Description	mapping maps task fields (title, description, is_completed, status_id, project_id, assignee_id, due_at,
+// @Description	priority, tags, recurrence) to the names of the file's columns, by default the columns named after the fields are used.
+// @Description	Tags are separated by commas and due dates are RFC 3339 timestamps or dates. A dry run only validates the
+// @Description	rows, returning the tasks that would be created without ids.
+// @Tags			Tasks
+// @Id				ImportTasks
+// @Accept			multipart/form-data
+// @Param			X-Workspace-ID	header		int		false	"workspace to act in, defaults to the personal workspace"
+// @Param			file			formData	file	true	"CSV file with a header row"
+// @Param			mapping			formData	string	false	"JSON object of task fields to column names"
+// @Param			dry_run			formData	bool	false	"only validate the rows"
+// @Success		200,201			{object}	SuccessResponse{data=ImportTasksResponse}
+// @Failure		400,401,413		{object}	ErrorResponse
+// @Failure		422				{object}	ImportTasksErrorResponse
+// @Security		BasicAuth
+// @Router			/tasks/import [post]
+func (a *Application) ImportTasks(w http.ResponseWriter, r *http.Request) {
+	user := a.getCtxUser(r)
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxImportSize)
+	if err := r.ParseMultipartForm(maxImportSize); err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			render.Render(w, r, ErrPayloadTooLarge(fmt.Sprintf("Imports can't be larger than %d bytes", maxImportSize)))
+			return
+		}
+		render.Render(w, r, ErrBadRequest("Expected a multipart/form-data request body"))
+		return
+	}
+	defer r.MultipartForm.RemoveAll()
+
+	file, _, err := r.FormFile("file")
+	if err != nil {
+		render.Render(w, r, ErrBadRequest("Missing file"))
+		return
+	}
+	defer file.Close()
+
+	var dryRun bool
+	if rawDryRun := r.FormValue("dry_run"); rawDryRun != "" {
+		dryRun, err = strconv.ParseBool(rawDryRun)
+		if err != nil {
+			render.Render(w, r, ErrBadRequest("Invalid dry_run"))
+			return
+		}
+	}
+
+	var mapping map[string]string
+	if rawMapping := r.FormValue("mapping"); rawMapping != "" {
+		if err := json.Unmarshal([]byte(rawMapping), &mapping); err != nil {
+			render.Render(w, r, ErrBadRequest("Invalid mapping"))
+			return
+		}
+	}
+
+	reader := csv.NewReader(file)
+
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			render.Render(w, r, ErrBadRequest("File is empty"))
+			return
+		}
+		render.Render(w, r, ErrBadRequest(fmt.Sprintf("Invalid CSV: %s", err)))
+		return
+	}
+
+	columns, err := importColumns(header, mapping)
+	if err != nil {
+		render.Render(w, r, ErrBadRequest(err.Error()))
+		return
+	}
+
+	var tasks []*Task
+	var mentionedIDs [][]int
+	rowErrors := []ImportRowError{}
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			render.Render(w, r, ErrBadRequest(fmt.Sprintf("Invalid CSV: %s", err)))
+			return
+		}
+
+		if len(tasks)+len(rowErrors) == maxImportRows {
+			render.Render(w, r, ErrBadRequest(fmt.Sprintf("Imports can't have more than %d rows", maxImportRows)))
+			return
+		}
+
+		line, _ := reader.FieldPos(0)
+
+		task, mentioned, err := a.taskFromImportRow(r, record, columns)
+		if err != nil {
+			var errResponse *ErrorResponse
+			if !errors.As(err, &errResponse) {
+				renderError(w, r, err)
+				return
+			}
+
+			rowErrors = append(rowErrors, ImportRowError{Row: line, Message: errResponse.Message})
+			continue
+		}
+
+		tasks = append(tasks, task)
+		mentionedIDs = append(mentionedIDs, mentioned)
+	}
+
+	if dryRun {
+		preview := make([]Task, len(tasks))
+		for i, task := range tasks {
+			preview[i] = *task
+		}
+
+		render.Render(w, r, NewSuccessResponse(ImportTasksResponse{DryRun: true, Tasks: preview, Errors: rowErrors}))
+		return
+	}
+
+	if len(rowErrors) > 0 {
+		renderImportRowErrors(w, r, rowErrors, "rows")
+		return
+	}
+
+	if len(tasks) == 0 {
+		render.Render(w, r, ErrBadRequest("File has no rows"))
+		return
+	}
+
+	newTasks, err := a.store.Tasks().CreateTasks(r.Context(), tasks, a.getEventMeta(r))
+	if err != nil {
+		render.Render(w, r, ErrInternalServerError("An unexpected error occured"))
+		slog.Error(err.Error())
+		return
+	}
+
+	for i := range newTasks {
+		a.notifyAssignee(r.Context(), &newTasks[i], user.ID)
+		a.saveMentions(r.Context(), &newTasks[i], null.Int{}, mentionedIDs[i], user.ID)
+	}
+
+	render.Status(r, http.StatusCreated)
+	render.Render(w, r, NewSuccessResponse(ImportTasksResponse{Tasks: newTasks, Errors: rowErrors}))
+}
+
+// renderImportRowErrors fails an import over the rows, or other parts of the
+// file, that are invalid
+func renderImportRowErrors(w http.ResponseWriter, r *http.Request, rowErrors []ImportRowError, parts string) {
+	render.Render(w, r, &ImportTasksErrorResponse{
+		ErrorResponse: ErrorResponse{
+			Status:     "error",
+			Message:    fmt.Sprintf("%d %s are invalid, nothing was imported", len(rowErrors), parts),
+			StatusCode: http.StatusUnprocessableEntity,
+		},
+		Errors: rowErrors,
+	})
+}
+
+// importColumns returns the index of the column each mapped task field is
+// read from
+func importColumns(header []string, mapping map[string]string) (map[string]int, error) {
+	indexes := make(map[string]int, len(header))
+	for i, name := range header {
+		// spreadsheet apps often start files with a byte order mark
+		if i == 0 {
+			name = strings.TrimPrefix(name, "\ufeff")
+		}
+		indexes[strings.TrimSpace(name)] = i
+	}
+
+	columns := make(map[string]int)
+	if mapping == nil {
+		for _, field := range importableTaskFields {
+			if i, ok := indexes[field]; ok {
+				columns[field] = i
+			}
+		}
+	}
+
+	for field, name := range mapping {
+		if !slices.Contains(importableTaskFields, field) {
+			return nil, fmt.Errorf("Unknown task field %q in mapping", field)
+		}
+
+		i, ok := indexes[name]
+		if !ok {
+			return nil, fmt.Errorf("Column %q is not in the file", name)
+		}
+		columns[field] = i
+	}
+
+	if _, ok := columns["title"]; !ok {
+		return nil, errors.New("A column must be mapped to title")
+	}
+
+	return columns, nil
+}
+
+// taskFromImportRow validates a row like a request to create the task
+func (a *Application) taskFromImportRow(r *http.Request, record []string, columns map[string]int) (*Task, []int, error) {
+	value := func(field string) string {
+		i, ok := columns[field]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	requestBody := CreateTaskRequest{
+		Title:       unescapeCSVCell(value("title")),
+		Description: unescapeCSVCell(value("description")),
+	}
+
+	ids := []struct {
+		field  string
+		target **int
+	}{
+		{"status_id", &requestBody.StatusID},
+		{"project_id", &requestBody.ProjectID},
+		{"assignee_id", &requestBody.AssigneeID},
+	}
+	for _, id := range ids {
+		raw := value(id.field)
+		if raw == "" {
+			continue
+		}
+
+		value, err := strconv.Atoi(raw)
+		if err != nil {
+			return nil, nil, clientError(ErrBadRequest(fmt.Sprintf("%s: must be an integer", id.field)))
+		}
+		*id.target = &value
+	}
+
+	category := StatusCategoryTodo
+	if raw := value("is_completed"); raw != "" {
+		isCompleted, err := strconv.ParseBool(raw)
+		if err != nil {
+			return nil, nil, clientError(ErrBadRequest("is_completed: must be true or false"))
+		}
+		if isCompleted {
+			category = StatusCategoryDone
+		}
+	}
+
+	if raw := value("due_at"); raw != "" {
+		dueAt, err := parseImportTime(raw)
+		if err != nil {
+			return nil, nil, clientError(ErrBadRequest("due_at: must be an RFC 3339 timestamp or a date"))
+		}
+		requestBody.DueAt = null.TimeFrom(dueAt)
+	}
+
+	requestBody.Priority = TaskPriority(strings.ToLower(value("priority")))
+
+	if raw := unescapeCSVCell(value("tags")); raw != "" {
+		requestBody.Tags = strings.Split(raw, ",")
+	}
+
+	requestBody.Recurrence = value("recurrence")
+
+	if err := requestBody.Validate(); err != nil {
+		return nil, nil, clientError(ErrBadRequest(err.Error()))
+	}
+
+	return a.newTaskFromRequest(r, &requestBody, category)
+}
+
+// parseImportTime parses RFC 3339 timestamps, or dates as midnight UTC
+func parseImportTime(raw string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, raw); err == nil {
+		return t, nil
+	}
+	return time.Parse(time.DateOnly, raw)
+}
@@ -0,0 +1,100 @@
+package app
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// untrackedTaskFields are bookkeeping fields that are left out of a task's change history
+var untrackedTaskFields = map[string]bool{
+	"id":            true,
+	"user_id":       true,
+	"created_at":    true,
+	"updated_at":    true,
+	"completed_at":  true,
+	"comment_count": true,
+	"workspace_id":  true,
+	"version":       true,
+}
+
+// DiffTasks returns the fields that differ between two versions of a task, keyed by
+// their json name. A nil old task is treated as the task being created.
+func DiffTasks(old, new *Task) (map[string]FieldChange, error) {
+	oldFields := map[string]json.RawMessage{}
+	if old != nil {
+		fields, err := taskFields(old)
+		if err != nil {
+			return nil, err
+		}
+		oldFields = fields
+	}
+
+	newFields, err := taskFields(new)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := map[string]FieldChange{}
+	for name, to := range newFields {
+		if untrackedTaskFields[name] {
+			continue
+		}
+
+		from, ok := oldFields[name]
+		if !ok {
+			from = json.RawMessage("null")
+		}
+
+		if bytes.Equal(from, to) {
+			continue
+		}
+
+		changes[name] = FieldChange{From: from, To: to}
+	}
+
+	return changes, nil
+}
+
+func taskFields(task *Task) (map[string]json.RawMessage, error) {
+	data, err := json.Marshal(task)
+	if err != nil {
+		return nil, err
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+
+	return fields, nil
+}
+
+// RevertTask returns a copy of task with the fields changed by event set back to
+// their previous values. ErrUndoConflict is returned if any of those fields no
+// longer hold the value the event changed them to.
+func RevertTask(task *Task, event *TaskEvent) (*Task, error) {
+	fields, err := taskFields(task)
+	if err != nil {
+		return nil, err
+	}
+
+	previous := map[string]json.RawMessage{}
+	for name, change := range event.Changes {
+		if !bytes.Equal(fields[name], change.To) {
+			return nil, ErrUndoConflict
+		}
+		previous[name] = change.From
+	}
+
+	data, err := json.Marshal(previous)
+	if err != nil {
+		return nil, err
+	}
+
+	reverted := *task
+	if err := json.Unmarshal(data, &reverted); err != nil {
+		return nil, err
+	}
+
+	return &reverted, nil
+}
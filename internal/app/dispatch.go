@@ -0,0 +1,133 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+// taskMutation is a change to a task made outside of the REST API, such as
+// over a websocket or in an offline sync, that runs through its REST handler
+type taskMutation struct {
+	Op     string
+	TaskID int
+	// BaseVersion is the version of the task the change was made against, the
+	// change fails with a 412 if the task has changed since. Zero skips the check.
+	BaseVersion int
+	Body        []byte
+	RequestID   string
+}
+
+type taskMutationRoute struct {
+	method  string
+	handler func(a *Application, w http.ResponseWriter, r *http.Request)
+}
+
+var taskMutationRoutes = map[string]taskMutationRoute{
+	"create_task":  {http.MethodPost, (*Application).CreateTask},
+	"update_task":  {http.MethodPatch, (*Application).EditTask},
+	"delete_task":  {http.MethodDelete, (*Application).DeleteTask},
+	"restore_task": {http.MethodPost, (*Application).RestoreTask},
+	"move_task":    {http.MethodPost, (*Application).MoveTask},
+}
+
+// taskMutationResult is the response of the REST handler a mutation ran through
+type taskMutationResult struct {
+	Status int
+	Body   []byte
+	// Task is the task in the response body, if it has one
+	Task *Task
+	// Message is the error message of failed mutations
+	Message string
+}
+
+func (result *taskMutationResult) Failed() bool {
+	return result.Status >= http.StatusBadRequest
+}
+
+// dispatchTaskMutation runs the mutation as the request's user, in the
+// request's workspace. It goes through the same validation and authorization
+// as a REST request, but skips authenticating the user again.
+func (a *Application) dispatchTaskMutation(r *http.Request, mutation taskMutation) (*taskMutationResult, bool) {
+	route, ok := taskMutationRoutes[mutation.Op]
+	if !ok {
+		return nil, false
+	}
+
+	body := mutation.Body
+	if len(body) == 0 {
+		body = []byte("{}")
+	}
+
+	routeCtx := chi.NewRouteContext()
+	routeCtx.URLParams.Add("id", strconv.Itoa(mutation.TaskID))
+
+	ctx := context.WithValue(r.Context(), chi.RouteCtxKey, routeCtx)
+	ctx = context.WithValue(ctx, middleware.RequestIDKey, mutation.RequestID)
+
+	req := r.Clone(ctx)
+	req.Method = route.method
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	req.ContentLength = int64(len(body))
+	req.Header = make(http.Header)
+	req.Header.Set("Content-Type", "application/json")
+	if mutation.BaseVersion > 0 {
+		req.Header.Set("If-Match", strconv.Quote(strconv.Itoa(mutation.BaseVersion)))
+	}
+
+	rec := &responseRecorder{header: make(http.Header)}
+	route.handler(a, rec, req)
+	if rec.status == 0 {
+		rec.status = http.StatusOK
+	}
+
+	result := &taskMutationResult{Status: rec.status, Body: rec.body.Bytes()}
+
+	if result.Failed() {
+		var errResponse ErrorResponse
+		json.Unmarshal(result.Body, &errResponse)
+		result.Message = errResponse.Message
+		return result, true
+	}
+
+	var response struct {
+		Data struct {
+			Task *Task `json:"task"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(result.Body, &response); err == nil {
+		result.Task = response.Data.Task
+	}
+
+	return result, true
+}
+
+// responseRecorder captures the response of a dispatched mutation
+type responseRecorder struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+func (rec *responseRecorder) Header() http.Header {
+	return rec.header
+}
+
+func (rec *responseRecorder) WriteHeader(status int) {
+	if rec.status == 0 {
+		rec.status = status
+	}
+}
+
+func (rec *responseRecorder) Write(b []byte) (int, error) {
+	if rec.status == 0 {
+		rec.status = http.StatusOK
+	}
+	return rec.body.Write(b)
+}
@@ -0,0 +1,207 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/render"
+)
+
+const (
+	taskStreamHeartbeatInterval = 15 * time.Second
+	taskStreamBatchSize         = 100
+	lastEventIDHeader           = "Last-Event-ID"
+)
+
+var errInvalidEventPosition = errors.New("invalid event position")
+
+// String is how positions are given to clients, as stream event ids and in
+// sync tokens
+func (p EventPosition) String() string {
+	return fmt.Sprintf("%d.%d", p.XactID, p.EventID)
+}
+
+func parseEventPosition(raw string) (EventPosition, error) {
+	rawXactID, rawEventID, ok := strings.Cut(raw, ".")
+	if !ok {
+		return EventPosition{}, errInvalidEventPosition
+	}
+
+	xactID, xactErr := strconv.ParseInt(rawXactID, 10, 64)
+	eventID, eventErr := strconv.Atoi(rawEventID)
+	if xactErr != nil || eventErr != nil || xactID < 0 || eventID < 0 {
+		return EventPosition{}, errInvalidEventPosition
+	}
+
+	return EventPosition{XactID: xactID, EventID: eventID}, nil
+}
+
+// taskStreamEventType returns the name a task event is streamed under. Changes
+// to tasks the user can't see anymore are sent as deletions.
+func taskStreamEventType(change TaskChange) string {
+	switch {
+	case change.Task == nil:
+		return "task.deleted"
+	case change.Event.Action == TaskCreated:
+		return "task.created"
+	case change.Event.Action == TaskDeleted || change.Event.Action == TaskPurged:
+		return "task.deleted"
+	default:
+		return "task.updated"
+	}
+}
+
+func newTaskStreamEvent(change TaskChange) TaskStreamEvent {
+	return TaskStreamEvent{
+		ID:        change.Event.ID,
+		Type:      taskStreamEventType(change),
+		Action:    change.Event.Action,
+		TaskID:    change.Event.TaskID,
+		Task:      change.Task,
+		Changes:   change.Event.Changes,
+		ActorID:   change.Event.ActorID,
+		CreatedAt: change.Event.CreatedAt,
+	}
+}
+
+func writeTaskStreamEvent(w io.Writer, change TaskChange) error {
+	event := newTaskStreamEvent(change)
+
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(w, "id: %s\nevent: %s\ndata: %s\n\n", change.Event.Position, event.Type, data)
+	return err
+}
+
+// forEachTaskChange calls fn with the events recorded after last that the
+// user can see, and returns the position to continue from
+func (a *Application) forEachTaskChange(ctx context.Context, workspaceID int, userID int, last EventPosition, fn func(TaskChange) error) (EventPosition, error) {
+	// events recorded while sending are picked up on the next call, reading up
+	// to a fixed position keeps events the user can't see from being scanned again
+	upTo, err := a.store.Tasks().GetTaskEventWatermark(ctx)
+	if err != nil {
+		return last, err
+	}
+
+	for last.Before(upTo) {
+		changes, err := a.store.Tasks().GetTaskChanges(ctx, workspaceID, userID, last, upTo, taskStreamBatchSize)
+		if err != nil {
+			return last, err
+		}
+
+		for _, change := range changes {
+			if err := fn(change); err != nil {
+				return last, err
+			}
+			last = change.Event.Position
+		}
+
+		if len(changes) < taskStreamBatchSize {
+			last = upTo
+		}
+	}
+
+	return last, nil
+}
+
+// @Summary		Stream Task Events
+// @Description	Streams changes to the tasks the user can see in the workspace as server-sent events named task.created, task.updated and task.deleted, with the position of the change as the event id. Reconnecting with the Last-Event-ID header, or the last_event_id query parameter, resumes after that change, otherwise only new changes are sent. A comment is sent as a heartbeat while there are no changes. Browsers can't set headers on an EventSource, so the workspace can also be given as the workspace_id query parameter.
+// @Tags			Tasks
+// @Id				StreamTaskEvents
+// @Produce		text/event-stream
+// @Param			X-Workspace-ID	header		int		false	"workspace to act in, defaults to the personal workspace"
+// @Param			workspace_id	query		int		false	"workspace to act in, used when the X-Workspace-ID header isn't set"
+// @Param			Last-Event-ID	header		string	false	"id of the last event received"
+// @Param			last_event_id	query		string	false	"id of the last event received, used when the Last-Event-ID header isn't set"
+// @Success		200				{object}	TaskStreamEvent
+// @Failure		400,401,403,404	{object}	ErrorResponse
+// @Security		BasicAuth
+// @Router			/tasks/events [get]
+func (a *Application) StreamTaskEvents(w http.ResponseWriter, r *http.Request) {
+	user := a.getCtxUser(r)
+	workspace := a.getCtxWorkspace(r)
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		render.Render(w, r, ErrInternalServerError("Streaming is not supported"))
+		return
+	}
+
+	rawLastEventID := r.Header.Get(lastEventIDHeader)
+	if rawLastEventID == "" {
+		rawLastEventID = r.URL.Query().Get("last_event_id")
+	}
+
+	// subscribing before reading any events makes sure none are missed in between
+	updates, unsubscribe := a.feed.Subscribe(TopicTaskEvents)
+	defer unsubscribe()
+
+	var last EventPosition
+	if rawLastEventID != "" {
+		position, err := parseEventPosition(rawLastEventID)
+		if err != nil {
+			render.Render(w, r, ErrBadRequest("Invalid last event id"))
+			return
+		}
+		last = position
+	} else {
+		position, err := a.store.Tasks().GetTaskEventWatermark(r.Context())
+		if err != nil {
+			render.Render(w, r, ErrInternalServerError("An unexpected error occured"))
+			slog.Error(err.Error())
+			return
+		}
+		last = position
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	// keeps reverse proxies like nginx from buffering the stream
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	heartbeat := time.NewTicker(taskStreamHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		var err error
+		last, err = a.forEachTaskChange(r.Context(), workspace.ID, user.ID, last, func(change TaskChange) error {
+			return writeTaskStreamEvent(w, change)
+		})
+		if err != nil {
+			// the client resumes from the last event it got when it reconnects
+			if r.Context().Err() == nil {
+				slog.Error(err.Error())
+			}
+			return
+		}
+		flusher.Flush()
+
+		select {
+		case <-r.Context().Done():
+			return
+		case <-a.shutdown:
+			return
+		case <-updates:
+			heartbeat.Reset(taskStreamHeartbeatInterval)
+			continue
+		case <-heartbeat.C:
+		}
+
+		if _, err := io.WriteString(w, ": heartbeat\n\n"); err != nil {
+			return
+		}
+	}
+}
@@ -6,8 +6,10 @@ import (
 	"log/slog"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/render"
 	"gopkg.in/guregu/null.v4"
 )
@@ -26,11 +28,59 @@ func (a *Application) getCtxUser(r *http.Request) *User {
 	return user
 }
 
+func (a *Application) getEventMeta(r *http.Request) EventMeta {
+	return EventMeta{
+		ActorID:         a.getCtxUser(r).ID,
+		RequestID:       middleware.GetReqID(r.Context()),
+		ExpectedVersion: expectedVersion(r),
+	}
+}
+
+// expectedVersion returns the task version in the request's If-Match header,
+// or 0 if it doesn't have one. Values that aren't a version can't match any.
+func expectedVersion(r *http.Request) int {
+	ifMatch := strings.TrimSpace(r.Header.Get("If-Match"))
+	if ifMatch == "" || ifMatch == "*" {
+		return 0
+	}
+
+	version, err := strconv.Atoi(strings.Trim(strings.TrimPrefix(ifMatch, "W/"), `"`))
+	if err != nil || version < 1 {
+		return -1
+	}
+
+	return version
+}
+
+// getTaskStatus returns the user's status in the workspace with the given id,
+// or their default status in category when no id is given
+func (a *Application) getTaskStatus(ctx context.Context, workspaceID int, userID int, statusID *int, category StatusCategory) (*Status, error) {
+	if statusID != nil {
+		return a.store.Statuses().GetStatusByID(ctx, workspaceID, userID, *statusID)
+	}
+
+	return a.store.Statuses().GetDefaultStatus(ctx, workspaceID, userID, category)
+}
+
 func (a *Application) setCtxPaging(r *http.Request, paging Paging) *http.Request {
 	ctx := context.WithValue(r.Context(), pagingContextKey, paging)
 	return r.WithContext(ctx)
 }
 
+func (a *Application) setCtxWorkspace(r *http.Request, workspace *Workspace) *http.Request {
+	ctx := context.WithValue(r.Context(), workspaceContextKey, workspace)
+	return r.WithContext(ctx)
+}
+
+func (a *Application) getCtxWorkspace(r *http.Request) *Workspace {
+	workspace, ok := r.Context().Value(workspaceContextKey).(*Workspace)
+	if !ok {
+		panic("missing workspace in request context")
+	}
+
+	return workspace
+}
+
 func (a *Application) getCtxPaging(r *http.Request) Paging {
 	paging, ok := r.Context().Value(pagingContextKey).(Paging)
 	if !ok {
@@ -98,9 +148,10 @@ func (a *Application) RegisterUser(w http.ResponseWriter, r *http.Request) {
 // @Summary	Create Task
 // @Tags		Tasks
 // @Id			CreateTasks
-// @Param		request	body		CreateTaskRequest	true	"request body"
-// @Success	201		{object}	SuccessResponse{data=CreateTaskResponse}
-// @Failure	400,401	{object}	ErrorResponse
+// @Param		X-Workspace-ID	header		int					false	"workspace to act in, defaults to the personal workspace"
+// @Param		request			body		CreateTaskRequest	true	"request body"
+// @Success	201				{object}	SuccessResponse{data=CreateTaskResponse}
+// @Failure	400,401,403,409	{object}	ErrorResponse
 // @Security	ApiKeyAuth
 // @Router		/tasks [post]
 func (a *Application) CreateTask(w http.ResponseWriter, r *http.Request) {
@@ -117,37 +168,155 @@ func (a *Application) CreateTask(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	taskPayload := &Task{
-		Title:       requestBody.Title,
-		Description: requestBody.Description,
-		UserID:      user.ID,
+	taskPayload, mentionedIDs, err := a.newTaskFromRequest(r, &requestBody, StatusCategoryTodo)
+	if err != nil {
+		renderError(w, r, err)
+		return
 	}
 
-	newTask, err := a.store.Tasks().CreateTask(r.Context(), taskPayload)
+	newTask, err := a.store.Tasks().CreateTask(r.Context(), taskPayload, a.getEventMeta(r))
 	if err != nil {
+		if errors.Is(err, ErrTaskUIDExists) {
+			render.Render(w, r, ErrConflict("A task with the same uid exists"))
+			return
+		}
 		render.Render(w, r, ErrInternalServerError("An unexpected error occured"))
 		slog.Error(err.Error())
 		return
 	}
 
+	a.notifyAssignee(r.Context(), newTask, user.ID)
+	a.saveMentions(r.Context(), newTask, null.Int{}, mentionedIDs, user.ID)
+
 	render.Status(r, http.StatusCreated)
 	render.Render(w, r, NewSuccessResponse(CreateTaskResponse{Task: *newTask}))
 }
 
+// newTaskFromRequest resolves a validated request into a task the request's
+// user can create, along with the ids of the users its description mentions.
+// Tasks without a status get the default status in category.
+func (a *Application) newTaskFromRequest(r *http.Request, requestBody *CreateTaskRequest, category StatusCategory) (*Task, []int, error) {
+	workspace := a.getCtxWorkspace(r)
+
+	taskPayload := &Task{
+		Title:       requestBody.Title,
+		Description: requestBody.Description,
+		UserID:      a.getCtxUser(r).ID,
+		WorkspaceID: workspace.ID,
+		DueAt:       requestBody.DueAt,
+		Priority:    requestBody.Priority,
+		Tags:        requestBody.Tags,
+		Recurrence:  requestBody.Recurrence,
+		UID:         null.NewString(requestBody.UID, requestBody.UID != ""),
+	}
+
+	if requestBody.ProjectID != nil {
+		project, err := a.authorizeProject(r, *requestBody.ProjectID, ProjectRoleEditor)
+		if err != nil {
+			switch {
+			case errors.Is(err, ErrProjectNotFound):
+				return nil, nil, clientError(ErrBadRequest("Invalid project"))
+			case errors.Is(err, ErrPermissionDenied):
+				return nil, nil, clientError(ErrForbidden("You do not have permission to add tasks to this project"))
+			}
+			return nil, nil, err
+		}
+
+		taskPayload.ProjectID = null.IntFrom(int64(project.ID))
+	}
+
+	if requestBody.ParentID != nil {
+		parent, err := a.authorizeTask(r, *requestBody.ParentID, ProjectRoleViewer, taskLive)
+		if err != nil {
+			if errors.Is(err, ErrTaskNotFound) {
+				return nil, nil, clientError(ErrBadRequest("Invalid parent task"))
+			}
+			return nil, nil, err
+		}
+
+		if parent.ProjectID != taskPayload.ProjectID {
+			return nil, nil, clientError(ErrBadRequest("Subtasks must be in the same project as their parent task"))
+		}
+
+		taskPayload.ParentID = null.IntFrom(int64(parent.ID))
+	}
+
+	statusOwnerID, err := a.statusOwnerID(r.Context(), taskPayload)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	status, err := a.getTaskStatus(r.Context(), taskPayload.WorkspaceID, statusOwnerID, requestBody.StatusID, category)
+	if err != nil {
+		if errors.Is(err, ErrStatusNotFound) {
+			return nil, nil, clientError(ErrBadRequest("Invalid status"))
+		}
+		return nil, nil, err
+	}
+
+	taskPayload.StatusID = status.ID
+
+	if requestBody.AssigneeID != nil {
+		if err := a.checkAssignee(r.Context(), taskPayload, *requestBody.AssigneeID); err != nil {
+			if errors.Is(err, ErrInvalidAssignee) {
+				return nil, nil, clientError(ErrBadRequest("Assignee is not a member of the task's project"))
+			}
+			return nil, nil, err
+		}
+
+		taskPayload.AssigneeID = null.IntFrom(int64(*requestBody.AssigneeID))
+	}
+
+	mentionedIDs, invalidMentions, err := a.resolveMentions(r.Context(), taskPayload, taskPayload.Description)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(invalidMentions) > 0 {
+		return nil, nil, clientError(ErrBadRequest(invalidMentionsMessage(invalidMentions)))
+	}
+
+	return taskPayload, mentionedIDs, nil
+}
+
 // @Summary	Get Tasks
 // @Tags		Tasks
 // @Id			GetTasks
-// @Param		cursor		query		int		false	"cursor for forward pagination"
-// @Param		per_page	query		int		false	"maximum number of tasks to return"
-// @Param		status		query		string	false	"filter by task status"	Enums(completed, pending)
-// @Success	201			{object}	SuccessResponse{data=CreateTaskResponse,paging=PaginationData}
-// @Failure	400,401		{object}	ErrorResponse
+// @Param		X-Workspace-ID	header		int		false	"workspace to act in, defaults to the personal workspace"
+// @Param		cursor			query		int		false	"cursor for forward pagination"
+// @Param		cursor_position	query		string	false	"next_cursor_position of the previous page when sorted by position"
+// @Param		per_page		query		int		false	"maximum number of tasks to return"
+// @Param		status			query		string	false	"filter by task status or status category"	Enums(completed, pending, archived, todo, in_progress, done, cancelled)
+// @Param		status_id		query		int		false	"filter by workflow status"
+// @Param		project_id		query		int		false	"filter by project"
+// @Param		assignee		query		string	false	"only return tasks assigned to the authenticated user"	Enums(me)
+// @Param		assignee_id		query		int		false	"filter by assignee"
+// @Param		mentioned		query		string	false	"only return tasks mentioning the authenticated user"	Enums(me)
+// @Param		tag				query		string	false	"filter by tag"
+// @Param		sort			query		string	false	"order tasks newest first or by their manual position"	Enums(newest, position)
+// @Success	201				{object}	SuccessResponse{data=CreateTaskResponse,paging=PaginationData}
+// @Failure	400,401			{object}	ErrorResponse
 // @Security	BasicAuth
 // @Router		/tasks [get]
 func (a *Application) GetTasks(w http.ResponseWriter, r *http.Request) {
 	user := a.getCtxUser(r)
+	workspace := a.getCtxWorkspace(r)
 	paging := a.getCtxPaging(r)
 
+	filter := taskFilterFromQuery(r, user)
+	tasks, paginationData, err := a.store.Tasks().GetTasks(r.Context(), workspace.ID, user.ID, filter, paging)
+	if err != nil {
+		render.Render(w, r, ErrInternalServerError("An unexpected error occured"))
+		slog.Error(err.Error())
+		return
+	}
+
+	payload := NewSuccessResponse(GetTasksResponse{tasks}).WithPaginationData(paginationData)
+	render.Render(w, r, payload)
+}
+
+// taskFilterFromQuery returns the task filter in the request's query
+func taskFilterFromQuery(r *http.Request, user *User) TaskFilter {
 	status := r.URL.Query().Get("status")
 	var isCompleted null.Bool
 	if status == "completed" {
@@ -156,24 +325,68 @@ func (a *Application) GetTasks(w http.ResponseWriter, r *http.Request) {
 		isCompleted = null.BoolFrom(false)
 	}
 
-	tasks, paginationData, err := a.store.Tasks().GetTasks(r.Context(), user.ID, TaskFilter{IsCompleted: isCompleted}, paging)
-	if err != nil {
-		render.Render(w, r, ErrInternalServerError("An unexpected error occured"))
-		slog.Error(err.Error())
-		return
+	var statusCategory null.String
+	if StatusCategory(status).IsValid() {
+		statusCategory = null.StringFrom(status)
 	}
 
-	payload := NewSuccessResponse(GetTasksResponse{tasks}).WithPaginationData(paginationData)
-	render.Render(w, r, payload)
+	var statusID null.Int
+	if rawStatusID, err := strconv.Atoi(r.URL.Query().Get("status_id")); err == nil {
+		statusID = null.IntFrom(int64(rawStatusID))
+	}
+
+	// archived tasks are hidden unless they are explicitly requested
+	isArchived := status == "archived"
+
+	var projectID null.Int
+	if rawProjectID, err := strconv.Atoi(r.URL.Query().Get("project_id")); err == nil {
+		projectID = null.IntFrom(int64(rawProjectID))
+	}
+
+	var mentionedUserID null.Int
+	if r.URL.Query().Get("mentioned") == "me" {
+		mentionedUserID = null.IntFrom(int64(user.ID))
+	}
+
+	var assigneeID null.Int
+	if r.URL.Query().Get("assignee") == "me" {
+		assigneeID = null.IntFrom(int64(user.ID))
+	} else if rawAssigneeID, err := strconv.Atoi(r.URL.Query().Get("assignee_id")); err == nil {
+		assigneeID = null.IntFrom(int64(rawAssigneeID))
+	}
+
+	var tag null.String
+	if rawTag := strings.TrimSpace(r.URL.Query().Get("tag")); rawTag != "" {
+		tag = null.StringFrom(strings.ToLower(strings.TrimPrefix(rawTag, "#")))
+	}
+
+	sort := TaskSortNewest
+	if TaskSort(r.URL.Query().Get("sort")) == TaskSortPosition {
+		sort = TaskSortPosition
+	}
+
+	return TaskFilter{
+		IsCompleted:     isCompleted,
+		IsArchived:      isArchived,
+		StatusID:        statusID,
+		StatusCategory:  statusCategory,
+		ProjectID:       projectID,
+		AssigneeID:      assigneeID,
+		MentionedUserID: mentionedUserID,
+		Tag:             tag,
+		Sort:            sort,
+	}
 }
 
 // @Summary	Edit Tasks
 // @Tags		Tasks
 // @Id			EditTasks
-// @Param		id			path		int					true	"task id"
-// @Param		request		body		EditTaskResponse	true	"request body"
-// @Success	200			{object}	SuccessResponse{data=EditTaskResponse}
-// @Failure	400,401,404	{object}	ErrorResponse
+// @Param		X-Workspace-ID		header		int					false	"workspace to act in, defaults to the personal workspace"
+// @Param		id					path		int					true	"task id"
+// @Param		If-Match			header		string				false	"only change the task if it is still at this version"
+// @Param		request				body		EditTaskResponse	true	"request body"
+// @Success	200					{object}	SuccessResponse{data=EditTaskResponse}
+// @Failure	400,401,403,404,412	{object}	ErrorResponse
 // @Security	BasicAuth
 // @Router		/tasks/{id} [patch]
 func (a *Application) EditTask(w http.ResponseWriter, r *http.Request) {
@@ -186,15 +399,9 @@ func (a *Application) EditTask(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	task, err := a.store.Tasks().GetTaskByID(r.Context(), user.ID, id)
+	task, err := a.authorizeTask(r, id, ProjectRoleEditor, taskLive)
 	if err != nil {
-		if errors.Is(err, ErrTaskNotFound) {
-			render.Render(w, r, ErrResourceNotFound("Task not found"))
-			return
-		}
-
-		render.Render(w, r, ErrInternalServerError("An unexpected error occured"))
-		slog.Error(err.Error())
+		a.renderTaskAccessError(w, r, err)
 		return
 	}
 
@@ -217,30 +424,252 @@ func (a *Application) EditTask(w http.ResponseWriter, r *http.Request) {
 		task.Description = *requestBody.Description
 	}
 
-	if requestBody.IsCompleted != nil {
-		task.IsCompleted = *requestBody.IsCompleted
+	if requestBody.DueAt.Set {
+		task.DueAt = requestBody.DueAt.Value
+	}
+
+	if requestBody.Priority != nil {
+		task.Priority = *requestBody.Priority
+	}
+
+	if requestBody.Tags != nil {
+		task.Tags = requestBody.Tags
+	}
+
+	if requestBody.Recurrence != nil {
+		task.Recurrence = *requestBody.Recurrence
 	}
 
-	updatedTask, err := a.store.Tasks().UpdateTask(r.Context(), task)
+	oldStatusOwnerID, err := a.statusOwnerID(r.Context(), task)
 	if err != nil {
 		render.Render(w, r, ErrInternalServerError("An unexpected error occured"))
 		slog.Error(err.Error())
 		return
 	}
 
+	if requestBody.ProjectID.Set {
+		projectID := requestBody.ProjectID.Value
+		if projectID.Valid {
+			project, err := a.authorizeProject(r, int(projectID.Int64), ProjectRoleEditor)
+			if err != nil {
+				switch {
+				case errors.Is(err, ErrProjectNotFound):
+					render.Render(w, r, ErrBadRequest("Invalid project"))
+				case errors.Is(err, ErrPermissionDenied):
+					render.Render(w, r, ErrForbidden("You do not have permission to add tasks to this project"))
+				default:
+					render.Render(w, r, ErrInternalServerError("An unexpected error occured"))
+					slog.Error(err.Error())
+				}
+				return
+			}
+			projectID = null.IntFrom(int64(project.ID))
+		} else if task.ProjectID.Valid && task.UserID != user.ID {
+			// tasks outside of projects are only visible to their creator
+			render.Render(w, r, ErrForbidden("Only the task's creator can take it out of its project"))
+			return
+		}
+
+		// subtasks share their parent's project, so only tasks outside of
+		// a hierarchy can be moved on their own
+		if task.ProjectID != projectID {
+			hasSubtasks, err := a.store.Tasks().HasSubtasks(r.Context(), task.WorkspaceID, task.ID)
+			if err != nil {
+				render.Render(w, r, ErrInternalServerError("An unexpected error occured"))
+				slog.Error(err.Error())
+				return
+			}
+
+			if task.ParentID.Valid || hasSubtasks {
+				render.Render(w, r, ErrBadRequest("Tasks with a parent task or subtasks can't be moved to another project"))
+				return
+			}
+		}
+
+		task.ProjectID = projectID
+	}
+
+	statusOwnerID, err := a.statusOwnerID(r.Context(), task)
+	if err != nil {
+		render.Render(w, r, ErrInternalServerError("An unexpected error occured"))
+		slog.Error(err.Error())
+		return
+	}
+
+	// is_completed is derived from the task's status, so marking a task as completed
+	// or pending moves it into the default done or todo status
+	isCompletedChanged := requestBody.IsCompleted != nil && *requestBody.IsCompleted != task.IsCompleted
+	if requestBody.StatusID != nil || isCompletedChanged {
+		category := StatusCategoryTodo
+		if requestBody.IsCompleted != nil && *requestBody.IsCompleted {
+			category = StatusCategoryDone
+		}
+
+		status, err := a.getTaskStatus(r.Context(), task.WorkspaceID, statusOwnerID, requestBody.StatusID, category)
+		if err != nil {
+			if errors.Is(err, ErrStatusNotFound) {
+				render.Render(w, r, ErrBadRequest("Invalid status"))
+				return
+			}
+
+			render.Render(w, r, ErrInternalServerError("An unexpected error occured"))
+			slog.Error(err.Error())
+			return
+		}
+
+		task.StatusID = status.ID
+	} else if statusOwnerID != oldStatusOwnerID {
+		// moving the task into a project with another workflow keeps the
+		// category of its status
+		oldStatus, err := a.store.Statuses().GetStatusByID(r.Context(), task.WorkspaceID, oldStatusOwnerID, task.StatusID)
+		if err != nil {
+			render.Render(w, r, ErrInternalServerError("An unexpected error occured"))
+			slog.Error(err.Error())
+			return
+		}
+
+		status, err := a.store.Statuses().GetDefaultStatus(r.Context(), task.WorkspaceID, statusOwnerID, oldStatus.Category)
+		if err != nil {
+			render.Render(w, r, ErrInternalServerError("An unexpected error occured"))
+			slog.Error(err.Error())
+			return
+		}
+
+		task.StatusID = status.ID
+	}
+
+	previousAssigneeID := task.AssigneeID
+	if requestBody.AssigneeID.Set {
+		task.AssigneeID = requestBody.AssigneeID.Value
+	}
+
+	// the assignee has to be able to access the task in its new project too
+	if task.AssigneeID.Valid {
+		if err := a.checkAssignee(r.Context(), task, int(task.AssigneeID.Int64)); err != nil {
+			if errors.Is(err, ErrInvalidAssignee) && !task.ProjectID.Valid {
+				render.Render(w, r, ErrBadRequest("Tasks outside of projects can only be assigned to their creator"))
+				return
+			}
+			if errors.Is(err, ErrInvalidAssignee) {
+				render.Render(w, r, ErrBadRequest("Assignee is not a member of the task's project"))
+				return
+			}
+
+			render.Render(w, r, ErrInternalServerError("An unexpected error occured"))
+			slog.Error(err.Error())
+			return
+		}
+	}
+
+	// mentions are only checked when the description changes, so that users
+	// who lost access to the task don't block unrelated edits
+	var mentionedIDs []int
+	if requestBody.Description != nil {
+		var invalidMentions []string
+		mentionedIDs, invalidMentions, err = a.resolveMentions(r.Context(), task, task.Description)
+		if err != nil {
+			render.Render(w, r, ErrInternalServerError("An unexpected error occured"))
+			slog.Error(err.Error())
+			return
+		}
+
+		if len(invalidMentions) > 0 {
+			a.renderInvalidMentions(w, r, invalidMentions)
+			return
+		}
+	}
+
+	updatedTask, err := a.store.Tasks().UpdateTask(r.Context(), task, a.getEventMeta(r))
+	if err != nil {
+		a.renderTaskAccessError(w, r, err)
+		return
+	}
+
+	if updatedTask.AssigneeID != previousAssigneeID {
+		a.notifyAssignee(r.Context(), updatedTask, user.ID)
+	}
+
+	if requestBody.Description != nil {
+		a.saveMentions(r.Context(), updatedTask, null.Int{}, mentionedIDs, user.ID)
+	}
+
 	render.Render(w, r, NewSuccessResponse(EditTaskResponse{*updatedTask}))
 }
 
-// @Summary	Delete Tasks
+// @Summary		Delete Tasks
+// @Description	Moves the task to the trash. Trashed tasks can be restored until they are purged.
+// @Tags			Tasks
+// @Id				DeleteTasks
+// @Param			X-Workspace-ID	header	int		false	"workspace to act in, defaults to the personal workspace"
+// @Param			id				path	int		true	"task id"
+// @Param			If-Match		header	string	false	"only change the task if it is still at this version"
+// @Success		204
+// @Failure		401,403,404,412	{object}	ErrorResponse
+// @Security		BasicAuth
+// @Router			/tasks/{id} [delete]
+func (a *Application) DeleteTask(w http.ResponseWriter, r *http.Request) {
+	workspace := a.getCtxWorkspace(r)
+
+	rawID := chi.URLParam(r, "id")
+
+	id, err := strconv.Atoi(rawID)
+	if err != nil {
+		render.Render(w, r, ErrResourceNotFound("Task not found"))
+		return
+	}
+
+	if _, err := a.authorizeTask(r, id, ProjectRoleEditor, taskLive); err != nil {
+		a.renderTaskAccessError(w, r, err)
+		return
+	}
+
+	if err := a.store.Tasks().DeleteTask(r.Context(), workspace.ID, id, a.getEventMeta(r)); err != nil {
+		a.renderTaskAccessError(w, r, err)
+		return
+	}
+
+	render.NoContent(w, r)
+}
+
+// @Summary	Get Trashed Tasks
 // @Tags		Tasks
-// @Id			DeleteTasks
-// @Param		id	path	int	true	"task id"
-// @Success	204
-// @Failure	401,404	{object}	ErrorResponse
+// @Id			GetTrashedTasks
+// @Param		X-Workspace-ID	header		int	false	"workspace to act in, defaults to the personal workspace"
+// @Param		cursor			query		int	false	"cursor for forward pagination"
+// @Param		per_page		query		int	false	"maximum number of tasks to return"
+// @Success	200				{object}	SuccessResponse{data=GetTasksResponse,paging=PaginationData}
+// @Failure	401				{object}	ErrorResponse
 // @Security	BasicAuth
-// @Router		/tasks/{id} [delete]
-func (a *Application) DeleteTask(w http.ResponseWriter, r *http.Request) {
+// @Router		/tasks/trash [get]
+func (a *Application) GetTrashedTasks(w http.ResponseWriter, r *http.Request) {
 	user := a.getCtxUser(r)
+	workspace := a.getCtxWorkspace(r)
+	paging := a.getCtxPaging(r)
+
+	tasks, paginationData, err := a.store.Tasks().GetDeletedTasks(r.Context(), workspace.ID, user.ID, paging)
+	if err != nil {
+		render.Render(w, r, ErrInternalServerError("An unexpected error occured"))
+		slog.Error(err.Error())
+		return
+	}
+
+	payload := NewSuccessResponse(GetTasksResponse{tasks}).WithPaginationData(paginationData)
+	render.Render(w, r, payload)
+}
+
+// @Summary	Restore Task
+// @Tags		Tasks
+// @Id			RestoreTask
+// @Param		X-Workspace-ID	header		int		false	"workspace to act in, defaults to the personal workspace"
+// @Param		id				path		int		true	"task id"
+// @Param		If-Match		header		string	false	"only change the task if it is still at this version"
+// @Success	200				{object}	SuccessResponse{data=RestoreTaskResponse}
+// @Failure	401,403,404,412	{object}	ErrorResponse
+// @Security	BasicAuth
+// @Router		/tasks/{id}/restore [post]
+func (a *Application) RestoreTask(w http.ResponseWriter, r *http.Request) {
+	workspace := a.getCtxWorkspace(r)
+
 	rawID := chi.URLParam(r, "id")
 
 	id, err := strconv.Atoi(rawID)
@@ -249,23 +678,360 @@ func (a *Application) DeleteTask(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	_, err = a.store.Tasks().GetTaskByID(r.Context(), user.ID, id)
+	if _, err := a.authorizeTask(r, id, ProjectRoleEditor, taskTrashed); err != nil {
+		a.renderTaskAccessError(w, r, err)
+		return
+	}
+
+	task, err := a.store.Tasks().RestoreTask(r.Context(), workspace.ID, id, a.getEventMeta(r))
 	if err != nil {
-		if errors.Is(err, ErrTaskNotFound) {
-			render.Render(w, r, ErrResourceNotFound("Task not found"))
-			return
-		}
+		a.renderTaskAccessError(w, r, err)
+		return
+	}
+
+	render.Render(w, r, NewSuccessResponse(RestoreTaskResponse{*task}))
+}
+
+// @Summary		Purge Task
+// @Description	Permanently deletes a task from the trash
+// @Tags			Tasks
+// @Id				PurgeTask
+// @Param			X-Workspace-ID	header	int		false	"workspace to act in, defaults to the personal workspace"
+// @Param			id				path	int		true	"task id"
+// @Param			If-Match		header	string	false	"only change the task if it is still at this version"
+// @Success		204
+// @Failure		401,403,404,412	{object}	ErrorResponse
+// @Security		BasicAuth
+// @Router			/tasks/trash/{id} [delete]
+func (a *Application) PurgeTask(w http.ResponseWriter, r *http.Request) {
+	workspace := a.getCtxWorkspace(r)
 
+	rawID := chi.URLParam(r, "id")
+
+	id, err := strconv.Atoi(rawID)
+	if err != nil {
+		render.Render(w, r, ErrResourceNotFound("Task not found"))
+		return
+	}
+
+	if _, err := a.authorizeTask(r, id, ProjectRoleEditor, taskTrashed); err != nil {
+		a.renderTaskAccessError(w, r, err)
+		return
+	}
+
+	if err := a.store.Tasks().PurgeTask(r.Context(), workspace.ID, id, a.getEventMeta(r)); err != nil {
+		a.renderTaskAccessError(w, r, err)
+		return
+	}
+
+	render.NoContent(w, r)
+}
+
+// @Summary	Archive Task
+// @Tags		Tasks
+// @Id			ArchiveTask
+// @Param		X-Workspace-ID	header		int		false	"workspace to act in, defaults to the personal workspace"
+// @Param		id				path		int		true	"task id"
+// @Param		If-Match		header		string	false	"only change the task if it is still at this version"
+// @Success	200				{object}	SuccessResponse{data=ArchiveTaskResponse}
+// @Failure	401,403,404,412	{object}	ErrorResponse
+// @Security	BasicAuth
+// @Router		/tasks/{id}/archive [post]
+func (a *Application) ArchiveTask(w http.ResponseWriter, r *http.Request) {
+	workspace := a.getCtxWorkspace(r)
+
+	rawID := chi.URLParam(r, "id")
+
+	id, err := strconv.Atoi(rawID)
+	if err != nil {
+		render.Render(w, r, ErrResourceNotFound("Task not found"))
+		return
+	}
+
+	if _, err := a.authorizeTask(r, id, ProjectRoleEditor, taskLive); err != nil {
+		a.renderTaskAccessError(w, r, err)
+		return
+	}
+
+	task, err := a.store.Tasks().ArchiveTask(r.Context(), workspace.ID, id, a.getEventMeta(r))
+	if err != nil {
+		a.renderTaskAccessError(w, r, err)
+		return
+	}
+
+	render.Render(w, r, NewSuccessResponse(ArchiveTaskResponse{*task}))
+}
+
+// @Summary	Unarchive Task
+// @Tags		Tasks
+// @Id			UnarchiveTask
+// @Param		X-Workspace-ID	header		int		false	"workspace to act in, defaults to the personal workspace"
+// @Param		id				path		int		true	"task id"
+// @Param		If-Match		header		string	false	"only change the task if it is still at this version"
+// @Success	200				{object}	SuccessResponse{data=ArchiveTaskResponse}
+// @Failure	401,403,404,412	{object}	ErrorResponse
+// @Security	BasicAuth
+// @Router		/tasks/{id}/unarchive [post]
+func (a *Application) UnarchiveTask(w http.ResponseWriter, r *http.Request) {
+	workspace := a.getCtxWorkspace(r)
+
+	rawID := chi.URLParam(r, "id")
+
+	id, err := strconv.Atoi(rawID)
+	if err != nil {
+		render.Render(w, r, ErrResourceNotFound("Task not found"))
+		return
+	}
+
+	if _, err := a.authorizeTask(r, id, ProjectRoleEditor, taskLive); err != nil {
+		a.renderTaskAccessError(w, r, err)
+		return
+	}
+
+	task, err := a.store.Tasks().UnarchiveTask(r.Context(), workspace.ID, id, a.getEventMeta(r))
+	if err != nil {
+		a.renderTaskAccessError(w, r, err)
+		return
+	}
+
+	render.Render(w, r, NewSuccessResponse(ArchiveTaskResponse{*task}))
+}
+
+// @Summary	Get Task History
+// @Tags		Tasks
+// @Id			GetTaskHistory
+// @Param		X-Workspace-ID	header		int	false	"workspace to act in, defaults to the personal workspace"
+// @Param		id				path		int	true	"task id"
+// @Param		cursor			query		int	false	"cursor for forward pagination"
+// @Param		per_page		query		int	false	"maximum number of events to return"
+// @Success	200				{object}	SuccessResponse{data=GetTaskHistoryResponse,paging=PaginationData}
+// @Failure	401,404			{object}	ErrorResponse
+// @Security	BasicAuth
+// @Router		/tasks/{id}/history [get]
+func (a *Application) GetTaskHistory(w http.ResponseWriter, r *http.Request) {
+	workspace := a.getCtxWorkspace(r)
+
+	paging := a.getCtxPaging(r)
+	rawID := chi.URLParam(r, "id")
+
+	id, err := strconv.Atoi(rawID)
+	if err != nil {
+		render.Render(w, r, ErrResourceNotFound("Task not found"))
+		return
+	}
+
+	if _, err := a.authorizeTask(r, id, ProjectRoleViewer, taskAnyState); err != nil {
+		a.renderTaskAccessError(w, r, err)
+		return
+	}
+
+	events, paginationData, err := a.store.Tasks().GetTaskEvents(r.Context(), workspace.ID, id, paging)
+	if err != nil {
 		render.Render(w, r, ErrInternalServerError("An unexpected error occured"))
 		slog.Error(err.Error())
 		return
 	}
 
-	if err := a.store.Tasks().DeleteTask(r.Context(), user.ID, id); err != nil {
+	payload := NewSuccessResponse(GetTaskHistoryResponse{events}).WithPaginationData(paginationData)
+	render.Render(w, r, payload)
+}
+
+// @Summary	Get Undo Stack
+// @Tags		Tasks
+// @Id			GetUndoStack
+// @Param		X-Workspace-ID	header		int	false	"workspace to act in, defaults to the personal workspace"
+// @Param		cursor			query		int	false	"cursor for forward pagination"
+// @Param		per_page		query		int	false	"maximum number of events to return"
+// @Success	200				{object}	SuccessResponse{data=GetUndoStackResponse,paging=PaginationData}
+// @Failure	401				{object}	ErrorResponse
+// @Security	BasicAuth
+// @Router		/tasks/undo [get]
+func (a *Application) GetUndoStack(w http.ResponseWriter, r *http.Request) {
+	user := a.getCtxUser(r)
+	workspace := a.getCtxWorkspace(r)
+	paging := a.getCtxPaging(r)
+
+	events, paginationData, err := a.store.Tasks().GetUndoableTaskEvents(r.Context(), workspace.ID, user.ID, paging)
+	if err != nil {
 		render.Render(w, r, ErrInternalServerError("An unexpected error occured"))
 		slog.Error(err.Error())
 		return
 	}
 
-	render.NoContent(w, r)
+	payload := NewSuccessResponse(GetUndoStackResponse{events}).WithPaginationData(paginationData)
+	render.Render(w, r, payload)
+}
+
+// @Summary		Undo Last Change
+// @Description	Reverts the most recent task change made by the authenticated user
+// @Tags			Tasks
+// @Id				UndoLastChange
+// @Param			X-Workspace-ID	header		int	false	"workspace to act in, defaults to the personal workspace"
+// @Success		200				{object}	SuccessResponse{data=UndoResponse}
+// @Failure		401,404,409		{object}	ErrorResponse
+// @Security		BasicAuth
+// @Router			/tasks/undo [post]
+func (a *Application) UndoLastChange(w http.ResponseWriter, r *http.Request) {
+	user := a.getCtxUser(r)
+	workspace := a.getCtxWorkspace(r)
+
+	latest, _, err := a.store.Tasks().GetUndoableTaskEvents(r.Context(), workspace.ID, user.ID, Paging{Cursor: defaultCursor, PerPage: 1})
+	if err != nil {
+		a.renderUndoError(w, r, err)
+		return
+	}
+
+	if len(latest) == 0 {
+		a.renderUndoError(w, r, ErrNothingToUndo)
+		return
+	}
+
+	// the user may have lost access to the task since they changed it
+	if _, err := a.authorizeTask(r, latest[0].TaskID, ProjectRoleEditor, taskAnyState); err != nil {
+		if errors.Is(err, ErrTaskNotFound) || errors.Is(err, ErrPermissionDenied) {
+			err = ErrUndoConflict
+		}
+		a.renderUndoError(w, r, err)
+		return
+	}
+
+	task, undone, err := a.store.Tasks().UndoLastTaskChange(r.Context(), workspace.ID, user.ID, latest[0].TaskID, a.getEventMeta(r))
+	if err != nil {
+		a.renderUndoError(w, r, err)
+		return
+	}
+
+	render.Render(w, r, NewSuccessResponse(UndoResponse{Task: *task, Undone: *undone}))
+}
+
+// @Summary		Undo Task Change
+// @Description	Reverts the most recent update to a task or restores it if it was deleted
+// @Tags			Tasks
+// @Id				UndoTask
+// @Param			X-Workspace-ID	header		int	false	"workspace to act in, defaults to the personal workspace"
+// @Param			id				path		int	true	"task id"
+// @Success		200				{object}	SuccessResponse{data=UndoResponse}
+// @Failure		401,403,404,409	{object}	ErrorResponse
+// @Security		BasicAuth
+// @Router			/tasks/{id}/undo [post]
+func (a *Application) UndoTask(w http.ResponseWriter, r *http.Request) {
+	user := a.getCtxUser(r)
+	workspace := a.getCtxWorkspace(r)
+	rawID := chi.URLParam(r, "id")
+
+	id, err := strconv.Atoi(rawID)
+	if err != nil {
+		render.Render(w, r, ErrResourceNotFound("Task not found"))
+		return
+	}
+
+	if _, err := a.authorizeTask(r, id, ProjectRoleEditor, taskAnyState); err != nil {
+		a.renderTaskAccessError(w, r, err)
+		return
+	}
+
+	task, undone, err := a.store.Tasks().UndoLastTaskChange(r.Context(), workspace.ID, user.ID, id, a.getEventMeta(r))
+	if err != nil {
+		a.renderUndoError(w, r, err)
+		return
+	}
+
+	render.Render(w, r, NewSuccessResponse(UndoResponse{Task: *task, Undone: *undone}))
+}
+
+func (a *Application) renderUndoError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, ErrNothingToUndo):
+		render.Render(w, r, ErrResourceNotFound("Nothing to undo"))
+	case errors.Is(err, ErrUndoConflict):
+		render.Render(w, r, ErrConflict("Task has been changed since, it can no longer be undone"))
+	default:
+		render.Render(w, r, ErrInternalServerError("An unexpected error occured"))
+		slog.Error(err.Error())
+	}
+}
+
+// @Summary		Move Task
+// @Description	Places a task between two neighbours in the manual order. Either neighbour can be omitted to place the task directly after or before the other one, and omitting both moves it to the end. A status_id moves the task into that status in the same change, e.g. between board columns.
+// @Tags			Tasks
+// @Id				MoveTask
+// @Param			X-Workspace-ID		header		int				false	"workspace to act in, defaults to the personal workspace"
+// @Param			id					path		int				true	"task id"
+// @Param			If-Match			header		string			false	"only change the task if it is still at this version"
+// @Param			request				body		MoveTaskRequest	true	"request body"
+// @Success		200					{object}	SuccessResponse{data=MoveTaskResponse}
+// @Failure		400,401,403,404,412	{object}	ErrorResponse
+// @Security		BasicAuth
+// @Router			/tasks/{id}/move [post]
+func (a *Application) MoveTask(w http.ResponseWriter, r *http.Request) {
+	workspace := a.getCtxWorkspace(r)
+
+	rawID := chi.URLParam(r, "id")
+
+	id, err := strconv.Atoi(rawID)
+	if err != nil {
+		render.Render(w, r, ErrResourceNotFound("Task not found"))
+		return
+	}
+
+	var requestBody MoveTaskRequest
+	if err := render.Bind(r, &requestBody); err != nil {
+		render.Render(w, r, ErrBadRequest("Invalid request body"))
+		return
+	}
+
+	if err := requestBody.Validate(); err != nil {
+		render.Render(w, r, ErrBadRequest(err.Error()))
+		return
+	}
+
+	task, err := a.authorizeTask(r, id, ProjectRoleEditor, taskLive)
+	if err != nil {
+		a.renderTaskAccessError(w, r, err)
+		return
+	}
+
+	if requestBody.StatusID.Valid {
+		statusOwnerID, err := a.statusOwnerID(r.Context(), task)
+		if err != nil {
+			render.Render(w, r, ErrInternalServerError("An unexpected error occured"))
+			slog.Error(err.Error())
+			return
+		}
+
+		if _, err := a.store.Statuses().GetStatusByID(r.Context(), task.WorkspaceID, statusOwnerID, int(requestBody.StatusID.Int64)); err != nil {
+			if errors.Is(err, ErrStatusNotFound) {
+				render.Render(w, r, ErrBadRequest("Invalid status"))
+				return
+			}
+
+			render.Render(w, r, ErrInternalServerError("An unexpected error occured"))
+			slog.Error(err.Error())
+			return
+		}
+	}
+
+	move := TaskMove{
+		AfterID:  requestBody.AfterID,
+		BeforeID: requestBody.BeforeID,
+		StatusID: requestBody.StatusID,
+	}
+
+	movedTask, err := a.store.Tasks().MoveTask(r.Context(), workspace.ID, id, move, a.getEventMeta(r))
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrTaskNotFound):
+			render.Render(w, r, ErrResourceNotFound("Task not found"))
+		case errors.Is(err, ErrInvalidMove):
+			render.Render(w, r, ErrBadRequest("Invalid neighbouring tasks"))
+		case errors.Is(err, ErrVersionConflict):
+			render.Render(w, r, ErrPreconditionFailed("Task was changed since the version in If-Match"))
+		default:
+			render.Render(w, r, ErrInternalServerError("An unexpected error occured"))
+			slog.Error(err.Error())
+		}
+		return
+	}
+
+	render.Render(w, r, NewSuccessResponse(MoveTaskResponse{*movedTask}))
 }
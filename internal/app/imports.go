@@ -0,0 +1,279 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+	"unicode/utf8"
+
+	"github.com/ayo-awe/golang_todo_api/internal/importer"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"gopkg.in/guregu/null.v4"
+)
+
+const (
+	maxImportTasks  = 5000
+	importBatchSize = 100
+	// importLease is how long a claimed import is hidden from other workers, it
+	// is extended after every batch
+	importLease = 5 * time.Minute
+	// maxNameLength is the length of task titles and project names
+	maxNameLength = 255
+)
+
+// @Summary		Import from another app
+// @Description	Imports a todo.txt file (todotxt), a Todoist project CSV (todoist_csv) or backup (todoist_json), or a
+// @Description	Trello board (trello_json). The file is parsed right away and its tasks are created in the background,
+// @Description	along with a project for each of the file's projects. Poll the import to follow its progress.
+// @Description	Priorities, tags and due dates are kept, Todoist sections, todo.txt contexts and Trello lists and labels
+// @Description	become tags. Tags that aren't valid are dropped and long titles are cut short.
+// @Tags			Imports
+// @Id				CreateImport
+// @Accept			multipart/form-data
+// @Param			X-Workspace-ID	header		int		false	"workspace to act in, defaults to the personal workspace"
+// @Param			file			formData	file	true	"exported file"
+// @Param			format			formData	string	true	"format of the file"	Enums(todotxt, todoist_csv, todoist_json, trello_json)
+// @Success		202				{object}	SuccessResponse{data=ImportResponse}
+// @Failure		400,401,403,413	{object}	ErrorResponse
+// @Security		BasicAuth
+// @Router			/imports [post]
+func (a *Application) CreateImport(w http.ResponseWriter, r *http.Request) {
+	user := a.getCtxUser(r)
+	workspace := a.getCtxWorkspace(r)
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxImportSize)
+	if err := r.ParseMultipartForm(maxImportSize); err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			render.Render(w, r, ErrPayloadTooLarge(fmt.Sprintf("Imports can't be larger than %d bytes", maxImportSize)))
+			return
+		}
+		render.Render(w, r, ErrBadRequest("Expected a multipart/form-data request body"))
+		return
+	}
+	defer r.MultipartForm.RemoveAll()
+
+	format := importer.Format(r.FormValue("format"))
+	if !format.IsValid() {
+		render.Render(w, r, ErrBadRequest("Invalid format"))
+		return
+	}
+
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		render.Render(w, r, ErrBadRequest("Missing file"))
+		return
+	}
+	defer file.Close()
+
+	result, err := importer.Parse(format, header.Filename, file)
+	if err != nil {
+		render.Render(w, r, ErrBadRequest(fmt.Sprintf("Invalid file: %s", err)))
+		return
+	}
+
+	if len(result.Tasks) == 0 {
+		render.Render(w, r, ErrBadRequest("File has no tasks"))
+		return
+	}
+
+	if len(result.Tasks) > maxImportTasks {
+		render.Render(w, r, ErrBadRequest(fmt.Sprintf("Imports can't have more than %d tasks", maxImportTasks)))
+		return
+	}
+
+	imp, err := a.store.Imports().CreateImport(r.Context(), &Import{
+		UserID:      user.ID,
+		WorkspaceID: workspace.ID,
+		Format:      format,
+		Data:        result,
+		Total:       len(result.Tasks),
+	})
+	if err != nil {
+		render.Render(w, r, ErrInternalServerError("An unexpected error occured"))
+		slog.Error(err.Error())
+		return
+	}
+
+	render.Status(r, http.StatusAccepted)
+	render.Render(w, r, NewSuccessResponse(ImportResponse{*imp}))
+}
+
+// @Summary	Get Import
+// @Tags		Imports
+// @Id			GetImport
+// @Param		id	path		int	true	"import id"
+// @Success	200	{object}	SuccessResponse{data=ImportResponse}
+// @Failure	401	{object}	ErrorResponse
+// @Failure	404	{object}	ErrorResponse
+// @Security	BasicAuth
+// @Router		/imports/{id} [get]
+func (a *Application) GetImport(w http.ResponseWriter, r *http.Request) {
+	user := a.getCtxUser(r)
+
+	rawID := chi.URLParam(r, "id")
+	importID, err := strconv.Atoi(rawID)
+	if err != nil {
+		render.Render(w, r, ErrResourceNotFound("Import not found"))
+		return
+	}
+
+	imp, err := a.store.Imports().GetImport(r.Context(), user.ID, importID)
+	if err != nil {
+		if errors.Is(err, ErrImportNotFound) {
+			render.Render(w, r, ErrResourceNotFound("Import not found"))
+			return
+		}
+		render.Render(w, r, ErrInternalServerError("An unexpected error occured"))
+		slog.Error(err.Error())
+		return
+	}
+
+	render.Render(w, r, NewSuccessResponse(ImportResponse{*imp}))
+}
+
+// runImports runs the imports that are waiting until there are none left
+func (a *Application) runImports(ctx context.Context) {
+	for ctx.Err() == nil {
+		imp, err := a.store.Imports().ClaimImport(ctx, time.Now().Add(importLease))
+		if err != nil {
+			if !errors.Is(err, ErrImportNotFound) {
+				slog.Error(err.Error())
+			}
+			return
+		}
+
+		message, err := a.runImport(ctx, imp)
+		if ctx.Err() != nil {
+			// shutting down, the import is picked up again once its lease runs out
+			return
+		}
+
+		if err != nil {
+			slog.Error(err.Error())
+			message = "An unexpected error occured"
+		}
+
+		if message != "" {
+			if err := a.store.Imports().FailImport(ctx, imp.ID, message); err != nil {
+				slog.Error(err.Error())
+			}
+		}
+	}
+}
+
+// runImport creates the import's projects and then its tasks, in batches. An
+// import that was interrupted carries on from its last batch. It returns why
+// the import failed if it can't be run.
+func (a *Application) runImport(ctx context.Context, imp *Import) (string, error) {
+	// the user may have left the workspace since uploading the file
+	if _, err := a.store.Workspaces().GetWorkspace(ctx, imp.WorkspaceID, imp.UserID); err != nil {
+		if errors.Is(err, ErrWorkspaceNotFound) {
+			return "You are no longer a member of the workspace", nil
+		}
+		return "", err
+	}
+
+	todo, err := a.store.Statuses().GetDefaultStatus(ctx, imp.WorkspaceID, imp.UserID, StatusCategoryTodo)
+	if err != nil {
+		return "", err
+	}
+
+	done, err := a.store.Statuses().GetDefaultStatus(ctx, imp.WorkspaceID, imp.UserID, StatusCategoryDone)
+	if err != nil {
+		return "", err
+	}
+
+	for _, name := range imp.Data.Projects {
+		if _, ok := imp.ProjectIDs[name]; ok {
+			continue
+		}
+
+		project, err := a.store.Projects().CreateProject(ctx, &Project{
+			UserID:      imp.UserID,
+			WorkspaceID: imp.WorkspaceID,
+			Name:        truncateName(name),
+		})
+		if err != nil {
+			return "", err
+		}
+
+		// saved after every project so none are created twice
+		imp.ProjectIDs[name] = project.ID
+		if err := a.store.Imports().SetProjectIDs(ctx, imp.ID, imp.ProjectIDs); err != nil {
+			return "", err
+		}
+	}
+
+	meta := EventMeta{ActorID: imp.UserID, RequestID: fmt.Sprintf("import-%d", imp.ID)}
+
+	for start := imp.Processed; start < len(imp.Data.Tasks); start += importBatchSize {
+		end := min(start+importBatchSize, len(imp.Data.Tasks))
+
+		tasks := make([]*Task, 0, end-start)
+		for _, imported := range imp.Data.Tasks[start:end] {
+			task := &Task{
+				Title:       truncateName(imported.Title),
+				Description: imported.Description,
+				UserID:      imp.UserID,
+				WorkspaceID: imp.WorkspaceID,
+				StatusID:    todo.ID,
+				Priority:    TaskPriority(imported.Priority),
+				Tags:        importedTags(imported.Tags),
+			}
+
+			if imported.Completed {
+				task.StatusID = done.ID
+			}
+
+			if !task.Priority.IsValid() {
+				task.Priority = PriorityNone
+			}
+
+			if imported.DueAt != nil {
+				task.DueAt = null.TimeFrom(*imported.DueAt)
+			}
+
+			if projectID, ok := imp.ProjectIDs[imported.Project]; ok {
+				task.ProjectID = null.IntFrom(int64(projectID))
+			}
+
+			tasks = append(tasks, task)
+		}
+
+		if err := a.store.Imports().ImportTasks(ctx, imp.ID, tasks, end, time.Now().Add(importLease), meta); err != nil {
+			return "", err
+		}
+	}
+
+	_, err = a.store.Imports().CompleteImport(ctx, imp.ID)
+	return "", err
+}
+
+// importedTags drops the tags that aren't valid, along with those past the
+// limit, rather than failing the import over them
+func importedTags(tags []string) []string {
+	valid := []string{}
+	for _, tag := range tags {
+		normalized, err := normalizeTags(append(valid, tag))
+		if err != nil {
+			continue
+		}
+		valid = normalized
+	}
+
+	return valid
+}
+
+func truncateName(name string) string {
+	if utf8.RuneCountInString(name) <= maxNameLength {
+		return name
+	}
+
+	return string([]rune(name)[:maxNameLength])
+}
@@ -0,0 +1,222 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"gopkg.in/guregu/null.v4"
+)
+
+const (
+	trashPurgeInterval     = time.Hour
+	autoArchiveInterval    = time.Hour
+	blobCleanupInterval    = 5 * time.Minute
+	orphanedBlobsBatchSize = 100
+
+	webhookDeliveryInterval  = 5 * time.Second
+	webhookDeliveryBatchSize = 10
+	// webhookDeliveryLease is how long claimed deliveries are hidden from other
+	// workers, it should outlast sending a whole batch
+	webhookDeliveryLease = 5 * time.Minute
+	webhookRetryBackoff  = 30 * time.Second
+
+	presenceCleanupInterval = time.Minute
+
+	importInterval = 5 * time.Second
+
+	taskReminderInterval = time.Minute
+	// taskReminderLead is how long before a task is due it's reminded of
+	taskReminderLead = 15 * time.Minute
+	// taskReminderWindow is how long after a task was due its reminder is still
+	// sent, so reminders missed while the server was down aren't all sent at once
+	taskReminderWindow = time.Hour
+)
+
+func (a *Application) startBackgroundJobs(ctx context.Context) {
+	go runPeriodically(ctx, trashPurgeInterval, a.purgeExpiredTrash)
+	go runPeriodically(ctx, blobCleanupInterval, a.deleteOrphanedBlobs)
+	go runPeriodically(ctx, webhookDeliveryInterval, a.deliverWebhooks)
+	go runPeriodically(ctx, presenceCleanupInterval, a.deleteStaleViewers)
+	go runPeriodically(ctx, importInterval, a.runImports)
+	go runPeriodically(ctx, taskReminderInterval, a.remindDueTasks)
+	go a.feed.Run(ctx)
+
+	// auto archiving is opt-in, a zero duration disables it
+	if a.config.AUTO_ARCHIVE_AFTER > 0 {
+		go runPeriodically(ctx, autoArchiveInterval, a.archiveCompletedTasks)
+	}
+}
+
+// runPeriodically calls fn immediately and then on every tick of interval
+// until ctx is cancelled
+func runPeriodically(ctx context.Context, interval time.Duration, fn func(context.Context)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		fn(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (a *Application) purgeExpiredTrash(ctx context.Context) {
+	deletedBefore := time.Now().Add(-a.config.TRASH_RETENTION)
+
+	meta := EventMeta{RequestID: fmt.Sprintf("trash-purge-%d", time.Now().Unix())}
+
+	count, err := a.store.Tasks().PurgeDeletedTasks(ctx, deletedBefore, meta)
+	if err != nil {
+		slog.Error(err.Error())
+		return
+	}
+
+	if count > 0 {
+		slog.Info("purged expired tasks from trash", "count", count)
+	}
+}
+
+// remindDueTasks sends task_due notifications for the tasks that are almost due.
+// Reminders are claimed before they're sent, so every due date is reminded of
+// once however many servers run the job.
+func (a *Application) remindDueTasks(ctx context.Context) {
+	now := time.Now()
+
+	tasks, err := a.store.Tasks().ClaimTaskReminders(ctx, now.Add(-taskReminderWindow), now.Add(taskReminderLead))
+	if err != nil {
+		slog.Error(err.Error())
+		return
+	}
+
+	for i := range tasks {
+		a.notifyDue(ctx, &tasks[i])
+	}
+}
+
+func (a *Application) archiveCompletedTasks(ctx context.Context) {
+	completedBefore := time.Now().Add(-a.config.AUTO_ARCHIVE_AFTER)
+
+	meta := EventMeta{RequestID: fmt.Sprintf("auto-archive-%d", time.Now().Unix())}
+
+	count, err := a.store.Tasks().ArchiveCompletedTasks(ctx, completedBefore, meta)
+	if err != nil {
+		slog.Error(err.Error())
+		return
+	}
+
+	if count > 0 {
+		slog.Info("archived completed tasks", "count", count)
+	}
+}
+
+// deleteOrphanedBlobs removes the blobs of deleted attachments, including those of
+// purged tasks, from blob storage. Blobs that fail to delete are retried on the next run.
+func (a *Application) deleteOrphanedBlobs(ctx context.Context) {
+	for {
+		keys, err := a.store.Attachments().GetOrphanedBlobs(ctx, orphanedBlobsBatchSize)
+		if err != nil {
+			slog.Error(err.Error())
+			return
+		}
+
+		deleted := make([]string, 0, len(keys))
+		for _, key := range keys {
+			if err := a.blobs.Delete(ctx, key); err != nil {
+				slog.Error(err.Error())
+				continue
+			}
+			deleted = append(deleted, key)
+		}
+
+		if len(deleted) > 0 {
+			if err := a.store.Attachments().DeleteOrphanedBlobs(ctx, deleted); err != nil {
+				slog.Error(err.Error())
+				return
+			}
+			slog.Info("deleted orphaned attachment blobs", "count", len(deleted))
+		}
+
+		if len(keys) < orphanedBlobsBatchSize || len(deleted) < len(keys) {
+			return
+		}
+	}
+}
+
+// deliverWebhooks sends the deliveries that are due until there are none left
+func (a *Application) deliverWebhooks(ctx context.Context) {
+	for {
+		webhooks, err := a.store.Webhooks().ClaimDeliveries(ctx, webhookDeliveryBatchSize, time.Now().Add(webhookDeliveryLease))
+		if err != nil {
+			slog.Error(err.Error())
+			return
+		}
+
+		for _, webhook := range webhooks {
+			a.deliverWebhook(ctx, webhook)
+		}
+
+		if len(webhooks) < webhookDeliveryBatchSize || ctx.Err() != nil {
+			return
+		}
+	}
+}
+
+// deliverWebhook sends a delivery once. Failed attempts are retried with
+// exponential backoff until the delivery runs out of attempts.
+func (a *Application) deliverWebhook(ctx context.Context, webhook OutgoingWebhook) {
+	delivery := webhook.Delivery
+	delivery.Attempts++
+	delivery.ResponseStatus = null.Int{}
+	delivery.ResponseBody = ""
+	delivery.Error = ""
+
+	response, err := a.webhookSender.Send(ctx, webhook)
+	if ctx.Err() != nil {
+		// shutting down, the delivery is retried once its lease runs out
+		return
+	}
+
+	if err != nil {
+		delivery.Error = err.Error()
+	} else {
+		delivery.ResponseStatus = null.IntFrom(int64(response.StatusCode))
+		delivery.ResponseBody = response.Body
+		if response.StatusCode < 200 || response.StatusCode > 299 {
+			delivery.Error = fmt.Sprintf("receiver responded with status %d", response.StatusCode)
+		}
+	}
+
+	switch {
+	case delivery.Error == "":
+		delivery.Status = DeliverySucceeded
+	case delivery.Attempts >= a.config.WEBHOOK_MAX_ATTEMPTS:
+		delivery.Status = DeliveryFailed
+	default:
+		delivery.Status = DeliveryPending
+		delivery.NextAttemptAt = time.Now().Add(webhookRetryBackoff << (delivery.Attempts - 1))
+	}
+
+	if err := a.store.Webhooks().RecordAttempt(ctx, &delivery); err != nil {
+		slog.Error(err.Error())
+	}
+}
+
+// deleteStaleViewers removes the viewers of sessions that ended without
+// cleaning up after themselves, such as those of a crashed server
+func (a *Application) deleteStaleViewers(ctx context.Context) {
+	count, err := a.store.Presence().DeleteStaleViewers(ctx, time.Now().Add(-presenceTTL))
+	if err != nil {
+		slog.Error(err.Error())
+		return
+	}
+
+	if count > 0 {
+		slog.Info("deleted stale project viewers", "count", count)
+	}
+}
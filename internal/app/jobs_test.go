@@ -0,0 +1,201 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"gopkg.in/guregu/null.v4"
+)
+
+// stubWebhookSender responds to every delivery the same way
+type stubWebhookSender struct {
+	response *WebhookResponse
+	err      error
+}
+
+func (s *stubWebhookSender) Send(ctx context.Context, webhook OutgoingWebhook) (*WebhookResponse, error) {
+	return s.response, s.err
+}
+
+// recordingWebhookRepo keeps the attempts recorded with it, the rest of the
+// repository isn't implemented
+type recordingWebhookRepo struct {
+	WebhookRepository
+	attempts []WebhookDelivery
+}
+
+func (repo *recordingWebhookRepo) RecordAttempt(ctx context.Context, delivery *WebhookDelivery) error {
+	repo.attempts = append(repo.attempts, *delivery)
+	return nil
+}
+
+type webhookStore struct {
+	Store
+	webhooks *recordingWebhookRepo
+}
+
+func (s *webhookStore) Webhooks() WebhookRepository { return s.webhooks }
+
+func newWebhookTestApp(sender WebhookSender, maxAttempts int) (*Application, *recordingWebhookRepo) {
+	repo := &recordingWebhookRepo{}
+	a := &Application{
+		config:        &Config{WEBHOOK_MAX_ATTEMPTS: maxAttempts},
+		store:         &webhookStore{webhooks: repo},
+		webhookSender: sender,
+	}
+	return a, repo
+}
+
+func TestDeliverWebhookRetriesUntilMaxAttempts(t *testing.T) {
+	const maxAttempts = 3
+	sender := &stubWebhookSender{response: &WebhookResponse{StatusCode: 500, Body: "down"}}
+	a, repo := newWebhookTestApp(sender, maxAttempts)
+
+	delivery := WebhookDelivery{ID: 1, Status: DeliveryPending}
+	for attempt := 1; attempt <= maxAttempts; attempt++ {
+		start := time.Now()
+		a.deliverWebhook(context.Background(), OutgoingWebhook{Delivery: delivery})
+		delivery = repo.attempts[len(repo.attempts)-1]
+
+		if delivery.Attempts != attempt {
+			t.Fatalf("attempts = %d, want %d", delivery.Attempts, attempt)
+		}
+		if delivery.Error == "" || delivery.ResponseStatus.Int64 != 500 || delivery.ResponseBody != "down" {
+			t.Errorf("attempt %d recorded as %+v", attempt, delivery)
+		}
+
+		if attempt < maxAttempts {
+			if delivery.Status != DeliveryPending {
+				t.Fatalf("status after attempt %d = %s, want %s", attempt, delivery.Status, DeliveryPending)
+			}
+			// backoff doubles with every attempt
+			backoff := webhookRetryBackoff << (attempt - 1)
+			if wait := delivery.NextAttemptAt.Sub(start); wait < backoff || wait > backoff+time.Second {
+				t.Errorf("next attempt after attempt %d is in %s, want %s", attempt, wait, backoff)
+			}
+		} else if delivery.Status != DeliveryFailed {
+			t.Fatalf("status after the last attempt = %s, want %s", delivery.Status, DeliveryFailed)
+		}
+	}
+}
+
+func TestDeliverWebhookRetriesUnreachableReceivers(t *testing.T) {
+	a, repo := newWebhookTestApp(&stubWebhookSender{err: errors.New("connection refused")}, 8)
+
+	a.deliverWebhook(context.Background(), OutgoingWebhook{Delivery: WebhookDelivery{ID: 1}})
+
+	delivery := repo.attempts[0]
+	if delivery.Status != DeliveryPending || delivery.Error != "connection refused" || delivery.ResponseStatus.Valid {
+		t.Errorf("attempt recorded as %+v", delivery)
+	}
+}
+
+func TestDeliverWebhookSucceeds(t *testing.T) {
+	sender := &stubWebhookSender{response: &WebhookResponse{StatusCode: 204}}
+	a, repo := newWebhookTestApp(sender, 8)
+
+	// a previous failure is cleared by the successful attempt
+	a.deliverWebhook(context.Background(), OutgoingWebhook{Delivery: WebhookDelivery{ID: 1, Attempts: 2, Error: "timeout"}})
+
+	delivery := repo.attempts[0]
+	if delivery.Status != DeliverySucceeded || delivery.Attempts != 3 || delivery.Error != "" {
+		t.Errorf("attempt recorded as %+v", delivery)
+	}
+}
+
+// dueTaskRepo hands out the tasks it holds once, like reminders are claimed
+type dueTaskRepo struct {
+	TaskRepository
+	tasks     []Task
+	dueAfter  time.Time
+	dueBefore time.Time
+}
+
+func (repo *dueTaskRepo) ClaimTaskReminders(ctx context.Context, dueAfter time.Time, dueBefore time.Time) ([]Task, error) {
+	repo.dueAfter, repo.dueBefore = dueAfter, dueBefore
+	tasks := repo.tasks
+	repo.tasks = nil
+	return tasks, nil
+}
+
+// inboxRepo keeps the notifications created with it, preferences are
+// whatever it was given
+type inboxRepo struct {
+	NotificationRepository
+	preferences   []NotificationPreference
+	notifications []Notification
+}
+
+func (repo *inboxRepo) GetNotificationPreferences(ctx context.Context, userID int) ([]NotificationPreference, error) {
+	return repo.preferences, nil
+}
+
+func (repo *inboxRepo) CreateNotification(ctx context.Context, notification *Notification) (*Notification, error) {
+	repo.notifications = append(repo.notifications, *notification)
+	return notification, nil
+}
+
+type recordingNotifier struct {
+	notifications []Notification
+}
+
+func (n *recordingNotifier) Notify(ctx context.Context, notification Notification) error {
+	n.notifications = append(n.notifications, notification)
+	return nil
+}
+
+type reminderStore struct {
+	Store
+	tasks         *dueTaskRepo
+	notifications *inboxRepo
+}
+
+func (s *reminderStore) Tasks() TaskRepository                 { return s.tasks }
+func (s *reminderStore) Notifications() NotificationRepository { return s.notifications }
+
+func TestRemindDueTasks(t *testing.T) {
+	tasks := &dueTaskRepo{tasks: []Task{
+		{ID: 1, Title: "unassigned", UserID: 10},
+		{ID: 2, Title: "assigned", UserID: 10, AssigneeID: null.IntFrom(20)},
+	}}
+	inbox := &inboxRepo{preferences: []NotificationPreference{
+		{Type: NotificationTaskDue, Channel: NotificationChannelEmail, Enabled: false},
+	}}
+	notifier := &recordingNotifier{}
+	a := &Application{
+		store:    &reminderStore{tasks: tasks, notifications: inbox},
+		notifier: notifier,
+	}
+
+	start := time.Now()
+	a.remindDueTasks(context.Background())
+
+	if lead := tasks.dueBefore.Sub(start); lead < taskReminderLead || lead > taskReminderLead+time.Second {
+		t.Errorf("tasks due up to %s from now were reminded of, want %s", lead, taskReminderLead)
+	}
+	if window := start.Sub(tasks.dueAfter); window < taskReminderWindow-time.Second || window > taskReminderWindow {
+		t.Errorf("tasks due up to %s ago were reminded of, want %s", window, taskReminderWindow)
+	}
+
+	// the assignee is reminded, or the creator if nobody is assigned, and only
+	// on the channels they haven't turned off
+	want := []Notification{
+		{UserID: 10, Type: NotificationTaskDue, TaskID: 1, TaskTitle: "unassigned"},
+		{UserID: 20, Type: NotificationTaskDue, TaskID: 2, TaskTitle: "assigned"},
+	}
+	if !reflect.DeepEqual(inbox.notifications, want) {
+		t.Errorf("in app notifications = %+v, want %+v", inbox.notifications, want)
+	}
+	if len(notifier.notifications) != 0 {
+		t.Errorf("emailed %+v with email reminders turned off", notifier.notifications)
+	}
+
+	// claimed reminders aren't sent again
+	a.remindDueTasks(context.Background())
+	if len(inbox.notifications) != len(want) {
+		t.Errorf("%d notifications after running again, want %d", len(inbox.notifications), len(want))
+	}
+}
@@ -0,0 +1,297 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/ayo-awe/golang_todo_api/internal/checklist"
+	"github.com/go-chi/render"
+	"gopkg.in/guregu/null.v4"
+)
+
+// exportMarkdownTasks writes the tasks matching the filter as a checklist.
+// Unlike the CSV export it's only written once every task is fetched, as
+// subtasks are nested under their parents.
+func (a *Application) exportMarkdownTasks(w http.ResponseWriter, r *http.Request, filter TaskFilter) {
+	user := a.getCtxUser(r)
+	workspace := a.getCtxWorkspace(r)
+
+	var tasks []Task
+	paging := Paging{Cursor: defaultCursor, PerPage: maxPerPage}
+	for {
+		page, paginationData, err := a.store.Tasks().GetTasks(r.Context(), workspace.ID, user.ID, filter, paging)
+		if err != nil {
+			render.Render(w, r, ErrInternalServerError("An unexpected error occured"))
+			slog.Error(err.Error())
+			return
+		}
+
+		tasks = append(tasks, page...)
+
+		if !paginationData.NextCursor.Valid {
+			break
+		}
+		paging.Cursor = int(paginationData.NextCursor.Int64)
+	}
+
+	// tasks outside of projects come first, then those of each project in the
+	// order the projects first appear in
+	var projectIDs []int
+	byProject := make(map[int][]Task)
+	for _, task := range tasks {
+		projectID := int(task.ProjectID.Int64)
+		if _, ok := byProject[projectID]; !ok && task.ProjectID.Valid {
+			projectIDs = append(projectIDs, projectID)
+		}
+		byProject[projectID] = append(byProject[projectID], task)
+	}
+
+	type section struct {
+		heading string
+		tasks   []Task
+	}
+
+	var sections []section
+	if !filter.ProjectID.Valid {
+		sections = append(sections, section{"# Tasks", byProject[0]})
+	}
+
+	for _, projectID := range projectIDs {
+		project, err := a.store.Projects().GetProjectByID(r.Context(), workspace.ID, projectID)
+		if err != nil {
+			render.Render(w, r, ErrInternalServerError("An unexpected error occured"))
+			slog.Error(err.Error())
+			return
+		}
+
+		heading := "## " + project.Name
+		if filter.ProjectID.Valid {
+			heading = "# " + project.Name
+		}
+		sections = append(sections, section{heading, byProject[projectID]})
+	}
+
+	w.Header().Set("Content-Type", "text/markdown; charset=utf-8")
+	w.Header().Set("Content-Disposition", `attachment; filename="tasks.md"`)
+
+	for i, section := range sections {
+		if i > 0 {
+			io.WriteString(w, "\n")
+		}
+		io.WriteString(w, section.heading+"\n\n")
+
+		if err := checklist.Write(w, taskChecklist(section.tasks)); err != nil {
+			return
+		}
+	}
+}
+
+// taskChecklist nests subtasks under their parents, tasks whose parent isn't
+// in the list are at the top level
+func taskChecklist(tasks []Task) []checklist.Item {
+	inList := make(map[int]bool, len(tasks))
+	for _, task := range tasks {
+		inList[task.ID] = true
+	}
+
+	subtasks := make(map[int][]Task)
+	var topLevel []Task
+	for _, task := range tasks {
+		if task.ParentID.Valid && inList[int(task.ParentID.Int64)] {
+			parentID := int(task.ParentID.Int64)
+			subtasks[parentID] = append(subtasks[parentID], task)
+		} else {
+			topLevel = append(topLevel, task)
+		}
+	}
+
+	var toItems func(tasks []Task) []checklist.Item
+	toItems = func(tasks []Task) []checklist.Item {
+		items := make([]checklist.Item, len(tasks))
+		for i, task := range tasks {
+			items[i] = checklist.Item{
+				Title:       task.Title,
+				Description: task.Description,
+				Done:        task.IsCompleted,
+				Tags:        task.Tags,
+				Items:       toItems(subtasks[task.ID]),
+			}
+			if task.DueAt.Valid {
+				items[i].DueAt = &task.DueAt.Time
+			}
+		}
+		return items
+	}
+
+	return toItems(topLevel)
+}
+
+// @Summary		Import Markdown Tasks
+// @Description	Creates a task for every item of the document's checklists ("- [ ] task" or "- [x] done"), all at once
+// @Description	or, if any item is invalid, not at all. Items indented under an item become its subtasks, and other text
+// @Description	indented under it its description. #tags and due:2006-01-02 dates in an item are taken out of its
+// @Description	title. Headings and other text are skipped, so meeting notes can be imported as they are. Errors are
+// @Description	reported by line. A dry run only validates the items, returning the tasks that would be created without ids.
+// @Tags			Tasks
+// @Id				ImportMarkdownTasks
+// @Accept			text/markdown
+// @Param			X-Workspace-ID	header		int		false	"workspace to act in, defaults to the personal workspace"
+// @Param			project_id		query		int		false	"project to add the tasks to"
+// @Param			dry_run			query		bool	false	"only validate the items"
+// @Param			request			body		string	true	"Markdown document"
+// @Success		200,201			{object}	SuccessResponse{data=ImportTasksResponse}
+// @Failure		400,401,403,413	{object}	ErrorResponse
+// @Failure		422				{object}	ImportTasksErrorResponse
+// @Security		BasicAuth
+// @Router			/tasks/import/markdown [post]
+func (a *Application) ImportMarkdownTasks(w http.ResponseWriter, r *http.Request) {
+	user := a.getCtxUser(r)
+
+	var projectID *int
+	if rawProjectID := r.URL.Query().Get("project_id"); rawProjectID != "" {
+		id, err := strconv.Atoi(rawProjectID)
+		if err != nil {
+			render.Render(w, r, ErrBadRequest("Invalid project_id"))
+			return
+		}
+		projectID = &id
+
+		// checked once here rather than failing every item over it
+		if _, err := a.authorizeProject(r, id, ProjectRoleEditor); err != nil {
+			a.renderProjectAccessError(w, r, err)
+			return
+		}
+	}
+
+	var dryRun bool
+	if rawDryRun := r.URL.Query().Get("dry_run"); rawDryRun != "" {
+		var err error
+		dryRun, err = strconv.ParseBool(rawDryRun)
+		if err != nil {
+			render.Render(w, r, ErrBadRequest("Invalid dry_run"))
+			return
+		}
+	}
+
+	items, err := checklist.Parse(http.MaxBytesReader(w, r.Body, maxImportSize))
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			render.Render(w, r, ErrPayloadTooLarge(fmt.Sprintf("Imports can't be larger than %d bytes", maxImportSize)))
+			return
+		}
+		render.Render(w, r, ErrBadRequest(fmt.Sprintf("Invalid Markdown: %s", err)))
+		return
+	}
+
+	var count func(items []checklist.Item) int
+	count = func(items []checklist.Item) int {
+		n := len(items)
+		for _, item := range items {
+			n += count(item.Items)
+		}
+		return n
+	}
+
+	switch n := count(items); {
+	case n == 0:
+		render.Render(w, r, ErrBadRequest("Document has no checklist items"))
+		return
+	case n > maxImportRows:
+		render.Render(w, r, ErrBadRequest(fmt.Sprintf("Imports can't have more than %d items", maxImportRows)))
+		return
+	}
+
+	// tasks and the ids of the users they mention are listed parents first,
+	// the order the tasks are created in
+	var (
+		tasks        = []Task{}
+		mentionedIDs [][]int
+		rowErrors    = []ImportRowError{}
+	)
+
+	var toTrees func(items []checklist.Item) ([]TaskTree, error)
+	toTrees = func(items []checklist.Item) ([]TaskTree, error) {
+		trees := make([]TaskTree, 0, len(items))
+		for _, item := range items {
+			requestBody := CreateTaskRequest{
+				Title:       item.Title,
+				Description: item.Description,
+				ProjectID:   projectID,
+				Tags:        item.Tags,
+			}
+			if item.DueAt != nil {
+				requestBody.DueAt = null.TimeFrom(*item.DueAt)
+			}
+
+			category := StatusCategoryTodo
+			if item.Done {
+				category = StatusCategoryDone
+			}
+
+			var (
+				task      *Task
+				mentioned []int
+			)
+			err := requestBody.Validate()
+			if err != nil {
+				err = clientError(ErrBadRequest(err.Error()))
+			} else {
+				task, mentioned, err = a.newTaskFromRequest(r, &requestBody, category)
+			}
+			if err != nil {
+				var errResponse *ErrorResponse
+				if !errors.As(err, &errResponse) {
+					return nil, err
+				}
+				rowErrors = append(rowErrors, ImportRowError{Row: item.Line, Message: errResponse.Message})
+				task = &Task{}
+			} else {
+				tasks = append(tasks, *task)
+				mentionedIDs = append(mentionedIDs, mentioned)
+			}
+
+			subtasks, err := toTrees(item.Items)
+			if err != nil {
+				return nil, err
+			}
+			trees = append(trees, TaskTree{Task: task, Subtasks: subtasks})
+		}
+		return trees, nil
+	}
+
+	trees, err := toTrees(items)
+	if err != nil {
+		renderError(w, r, err)
+		return
+	}
+
+	if dryRun {
+		render.Render(w, r, NewSuccessResponse(ImportTasksResponse{DryRun: true, Tasks: tasks, Errors: rowErrors}))
+		return
+	}
+
+	if len(rowErrors) > 0 {
+		renderImportRowErrors(w, r, rowErrors, "items")
+		return
+	}
+
+	newTasks, err := a.store.Tasks().CreateTaskTrees(r.Context(), trees, a.getEventMeta(r))
+	if err != nil {
+		render.Render(w, r, ErrInternalServerError("An unexpected error occured"))
+		slog.Error(err.Error())
+		return
+	}
+
+	for i := range newTasks {
+		a.notifyAssignee(r.Context(), &newTasks[i], user.ID)
+		a.saveMentions(r.Context(), &newTasks[i], null.Int{}, mentionedIDs[i], user.ID)
+	}
+
+	render.Status(r, http.StatusCreated)
+	render.Render(w, r, NewSuccessResponse(ImportTasksResponse{Tasks: newTasks, Errors: rowErrors}))
+}
@@ -0,0 +1,176 @@
+package app
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+)
+
+// @Summary	Get Project Members
+// @Tags		Projects
+// @Id			GetProjectMembers
+// @Param		X-Workspace-ID	header		int	false	"workspace to act in, defaults to the personal workspace"
+// @Param		id				path		int	true	"project id"
+// @Success	200				{object}	SuccessResponse{data=GetProjectMembersResponse}
+// @Failure	401,404			{object}	ErrorResponse
+// @Security	BasicAuth
+// @Router		/projects/{id}/members [get]
+func (a *Application) GetProjectMembers(w http.ResponseWriter, r *http.Request) {
+	rawID := chi.URLParam(r, "id")
+
+	id, err := strconv.Atoi(rawID)
+	if err != nil {
+		render.Render(w, r, ErrResourceNotFound("Project not found"))
+		return
+	}
+
+	if _, err := a.authorizeProject(r, id, ProjectRoleViewer); err != nil {
+		a.renderProjectAccessError(w, r, err)
+		return
+	}
+
+	members, err := a.store.Projects().GetProjectMembers(r.Context(), id)
+	if err != nil {
+		render.Render(w, r, ErrInternalServerError("An unexpected error occured"))
+		slog.Error(err.Error())
+		return
+	}
+
+	render.Render(w, r, NewSuccessResponse(GetProjectMembersResponse{members}))
+}
+
+// @Summary		Add Project Member
+// @Description	Adds a member of the project's workspace to the project by email. Viewers can read the project's tasks, editors can also change them and owners can also manage the project and its members.
+// @Tags			Projects
+// @Id				AddProjectMember
+// @Param			X-Workspace-ID		header		int						false	"workspace to act in, defaults to the personal workspace"
+// @Param			id					path		int						true	"project id"
+// @Param			request				body		AddProjectMemberRequest	true	"request body"
+// @Success		201					{object}	SuccessResponse{data=AddProjectMemberResponse}
+// @Failure		400,401,403,404,409	{object}	ErrorResponse
+// @Security		BasicAuth
+// @Router			/projects/{id}/members [post]
+func (a *Application) AddProjectMember(w http.ResponseWriter, r *http.Request) {
+	rawID := chi.URLParam(r, "id")
+
+	id, err := strconv.Atoi(rawID)
+	if err != nil {
+		render.Render(w, r, ErrResourceNotFound("Project not found"))
+		return
+	}
+
+	if _, err := a.authorizeProject(r, id, ProjectRoleOwner); err != nil {
+		a.renderProjectAccessError(w, r, err)
+		return
+	}
+
+	var requestBody AddProjectMemberRequest
+	if err := render.Bind(r, &requestBody); err != nil {
+		render.Render(w, r, ErrBadRequest("Invalid request body"))
+		return
+	}
+
+	if err := requestBody.Validate(); err != nil {
+		render.Render(w, r, ErrBadRequest(err.Error()))
+		return
+	}
+
+	invitee, err := a.store.Users().GetUserByEmail(r.Context(), requestBody.Email)
+	if err != nil {
+		if errors.Is(err, ErrUserNotFound) {
+			render.Render(w, r, ErrResourceNotFound("User not found"))
+			return
+		}
+
+		render.Render(w, r, ErrInternalServerError("An unexpected error occured"))
+		slog.Error(err.Error())
+		return
+	}
+
+	// projects are only shared within their workspace
+	workspace := a.getCtxWorkspace(r)
+	if _, err := a.store.Workspaces().GetWorkspace(r.Context(), workspace.ID, invitee.ID); err != nil {
+		if errors.Is(err, ErrWorkspaceNotFound) {
+			render.Render(w, r, ErrBadRequest("User is not a member of the workspace"))
+			return
+		}
+
+		render.Render(w, r, ErrInternalServerError("An unexpected error occured"))
+		slog.Error(err.Error())
+		return
+	}
+
+	member, err := a.store.Projects().AddProjectMember(r.Context(), id, invitee.ID, requestBody.Role)
+	if err != nil {
+		if errors.Is(err, ErrMemberExists) {
+			render.Render(w, r, ErrConflict("User is already a member of the project"))
+			return
+		}
+
+		render.Render(w, r, ErrInternalServerError("An unexpected error occured"))
+		slog.Error(err.Error())
+		return
+	}
+
+	render.Status(r, http.StatusCreated)
+	render.Render(w, r, NewSuccessResponse(AddProjectMemberResponse{*member}))
+}
+
+// @Summary		Remove Project Member
+// @Description	Removes a member from the project. Owners can remove anyone and other members can only remove themselves. The last owner of a project can't be removed.
+// @Tags			Projects
+// @Id				RemoveProjectMember
+// @Param			X-Workspace-ID	header	int	false	"workspace to act in, defaults to the personal workspace"
+// @Param			id				path	int	true	"project id"
+// @Param			userID			path	int	true	"user id of the member"
+// @Success		204
+// @Failure		401,403,404,409	{object}	ErrorResponse
+// @Security		BasicAuth
+// @Router			/projects/{id}/members/{userID} [delete]
+func (a *Application) RemoveProjectMember(w http.ResponseWriter, r *http.Request) {
+	user := a.getCtxUser(r)
+	rawID := chi.URLParam(r, "id")
+	rawUserID := chi.URLParam(r, "userID")
+
+	id, err := strconv.Atoi(rawID)
+	if err != nil {
+		render.Render(w, r, ErrResourceNotFound("Project not found"))
+		return
+	}
+
+	memberID, err := strconv.Atoi(rawUserID)
+	if err != nil {
+		render.Render(w, r, ErrResourceNotFound("Member not found"))
+		return
+	}
+
+	// leaving a project only requires being a member of it
+	role := ProjectRoleOwner
+	if memberID == user.ID {
+		role = ProjectRoleViewer
+	}
+
+	if _, err := a.authorizeProject(r, id, role); err != nil {
+		a.renderProjectAccessError(w, r, err)
+		return
+	}
+
+	if err := a.store.Projects().RemoveProjectMember(r.Context(), id, memberID); err != nil {
+		switch {
+		case errors.Is(err, ErrMemberNotFound):
+			render.Render(w, r, ErrResourceNotFound("Member not found"))
+		case errors.Is(err, ErrLastOwner):
+			render.Render(w, r, ErrConflict("The last owner of a project can't be removed"))
+		default:
+			render.Render(w, r, ErrInternalServerError("An unexpected error occured"))
+			slog.Error(err.Error())
+		}
+		return
+	}
+
+	render.NoContent(w, r)
+}
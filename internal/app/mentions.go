@@ -0,0 +1,132 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/go-chi/render"
+	"gopkg.in/guregu/null.v4"
+)
+
+// mentionPattern matches @email mentions that aren't part of a longer word
+var mentionPattern = regexp.MustCompile(`(?:^|[^\w.@])@([\w.%+-]+@[\w-]+(?:\.[\w-]+)+)`)
+
+// parseMentions returns the distinct, lowercased emails mentioned in text
+func parseMentions(text string) []string {
+	var emails []string
+	seen := map[string]bool{}
+	for _, match := range mentionPattern.FindAllStringSubmatch(text, -1) {
+		email := strings.ToLower(match[1])
+		if !seen[email] {
+			seen[email] = true
+			emails = append(emails, email)
+		}
+	}
+
+	return emails
+}
+
+// resolveMentions returns the ids of the users mentioned in text. Mentions of
+// emails that don't belong to a user who can see the task are returned as invalid.
+func (a *Application) resolveMentions(ctx context.Context, task *Task, text string) ([]int, []string, error) {
+	emails := parseMentions(text)
+	if len(emails) == 0 {
+		return nil, nil, nil
+	}
+
+	users, err := a.store.Users().GetUsersByEmails(ctx, emails)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	usersByEmail := map[string]User{}
+	for _, user := range users {
+		usersByEmail[strings.ToLower(user.Email)] = user
+	}
+
+	var userIDs []int
+	var invalid []string
+	for _, email := range emails {
+		user, ok := usersByEmail[email]
+		if ok {
+			ok, err = a.canViewTask(ctx, task, user.ID)
+			if err != nil {
+				return nil, nil, err
+			}
+		}
+
+		if !ok {
+			invalid = append(invalid, email)
+			continue
+		}
+
+		userIDs = append(userIDs, user.ID)
+	}
+
+	return userIDs, invalid, nil
+}
+
+// canViewTask reports whether the user can see the task. Tasks in a project can
+// be seen by its members and the admins of its workspace, other tasks only by
+// the user who created them.
+func (a *Application) canViewTask(ctx context.Context, task *Task, userID int) (bool, error) {
+	if !task.ProjectID.Valid {
+		return task.UserID == userID, nil
+	}
+
+	_, err := a.store.Projects().GetProjectRole(ctx, int(task.ProjectID.Int64), userID)
+	if err == nil {
+		return true, nil
+	}
+	if !errors.Is(err, ErrMemberNotFound) {
+		return false, err
+	}
+
+	workspace, err := a.store.Workspaces().GetWorkspace(ctx, task.WorkspaceID, userID)
+	if err != nil {
+		if errors.Is(err, ErrWorkspaceNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return workspace.Role.AtLeast(WorkspaceRoleAdmin), nil
+}
+
+// saveMentions records the users mentioned in the task's description, or in
+// one of its comments, and notifies the ones who weren't mentioned there
+// before. The task is already saved, so errors are only logged.
+func (a *Application) saveMentions(ctx context.Context, task *Task, commentID null.Int, userIDs []int, actorID int) {
+	added, err := a.store.Mentions().SetMentions(ctx, task.ID, commentID, userIDs)
+	if err != nil {
+		slog.Error(err.Error())
+		return
+	}
+
+	for _, userID := range added {
+		if userID == actorID {
+			continue
+		}
+
+		a.notify(ctx, Notification{
+			UserID:    userID,
+			Type:      NotificationTaskMentioned,
+			TaskID:    task.ID,
+			TaskTitle: task.Title,
+			ActorID:   null.IntFrom(int64(actorID)),
+		})
+	}
+}
+
+func (a *Application) renderInvalidMentions(w http.ResponseWriter, r *http.Request, emails []string) {
+	render.Render(w, r, ErrBadRequest(invalidMentionsMessage(emails)))
+}
+
+func invalidMentionsMessage(emails []string) string {
+	return fmt.Sprintf("Mentioned users must be able to see the task: %s", strings.Join(emails, ", "))
+}
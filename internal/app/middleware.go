@@ -9,6 +9,7 @@ import (
 	"strings"
 
 	"github.com/go-chi/render"
+	"gopkg.in/guregu/null.v4"
 )
 
 type contextKey string
@@ -21,6 +22,9 @@ const (
 
 const userContextKey contextKey = "user"
 const pagingContextKey contextKey = "paging"
+const workspaceContextKey contextKey = "workspace"
+
+const workspaceHeader = "X-Workspace-ID"
 
 func (a *Application) basicAuthMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -102,9 +106,15 @@ func (a *Application) Paginate(next http.Handler) http.Handler {
 			cursor = defaultCursor
 		}
 
+		var cursorPosition null.String
+		if rawCursorPosition := r.URL.Query().Get("cursor_position"); rawCursorPosition != "" {
+			cursorPosition = null.StringFrom(rawCursorPosition)
+		}
+
 		paging := Paging{
-			Cursor:  cursor,
-			PerPage: perPage,
+			Cursor:         cursor,
+			CursorPosition: cursorPosition,
+			PerPage:        perPage,
 		}
 
 		r = a.setCtxPaging(r, paging)
@@ -114,3 +124,42 @@ func (a *Application) Paginate(next http.Handler) http.Handler {
 		}
 	})
 }
+
+// workspaceMiddleware resolves the workspace a request acts in from the
+// X-Workspace-ID header, falling back to the user's personal workspace.
+// It must run after basicAuthMiddleware.
+func (a *Application) workspaceMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user := a.getCtxUser(r)
+
+		var workspace *Workspace
+		var err error
+		rawID := r.Header.Get(workspaceHeader)
+		if rawID == "" {
+			// for clients that can't set headers, such as a browser's EventSource
+			rawID = r.URL.Query().Get("workspace_id")
+		}
+
+		if rawID != "" {
+			id, convErr := strconv.Atoi(rawID)
+			if convErr != nil {
+				render.Render(w, r, ErrBadRequest("Invalid workspace id"))
+				return
+			}
+
+			workspace, err = a.store.Workspaces().GetWorkspace(r.Context(), id, user.ID)
+		} else {
+			workspace, err = a.store.Workspaces().GetPersonalWorkspace(r.Context(), user.ID)
+		}
+		if err != nil {
+			a.renderWorkspaceAccessError(w, r, err)
+			return
+		}
+
+		r = a.setCtxWorkspace(r, workspace)
+
+		if next != nil {
+			next.ServeHTTP(w, r)
+		}
+	})
+}
@@ -1,15 +1,25 @@
 package app
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
+	"log/slog"
 	"net/http"
+	"net/netip"
+	"net/url"
+	"slices"
 	"strings"
+	"time"
+	"unicode/utf8"
 
+	"github.com/ayo-awe/golang_todo_api/internal/ical"
 	"github.com/go-chi/render"
 	validation "github.com/go-ozzo/ozzo-validation/v4"
 	"github.com/go-ozzo/ozzo-validation/v4/is"
 	"golang.org/x/text/cases"
 	"golang.org/x/text/language"
+	"gopkg.in/guregu/null.v4"
 )
 
 type ErrorResponse struct {
@@ -45,6 +55,29 @@ func (e *ErrorResponse) Render(w http.ResponseWriter, r *http.Request) error {
 	return nil
 }
 
+func (e *ErrorResponse) Error() string {
+	return e.Message
+}
+
+// clientError returns an error response as an error, for helpers that report
+// problems with the request to the handler calling them
+func clientError(renderer render.Renderer) error {
+	return renderer.(*ErrorResponse)
+}
+
+// renderError renders client errors as they are and anything else as an
+// unexpected error
+func renderError(w http.ResponseWriter, r *http.Request, err error) {
+	var errResponse *ErrorResponse
+	if errors.As(err, &errResponse) {
+		render.Render(w, r, errResponse)
+		return
+	}
+
+	render.Render(w, r, ErrInternalServerError("An unexpected error occured"))
+	slog.Error(err.Error())
+}
+
 func ErrBadRequest(msg string) render.Renderer {
 	return &ErrorResponse{
 		Status:     "error",
@@ -84,6 +117,30 @@ func ErrConflict(msg string) render.Renderer {
 	}
 }
 
+func ErrPreconditionFailed(msg string) render.Renderer {
+	return &ErrorResponse{
+		Status:     "error",
+		Message:    msg,
+		StatusCode: http.StatusPreconditionFailed,
+	}
+}
+
+func ErrPayloadTooLarge(msg string) render.Renderer {
+	return &ErrorResponse{
+		Status:     "error",
+		Message:    msg,
+		StatusCode: http.StatusRequestEntityTooLarge,
+	}
+}
+
+func ErrUnsupportedMediaType(msg string) render.Renderer {
+	return &ErrorResponse{
+		Status:     "error",
+		Message:    msg,
+		StatusCode: http.StatusUnsupportedMediaType,
+	}
+}
+
 func ErrInternalServerError(msg string) render.Renderer {
 	return &ErrorResponse{
 		Status:     "error",
@@ -92,6 +149,59 @@ func ErrInternalServerError(msg string) render.Renderer {
 	}
 }
 
+// NullableInt is a request field that can be explicitly set to null. Unlike
+// a pointer it tells a null value apart from a field that was left out.
+type NullableInt struct {
+	Set   bool
+	Value null.Int
+}
+
+func (n *NullableInt) UnmarshalJSON(data []byte) error {
+	n.Set = true
+	return n.Value.UnmarshalJSON(data)
+}
+
+// NullableTime is a request field that can be explicitly set to null, like NullableInt
+type NullableTime struct {
+	Set   bool
+	Value null.Time
+}
+
+func (n *NullableTime) UnmarshalJSON(data []byte) error {
+	n.Set = true
+	return n.Value.UnmarshalJSON(data)
+}
+
+const (
+	maxTaskTags  = 20
+	maxTagLength = 64
+)
+
+// normalizeTags lowercases tags, strips a leading # and drops duplicates
+func normalizeTags(tags []string) ([]string, error) {
+	if len(tags) > maxTaskTags {
+		return nil, fmt.Errorf("tags: cannot have more than %d tags", maxTaskTags)
+	}
+
+	normalized := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(tag), "#")))
+		if tag == "" {
+			return nil, fmt.Errorf("tags: cannot be blank")
+		}
+
+		if utf8.RuneCountInString(tag) > maxTagLength {
+			return nil, fmt.Errorf("tags: cannot be longer than %d characters", maxTagLength)
+		}
+
+		if !slices.Contains(normalized, tag) {
+			normalized = append(normalized, tag)
+		}
+	}
+
+	return normalized, nil
+}
+
 type RegisterUserRequest struct {
 	Email     string `json:"email"`
 	Firstname string `json:"first_name"`
@@ -123,8 +233,20 @@ type RegisterUserResponse struct {
 }
 
 type CreateTaskRequest struct {
-	Title       string `json:"title"`
-	Description string `json:"description"`
+	Title       string       `json:"title"`
+	Description string       `json:"description"`
+	StatusID    *int         `json:"status_id"`
+	ProjectID   *int         `json:"project_id"`
+	AssigneeID  *int         `json:"assignee_id"`
+	DueAt       null.Time    `json:"due_at" swaggertype:"string"`
+	Priority    TaskPriority `json:"priority"`
+	Tags        []string     `json:"tags"`
+	// Recurrence is an iCalendar RRULE such as FREQ=WEEKLY;BYDAY=MO
+	Recurrence string `json:"recurrence"`
+	// UID is the task's iCalendar UID, for tasks created by calendar clients
+	UID string `json:"uid"`
+	// ParentID makes the task a subtask, it must be in the parent's project
+	ParentID *int `json:"parent_id"`
 }
 
 func (c *CreateTaskRequest) Bind(r *http.Request) error { return nil }
@@ -133,8 +255,30 @@ func (c *CreateTaskRequest) Validate() error {
 	c.Title = strings.TrimSpace(c.Title)
 	c.Description = strings.TrimSpace(c.Description)
 
+	if c.Priority == "" {
+		c.Priority = PriorityNone
+	}
+
+	tags, err := normalizeTags(c.Tags)
+	if err != nil {
+		return err
+	}
+	c.Tags = tags
+
+	if c.Recurrence != "" {
+		recurrence, err := normalizeRecurrence(c.Recurrence)
+		if err != nil {
+			return err
+		}
+		c.Recurrence = recurrence
+	}
+
+	c.UID = strings.TrimSpace(c.UID)
+
 	return validation.ValidateStruct(c,
 		validation.Field(&c.Title, validation.Required),
+		validation.Field(&c.Priority, validation.By(validateTaskPriority)),
+		validation.Field(&c.UID, validation.Length(0, 255)),
 	)
 }
 
@@ -147,9 +291,17 @@ type GetTasksResponse struct {
 }
 
 type EditTaskRequest struct {
-	Title       *string `json:"title"`
-	Description *string `json:"description"`
-	IsCompleted *bool   `json:"is_completed"`
+	Title       *string       `json:"title"`
+	Description *string       `json:"description"`
+	IsCompleted *bool         `json:"is_completed"`
+	StatusID    *int          `json:"status_id"`
+	ProjectID   NullableInt   `json:"project_id" swaggertype:"integer"`
+	AssigneeID  NullableInt   `json:"assignee_id" swaggertype:"integer"`
+	DueAt       NullableTime  `json:"due_at" swaggertype:"string"`
+	Priority    *TaskPriority `json:"priority"`
+	Tags        []string      `json:"tags"`
+	// Recurrence is an iCalendar RRULE, an empty string stops the task repeating
+	Recurrence *string `json:"recurrence"`
 }
 
 func (c *EditTaskRequest) Bind(r *http.Request) error { return nil }
@@ -169,9 +321,899 @@ func (c *EditTaskRequest) Validate() error {
 		return fmt.Errorf("title: field cannot be empty")
 	}
 
+	if c.Priority != nil && !c.Priority.IsValid() {
+		return fmt.Errorf("priority: unknown priority %q", *c.Priority)
+	}
+
+	if c.Tags != nil {
+		tags, err := normalizeTags(c.Tags)
+		if err != nil {
+			return err
+		}
+		c.Tags = tags
+	}
+
+	if c.Recurrence != nil && *c.Recurrence != "" {
+		recurrence, err := normalizeRecurrence(*c.Recurrence)
+		if err != nil {
+			return err
+		}
+		c.Recurrence = &recurrence
+	}
+
+	return nil
+}
+
+func normalizeRecurrence(rule string) (string, error) {
+	recurrence, err := ical.NormalizeRRule(rule)
+	if err != nil {
+		return "", fmt.Errorf("recurrence: %s", err)
+	}
+
+	return recurrence, nil
+}
+
+func validateTaskPriority(value interface{}) error {
+	priority, _ := value.(TaskPriority)
+	if !priority.IsValid() {
+		return fmt.Errorf("unknown priority %q", priority)
+	}
+
 	return nil
 }
 
 type EditTaskResponse struct {
 	Task Task `json:"task"`
 }
+
+// TaskStreamEvent is the data of an event on the task event stream
+type TaskStreamEvent struct {
+	ID        int                    `json:"id"`
+	Type      string                 `json:"type"`
+	Action    TaskAction             `json:"action"`
+	TaskID    int                    `json:"task_id"`
+	Task      *Task                  `json:"task"`
+	Changes   map[string]FieldChange `json:"changes"`
+	ActorID   null.Int               `json:"actor_id" swaggertype:"integer"`
+	CreatedAt time.Time              `json:"created_at"`
+}
+
+type RestoreTaskResponse struct {
+	Task Task `json:"task"`
+}
+
+type ArchiveTaskResponse struct {
+	Task Task `json:"task"`
+}
+
+type MoveTaskRequest struct {
+	AfterID  null.Int `json:"after_id" swaggertype:"integer"`
+	BeforeID null.Int `json:"before_id" swaggertype:"integer"`
+	StatusID null.Int `json:"status_id" swaggertype:"integer"`
+}
+
+func (c *MoveTaskRequest) Bind(r *http.Request) error { return nil }
+
+func (c *MoveTaskRequest) Validate() error {
+	if !c.AfterID.Valid && !c.BeforeID.Valid && !c.StatusID.Valid {
+		return fmt.Errorf("after_id, before_id or status_id is required")
+	}
+
+	return nil
+}
+
+type MoveTaskResponse struct {
+	Task Task `json:"task"`
+}
+
+type GetTaskHistoryResponse struct {
+	Events []TaskEvent `json:"events"`
+}
+
+type GetUndoStackResponse struct {
+	Events []TaskEvent `json:"events"`
+}
+
+type UndoResponse struct {
+	Task   Task      `json:"task"`
+	Undone TaskEvent `json:"undone"`
+}
+
+type GetStatusesResponse struct {
+	Statuses []Status `json:"statuses"`
+}
+
+type CreateStatusRequest struct {
+	Name     string         `json:"name"`
+	Category StatusCategory `json:"category"`
+	Position int            `json:"position"`
+}
+
+func (c *CreateStatusRequest) Bind(r *http.Request) error { return nil }
+
+func (c *CreateStatusRequest) Validate() error {
+	c.Name = strings.TrimSpace(c.Name)
+
+	return validation.ValidateStruct(c,
+		validation.Field(&c.Name, validation.Required, validation.Length(1, 255)),
+		validation.Field(&c.Category, validation.Required, validation.By(func(value interface{}) error {
+			if !c.Category.IsValid() {
+				return fmt.Errorf("must be one of todo, in_progress, done or cancelled")
+			}
+			return nil
+		})),
+	)
+}
+
+type CreateStatusResponse struct {
+	Status Status `json:"status"`
+}
+
+type EditStatusRequest struct {
+	Name     *string `json:"name"`
+	Position *int    `json:"position"`
+}
+
+func (c *EditStatusRequest) Bind(r *http.Request) error { return nil }
+
+func (c *EditStatusRequest) Validate() error {
+	if c.Name != nil {
+		trimmed := strings.TrimSpace(*c.Name)
+		c.Name = &trimmed
+	}
+
+	if c.Name != nil && len(*c.Name) < 1 {
+		return fmt.Errorf("name: field cannot be empty")
+	}
+
+	return nil
+}
+
+type EditStatusResponse struct {
+	Status Status `json:"status"`
+}
+
+type GetProjectsResponse struct {
+	Projects []Project `json:"projects"`
+}
+
+type CreateProjectRequest struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+func (c *CreateProjectRequest) Bind(r *http.Request) error { return nil }
+
+func (c *CreateProjectRequest) Validate() error {
+	c.Name = strings.TrimSpace(c.Name)
+	c.Description = strings.TrimSpace(c.Description)
+
+	return validation.ValidateStruct(c,
+		validation.Field(&c.Name, validation.Required, validation.Length(1, 255)),
+	)
+}
+
+type CreateProjectResponse struct {
+	Project Project `json:"project"`
+}
+
+type EditProjectRequest struct {
+	Name        *string `json:"name"`
+	Description *string `json:"description"`
+}
+
+func (c *EditProjectRequest) Bind(r *http.Request) error { return nil }
+
+func (c *EditProjectRequest) Validate() error {
+	if c.Name != nil {
+		trimmed := strings.TrimSpace(*c.Name)
+		c.Name = &trimmed
+	}
+
+	if c.Description != nil {
+		trimmed := strings.TrimSpace(*c.Description)
+		c.Description = &trimmed
+	}
+
+	if c.Name != nil && len(*c.Name) < 1 {
+		return fmt.Errorf("name: field cannot be empty")
+	}
+
+	return nil
+}
+
+type EditProjectResponse struct {
+	Project Project `json:"project"`
+}
+
+// BoardColumn holds the first page of tasks in a status, ordered by position
+type BoardColumn struct {
+	Status Status         `json:"status"`
+	Tasks  []Task         `json:"tasks"`
+	Paging PaginationData `json:"paging"`
+}
+
+type GetBoardResponse struct {
+	Project Project       `json:"project"`
+	Columns []BoardColumn `json:"columns"`
+}
+
+type GetProjectMembersResponse struct {
+	Members []ProjectMember `json:"members"`
+}
+
+type AddProjectMemberRequest struct {
+	Email string      `json:"email"`
+	Role  ProjectRole `json:"role"`
+}
+
+func (c *AddProjectMemberRequest) Bind(r *http.Request) error { return nil }
+
+func (c *AddProjectMemberRequest) Validate() error {
+	c.Email = strings.TrimSpace(strings.ToLower(c.Email))
+
+	return validation.ValidateStruct(c,
+		validation.Field(&c.Email, validation.Required, is.EmailFormat),
+		validation.Field(&c.Role, validation.Required, validation.By(func(value interface{}) error {
+			if !c.Role.IsValid() {
+				return fmt.Errorf("must be one of viewer, editor or owner")
+			}
+			return nil
+		})),
+	)
+}
+
+type AddProjectMemberResponse struct {
+	Member ProjectMember `json:"member"`
+}
+
+const maxCommentLength = 10_000
+
+type GetCommentsResponse struct {
+	Comments []Comment `json:"comments"`
+}
+
+type CreateCommentRequest struct {
+	Body string `json:"body"`
+}
+
+func (c *CreateCommentRequest) Bind(r *http.Request) error { return nil }
+
+func (c *CreateCommentRequest) Validate() error {
+	c.Body = strings.TrimSpace(c.Body)
+
+	return validation.ValidateStruct(c,
+		validation.Field(&c.Body, validation.Required, validation.RuneLength(1, maxCommentLength)),
+	)
+}
+
+type CreateCommentResponse struct {
+	Comment Comment `json:"comment"`
+}
+
+type EditCommentRequest struct {
+	Body string `json:"body"`
+}
+
+func (c *EditCommentRequest) Bind(r *http.Request) error { return nil }
+
+func (c *EditCommentRequest) Validate() error {
+	c.Body = strings.TrimSpace(c.Body)
+
+	return validation.ValidateStruct(c,
+		validation.Field(&c.Body, validation.Required, validation.RuneLength(1, maxCommentLength)),
+	)
+}
+
+type EditCommentResponse struct {
+	Comment Comment `json:"comment"`
+}
+
+type GetAttachmentsResponse struct {
+	Attachments []Attachment `json:"attachments"`
+}
+
+type UploadAttachmentResponse struct {
+	Attachment Attachment `json:"attachment"`
+}
+
+type GetWorkspacesResponse struct {
+	Workspaces []Workspace `json:"workspaces"`
+}
+
+type CreateWorkspaceRequest struct {
+	Name string `json:"name"`
+}
+
+func (c *CreateWorkspaceRequest) Bind(r *http.Request) error { return nil }
+
+func (c *CreateWorkspaceRequest) Validate() error {
+	c.Name = strings.TrimSpace(c.Name)
+
+	return validation.ValidateStruct(c,
+		validation.Field(&c.Name, validation.Required, validation.Length(1, 255)),
+	)
+}
+
+type CreateWorkspaceResponse struct {
+	Workspace Workspace `json:"workspace"`
+}
+
+type EditWorkspaceRequest struct {
+	Name string `json:"name"`
+}
+
+func (c *EditWorkspaceRequest) Bind(r *http.Request) error { return nil }
+
+func (c *EditWorkspaceRequest) Validate() error {
+	c.Name = strings.TrimSpace(c.Name)
+
+	return validation.ValidateStruct(c,
+		validation.Field(&c.Name, validation.Required, validation.Length(1, 255)),
+	)
+}
+
+type EditWorkspaceResponse struct {
+	Workspace Workspace `json:"workspace"`
+}
+
+type GetWorkspaceMembersResponse struct {
+	Members []WorkspaceMember `json:"members"`
+}
+
+type CreateInvitationRequest struct {
+	Email string        `json:"email"`
+	Role  WorkspaceRole `json:"role"`
+}
+
+func (c *CreateInvitationRequest) Bind(r *http.Request) error { return nil }
+
+func (c *CreateInvitationRequest) Validate() error {
+	c.Email = strings.TrimSpace(strings.ToLower(c.Email))
+
+	return validation.ValidateStruct(c,
+		validation.Field(&c.Email, validation.Required, is.EmailFormat),
+		validation.Field(&c.Role, validation.Required, validation.By(func(value interface{}) error {
+			if !c.Role.IsValid() {
+				return fmt.Errorf("must be one of member, admin or owner")
+			}
+			return nil
+		})),
+	)
+}
+
+type CreateInvitationResponse struct {
+	Invitation WorkspaceInvitation `json:"invitation"`
+	Token      string              `json:"token"`
+	URL        string              `json:"url"`
+}
+
+type GetInvitationsResponse struct {
+	Invitations []WorkspaceInvitation `json:"invitations"`
+}
+
+type AcceptInvitationResponse struct {
+	Member WorkspaceMember `json:"member"`
+}
+
+type GetNotificationsResponse struct {
+	Notifications []Notification `json:"notifications"`
+	UnreadCount   int            `json:"unread_count"`
+}
+
+type MarkNotificationReadResponse struct {
+	Notification Notification `json:"notification"`
+}
+
+type MarkAllNotificationsReadResponse struct {
+	Count int `json:"count"`
+}
+
+type NotificationPreferencesResponse struct {
+	Preferences []NotificationPreference `json:"preferences"`
+}
+
+type EditNotificationPreferencesRequest struct {
+	Preferences []NotificationPreference `json:"preferences"`
+}
+
+func (c *EditNotificationPreferencesRequest) Bind(r *http.Request) error { return nil }
+
+func (c *EditNotificationPreferencesRequest) Validate() error {
+	if len(c.Preferences) == 0 {
+		return fmt.Errorf("preferences: cannot be blank")
+	}
+
+	for _, preference := range c.Preferences {
+		if !preference.Type.IsValid() {
+			return fmt.Errorf("preferences: unknown notification type %q", preference.Type)
+		}
+
+		if !preference.Channel.IsValid() {
+			return fmt.Errorf("preferences: unknown channel %q", preference.Channel)
+		}
+	}
+
+	return nil
+}
+
+const maxWebhookURLLength = 2048
+
+type GetWebhooksResponse struct {
+	Webhooks []Webhook `json:"webhooks"`
+}
+
+type CreateWebhookRequest struct {
+	URL        string             `json:"url"`
+	Secret     string             `json:"secret"`
+	EventTypes []WebhookEventType `json:"event_types"`
+}
+
+func (c *CreateWebhookRequest) Bind(r *http.Request) error { return nil }
+
+func (c *CreateWebhookRequest) Validate() error {
+	c.URL = strings.TrimSpace(c.URL)
+
+	return validation.ValidateStruct(c,
+		validation.Field(&c.URL, validation.Required, validation.Length(1, maxWebhookURLLength), validation.By(validateWebhookURL)),
+		validation.Field(&c.Secret, validation.Length(16, 255)),
+		validation.Field(&c.EventTypes, validation.Required, validation.By(validateWebhookEventTypes)),
+	)
+}
+
+type CalendarFeedResponse struct {
+	URL string `json:"url"`
+}
+
+type ImportResponse struct {
+	Import Import `json:"import"`
+}
+
+type CreateWebhookResponse struct {
+	Webhook Webhook `json:"webhook"`
+	Secret  string  `json:"secret"`
+}
+
+type EditWebhookRequest struct {
+	URL        *string            `json:"url"`
+	EventTypes []WebhookEventType `json:"event_types"`
+	IsActive   *bool              `json:"is_active"`
+}
+
+func (c *EditWebhookRequest) Bind(r *http.Request) error { return nil }
+
+func (c *EditWebhookRequest) Validate() error {
+	if c.URL != nil {
+		trimmed := strings.TrimSpace(*c.URL)
+		c.URL = &trimmed
+	}
+
+	return validation.ValidateStruct(c,
+		validation.Field(&c.URL, validation.NilOrNotEmpty, validation.Length(1, maxWebhookURLLength), validation.By(validateWebhookURL)),
+		validation.Field(&c.EventTypes, validation.NilOrNotEmpty, validation.By(validateWebhookEventTypes)),
+	)
+}
+
+type EditWebhookResponse struct {
+	Webhook Webhook `json:"webhook"`
+}
+
+type GetWebhookDeliveriesResponse struct {
+	Deliveries []WebhookDelivery `json:"deliveries"`
+}
+
+type RedeliverWebhookResponse struct {
+	Delivery WebhookDelivery `json:"delivery"`
+}
+
+func validateWebhookURL(value interface{}) error {
+	rawURL, _ := value.(string)
+	if ptr, ok := value.(*string); ok && ptr != nil {
+		rawURL = *ptr
+	}
+
+	if rawURL == "" {
+		return nil
+	}
+
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("must be an absolute http or https url")
+	}
+
+	// hosts that resolve to forbidden addresses are rejected when deliveries
+	// are sent, those that obviously do are rejected here too
+	host := strings.TrimSuffix(strings.ToLower(u.Hostname()), ".")
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return fmt.Errorf("must not be a local address")
+	}
+	if addr, err := netip.ParseAddr(host); err == nil && IsForbiddenWebhookAddr(addr) {
+		return fmt.Errorf("must not be a local or private address")
+	}
+
+	return nil
+}
+
+func validateWebhookEventTypes(value interface{}) error {
+	eventTypes, _ := value.([]WebhookEventType)
+	for _, eventType := range eventTypes {
+		if !eventType.IsValid() {
+			return fmt.Errorf("unknown event type %q", eventType)
+		}
+	}
+
+	return nil
+}
+
+const maxSyncMutations = 100
+
+// SyncTombstone is a task that was moved to the trash or purged
+type SyncTombstone struct {
+	ID        int       `json:"id"`
+	DeletedAt time.Time `json:"deleted_at"`
+}
+
+type GetSyncChangesResponse struct {
+	Tasks   []Task          `json:"tasks"`
+	Deleted []SyncTombstone `json:"deleted"`
+	// NextToken is passed as since on the next pull
+	NextToken string `json:"next_token"`
+	// HasMore is set when there are more changes to pull right away
+	HasMore bool `json:"has_more"`
+}
+
+type SyncOp string
+
+const (
+	SyncCreate SyncOp = "create"
+	SyncUpdate SyncOp = "update"
+	SyncDelete SyncOp = "delete"
+)
+
+// SyncMutation is a change a client made while offline. Data has the same
+// body as creating or editing a task.
+type SyncMutation struct {
+	ClientID    string          `json:"client_id"`
+	Op          SyncOp          `json:"op"`
+	TaskID      int             `json:"task_id"`
+	BaseVersion int             `json:"base_version"`
+	ModifiedAt  time.Time       `json:"modified_at"`
+	Data        json.RawMessage `json:"data" swaggertype:"object"`
+}
+
+type UploadSyncMutationsRequest struct {
+	Mutations []SyncMutation `json:"mutations"`
+}
+
+func (c *UploadSyncMutationsRequest) Bind(r *http.Request) error { return nil }
+
+func (c *UploadSyncMutationsRequest) Validate() error {
+	if len(c.Mutations) == 0 {
+		return fmt.Errorf("mutations: cannot be blank")
+	}
+
+	if len(c.Mutations) > maxSyncMutations {
+		return fmt.Errorf("mutations: cannot have more than %d mutations", maxSyncMutations)
+	}
+
+	for i, mutation := range c.Mutations {
+		if strings.TrimSpace(mutation.ClientID) == "" {
+			return fmt.Errorf("mutations[%d].client_id: cannot be blank", i)
+		}
+
+		switch mutation.Op {
+		case SyncCreate:
+		case SyncUpdate, SyncDelete:
+			if mutation.TaskID < 1 {
+				return fmt.Errorf("mutations[%d].task_id: cannot be blank", i)
+			}
+			if mutation.BaseVersion < 1 {
+				return fmt.Errorf("mutations[%d].base_version: cannot be blank", i)
+			}
+			if mutation.ModifiedAt.IsZero() {
+				return fmt.Errorf("mutations[%d].modified_at: cannot be blank", i)
+			}
+		default:
+			return fmt.Errorf("mutations[%d].op: unknown op %q", i, mutation.Op)
+		}
+	}
+
+	return nil
+}
+
+type SyncResultStatus string
+
+const (
+	SyncApplied  SyncResultStatus = "applied"
+	SyncConflict SyncResultStatus = "conflict"
+	SyncFailed   SyncResultStatus = "failed"
+)
+
+// SyncResolution is how a conflict between an offline change and a newer
+// version of the task was resolved. The change made last wins.
+type SyncResolution string
+
+const (
+	SyncClientWins SyncResolution = "client_wins"
+	SyncServerWins SyncResolution = "server_wins"
+)
+
+// SyncResult is the outcome of a mutation. Task is the task after applying
+// it, or the server's task when the server wins a conflict.
+type SyncResult struct {
+	ClientID   string           `json:"client_id"`
+	Status     SyncResultStatus `json:"status"`
+	Resolution SyncResolution   `json:"resolution,omitempty"`
+	StatusCode int              `json:"status_code"`
+	Task       *Task            `json:"task"`
+	Message    string           `json:"message,omitempty"`
+}
+
+type UploadSyncMutationsResponse struct {
+	Results []SyncResult `json:"results"`
+}
+
+// ImportRowError is why a row of an import is invalid, Row is its line in the file
+type ImportRowError struct {
+	Row     int    `json:"row"`
+	Message string `json:"message"`
+}
+
+type ImportTasksResponse struct {
+	DryRun bool             `json:"dry_run"`
+	Tasks  []Task           `json:"tasks"`
+	Errors []ImportRowError `json:"errors"`
+}
+
+type ImportTasksErrorResponse struct {
+	ErrorResponse
+	Errors []ImportRowError `json:"errors"`
+}
+
+type QuickAddTaskRequest struct {
+	// Text is read into the task, such as "Pay rent every month on the 1st #finance !high tomorrow 9am"
+	Text string `json:"text"`
+	// Timezone is the IANA time zone times of day and relative dates are in, defaults to UTC
+	Timezone  string `json:"timezone"`
+	ProjectID *int   `json:"project_id"`
+	// Preview only reads the text, without creating the task
+	Preview bool `json:"preview"`
+}
+
+func (c *QuickAddTaskRequest) Bind(r *http.Request) error { return nil }
+
+func (c *QuickAddTaskRequest) Validate() error {
+	c.Text = strings.TrimSpace(c.Text)
+	c.Timezone = strings.TrimSpace(c.Timezone)
+
+	return validation.ValidateStruct(c,
+		validation.Field(&c.Text, validation.Required, validation.Length(1, maxQuickAddLength)),
+		validation.Field(&c.Timezone, validation.By(validateTimezone)),
+	)
+}
+
+func validateTimezone(value interface{}) error {
+	timezone, _ := value.(string)
+	if _, err := time.LoadLocation(timezone); err != nil {
+		return errors.New("must be an IANA time zone such as Europe/London")
+	}
+
+	return nil
+}
+
+// QuickAddMatch is a phrase of the text that was read as part of the task
+type QuickAddMatch struct {
+	Kind string `json:"kind" enums:"tag,priority,date,time,recurrence"`
+	Text string `json:"text"`
+}
+
+// QuickAddInterpretation is how the text was read. Due dates without a time
+// of day are all day, at midnight UTC.
+type QuickAddInterpretation struct {
+	Title      string          `json:"title"`
+	Tags       []string        `json:"tags"`
+	Priority   TaskPriority    `json:"priority"`
+	DueAt      null.Time       `json:"due_at" swaggertype:"string"`
+	AllDay     bool            `json:"all_day"`
+	Recurrence string          `json:"recurrence"`
+	Matches    []QuickAddMatch `json:"matches"`
+}
+
+type QuickAddTaskResponse struct {
+	Interpretation QuickAddInterpretation `json:"interpretation"`
+	// Task is null for previews
+	Task *Task `json:"task"`
+}
+
+const (
+	maxTemplateTasks = 500
+	// maxChecklistItems is how many checklist items a task of a template can have
+	maxChecklistItems = 100
+	// maxDueOffset is how many days before or after the date a template is
+	// instantiated for its tasks can be due
+	maxDueOffset = 3650
+)
+
+// normalizeTemplateTask validates a task of a template and its subtasks the
+// way tasks are validated when they're created. count is the number of tasks
+// of the template so far.
+func normalizeTemplateTask(task *TemplateTask, count *int) error {
+	*count++
+	if *count > maxTemplateTasks {
+		return fmt.Errorf("templates can't have more than %d tasks", maxTemplateTasks)
+	}
+
+	task.Title = strings.TrimSpace(task.Title)
+	task.Description = strings.TrimSpace(task.Description)
+	task.DueTime = strings.TrimSpace(task.DueTime)
+
+	if task.Checklist == nil {
+		task.Checklist = []string{}
+	}
+
+	for i, item := range task.Checklist {
+		// items are written on a single line
+		task.Checklist[i] = strings.Join(strings.Fields(item), " ")
+	}
+
+	if task.Priority == "" {
+		task.Priority = PriorityNone
+	}
+
+	tags, err := normalizeTags(task.Tags)
+	if err != nil {
+		return err
+	}
+	task.Tags = tags
+
+	if task.Recurrence != "" {
+		recurrence, err := normalizeRecurrence(task.Recurrence)
+		if err != nil {
+			return err
+		}
+		task.Recurrence = recurrence
+	}
+
+	err = validation.ValidateStruct(task,
+		validation.Field(&task.Title, validation.Required),
+		validation.Field(&task.Priority, validation.By(validateTaskPriority)),
+		validation.Field(&task.DueOffset, validation.By(func(value interface{}) error {
+			offset, _ := value.(null.Int)
+			if offset.Valid && (offset.Int64 < -maxDueOffset || offset.Int64 > maxDueOffset) {
+				return fmt.Errorf("must be between %d and %d days", -maxDueOffset, maxDueOffset)
+			}
+			return nil
+		})),
+		validation.Field(&task.DueTime, validation.Date("15:04").Error("must be a time of day such as 17:30")),
+		validation.Field(&task.Checklist,
+			validation.Length(0, maxChecklistItems),
+			validation.Each(validation.Required, validation.RuneLength(0, maxNameLength)),
+		),
+	)
+	if err != nil {
+		return err
+	}
+
+	if task.DueTime != "" && !task.DueOffset.Valid {
+		return errors.New("due_time: cannot be set without a due_offset")
+	}
+
+	if task.DueTime != "" {
+		// validated above, 9:30 is stored as 09:30
+		clock, _ := time.Parse("15:04", task.DueTime)
+		task.DueTime = clock.Format("15:04")
+	}
+
+	if task.Subtasks == nil {
+		task.Subtasks = []TemplateTask{}
+	}
+
+	for i := range task.Subtasks {
+		if err := normalizeTemplateTask(&task.Subtasks[i], count); err != nil {
+			return fmt.Errorf("subtasks[%d].%w", i, err)
+		}
+	}
+
+	return nil
+}
+
+type GetTemplatesResponse struct {
+	Templates []TaskTemplate `json:"templates"`
+}
+
+type TemplateResponse struct {
+	Template TaskTemplate `json:"template"`
+}
+
+type CreateTemplateRequest struct {
+	Name string        `json:"name"`
+	Task *TemplateTask `json:"task"`
+	// TaskID saves the task and its subtasks as the template instead, with due
+	// dates as days after the task's due date
+	TaskID *int `json:"task_id"`
+	// Timezone is the IANA time zone the due times of the saved task are in,
+	// defaults to UTC
+	Timezone string `json:"timezone"`
+}
+
+func (c *CreateTemplateRequest) Bind(r *http.Request) error { return nil }
+
+func (c *CreateTemplateRequest) Validate() error {
+	c.Name = strings.TrimSpace(c.Name)
+	c.Timezone = strings.TrimSpace(c.Timezone)
+
+	err := validation.ValidateStruct(c,
+		validation.Field(&c.Name, validation.Required, validation.Length(1, 255)),
+		validation.Field(&c.Task, validation.When(c.TaskID == nil, validation.Required.Error("either task or task_id is required"))),
+		validation.Field(&c.TaskID, validation.When(c.Task != nil, validation.Nil.Error("cannot be set along with task"))),
+		validation.Field(&c.Timezone, validation.By(validateTimezone)),
+	)
+	if err != nil {
+		return err
+	}
+
+	if c.Task != nil {
+		var count int
+		if err := normalizeTemplateTask(c.Task, &count); err != nil {
+			return fmt.Errorf("task.%w", err)
+		}
+	}
+
+	return nil
+}
+
+type EditTemplateRequest struct {
+	Name *string       `json:"name"`
+	Task *TemplateTask `json:"task"`
+}
+
+func (c *EditTemplateRequest) Bind(r *http.Request) error { return nil }
+
+func (c *EditTemplateRequest) Validate() error {
+	if c.Name != nil {
+		trimmed := strings.TrimSpace(*c.Name)
+		c.Name = &trimmed
+	}
+
+	err := validation.ValidateStruct(c,
+		validation.Field(&c.Name, validation.NilOrNotEmpty, validation.Length(1, 255)),
+	)
+	if err != nil {
+		return err
+	}
+
+	if c.Task != nil {
+		var count int
+		if err := normalizeTemplateTask(c.Task, &count); err != nil {
+			return fmt.Errorf("task.%w", err)
+		}
+	}
+
+	return nil
+}
+
+type InstantiateTemplateRequest struct {
+	// AnchorDate is the date due offsets are counted from, such as 2026-10-19,
+	// defaults to today
+	AnchorDate string `json:"anchor_date"`
+	// Timezone is the IANA time zone due times and today are in, defaults to UTC
+	Timezone  string `json:"timezone"`
+	ProjectID *int   `json:"project_id"`
+}
+
+func (c *InstantiateTemplateRequest) Bind(r *http.Request) error { return nil }
+
+func (c *InstantiateTemplateRequest) Validate() error {
+	c.AnchorDate = strings.TrimSpace(c.AnchorDate)
+	c.Timezone = strings.TrimSpace(c.Timezone)
+
+	return validation.ValidateStruct(c,
+		validation.Field(&c.AnchorDate, validation.Date(time.DateOnly).Error("must be a date such as 2006-01-02")),
+		validation.Field(&c.Timezone, validation.By(validateTimezone)),
+	)
+}
+
+type InstantiateTemplateResponse struct {
+	// Tasks are listed parents first, with subtasks after their parent
+	Tasks []Task `json:"tasks"`
+}
@@ -0,0 +1,268 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"gopkg.in/guregu/null.v4"
+)
+
+// notify delivers the notification on every channel its recipient hasn't turned
+// off for its type. Notifications never fail the change that caused them, so
+// errors are only logged.
+func (a *Application) notify(ctx context.Context, notification Notification) {
+	preferences, err := a.notificationPreferences(ctx, notification.UserID)
+	if err != nil {
+		slog.Error(err.Error())
+		return
+	}
+
+	for _, preference := range preferences {
+		if preference.Type != notification.Type || !preference.Enabled {
+			continue
+		}
+
+		switch preference.Channel {
+		case NotificationChannelInApp:
+			_, err = a.store.Notifications().CreateNotification(ctx, &notification)
+		case NotificationChannelEmail:
+			err = a.notifier.Notify(ctx, notification)
+		}
+		if err != nil {
+			slog.Error(err.Error())
+		}
+	}
+}
+
+// notificationPreferences returns the user's preference for every type of
+// notification on every channel
+func (a *Application) notificationPreferences(ctx context.Context, userID int) ([]NotificationPreference, error) {
+	stored, err := a.store.Notifications().GetNotificationPreferences(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	disabled := map[NotificationPreference]bool{}
+	for _, preference := range stored {
+		if !preference.Enabled {
+			disabled[NotificationPreference{Type: preference.Type, Channel: preference.Channel}] = true
+		}
+	}
+
+	preferences := make([]NotificationPreference, 0, len(NotificationTypes)*len(NotificationChannels))
+	for _, notificationType := range NotificationTypes {
+		for _, channel := range NotificationChannels {
+			preference := NotificationPreference{Type: notificationType, Channel: channel}
+			preference.Enabled = !disabled[preference]
+			preferences = append(preferences, preference)
+		}
+	}
+
+	return preferences, nil
+}
+
+// notifyAssignee tells the task's assignee that it was assigned to them,
+// unless they assigned it to themselves
+func (a *Application) notifyAssignee(ctx context.Context, task *Task, actorID int) {
+	if !task.AssigneeID.Valid || int(task.AssigneeID.Int64) == actorID {
+		return
+	}
+
+	a.notify(ctx, Notification{
+		UserID:    int(task.AssigneeID.Int64),
+		Type:      NotificationTaskAssigned,
+		TaskID:    task.ID,
+		TaskTitle: task.Title,
+		ActorID:   null.IntFrom(int64(actorID)),
+	})
+}
+
+// notifyCommented tells the task's creator and assignee that someone else
+// commented on it
+func (a *Application) notifyCommented(ctx context.Context, task *Task, actorID int) {
+	recipients := []int{task.UserID}
+	if task.AssigneeID.Valid && int(task.AssigneeID.Int64) != task.UserID {
+		recipients = append(recipients, int(task.AssigneeID.Int64))
+	}
+
+	for _, userID := range recipients {
+		if userID == actorID {
+			continue
+		}
+
+		a.notify(ctx, Notification{
+			UserID:    userID,
+			Type:      NotificationTaskCommented,
+			TaskID:    task.ID,
+			TaskTitle: task.Title,
+			ActorID:   null.IntFrom(int64(actorID)),
+		})
+	}
+}
+
+// notifyDue reminds the task's assignee, or its creator if nobody is
+// assigned, that the task is almost due
+func (a *Application) notifyDue(ctx context.Context, task *Task) {
+	userID := task.UserID
+	if task.AssigneeID.Valid {
+		userID = int(task.AssigneeID.Int64)
+	}
+
+	a.notify(ctx, Notification{
+		UserID:    userID,
+		Type:      NotificationTaskDue,
+		TaskID:    task.ID,
+		TaskTitle: task.Title,
+	})
+}
+
+// @Summary	Get Notifications
+// @Tags		Notifications
+// @Id			GetNotifications
+// @Param		unread		query		bool	false	"only return unread notifications"
+// @Param		cursor		query		int		false	"cursor for forward pagination"
+// @Param		per_page	query		int		false	"maximum number of notifications to return"
+// @Success	200			{object}	SuccessResponse{data=GetNotificationsResponse,paging=PaginationData}
+// @Failure	401			{object}	ErrorResponse
+// @Security	BasicAuth
+// @Router		/notifications [get]
+func (a *Application) GetNotifications(w http.ResponseWriter, r *http.Request) {
+	user := a.getCtxUser(r)
+	paging := a.getCtxPaging(r)
+
+	unreadOnly := r.URL.Query().Get("unread") == "true"
+
+	notifications, paginationData, err := a.store.Notifications().GetNotifications(r.Context(), user.ID, unreadOnly, paging)
+	if err != nil {
+		render.Render(w, r, ErrInternalServerError("An unexpected error occured"))
+		slog.Error(err.Error())
+		return
+	}
+
+	unreadCount, err := a.store.Notifications().CountUnreadNotifications(r.Context(), user.ID)
+	if err != nil {
+		render.Render(w, r, ErrInternalServerError("An unexpected error occured"))
+		slog.Error(err.Error())
+		return
+	}
+
+	payload := NewSuccessResponse(GetNotificationsResponse{notifications, unreadCount}).WithPaginationData(paginationData)
+	render.Render(w, r, payload)
+}
+
+// @Summary	Mark Notification Read
+// @Tags		Notifications
+// @Id			MarkNotificationRead
+// @Param		id		path		int	true	"notification id"
+// @Success	200		{object}	SuccessResponse{data=MarkNotificationReadResponse}
+// @Failure	401,404	{object}	ErrorResponse
+// @Security	BasicAuth
+// @Router		/notifications/{id}/read [post]
+func (a *Application) MarkNotificationRead(w http.ResponseWriter, r *http.Request) {
+	user := a.getCtxUser(r)
+	rawID := chi.URLParam(r, "id")
+
+	id, err := strconv.Atoi(rawID)
+	if err != nil {
+		render.Render(w, r, ErrResourceNotFound("Notification not found"))
+		return
+	}
+
+	notification, err := a.store.Notifications().MarkNotificationRead(r.Context(), user.ID, id)
+	if err != nil {
+		if errors.Is(err, ErrNotificationNotFound) {
+			render.Render(w, r, ErrResourceNotFound("Notification not found"))
+			return
+		}
+
+		render.Render(w, r, ErrInternalServerError("An unexpected error occured"))
+		slog.Error(err.Error())
+		return
+	}
+
+	render.Render(w, r, NewSuccessResponse(MarkNotificationReadResponse{*notification}))
+}
+
+// @Summary	Mark All Notifications Read
+// @Tags		Notifications
+// @Id			MarkAllNotificationsRead
+// @Success	200	{object}	SuccessResponse{data=MarkAllNotificationsReadResponse}
+// @Failure	401	{object}	ErrorResponse
+// @Security	BasicAuth
+// @Router		/notifications/read [post]
+func (a *Application) MarkAllNotificationsRead(w http.ResponseWriter, r *http.Request) {
+	user := a.getCtxUser(r)
+
+	count, err := a.store.Notifications().MarkAllNotificationsRead(r.Context(), user.ID)
+	if err != nil {
+		render.Render(w, r, ErrInternalServerError("An unexpected error occured"))
+		slog.Error(err.Error())
+		return
+	}
+
+	render.Render(w, r, NewSuccessResponse(MarkAllNotificationsReadResponse{count}))
+}
+
+// @Summary	Get Notification Preferences
+// @Tags		Notifications
+// @Id			GetNotificationPreferences
+// @Success	200	{object}	SuccessResponse{data=NotificationPreferencesResponse}
+// @Failure	401	{object}	ErrorResponse
+// @Security	BasicAuth
+// @Router		/notifications/preferences [get]
+func (a *Application) GetNotificationPreferences(w http.ResponseWriter, r *http.Request) {
+	user := a.getCtxUser(r)
+
+	preferences, err := a.notificationPreferences(r.Context(), user.ID)
+	if err != nil {
+		render.Render(w, r, ErrInternalServerError("An unexpected error occured"))
+		slog.Error(err.Error())
+		return
+	}
+
+	render.Render(w, r, NewSuccessResponse(NotificationPreferencesResponse{preferences}))
+}
+
+// @Summary		Edit Notification Preferences
+// @Description	Turns types of notifications on or off per channel. Preferences that aren't sent are left unchanged, every channel is enabled until it is turned off.
+// @Tags			Notifications
+// @Id				EditNotificationPreferences
+// @Param			request	body		EditNotificationPreferencesRequest	true	"request body"
+// @Success		200		{object}	SuccessResponse{data=NotificationPreferencesResponse}
+// @Failure		400,401	{object}	ErrorResponse
+// @Security		BasicAuth
+// @Router			/notifications/preferences [put]
+func (a *Application) EditNotificationPreferences(w http.ResponseWriter, r *http.Request) {
+	user := a.getCtxUser(r)
+
+	var requestBody EditNotificationPreferencesRequest
+	if err := render.Bind(r, &requestBody); err != nil {
+		render.Render(w, r, ErrBadRequest("Invalid request body"))
+		return
+	}
+
+	if err := requestBody.Validate(); err != nil {
+		render.Render(w, r, ErrBadRequest(err.Error()))
+		return
+	}
+
+	if err := a.store.Notifications().SetNotificationPreferences(r.Context(), user.ID, requestBody.Preferences); err != nil {
+		render.Render(w, r, ErrInternalServerError("An unexpected error occured"))
+		slog.Error(err.Error())
+		return
+	}
+
+	preferences, err := a.notificationPreferences(r.Context(), user.ID)
+	if err != nil {
+		render.Render(w, r, ErrInternalServerError("An unexpected error occured"))
+		slog.Error(err.Error())
+		return
+	}
+
+	render.Render(w, r, NewSuccessResponse(NotificationPreferencesResponse{preferences}))
+}
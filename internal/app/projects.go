@@ -0,0 +1,254 @@
+package app
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"gopkg.in/guregu/null.v4"
+)
+
+// @Summary	Get Projects
+// @Tags		Projects
+// @Id			GetProjects
+// @Param		X-Workspace-ID	header		int	false	"workspace to act in, defaults to the personal workspace"
+// @Param		cursor			query		int	false	"cursor for forward pagination"
+// @Param		per_page		query		int	false	"maximum number of projects to return"
+// @Success	200				{object}	SuccessResponse{data=GetProjectsResponse,paging=PaginationData}
+// @Failure	400,401			{object}	ErrorResponse
+// @Security	BasicAuth
+// @Router		/projects [get]
+func (a *Application) GetProjects(w http.ResponseWriter, r *http.Request) {
+	user := a.getCtxUser(r)
+	workspace := a.getCtxWorkspace(r)
+	paging := a.getCtxPaging(r)
+
+	projects, paginationData, err := a.store.Projects().GetProjects(r.Context(), workspace.ID, user.ID, paging)
+	if err != nil {
+		render.Render(w, r, ErrInternalServerError("An unexpected error occured"))
+		slog.Error(err.Error())
+		return
+	}
+
+	payload := NewSuccessResponse(GetProjectsResponse{projects}).WithPaginationData(paginationData)
+	render.Render(w, r, payload)
+}
+
+// @Summary	Create Project
+// @Tags		Projects
+// @Id			CreateProject
+// @Param		X-Workspace-ID	header		int						false	"workspace to act in, defaults to the personal workspace"
+// @Param		request			body		CreateProjectRequest	true	"request body"
+// @Success	201				{object}	SuccessResponse{data=CreateProjectResponse}
+// @Failure	400,401			{object}	ErrorResponse
+// @Security	BasicAuth
+// @Router		/projects [post]
+func (a *Application) CreateProject(w http.ResponseWriter, r *http.Request) {
+	user := a.getCtxUser(r)
+
+	var requestBody CreateProjectRequest
+	if err := render.Bind(r, &requestBody); err != nil {
+		render.Render(w, r, ErrBadRequest("Invalid request body"))
+		return
+	}
+
+	if err := requestBody.Validate(); err != nil {
+		render.Render(w, r, ErrBadRequest(err.Error()))
+		return
+	}
+
+	projectPayload := &Project{
+		UserID:      user.ID,
+		WorkspaceID: a.getCtxWorkspace(r).ID,
+		Name:        requestBody.Name,
+		Description: requestBody.Description,
+	}
+
+	project, err := a.store.Projects().CreateProject(r.Context(), projectPayload)
+	if err != nil {
+		render.Render(w, r, ErrInternalServerError("An unexpected error occured"))
+		slog.Error(err.Error())
+		return
+	}
+
+	render.Status(r, http.StatusCreated)
+	render.Render(w, r, NewSuccessResponse(CreateProjectResponse{*project}))
+}
+
+// @Summary	Edit Project
+// @Tags		Projects
+// @Id			EditProject
+// @Param		X-Workspace-ID	header		int					false	"workspace to act in, defaults to the personal workspace"
+// @Param		id				path		int					true	"project id"
+// @Param		request			body		EditProjectRequest	true	"request body"
+// @Success	200				{object}	SuccessResponse{data=EditProjectResponse}
+// @Failure	400,401,403,404	{object}	ErrorResponse
+// @Security	BasicAuth
+// @Router		/projects/{id} [patch]
+func (a *Application) EditProject(w http.ResponseWriter, r *http.Request) {
+	rawID := chi.URLParam(r, "id")
+
+	id, err := strconv.Atoi(rawID)
+	if err != nil {
+		render.Render(w, r, ErrResourceNotFound("Project not found"))
+		return
+	}
+
+	project, err := a.authorizeProject(r, id, ProjectRoleOwner)
+	if err != nil {
+		a.renderProjectAccessError(w, r, err)
+		return
+	}
+
+	var requestBody EditProjectRequest
+	if err := render.Bind(r, &requestBody); err != nil {
+		render.Render(w, r, ErrBadRequest("Invalid request body"))
+		return
+	}
+
+	if err := requestBody.Validate(); err != nil {
+		render.Render(w, r, ErrBadRequest(err.Error()))
+		return
+	}
+
+	if requestBody.Name != nil {
+		project.Name = *requestBody.Name
+	}
+
+	if requestBody.Description != nil {
+		project.Description = *requestBody.Description
+	}
+
+	updatedProject, err := a.store.Projects().UpdateProject(r.Context(), project)
+	if err != nil {
+		if errors.Is(err, ErrProjectNotFound) {
+			render.Render(w, r, ErrResourceNotFound("Project not found"))
+			return
+		}
+
+		render.Render(w, r, ErrInternalServerError("An unexpected error occured"))
+		slog.Error(err.Error())
+		return
+	}
+
+	render.Render(w, r, NewSuccessResponse(EditProjectResponse{*updatedProject}))
+}
+
+// @Summary		Delete Project
+// @Description	Deletes a project. Its tasks are kept without a project and go back to the members who created them. Only owners can delete a project.
+// @Tags			Projects
+// @Id				DeleteProject
+// @Param			X-Workspace-ID	header	int	false	"workspace to act in, defaults to the personal workspace"
+// @Param			id				path	int	true	"project id"
+// @Success		204
+// @Failure		401,403,404	{object}	ErrorResponse
+// @Security		BasicAuth
+// @Router			/projects/{id} [delete]
+func (a *Application) DeleteProject(w http.ResponseWriter, r *http.Request) {
+	rawID := chi.URLParam(r, "id")
+
+	id, err := strconv.Atoi(rawID)
+	if err != nil {
+		render.Render(w, r, ErrResourceNotFound("Project not found"))
+		return
+	}
+
+	if _, err := a.authorizeProject(r, id, ProjectRoleOwner); err != nil {
+		a.renderProjectAccessError(w, r, err)
+		return
+	}
+
+	if err := a.store.Projects().DeleteProject(r.Context(), id); err != nil {
+		if errors.Is(err, ErrProjectNotFound) {
+			render.Render(w, r, ErrResourceNotFound("Project not found"))
+			return
+		}
+
+		render.Render(w, r, ErrInternalServerError("An unexpected error occured"))
+		slog.Error(err.Error())
+		return
+	}
+
+	render.NoContent(w, r)
+}
+
+// @Summary		Get Project Board
+// @Description	Returns the project's tasks grouped into a column per status and ordered by position. Each column holds the first page of its tasks; later pages of a column are fetched by passing its status_id with the column's next cursor. Tasks are moved between columns with the move task endpoint.
+// @Tags			Projects
+// @Id				GetProjectBoard
+// @Param			X-Workspace-ID	header		int		false	"workspace to act in, defaults to the personal workspace"
+// @Param			id				path		int		true	"project id"
+// @Param			status_id		query		int		false	"only return the column of this status"
+// @Param			cursor			query		int		false	"cursor for the column selected by status_id"
+// @Param			cursor_position	query		string	false	"next_cursor_position of the column's previous page"
+// @Param			per_page		query		int		false	"maximum number of tasks to return per column"
+// @Success		200				{object}	SuccessResponse{data=GetBoardResponse}
+// @Failure		400,401,404		{object}	ErrorResponse
+// @Security		BasicAuth
+// @Router			/projects/{id}/board [get]
+func (a *Application) GetProjectBoard(w http.ResponseWriter, r *http.Request) {
+	user := a.getCtxUser(r)
+	paging := a.getCtxPaging(r)
+	rawID := chi.URLParam(r, "id")
+
+	id, err := strconv.Atoi(rawID)
+	if err != nil {
+		render.Render(w, r, ErrResourceNotFound("Project not found"))
+		return
+	}
+
+	project, err := a.authorizeProject(r, id, ProjectRoleViewer)
+	if err != nil {
+		a.renderProjectAccessError(w, r, err)
+		return
+	}
+
+	// project tasks use the workflow of the project's creator
+	statuses, err := a.store.Statuses().GetStatuses(r.Context(), project.WorkspaceID, project.UserID)
+	if err != nil {
+		render.Render(w, r, ErrInternalServerError("An unexpected error occured"))
+		slog.Error(err.Error())
+		return
+	}
+
+	// a cursor belongs to a single column, so it's ignored when the whole board is requested
+	var statusID null.Int
+	if rawStatusID, err := strconv.Atoi(r.URL.Query().Get("status_id")); err == nil {
+		statusID = null.IntFrom(int64(rawStatusID))
+	} else {
+		paging.Cursor = defaultCursor
+		paging.CursorPosition = null.String{}
+	}
+
+	columns := []BoardColumn{}
+	for _, status := range statuses {
+		if statusID.Valid && int64(status.ID) != statusID.Int64 {
+			continue
+		}
+
+		filter := TaskFilter{
+			ProjectID: null.IntFrom(int64(project.ID)),
+			StatusID:  null.IntFrom(int64(status.ID)),
+			Sort:      TaskSortPosition,
+		}
+
+		tasks, paginationData, err := a.store.Tasks().GetTasks(r.Context(), project.WorkspaceID, user.ID, filter, paging)
+		if err != nil {
+			render.Render(w, r, ErrInternalServerError("An unexpected error occured"))
+			slog.Error(err.Error())
+			return
+		}
+
+		columns = append(columns, BoardColumn{Status: status, Tasks: tasks, Paging: paginationData})
+	}
+
+	if statusID.Valid && len(columns) == 0 {
+		render.Render(w, r, ErrResourceNotFound("Status not found"))
+		return
+	}
+
+	render.Render(w, r, NewSuccessResponse(GetBoardResponse{Project: *project, Columns: columns}))
+}
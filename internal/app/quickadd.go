@@ -0,0 +1,110 @@
+package app
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/ayo-awe/golang_todo_api/internal/quickadd"
+	"github.com/go-chi/render"
+	"gopkg.in/guregu/null.v4"
+)
+
+// maxQuickAddLength is the longest text quick add reads, in bytes
+const maxQuickAddLength = 1000
+
+// @Summary		Quick Add Task
+// @Description	Creates a task from a line of text such as "Pay rent every month on the 1st #finance !high tomorrow 9am".
+// @Description	#tags and priorities (!urgent, !high, !medium, !low or !1 to !4) are taken out of the text, along with the
+// @Description	first due date (today, friday, next monday, in 3 days, oct 30th, 2026-10-30), time of day (9am, 17:30,
+// @Description	noon) and recurrence (daily, every 2 weeks, every mon and thu, every month on the 1st). The rest is the
+// @Description	task's title. The interpretation is returned along with the task, or on its own for previews.
+// @Tags			Tasks
+// @Id				QuickAddTask
+// @Param			X-Workspace-ID	header		int					false	"workspace to act in, defaults to the personal workspace"
+// @Param			request			body		QuickAddTaskRequest	true	"request body"
+// @Success		200,201			{object}	SuccessResponse{data=QuickAddTaskResponse}
+// @Failure		400,401,403		{object}	ErrorResponse
+// @Security		BasicAuth
+// @Router			/tasks/quick [post]
+func (a *Application) QuickAddTask(w http.ResponseWriter, r *http.Request) {
+	user := a.getCtxUser(r)
+
+	var requestBody QuickAddTaskRequest
+	if err := render.Bind(r, &requestBody); err != nil {
+		render.Render(w, r, ErrBadRequest("Invalid request body"))
+		return
+	}
+
+	if err := requestBody.Validate(); err != nil {
+		render.Render(w, r, ErrBadRequest(err.Error()))
+		return
+	}
+
+	location := time.UTC
+	if requestBody.Timezone != "" {
+		// validated above
+		location, _ = time.LoadLocation(requestBody.Timezone)
+	}
+
+	result := quickadd.Parse(requestBody.Text, time.Now().In(location))
+
+	taskRequest := CreateTaskRequest{
+		Title:      result.Title,
+		ProjectID:  requestBody.ProjectID,
+		Priority:   TaskPriority(result.Priority),
+		Tags:       result.Tags,
+		Recurrence: result.Recurrence,
+	}
+	if result.DueAt != nil {
+		taskRequest.DueAt = null.TimeFrom(*result.DueAt)
+	}
+
+	// the title is what's left of the text, so it isn't reported as a field
+	if result.Title == "" {
+		render.Render(w, r, ErrBadRequest("Text has no title"))
+		return
+	}
+
+	if err := taskRequest.Validate(); err != nil {
+		render.Render(w, r, ErrBadRequest(err.Error()))
+		return
+	}
+
+	interpretation := QuickAddInterpretation{
+		Title:      taskRequest.Title,
+		Tags:       taskRequest.Tags,
+		Priority:   taskRequest.Priority,
+		DueAt:      taskRequest.DueAt,
+		AllDay:     result.AllDay,
+		Recurrence: taskRequest.Recurrence,
+		Matches:    make([]QuickAddMatch, len(result.Matches)),
+	}
+	for i, match := range result.Matches {
+		interpretation.Matches[i] = QuickAddMatch{Kind: match.Kind, Text: match.Text}
+	}
+
+	taskPayload, mentionedIDs, err := a.newTaskFromRequest(r, &taskRequest, StatusCategoryTodo)
+	if err != nil {
+		renderError(w, r, err)
+		return
+	}
+
+	if requestBody.Preview {
+		render.Render(w, r, NewSuccessResponse(QuickAddTaskResponse{Interpretation: interpretation}))
+		return
+	}
+
+	newTask, err := a.store.Tasks().CreateTask(r.Context(), taskPayload, a.getEventMeta(r))
+	if err != nil {
+		render.Render(w, r, ErrInternalServerError("An unexpected error occured"))
+		slog.Error(err.Error())
+		return
+	}
+
+	a.notifyAssignee(r.Context(), newTask, user.ID)
+	a.saveMentions(r.Context(), newTask, null.Int{}, mentionedIDs, user.ID)
+
+	render.Status(r, http.StatusCreated)
+	render.Render(w, r, NewSuccessResponse(QuickAddTaskResponse{Interpretation: interpretation, Task: newTask}))
+}
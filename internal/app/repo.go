@@ -2,16 +2,46 @@ package app
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"io"
 	"time"
 
+	"github.com/ayo-awe/golang_todo_api/internal/importer"
 	"golang.org/x/crypto/bcrypt"
 	"gopkg.in/guregu/null.v4"
 )
 
 var (
-	ErrUserNotFound = errors.New("user not found")
-	ErrTaskNotFound = errors.New("task not found")
+	ErrUserNotFound         = errors.New("user not found")
+	ErrTaskNotFound         = errors.New("task not found")
+	ErrStatusNotFound       = errors.New("status not found")
+	ErrStatusInUse          = errors.New("status is in use")
+	ErrStatusRequired       = errors.New("at least one status is required in the category")
+	ErrStatusExists         = errors.New("status with the same name exists")
+	ErrNothingToUndo        = errors.New("nothing to undo")
+	ErrUndoConflict         = errors.New("task has been changed since")
+	ErrInvalidMove          = errors.New("invalid task neighbours")
+	ErrProjectNotFound      = errors.New("project not found")
+	ErrCommentNotFound      = errors.New("comment not found")
+	ErrAttachmentNotFound   = errors.New("attachment not found")
+	ErrQuotaExceeded        = errors.New("storage quota exceeded")
+	ErrBlobNotFound         = errors.New("blob not found")
+	ErrPermissionDenied     = errors.New("permission denied")
+	ErrMemberExists         = errors.New("user is already a member of the project")
+	ErrMemberNotFound       = errors.New("member not found")
+	ErrLastOwner            = errors.New("project must have at least one owner")
+	ErrInvalidAssignee      = errors.New("assignee can't access the task")
+	ErrWorkspaceNotFound    = errors.New("workspace not found")
+	ErrInvitationNotFound   = errors.New("invitation not found")
+	ErrNotificationNotFound = errors.New("notification not found")
+	ErrWebhookNotFound      = errors.New("webhook not found")
+	ErrDeliveryNotFound     = errors.New("delivery not found")
+	ErrImportNotFound       = errors.New("import not found")
+	ErrCalendarFeedNotFound = errors.New("calendar feed not found")
+	ErrTemplateNotFound     = errors.New("template not found")
+	ErrTaskUIDExists        = errors.New("task with the same uid exists")
+	ErrVersionConflict      = errors.New("task was changed since the expected version")
 )
 
 type User struct {
@@ -40,48 +70,901 @@ func (u *User) ComparePassword(password string) bool {
 }
 
 type Task struct {
+	ID           int          `json:"id"`
+	Title        string       `json:"title"`
+	Description  string       `json:"description"`
+	IsCompleted  bool         `json:"is_completed"`
+	UserID       int          `json:"user_id"`
+	CreatedAt    time.Time    `json:"created_at"`
+	UpdatedAt    time.Time    `json:"updated_at"`
+	DeletedAt    null.Time    `json:"deleted_at" swaggertype:"string"`
+	CompletedAt  null.Time    `json:"completed_at" swaggertype:"string"`
+	ArchivedAt   null.Time    `json:"archived_at" swaggertype:"string"`
+	StatusID     int          `json:"status_id"`
+	Position     string       `json:"position"`
+	ProjectID    null.Int     `json:"project_id" swaggertype:"integer"`
+	CommentCount int          `json:"comment_count"`
+	AssigneeID   null.Int     `json:"assignee_id" swaggertype:"integer"`
+	WorkspaceID  int          `json:"workspace_id"`
+	Version      int          `json:"version"`
+	DueAt        null.Time    `json:"due_at" swaggertype:"string"`
+	Priority     TaskPriority `json:"priority"`
+	Tags         []string     `json:"tags"`
+	// Recurrence is an iCalendar RRULE, empty if the task doesn't repeat
+	Recurrence string `json:"recurrence"`
+	// UID is the iCalendar UID of tasks created over CalDAV
+	UID null.String `json:"uid" swaggertype:"string"`
+	// ParentID is the task this is a subtask of, it's set when the task is
+	// created
+	ParentID null.Int `json:"parent_id" swaggertype:"integer"`
+}
+
+// TaskTree is a task to create along with its subtasks
+type TaskTree struct {
+	Task     *Task
+	Subtasks []TaskTree
+}
+
+// Comment is a markdown note left on a task. EditedAt is set once the
+// author changes the body.
+type Comment struct {
+	ID        int       `json:"id"`
+	TaskID    int       `json:"task_id"`
+	AuthorID  int       `json:"author_id"`
+	Body      string    `json:"body"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+	EditedAt  null.Time `json:"edited_at" swaggertype:"string"`
+}
+
+type Project struct {
 	ID          int       `json:"id"`
-	Title       string    `json:"title"`
-	Description string    `json:"description"`
-	IsCompleted bool      `json:"is_completed"`
 	UserID      int       `json:"user_id"`
+	WorkspaceID int       `json:"workspace_id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
 	CreatedAt   time.Time `json:"created_at"`
 	UpdatedAt   time.Time `json:"updated_at"`
 }
 
+// WorkspaceRole is the access a member has to a workspace. Each role can do
+// everything the roles before it can.
+type WorkspaceRole string
+
+const (
+	WorkspaceRoleMember WorkspaceRole = "member"
+	WorkspaceRoleAdmin  WorkspaceRole = "admin"
+	WorkspaceRoleOwner  WorkspaceRole = "owner"
+)
+
+var WorkspaceRoles = []WorkspaceRole{
+	WorkspaceRoleMember,
+	WorkspaceRoleAdmin,
+	WorkspaceRoleOwner,
+}
+
+func (r WorkspaceRole) IsValid() bool {
+	return r.level() >= 0
+}
+
+// AtLeast reports whether r grants the access of role
+func (r WorkspaceRole) AtLeast(role WorkspaceRole) bool {
+	return r.IsValid() && r.level() >= role.level()
+}
+
+func (r WorkspaceRole) level() int {
+	for i, role := range WorkspaceRoles {
+		if r == role {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// Workspace holds the projects and tasks of a team. Every user has a personal
+// workspace, which is used when a request doesn't name one. Role is the
+// access the requesting user has to the workspace.
+type Workspace struct {
+	ID         int           `json:"id"`
+	Name       string        `json:"name"`
+	IsPersonal bool          `json:"is_personal"`
+	Role       WorkspaceRole `json:"role"`
+	CreatedAt  time.Time     `json:"created_at"`
+	UpdatedAt  time.Time     `json:"updated_at"`
+}
+
+type WorkspaceMember struct {
+	WorkspaceID int           `json:"workspace_id"`
+	UserID      int           `json:"user_id"`
+	Email       string        `json:"email"`
+	Firstname   string        `json:"first_name"`
+	Lastname    string        `json:"last_name"`
+	Role        WorkspaceRole `json:"role"`
+	CreatedAt   time.Time     `json:"created_at"`
+}
+
+// WorkspaceInvitation lets the user with Email join a workspace. Only a hash of
+// the invitation's token is stored, the token itself is handed out once in the
+// invitation link.
+type WorkspaceInvitation struct {
+	ID          int           `json:"id"`
+	WorkspaceID int           `json:"workspace_id"`
+	Email       string        `json:"email"`
+	Role        WorkspaceRole `json:"role"`
+	TokenHash   string        `json:"-"`
+	InvitedBy   int           `json:"invited_by"`
+	CreatedAt   time.Time     `json:"created_at"`
+	ExpiresAt   time.Time     `json:"expires_at"`
+}
+
+// ProjectRole is the access a member has to a project and its tasks.
+// Each role can do everything the roles before it can.
+type ProjectRole string
+
+const (
+	ProjectRoleViewer ProjectRole = "viewer"
+	ProjectRoleEditor ProjectRole = "editor"
+	ProjectRoleOwner  ProjectRole = "owner"
+)
+
+var ProjectRoles = []ProjectRole{
+	ProjectRoleViewer,
+	ProjectRoleEditor,
+	ProjectRoleOwner,
+}
+
+func (r ProjectRole) IsValid() bool {
+	return r.level() >= 0
+}
+
+// AtLeast reports whether r grants the access of role
+func (r ProjectRole) AtLeast(role ProjectRole) bool {
+	return r.IsValid() && r.level() >= role.level()
+}
+
+func (r ProjectRole) level() int {
+	for i, role := range ProjectRoles {
+		if r == role {
+			return i
+		}
+	}
+
+	return -1
+}
+
+type ProjectMember struct {
+	ProjectID int         `json:"project_id"`
+	UserID    int         `json:"user_id"`
+	Email     string      `json:"email"`
+	Firstname string      `json:"first_name"`
+	Lastname  string      `json:"last_name"`
+	Role      ProjectRole `json:"role"`
+	CreatedAt time.Time   `json:"created_at"`
+}
+
+// ProjectViewer is a user who has a project open
+type ProjectViewer struct {
+	UserID    int    `json:"user_id"`
+	Firstname string `json:"first_name"`
+	Lastname  string `json:"last_name"`
+	Email     string `json:"email"`
+}
+
+type TaskPriority string
+
+const (
+	PriorityNone   TaskPriority = "none"
+	PriorityLow    TaskPriority = "low"
+	PriorityMedium TaskPriority = "medium"
+	PriorityHigh   TaskPriority = "high"
+	PriorityUrgent TaskPriority = "urgent"
+)
+
+var TaskPriorities = []TaskPriority{
+	PriorityNone,
+	PriorityLow,
+	PriorityMedium,
+	PriorityHigh,
+	PriorityUrgent,
+}
+
+func (p TaskPriority) IsValid() bool {
+	for _, priority := range TaskPriorities {
+		if p == priority {
+			return true
+		}
+	}
+
+	return false
+}
+
+type StatusCategory string
+
+const (
+	StatusCategoryTodo       StatusCategory = "todo"
+	StatusCategoryInProgress StatusCategory = "in_progress"
+	StatusCategoryDone       StatusCategory = "done"
+	StatusCategoryCancelled  StatusCategory = "cancelled"
+)
+
+var StatusCategories = []StatusCategory{
+	StatusCategoryTodo,
+	StatusCategoryInProgress,
+	StatusCategoryDone,
+	StatusCategoryCancelled,
+}
+
+func (c StatusCategory) IsValid() bool {
+	for _, category := range StatusCategories {
+		if c == category {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Status is a step in a user's task workflow. Tasks are considered completed
+// when their status is in the done category.
+type Status struct {
+	ID          int            `json:"id"`
+	UserID      int            `json:"user_id"`
+	WorkspaceID int            `json:"workspace_id"`
+	Name        string         `json:"name"`
+	Category    StatusCategory `json:"category"`
+	Position    int            `json:"position"`
+	CreatedAt   time.Time      `json:"created_at"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+}
+
+type TaskAction string
+
+const (
+	TaskCreated    TaskAction = "created"
+	TaskUpdated    TaskAction = "updated"
+	TaskDeleted    TaskAction = "deleted"
+	TaskRestored   TaskAction = "restored"
+	TaskPurged     TaskAction = "purged"
+	TaskArchived   TaskAction = "archived"
+	TaskUnarchived TaskAction = "unarchived"
+	TaskReverted   TaskAction = "reverted"
+	TaskMoved      TaskAction = "moved"
+	// TaskAccessChanged is only sent to the user whose access to the task
+	// changed, such as when they were removed from its project
+	TaskAccessChanged TaskAction = "access_changed"
+)
+
+// FieldChange holds the json encoded value of a task field before and after a change
+type FieldChange struct {
+	From json.RawMessage `json:"from" swaggertype:"object"`
+	To   json.RawMessage `json:"to" swaggertype:"object"`
+}
+
+// TaskEvent is a change to a task. ProjectID is the project the task was in
+// after the change, and PreviousProjectID the one it was in before.
+type TaskEvent struct {
+	ID                int                    `json:"id"`
+	TaskID            int                    `json:"task_id"`
+	ActorID           null.Int               `json:"actor_id" swaggertype:"integer"`
+	Action            TaskAction             `json:"action"`
+	Changes           map[string]FieldChange `json:"changes"`
+	RequestID         string                 `json:"request_id"`
+	CreatedAt         time.Time              `json:"created_at"`
+	RevertedBy        null.Int               `json:"reverted_by" swaggertype:"integer"`
+	Position          EventPosition          `json:"-"`
+	ProjectID         null.Int               `json:"-"`
+	PreviousProjectID null.Int               `json:"-"`
+}
+
+// EventPosition is where an event is in the order changes are read in, which is
+// by the transaction that recorded it and then by id. Ids are handed out before
+// their transactions commit, so on their own they can commit out of order, but
+// no event can show up behind a transaction that had ended when it was read.
+type EventPosition struct {
+	XactID  int64
+	EventID int
+}
+
+func (p EventPosition) Before(other EventPosition) bool {
+	return p.XactID < other.XactID || (p.XactID == other.XactID && p.EventID < other.EventID)
+}
+
+// TaskChange is a task event along with the current version of its task, which
+// is nil if the task was purged or can't be seen by the user anymore
+type TaskChange struct {
+	Event TaskEvent
+	Task  *Task
+}
+
+// FeedTopic is a kind of change a ChangeFeed can be subscribed to
+type FeedTopic string
+
+const (
+	// TopicTaskEvents changes whenever task events are recorded
+	TopicTaskEvents FeedTopic = "task_events"
+	// TopicProjectViewers changes whenever someone starts or stops viewing a project
+	TopicProjectViewers FeedTopic = "project_viewers"
+)
+
+var FeedTopics = []FeedTopic{
+	TopicTaskEvents,
+	TopicProjectViewers,
+}
+
+// ChangeFeed tells subscribers when a topic changed, on this or any other
+// instance of the API
+type ChangeFeed interface {
+	// Run listens for changes until ctx is cancelled
+	Run(ctx context.Context)
+	// Subscribe returns a channel that receives a value whenever the topic may
+	// have changed, and a function that ends the subscription
+	Subscribe(topic FeedTopic) (<-chan struct{}, func())
+}
+
+// EventMeta identifies who made a change and the request it was made in. Changes
+// made by background jobs have no ActorID.
+// Changes with an ExpectedVersion fail with ErrVersionConflict unless the task
+// is still at that version.
+type EventMeta struct {
+	ActorID         int
+	RequestID       string
+	ExpectedVersion int
+}
+
+type TaskSort string
+
+const (
+	TaskSortNewest   TaskSort = "newest"
+	TaskSortPosition TaskSort = "position"
+)
+
 type TaskFilter struct {
-	IsCompleted null.Bool
+	IsCompleted    null.Bool
+	IsArchived     bool
+	StatusID       null.Int
+	StatusCategory null.String
+	ProjectID      null.Int
+	AssigneeID     null.Int
+	// MentionedUserID only keeps tasks whose description or comments mention the user
+	MentionedUserID null.Int
+	Tag             null.String
+	Sort            TaskSort
+}
+
+// TaskMove places a task after AfterID and before BeforeID. Either neighbour
+// can be left out to place the task directly next to the other one, and
+// leaving out both moves it to the end of the list. A set StatusID moves the
+// task into that status in the same change.
+type TaskMove struct {
+	AfterID  null.Int
+	BeforeID null.Int
+	StatusID null.Int
 }
 
 type PaginationData struct {
 	NextCursor null.Int `json:"next_cursor" swaggertype:"integer"`
-	ItemCount  int      `json:"item_count"`
-	PerPage    int      `json:"per_page"`
+	// NextCursorPosition is set on lists ordered by position, whose cursor is
+	// the next task's position together with its id
+	NextCursorPosition string `json:"next_cursor_position,omitempty"`
+	ItemCount          int    `json:"item_count"`
+	PerPage            int    `json:"per_page"`
 }
 
 type Paging struct {
-	Cursor  int
-	PerPage int
+	Cursor int
+	// CursorPosition is the position of the cursor's task on lists ordered by
+	// position, so the list continues where it was even if the task has moved
+	CursorPosition null.String
+	PerPage        int
 }
 
 func (p Paging) Limit() int {
 	return p.PerPage + 1
 }
 
+// Attachment is a file uploaded to a task. Its contents are kept in a
+// BlobStore under BlobKey.
+type Attachment struct {
+	ID          int       `json:"id"`
+	TaskID      int       `json:"task_id"`
+	UploaderID  int       `json:"uploader_id"`
+	Filename    string    `json:"filename"`
+	ContentType string    `json:"content_type"`
+	Size        int64     `json:"size"`
+	BlobKey     string    `json:"-"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
 type Store interface {
 	Users() UserRepository
 	Tasks() TaskRepository
+	Statuses() StatusRepository
+	Projects() ProjectRepository
+	Comments() CommentRepository
+	Attachments() AttachmentRepository
+	Workspaces() WorkspaceRepository
+	Notifications() NotificationRepository
+	Mentions() MentionRepository
+	Webhooks() WebhookRepository
+	Presence() PresenceRepository
+	Imports() ImportRepository
+	Calendars() CalendarRepository
+	Templates() TemplateRepository
 }
 
 type UserRepository interface {
 	GetUserByEmail(context.Context, string) (*User, error)
+	GetUsersByEmails(ctx context.Context, emails []string) ([]User, error)
 	CreateUser(context.Context, *User) (*User, error)
 }
 
+// TaskRepository does not check access to single tasks, callers are expected
+// to authorize the user first. GetTaskByID returns tasks in the trash too.
 type TaskRepository interface {
-	GetTaskByID(ctx context.Context, userID int, taskID int) (*Task, error)
-	UpdateTask(ctx context.Context, task *Task) (*Task, error)
-	CreateTask(ctx context.Context, task *Task) (*Task, error)
-	GetTasks(ctx context.Context, userID int, taskFilter TaskFilter, paging Paging) ([]Task, PaginationData, error)
-	DeleteTask(ctx context.Context, userID int, taskID int) error
+	GetTaskByID(ctx context.Context, workspaceID int, taskID int) (*Task, error)
+	GetTaskByUID(ctx context.Context, workspaceID int, uid string) (*Task, error)
+	// GetSubtasks returns the subtasks of a task that aren't in the trash, in
+	// the order of the list
+	GetSubtasks(ctx context.Context, workspaceID int, parentID int) ([]Task, error)
+	// HasSubtasks reports whether a task has subtasks, counting those in the trash
+	HasSubtasks(ctx context.Context, workspaceID int, parentID int) (bool, error)
+	UpdateTask(ctx context.Context, task *Task, meta EventMeta) (*Task, error)
+	// CreateTask fails with ErrTaskUIDExists if the task has a UID another task
+	// in its workspace has
+	CreateTask(ctx context.Context, task *Task, meta EventMeta) (*Task, error)
+	// CreateTasks creates all of the tasks or, if any of them fails, none
+	CreateTasks(ctx context.Context, tasks []*Task, meta EventMeta) ([]Task, error)
+	// CreateTaskTrees creates the tasks and their subtasks, subtasks get the id
+	// of their parent, all at once. The new tasks are returned parents first.
+	CreateTaskTrees(ctx context.Context, trees []TaskTree, meta EventMeta) ([]Task, error)
+	// GetTasks returns the user's own tasks in the workspace and the tasks of the
+	// workspace's projects they can access
+	GetTasks(ctx context.Context, workspaceID int, userID int, taskFilter TaskFilter, paging Paging) ([]Task, PaginationData, error)
+	DeleteTask(ctx context.Context, workspaceID int, taskID int, meta EventMeta) error
+	GetDeletedTasks(ctx context.Context, workspaceID int, userID int, paging Paging) ([]Task, PaginationData, error)
+	RestoreTask(ctx context.Context, workspaceID int, taskID int, meta EventMeta) (*Task, error)
+	PurgeTask(ctx context.Context, workspaceID int, taskID int, meta EventMeta) error
+	// PurgeDeletedTasks purges the tasks trashed before deletedBefore and
+	// returns how many were purged
+	PurgeDeletedTasks(ctx context.Context, deletedBefore time.Time, meta EventMeta) (int, error)
+	ArchiveTask(ctx context.Context, workspaceID int, taskID int, meta EventMeta) (*Task, error)
+	UnarchiveTask(ctx context.Context, workspaceID int, taskID int, meta EventMeta) (*Task, error)
+	// ArchiveCompletedTasks archives the tasks completed before completedBefore
+	// and returns how many were archived
+	ArchiveCompletedTasks(ctx context.Context, completedBefore time.Time, meta EventMeta) (int, error)
+	// ClaimTaskReminders returns the pending tasks due after dueAfter and up to
+	// dueBefore whose reminder for their due date hasn't been claimed yet, and
+	// claims it
+	ClaimTaskReminders(ctx context.Context, dueAfter time.Time, dueBefore time.Time) ([]Task, error)
+	GetTaskEvents(ctx context.Context, workspaceID int, taskID int, paging Paging) ([]TaskEvent, PaginationData, error)
+	GetUndoableTaskEvents(ctx context.Context, workspaceID int, actorID int, paging Paging) ([]TaskEvent, PaginationData, error)
+	UndoLastTaskChange(ctx context.Context, workspaceID int, actorID int, taskID int, meta EventMeta) (*Task, *TaskEvent, error)
+	MoveTask(ctx context.Context, workspaceID int, taskID int, move TaskMove, meta EventMeta) (*Task, error)
+	// GetTaskChanges returns, in order, up to limit events positioned in
+	// (after, upTo] that the user can see in the workspace, by where the task
+	// was before and after each change. The task is nil if it was purged or the
+	// user can't see it anymore.
+	GetTaskChanges(ctx context.Context, workspaceID int, userID int, after EventPosition, upTo EventPosition, limit int) ([]TaskChange, error)
+	// GetTaskEventWatermark returns the position every committed event is at or
+	// before, and that no event can be recorded at or before later on, so it's
+	// safe to read changes up to. It only moves on once the oldest transaction
+	// still running ends.
+	GetTaskEventWatermark(ctx context.Context) (EventPosition, error)
+	// GetSyncTasks returns, by id, up to limit of the tasks the user can see in
+	// the workspace with ids after afterID. Archived tasks are included, tasks
+	// in the trash are not.
+	GetSyncTasks(ctx context.Context, workspaceID int, userID int, afterID int, limit int) ([]Task, error)
+	// GetCalendarTasks returns, by due date, up to limit of the tasks with due
+	// dates the user can see in the workspace. Completed tasks due before
+	// completedDueAfter, archived tasks and tasks in the trash are left out.
+	GetCalendarTasks(ctx context.Context, workspaceID int, userID int, completedDueAfter time.Time, limit int) ([]Task, error)
+}
+
+// StatusRepository manages the statuses users have in each of their workspaces
+type StatusRepository interface {
+	GetStatuses(ctx context.Context, workspaceID int, userID int) ([]Status, error)
+	GetStatusByID(ctx context.Context, workspaceID int, userID int, statusID int) (*Status, error)
+	// GetStatusesByIDs returns the workspace's statuses with the ids, whoever they belong to
+	GetStatusesByIDs(ctx context.Context, workspaceID int, statusIDs []int) ([]Status, error)
+	GetDefaultStatus(ctx context.Context, workspaceID int, userID int, category StatusCategory) (*Status, error)
+	CreateStatus(ctx context.Context, status *Status) (*Status, error)
+	UpdateStatus(ctx context.Context, status *Status) (*Status, error)
+	DeleteStatus(ctx context.Context, workspaceID int, userID int, statusID int) error
+}
+
+type ProjectRepository interface {
+	// GetProjects returns the workspace's projects the user is a member of, or
+	// all of them if the user is one of the workspace's admins
+	GetProjects(ctx context.Context, workspaceID int, userID int, paging Paging) ([]Project, PaginationData, error)
+	GetProjectByID(ctx context.Context, workspaceID int, projectID int) (*Project, error)
+	// CreateProject makes the project's creator its owner
+	CreateProject(ctx context.Context, project *Project) (*Project, error)
+	UpdateProject(ctx context.Context, project *Project) (*Project, error)
+	DeleteProject(ctx context.Context, projectID int) error
+	GetProjectMembers(ctx context.Context, projectID int) ([]ProjectMember, error)
+	// GetProjectRole fails with ErrMemberNotFound if the user is not a member of the project
+	GetProjectRole(ctx context.Context, projectID int, userID int) (ProjectRole, error)
+	// AddProjectMember fails with ErrMemberExists if the user is already a member
+	AddProjectMember(ctx context.Context, projectID int, userID int, role ProjectRole) (*ProjectMember, error)
+	// RemoveProjectMember unassigns the member from the project's tasks. It fails
+	// with ErrLastOwner instead of leaving a project without an owner
+	RemoveProjectMember(ctx context.Context, projectID int, userID int) error
+}
+
+type WorkspaceRepository interface {
+	// GetWorkspaces returns the workspaces the user is a member of
+	GetWorkspaces(ctx context.Context, userID int, paging Paging) ([]Workspace, PaginationData, error)
+	// GetWorkspace fails with ErrWorkspaceNotFound if the user is not a member of the workspace
+	GetWorkspace(ctx context.Context, workspaceID int, userID int) (*Workspace, error)
+	GetPersonalWorkspace(ctx context.Context, userID int) (*Workspace, error)
+	// CreateWorkspace makes ownerID the owner of the new workspace
+	CreateWorkspace(ctx context.Context, workspace *Workspace, ownerID int) (*Workspace, error)
+	UpdateWorkspace(ctx context.Context, workspace *Workspace) (*Workspace, error)
+	GetWorkspaceMembers(ctx context.Context, workspaceID int) ([]WorkspaceMember, error)
+	// RemoveWorkspaceMember also removes the member from the workspace's projects
+	// and unassigns them from its tasks. It fails with ErrLastOwner instead of
+	// leaving a workspace without an owner.
+	RemoveWorkspaceMember(ctx context.Context, workspaceID int, userID int) error
+	CreateInvitation(ctx context.Context, invitation *WorkspaceInvitation) (*WorkspaceInvitation, error)
+	// GetInvitations returns the workspace's invitations that are still pending
+	GetInvitations(ctx context.Context, workspaceID int) ([]WorkspaceInvitation, error)
+	// GetInvitationByTokenHash fails with ErrInvitationNotFound if the invitation
+	// doesn't exist, has expired or was already accepted
+	GetInvitationByTokenHash(ctx context.Context, tokenHash string) (*WorkspaceInvitation, error)
+	// AcceptInvitation adds the user to the invitation's workspace. It fails with
+	// ErrMemberExists if they already are a member.
+	AcceptInvitation(ctx context.Context, invitationID int, userID int) (*WorkspaceMember, error)
+	DeleteInvitation(ctx context.Context, workspaceID int, invitationID int) error
+}
+
+type CommentRepository interface {
+	GetComments(ctx context.Context, taskID int, paging Paging) ([]Comment, PaginationData, error)
+	GetCommentByID(ctx context.Context, taskID int, commentID int) (*Comment, error)
+	CreateComment(ctx context.Context, comment *Comment) (*Comment, error)
+	UpdateComment(ctx context.Context, comment *Comment) (*Comment, error)
+	DeleteComment(ctx context.Context, taskID int, commentID int) error
+}
+
+type AttachmentRepository interface {
+	GetAttachments(ctx context.Context, taskID int, paging Paging) ([]Attachment, PaginationData, error)
+	GetAttachmentByID(ctx context.Context, taskID int, attachmentID int) (*Attachment, error)
+	// CreateAttachment fails with ErrQuotaExceeded if the uploader's attachments
+	// would take up more than quota bytes
+	CreateAttachment(ctx context.Context, attachment *Attachment, quota int64) (*Attachment, error)
+	DeleteAttachment(ctx context.Context, taskID int, attachmentID int) error
+	GetStorageUsage(ctx context.Context, userID int) (int64, error)
+	// GetOrphanedBlobs returns the keys of blobs whose attachments were deleted
+	GetOrphanedBlobs(ctx context.Context, limit int) ([]string, error)
+	DeleteOrphanedBlobs(ctx context.Context, keys []string) error
+}
+
+type MentionRepository interface {
+	// SetMentions replaces the users mentioned in a task's description, or in one
+	// of its comments if commentID is set. It returns the users who weren't
+	// mentioned there before.
+	SetMentions(ctx context.Context, taskID int, commentID null.Int, userIDs []int) ([]int, error)
+}
+
+// PresenceRepository keeps track of who is viewing which project, per
+// websocket session
+type PresenceRepository interface {
+	AddViewer(ctx context.Context, sessionID string, projectID int, userID int) error
+	RemoveViewer(ctx context.Context, sessionID string, projectID int) error
+	RemoveSession(ctx context.Context, sessionID string) error
+	// TouchSession marks the session's viewers as still being there
+	TouchSession(ctx context.Context, sessionID string) error
+	// GetViewers returns the users whose sessions were seen viewing the project after seenAfter
+	GetViewers(ctx context.Context, projectID int, seenAfter time.Time) ([]ProjectViewer, error)
+	DeleteStaleViewers(ctx context.Context, seenBefore time.Time) (int, error)
+}
+
+// WebhookRepository only returns the webhooks, and their deliveries, of the
+// user who registered them in the workspace
+type WebhookRepository interface {
+	GetWebhooks(ctx context.Context, workspaceID int, userID int) ([]Webhook, error)
+	GetWebhookByID(ctx context.Context, workspaceID int, userID int, webhookID int) (*Webhook, error)
+	CreateWebhook(ctx context.Context, webhook *Webhook) (*Webhook, error)
+	UpdateWebhook(ctx context.Context, webhook *Webhook) (*Webhook, error)
+	DeleteWebhook(ctx context.Context, workspaceID int, userID int, webhookID int) error
+	GetDeliveries(ctx context.Context, webhookID int, paging Paging) ([]WebhookDelivery, PaginationData, error)
+	GetDeliveryByID(ctx context.Context, webhookID int, deliveryID int) (*WebhookDelivery, error)
+	// CreateDelivery queues a delivery to be sent by the delivery worker
+	CreateDelivery(ctx context.Context, delivery *WebhookDelivery) (*WebhookDelivery, error)
+	// ClaimDeliveries returns up to limit pending deliveries that are due and
+	// hides them from other workers until leasedUntil
+	ClaimDeliveries(ctx context.Context, limit int, leasedUntil time.Time) ([]OutgoingWebhook, error)
+	// RecordAttempt saves the outcome of sending a delivery
+	RecordAttempt(ctx context.Context, delivery *WebhookDelivery) error
+}
+
+// ImportRepository is used by the import job to create the tasks of an import
+// in batches, recording its progress along with each batch
+type ImportRepository interface {
+	CreateImport(ctx context.Context, imp *Import) (*Import, error)
+	GetImport(ctx context.Context, userID int, importID int) (*Import, error)
+	// ClaimImport leases the oldest unfinished import that isn't leased, it
+	// returns ErrImportNotFound if there are none
+	ClaimImport(ctx context.Context, leasedUntil time.Time) (*Import, error)
+	SetProjectIDs(ctx context.Context, importID int, projectIDs map[string]int) error
+	// ImportTasks creates the tasks and sets the import's progress in the same
+	// transaction, extending its lease
+	ImportTasks(ctx context.Context, importID int, tasks []*Task, processed int, leasedUntil time.Time, meta EventMeta) error
+	CompleteImport(ctx context.Context, importID int) (*Import, error)
+	FailImport(ctx context.Context, importID int, message string) error
+}
+
+// CalendarRepository stores the calendar feeds users subscribe to, each user
+// has at most one per workspace
+type CalendarRepository interface {
+	// SetFeedToken creates the user's feed for the workspace, or replaces the
+	// token of the one they have
+	SetFeedToken(ctx context.Context, userID int, workspaceID int, tokenHash string) error
+	GetFeedByTokenHash(ctx context.Context, tokenHash string) (*CalendarFeed, error)
+	DeleteFeed(ctx context.Context, userID int, workspaceID int) error
+}
+
+// TemplateRepository only returns the templates of the user in the workspace
+type TemplateRepository interface {
+	GetTemplates(ctx context.Context, userID int, workspaceID int) ([]TaskTemplate, error)
+	GetTemplateByID(ctx context.Context, userID int, workspaceID int, templateID int) (*TaskTemplate, error)
+	CreateTemplate(ctx context.Context, template *TaskTemplate) (*TaskTemplate, error)
+	UpdateTemplate(ctx context.Context, template *TaskTemplate) (*TaskTemplate, error)
+	DeleteTemplate(ctx context.Context, userID int, workspaceID int, templateID int) error
+}
+
+type NotificationRepository interface {
+	CreateNotification(ctx context.Context, notification *Notification) (*Notification, error)
+	GetNotifications(ctx context.Context, userID int, unreadOnly bool, paging Paging) ([]Notification, PaginationData, error)
+	CountUnreadNotifications(ctx context.Context, userID int) (int, error)
+	MarkNotificationRead(ctx context.Context, userID int, notificationID int) (*Notification, error)
+	// MarkAllNotificationsRead returns the number of notifications that were unread
+	MarkAllNotificationsRead(ctx context.Context, userID int) (int, error)
+	// GetNotificationPreferences returns the preferences the user has set,
+	// channels without one are enabled
+	GetNotificationPreferences(ctx context.Context, userID int) ([]NotificationPreference, error)
+	SetNotificationPreferences(ctx context.Context, userID int, preferences []NotificationPreference) error
+}
+
+type NotificationType string
+
+const (
+	NotificationTaskAssigned  NotificationType = "task_assigned"
+	NotificationTaskCommented NotificationType = "task_commented"
+	NotificationTaskMentioned NotificationType = "task_mentioned"
+	NotificationTaskDue       NotificationType = "task_due"
+)
+
+var NotificationTypes = []NotificationType{
+	NotificationTaskAssigned,
+	NotificationTaskCommented,
+	NotificationTaskMentioned,
+	NotificationTaskDue,
+}
+
+func (t NotificationType) IsValid() bool {
+	for _, notificationType := range NotificationTypes {
+		if t == notificationType {
+			return true
+		}
+	}
+
+	return false
+}
+
+// NotificationChannel is a way of delivering notifications. In app notifications
+// are kept in the user's inbox, the others are handed to a Notifier.
+type NotificationChannel string
+
+const (
+	NotificationChannelInApp NotificationChannel = "in_app"
+	NotificationChannelEmail NotificationChannel = "email"
+)
+
+var NotificationChannels = []NotificationChannel{
+	NotificationChannelInApp,
+	NotificationChannelEmail,
+}
+
+func (c NotificationChannel) IsValid() bool {
+	for _, channel := range NotificationChannels {
+		if c == channel {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Notification tells a user about a change someone else made to a task, or
+// that a task is almost due
+type Notification struct {
+	ID        int              `json:"id"`
+	UserID    int              `json:"user_id"`
+	Type      NotificationType `json:"type"`
+	TaskID    int              `json:"task_id"`
+	TaskTitle string           `json:"task_title"`
+	ActorID   null.Int         `json:"actor_id" swaggertype:"integer"`
+	ReadAt    null.Time        `json:"read_at" swaggertype:"string"`
+	CreatedAt time.Time        `json:"created_at"`
+}
+
+// NotificationPreference turns a type of notification on or off for a channel
+type NotificationPreference struct {
+	Type    NotificationType    `json:"type"`
+	Channel NotificationChannel `json:"channel"`
+	Enabled bool                `json:"enabled"`
+}
+
+// Notifier delivers notifications outside of the app, such as by email
+type Notifier interface {
+	Notify(ctx context.Context, notification Notification) error
+}
+
+// BlobStore stores the contents of attachments by key
+type BlobStore interface {
+	Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error
+	// Get fails with ErrBlobNotFound if there is no blob stored under key
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+}
+
+// Webhook is a url that is sent the events a user subscribed to for the tasks
+// they can see in a workspace
+type Webhook struct {
+	ID          int                `json:"id"`
+	UserID      int                `json:"user_id"`
+	WorkspaceID int                `json:"workspace_id"`
+	URL         string             `json:"url"`
+	Secret      string             `json:"-"`
+	EventTypes  []WebhookEventType `json:"event_types"`
+	IsActive    bool               `json:"is_active"`
+	CreatedAt   time.Time          `json:"created_at"`
+	UpdatedAt   time.Time          `json:"updated_at"`
+}
+
+type WebhookEventType string
+
+const (
+	WebhookTaskCreated   WebhookEventType = "task.created"
+	WebhookTaskUpdated   WebhookEventType = "task.updated"
+	WebhookTaskCompleted WebhookEventType = "task.completed"
+	WebhookTaskDeleted   WebhookEventType = "task.deleted"
+)
+
+var WebhookEventTypes = []WebhookEventType{
+	WebhookTaskCreated,
+	WebhookTaskUpdated,
+	WebhookTaskCompleted,
+	WebhookTaskDeleted,
+}
+
+func (t WebhookEventType) IsValid() bool {
+	for _, eventType := range WebhookEventTypes {
+		if t == eventType {
+			return true
+		}
+	}
+
+	return false
+}
+
+type WebhookDeliveryStatus string
+
+const (
+	DeliveryPending   WebhookDeliveryStatus = "pending"
+	DeliverySucceeded WebhookDeliveryStatus = "succeeded"
+	DeliveryFailed    WebhookDeliveryStatus = "failed"
+)
+
+// WebhookDelivery is a single event sent to a webhook, along with the outcome
+// of its latest attempt
+type WebhookDelivery struct {
+	ID             int                   `json:"id"`
+	WebhookID      int                   `json:"webhook_id"`
+	EventType      WebhookEventType      `json:"event_type"`
+	Payload        json.RawMessage       `json:"payload" swaggertype:"object"`
+	Status         WebhookDeliveryStatus `json:"status"`
+	Attempts       int                   `json:"attempts"`
+	NextAttemptAt  time.Time             `json:"next_attempt_at"`
+	LastAttemptAt  null.Time             `json:"last_attempt_at" swaggertype:"string"`
+	ResponseStatus null.Int              `json:"response_status" swaggertype:"integer"`
+	ResponseBody   string                `json:"response_body"`
+	Error          string                `json:"error"`
+	CreatedAt      time.Time             `json:"created_at"`
+}
+
+// WebhookPayload is the body of a delivery
+type WebhookPayload struct {
+	Event       WebhookEventType       `json:"event"`
+	TaskEventID int                    `json:"task_event_id"`
+	Task        Task                   `json:"task"`
+	Changes     map[string]FieldChange `json:"changes"`
+	CreatedAt   time.Time              `json:"created_at"`
+}
+
+// OutgoingWebhook is a delivery along with the webhook it is sent to
+type OutgoingWebhook struct {
+	URL      string
+	Secret   string
+	Delivery WebhookDelivery
+}
+
+// WebhookResponse is what a webhook's receiver responded with
+type WebhookResponse struct {
+	StatusCode int
+	Body       string
+}
+
+// WebhookSender sends deliveries to their webhooks. Responses are returned
+// whatever their status, errors mean the receiver couldn't be reached.
+type WebhookSender interface {
+	Send(ctx context.Context, webhook OutgoingWebhook) (*WebhookResponse, error)
+}
+
+type ImportStatus string
+
+const (
+	ImportPending   ImportStatus = "pending"
+	ImportRunning   ImportStatus = "running"
+	ImportCompleted ImportStatus = "completed"
+	ImportFailed    ImportStatus = "failed"
+)
+
+// Import is an export of another todo app being turned into tasks in the
+// background. Data holds the parsed export and ProjectIDs the projects that
+// have been created for it so far, by name.
+type Import struct {
+	ID          int              `json:"id"`
+	UserID      int              `json:"user_id"`
+	WorkspaceID int              `json:"workspace_id"`
+	Format      importer.Format  `json:"format" swaggertype:"string"`
+	Status      ImportStatus     `json:"status"`
+	Data        *importer.Result `json:"-"`
+	ProjectIDs  map[string]int   `json:"-"`
+	Total       int              `json:"total"`
+	Processed   int              `json:"processed"`
+	Error       string           `json:"error"`
+	CreatedAt   time.Time        `json:"created_at"`
+	UpdatedAt   time.Time        `json:"updated_at"`
+	CompletedAt null.Time        `json:"completed_at" swaggertype:"string"`
+}
+
+// CalendarFeed is a secret url a user subscribes to their tasks in a
+// workspace with from a calendar app
+type CalendarFeed struct {
+	UserID      int
+	WorkspaceID int
+	TokenHash   string
+	CreatedAt   time.Time
+}
+
+// TaskTemplate is a task, along with its subtasks, that tasks can be created
+// from again and again
+type TaskTemplate struct {
+	ID          int          `json:"id"`
+	UserID      int          `json:"user_id"`
+	WorkspaceID int          `json:"workspace_id"`
+	Name        string       `json:"name"`
+	Task        TemplateTask `json:"task"`
+	CreatedAt   time.Time    `json:"created_at"`
+	UpdatedAt   time.Time    `json:"updated_at"`
+}
+
+// TemplateTask is a task of a template. Its due date is DueOffset days after
+// the date the template is instantiated for, at DueTime if it's set and
+// otherwise all day.
+type TemplateTask struct {
+	Title       string       `json:"title"`
+	Description string       `json:"description"`
+	Priority    TaskPriority `json:"priority"`
+	Tags        []string     `json:"tags"`
+	Recurrence  string       `json:"recurrence"`
+	DueOffset   null.Int     `json:"due_offset" swaggertype:"integer"`
+	// DueTime is a time of day such as 17:30
+	DueTime string `json:"due_time"`
+	// Checklist is added to the description of the tasks made from the
+	// template, as unchecked Markdown task list items
+	Checklist []string       `json:"checklist"`
+	Subtasks  []TemplateTask `json:"subtasks"`
 }
@@ -0,0 +1,208 @@
+package app
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+)
+
+// @Summary		Get Statuses
+// @Description	Returns the user's statuses, or with project_id the statuses of the project's tasks, which are those of
+// @Description	the project's owner
+// @Tags			Statuses
+// @Id				GetStatuses
+// @Param			X-Workspace-ID	header		int	false	"workspace to act in, defaults to the personal workspace"
+// @Param			project_id		query		int	false	"project whose statuses to return"
+// @Success		200				{object}	SuccessResponse{data=GetStatusesResponse}
+// @Failure		400,401,403,404	{object}	ErrorResponse
+// @Security		BasicAuth
+// @Router			/statuses [get]
+func (a *Application) GetStatuses(w http.ResponseWriter, r *http.Request) {
+	user := a.getCtxUser(r)
+	workspace := a.getCtxWorkspace(r)
+
+	workspaceID, ownerID := workspace.ID, user.ID
+	if rawProjectID := r.URL.Query().Get("project_id"); rawProjectID != "" {
+		projectID, err := strconv.Atoi(rawProjectID)
+		if err != nil {
+			render.Render(w, r, ErrBadRequest("Invalid project_id"))
+			return
+		}
+
+		project, err := a.authorizeProject(r, projectID, ProjectRoleViewer)
+		if err != nil {
+			a.renderProjectAccessError(w, r, err)
+			return
+		}
+		workspaceID, ownerID = project.WorkspaceID, project.UserID
+	}
+
+	statuses, err := a.store.Statuses().GetStatuses(r.Context(), workspaceID, ownerID)
+	if err != nil {
+		render.Render(w, r, ErrInternalServerError("An unexpected error occured"))
+		slog.Error(err.Error())
+		return
+	}
+
+	render.Render(w, r, NewSuccessResponse(GetStatusesResponse{statuses}))
+}
+
+// @Summary		Create Status
+// @Description	Creates a status in the workspace, each workspace has its own statuses
+// @Tags			Statuses
+// @Id				CreateStatus
+// @Param			X-Workspace-ID		header		int					false	"workspace to act in, defaults to the personal workspace"
+// @Param			request				body		CreateStatusRequest	true	"request body"
+// @Success		201					{object}	SuccessResponse{data=CreateStatusResponse}
+// @Failure		400,401,403,404,409	{object}	ErrorResponse
+// @Security		BasicAuth
+// @Router			/statuses [post]
+func (a *Application) CreateStatus(w http.ResponseWriter, r *http.Request) {
+	user := a.getCtxUser(r)
+	workspace := a.getCtxWorkspace(r)
+
+	var requestBody CreateStatusRequest
+	if err := render.Bind(r, &requestBody); err != nil {
+		render.Render(w, r, ErrBadRequest("Invalid request body"))
+		return
+	}
+
+	if err := requestBody.Validate(); err != nil {
+		render.Render(w, r, ErrBadRequest(err.Error()))
+		return
+	}
+
+	statusPayload := &Status{
+		UserID:      user.ID,
+		WorkspaceID: workspace.ID,
+		Name:        requestBody.Name,
+		Category:    requestBody.Category,
+		Position:    requestBody.Position,
+	}
+
+	status, err := a.store.Statuses().CreateStatus(r.Context(), statusPayload)
+	if err != nil {
+		if errors.Is(err, ErrStatusExists) {
+			render.Render(w, r, ErrConflict("Existing status name"))
+			return
+		}
+
+		render.Render(w, r, ErrInternalServerError("An unexpected error occured"))
+		slog.Error(err.Error())
+		return
+	}
+
+	render.Status(r, http.StatusCreated)
+	render.Render(w, r, NewSuccessResponse(CreateStatusResponse{*status}))
+}
+
+// @Summary		Edit Status
+// @Description	Renames or reorders a status. A status' category can't be changed.
+// @Tags			Statuses
+// @Id				EditStatus
+// @Param			X-Workspace-ID		header		int					false	"workspace to act in, defaults to the personal workspace"
+// @Param			id					path		int					true	"status id"
+// @Param			request				body		EditStatusRequest	true	"request body"
+// @Success		200					{object}	SuccessResponse{data=EditStatusResponse}
+// @Failure		400,401,403,404,409	{object}	ErrorResponse
+// @Security		BasicAuth
+// @Router			/statuses/{id} [patch]
+func (a *Application) EditStatus(w http.ResponseWriter, r *http.Request) {
+	user := a.getCtxUser(r)
+	workspace := a.getCtxWorkspace(r)
+	rawID := chi.URLParam(r, "id")
+
+	id, err := strconv.Atoi(rawID)
+	if err != nil {
+		render.Render(w, r, ErrResourceNotFound("Status not found"))
+		return
+	}
+
+	status, err := a.store.Statuses().GetStatusByID(r.Context(), workspace.ID, user.ID, id)
+	if err != nil {
+		if errors.Is(err, ErrStatusNotFound) {
+			render.Render(w, r, ErrResourceNotFound("Status not found"))
+			return
+		}
+
+		render.Render(w, r, ErrInternalServerError("An unexpected error occured"))
+		slog.Error(err.Error())
+		return
+	}
+
+	var requestBody EditStatusRequest
+	if err := render.Bind(r, &requestBody); err != nil {
+		render.Render(w, r, ErrBadRequest("Invalid request body"))
+		return
+	}
+
+	if err := requestBody.Validate(); err != nil {
+		render.Render(w, r, ErrBadRequest(err.Error()))
+		return
+	}
+
+	if requestBody.Name != nil {
+		status.Name = *requestBody.Name
+	}
+
+	if requestBody.Position != nil {
+		status.Position = *requestBody.Position
+	}
+
+	updatedStatus, err := a.store.Statuses().UpdateStatus(r.Context(), status)
+	if err != nil {
+		if errors.Is(err, ErrStatusExists) {
+			render.Render(w, r, ErrConflict("Existing status name"))
+			return
+		}
+
+		render.Render(w, r, ErrInternalServerError("An unexpected error occured"))
+		slog.Error(err.Error())
+		return
+	}
+
+	render.Render(w, r, NewSuccessResponse(EditStatusResponse{*updatedStatus}))
+}
+
+// @Summary		Delete Status
+// @Description	Deletes a status that no task uses. The last todo and done statuses can't be deleted.
+// @Tags			Statuses
+// @Id				DeleteStatus
+// @Param			X-Workspace-ID	header	int	false	"workspace to act in, defaults to the personal workspace"
+// @Param			id				path	int	true	"status id"
+// @Success		204
+// @Failure		401,403,404,409	{object}	ErrorResponse
+// @Security		BasicAuth
+// @Router			/statuses/{id} [delete]
+func (a *Application) DeleteStatus(w http.ResponseWriter, r *http.Request) {
+	user := a.getCtxUser(r)
+	workspace := a.getCtxWorkspace(r)
+	rawID := chi.URLParam(r, "id")
+
+	id, err := strconv.Atoi(rawID)
+	if err != nil {
+		render.Render(w, r, ErrResourceNotFound("Status not found"))
+		return
+	}
+
+	if err := a.store.Statuses().DeleteStatus(r.Context(), workspace.ID, user.ID, id); err != nil {
+		switch {
+		case errors.Is(err, ErrStatusNotFound):
+			render.Render(w, r, ErrResourceNotFound("Status not found"))
+		case errors.Is(err, ErrStatusInUse):
+			render.Render(w, r, ErrConflict("Status is used by existing tasks"))
+		case errors.Is(err, ErrStatusRequired):
+			render.Render(w, r, ErrConflict("At least one todo and one done status is required"))
+		default:
+			render.Render(w, r, ErrInternalServerError("An unexpected error occured"))
+			slog.Error(err.Error())
+		}
+		return
+	}
+
+	render.NoContent(w, r)
+}
@@ -0,0 +1,270 @@
+package app
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+)
+
+const syncPageSize = 500
+
+var errInvalidSyncToken = errors.New("invalid sync token")
+
+// syncToken is how far a client has synced. Clients without one first pull a
+// snapshot of their tasks, paged by task id, and then the changes recorded
+// since the snapshot started.
+type syncToken struct {
+	Position EventPosition
+	// InSnapshot is set while the snapshot is being pulled, with SnapshotAfterID
+	// the id of the last task pulled
+	InSnapshot      bool
+	SnapshotAfterID int
+}
+
+func (t syncToken) String() string {
+	raw := t.Position.String()
+	if t.InSnapshot {
+		raw = fmt.Sprintf("s.%d.%s", t.SnapshotAfterID, t.Position)
+	}
+
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func parseSyncToken(rawToken string) (syncToken, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(rawToken)
+	if err != nil {
+		return syncToken{}, errInvalidSyncToken
+	}
+
+	if snapshot, ok := strings.CutPrefix(string(raw), "s."); ok {
+		rawAfterID, rawPosition, _ := strings.Cut(snapshot, ".")
+		afterID, afterErr := strconv.Atoi(rawAfterID)
+		position, positionErr := parseEventPosition(rawPosition)
+		if afterErr != nil || positionErr != nil || afterID < 0 {
+			return syncToken{}, errInvalidSyncToken
+		}
+		return syncToken{Position: position, InSnapshot: true, SnapshotAfterID: afterID}, nil
+	}
+
+	position, err := parseEventPosition(string(raw))
+	if err != nil {
+		return syncToken{}, errInvalidSyncToken
+	}
+
+	return syncToken{Position: position}, nil
+}
+
+// @Summary		Pull task changes for offline sync
+// @Description	Without since, returns a snapshot of the user's tasks, pulled in pages while has_more is set. With since,
+// @Description	returns the tasks created or changed since the token was issued, and tombstones for those deleted since.
+// @Description	A task is only returned once per page, in its current state.
+// @Tags			sync
+// @Id				GetSyncChanges
+// @Param			X-Workspace-ID	header		int		false	"workspace to act in, defaults to the personal workspace"
+// @Param			since			query		string	false	"next_token of the previous pull"
+// @Success		200				{object}	SuccessResponse{data=GetSyncChangesResponse}
+// @Failure		400,401,403,404	{object}	ErrorResponse
+// @Security		BasicAuth
+// @Router			/sync [get]
+func (a *Application) GetSyncChanges(w http.ResponseWriter, r *http.Request) {
+	user := a.getCtxUser(r)
+	workspace := a.getCtxWorkspace(r)
+
+	var token syncToken
+	if since := r.URL.Query().Get("since"); since != "" {
+		var err error
+		token, err = parseSyncToken(since)
+		if err != nil {
+			render.Render(w, r, ErrBadRequest("Invalid sync token"))
+			return
+		}
+	} else {
+		// every event up to the watermark is committed, so the snapshot has
+		// their changes and the events after it are sent as changes later
+		watermark, err := a.store.Tasks().GetTaskEventWatermark(r.Context())
+		if err != nil {
+			render.Render(w, r, ErrInternalServerError("An unexpected error occured"))
+			slog.Error(err.Error())
+			return
+		}
+		token = syncToken{Position: watermark, InSnapshot: true}
+	}
+
+	response := GetSyncChangesResponse{Tasks: []Task{}, Deleted: []SyncTombstone{}}
+
+	if token.InSnapshot {
+		tasks, err := a.store.Tasks().GetSyncTasks(r.Context(), workspace.ID, user.ID, token.SnapshotAfterID, syncPageSize)
+		if err != nil {
+			render.Render(w, r, ErrInternalServerError("An unexpected error occured"))
+			slog.Error(err.Error())
+			return
+		}
+
+		response.Tasks = append(response.Tasks, tasks...)
+
+		// changes made while the snapshot is pulled are pulled after it
+		if len(tasks) == syncPageSize {
+			token.SnapshotAfterID = tasks[len(tasks)-1].ID
+			response.HasMore = true
+		} else {
+			token = syncToken{Position: token.Position}
+		}
+
+		response.NextToken = token.String()
+		render.Render(w, r, NewSuccessResponse(response))
+		return
+	}
+
+	// a change that commits late is positioned after the watermark, so it
+	// can't end up behind the token it would then be skipped by
+	upTo, err := a.store.Tasks().GetTaskEventWatermark(r.Context())
+	if err != nil {
+		render.Render(w, r, ErrInternalServerError("An unexpected error occured"))
+		slog.Error(err.Error())
+		return
+	}
+
+	changes, err := a.store.Tasks().GetTaskChanges(r.Context(), workspace.ID, user.ID, token.Position, upTo, syncPageSize)
+	if err != nil {
+		render.Render(w, r, ErrInternalServerError("An unexpected error occured"))
+		slog.Error(err.Error())
+		return
+	}
+
+	// changes have the current state of the task, so only the first change
+	// of each task is needed
+	seen := make(map[int]bool, len(changes))
+	for _, change := range changes {
+		if seen[change.Event.TaskID] {
+			continue
+		}
+		seen[change.Event.TaskID] = true
+
+		switch {
+		case change.Task == nil:
+			response.Deleted = append(response.Deleted, SyncTombstone{ID: change.Event.TaskID, DeletedAt: change.Event.CreatedAt})
+		case change.Task.DeletedAt.Valid:
+			response.Deleted = append(response.Deleted, SyncTombstone{ID: change.Task.ID, DeletedAt: change.Task.DeletedAt.Time})
+		default:
+			response.Tasks = append(response.Tasks, *change.Task)
+		}
+	}
+
+	if len(changes) == syncPageSize {
+		token.Position = changes[len(changes)-1].Event.Position
+		response.HasMore = true
+	} else if token.Position.Before(upTo) {
+		token.Position = upTo
+	}
+
+	response.NextToken = token.String()
+	render.Render(w, r, NewSuccessResponse(response))
+}
+
+// @Summary		Push offline changes
+// @Description	Applies the mutations in order and reports the outcome of each. Updates and deletes are checked against
+// @Description	base_version, when the task has changed since then the change made last, by modified_at and the task's
+// @Description	updated_at, wins. A winning update only overwrites the fields in its data.
+// @Tags			sync
+// @Id				UploadSyncMutations
+// @Param			X-Workspace-ID	header		int							false	"workspace to act in, defaults to the personal workspace"
+// @Param			request			body		UploadSyncMutationsRequest	true	"request body"
+// @Success		200				{object}	SuccessResponse{data=UploadSyncMutationsResponse}
+// @Failure		400,401,403,404	{object}	ErrorResponse
+// @Security		BasicAuth
+// @Router			/sync [post]
+func (a *Application) UploadSyncMutations(w http.ResponseWriter, r *http.Request) {
+	var requestBody UploadSyncMutationsRequest
+	if err := render.Bind(r, &requestBody); err != nil {
+		render.Render(w, r, ErrBadRequest("Invalid request body"))
+		return
+	}
+
+	if err := requestBody.Validate(); err != nil {
+		render.Render(w, r, ErrBadRequest(err.Error()))
+		return
+	}
+
+	results := make([]SyncResult, len(requestBody.Mutations))
+	for i, mutation := range requestBody.Mutations {
+		results[i] = a.applySyncMutation(r, mutation)
+	}
+
+	render.Render(w, r, NewSuccessResponse(UploadSyncMutationsResponse{results}))
+}
+
+var syncMutationOps = map[SyncOp]string{
+	SyncCreate: "create_task",
+	SyncUpdate: "update_task",
+	SyncDelete: "delete_task",
+}
+
+func (a *Application) applySyncMutation(r *http.Request, mutation SyncMutation) SyncResult {
+	taskMutation := taskMutation{
+		Op:          syncMutationOps[mutation.Op],
+		TaskID:      mutation.TaskID,
+		BaseVersion: mutation.BaseVersion,
+		Body:        mutation.Data,
+		// the whole upload is undone together
+		RequestID: middleware.GetReqID(r.Context()),
+	}
+
+	result, _ := a.dispatchTaskMutation(r, taskMutation)
+	if result.Status != http.StatusPreconditionFailed {
+		return newSyncResult(mutation, result, "")
+	}
+
+	task, err := a.authorizeTask(r, mutation.TaskID, ProjectRoleViewer, taskLive)
+	if err != nil {
+		if errors.Is(err, ErrTaskNotFound) {
+			return SyncResult{ClientID: mutation.ClientID, Status: SyncFailed, StatusCode: http.StatusNotFound, Message: "Task not found"}
+		}
+		slog.Error(err.Error())
+		return SyncResult{ClientID: mutation.ClientID, Status: SyncFailed, StatusCode: http.StatusInternalServerError, Message: "An unexpected error occured"}
+	}
+
+	if mutation.ModifiedAt.After(task.UpdatedAt) {
+		// checking against the version that lost keeps a change made in the
+		// meantime from being overwritten unseen
+		taskMutation.BaseVersion = task.Version
+		result, _ = a.dispatchTaskMutation(r, taskMutation)
+		if result.Status != http.StatusPreconditionFailed {
+			return newSyncResult(mutation, result, SyncClientWins)
+		}
+	}
+
+	return SyncResult{
+		ClientID:   mutation.ClientID,
+		Status:     SyncConflict,
+		Resolution: SyncServerWins,
+		StatusCode: http.StatusPreconditionFailed,
+		Task:       task,
+		Message:    "Task was changed after the offline change was made",
+	}
+}
+
+func newSyncResult(mutation SyncMutation, result *taskMutationResult, resolution SyncResolution) SyncResult {
+	if result.Failed() {
+		return SyncResult{ClientID: mutation.ClientID, Status: SyncFailed, StatusCode: result.Status, Message: result.Message}
+	}
+
+	status := SyncApplied
+	if resolution != "" {
+		status = SyncConflict
+	}
+
+	return SyncResult{
+		ClientID:   mutation.ClientID,
+		Status:     status,
+		Resolution: resolution,
+		StatusCode: result.Status,
+		Task:       result.Task,
+	}
+}
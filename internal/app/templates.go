@@ -0,0 +1,463 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ayo-awe/golang_todo_api/internal/checklist"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"gopkg.in/guregu/null.v4"
+)
+
+// @Summary	Get Templates
+// @Tags		Templates
+// @Id			GetTemplates
+// @Param		X-Workspace-ID	header		int	false	"workspace to act in, defaults to the personal workspace"
+// @Success	200				{object}	SuccessResponse{data=GetTemplatesResponse}
+// @Failure	401,403			{object}	ErrorResponse
+// @Security	BasicAuth
+// @Router		/templates [get]
+func (a *Application) GetTemplates(w http.ResponseWriter, r *http.Request) {
+	user := a.getCtxUser(r)
+	workspace := a.getCtxWorkspace(r)
+
+	templates, err := a.store.Templates().GetTemplates(r.Context(), user.ID, workspace.ID)
+	if err != nil {
+		render.Render(w, r, ErrInternalServerError("An unexpected error occured"))
+		slog.Error(err.Error())
+		return
+	}
+
+	render.Render(w, r, NewSuccessResponse(GetTemplatesResponse{templates}))
+}
+
+// @Summary		Create Template
+// @Description	Saves a task and its subtasks as a template, either as given or, with task_id, copied from a task the user
+// @Description	can see along with its subtasks in the same project. Due dates are saved as days after the date the template
+// @Description	is instantiated for, for saved tasks counted from the task's due date or, if it has none, today.
+// @Tags			Templates
+// @Id				CreateTemplate
+// @Param			X-Workspace-ID	header		int						false	"workspace to act in, defaults to the personal workspace"
+// @Param			request			body		CreateTemplateRequest	true	"request body"
+// @Success		201				{object}	SuccessResponse{data=TemplateResponse}
+// @Failure		400,401,403		{object}	ErrorResponse
+// @Security		BasicAuth
+// @Router			/templates [post]
+func (a *Application) CreateTemplate(w http.ResponseWriter, r *http.Request) {
+	user := a.getCtxUser(r)
+	workspace := a.getCtxWorkspace(r)
+
+	var requestBody CreateTemplateRequest
+	if err := render.Bind(r, &requestBody); err != nil {
+		render.Render(w, r, ErrBadRequest("Invalid request body"))
+		return
+	}
+
+	if err := requestBody.Validate(); err != nil {
+		render.Render(w, r, ErrBadRequest(err.Error()))
+		return
+	}
+
+	templatePayload := &TaskTemplate{
+		UserID:      user.ID,
+		WorkspaceID: workspace.ID,
+		Name:        requestBody.Name,
+	}
+
+	if requestBody.TaskID != nil {
+		location := time.UTC
+		if requestBody.Timezone != "" {
+			// validated above
+			location, _ = time.LoadLocation(requestBody.Timezone)
+		}
+
+		task, err := a.templateFromTask(r, *requestBody.TaskID, location)
+		if err != nil {
+			renderError(w, r, err)
+			return
+		}
+		templatePayload.Task = *task
+	} else {
+		templatePayload.Task = *requestBody.Task
+	}
+
+	template, err := a.store.Templates().CreateTemplate(r.Context(), templatePayload)
+	if err != nil {
+		render.Render(w, r, ErrInternalServerError("An unexpected error occured"))
+		slog.Error(err.Error())
+		return
+	}
+
+	render.Status(r, http.StatusCreated)
+	render.Render(w, r, NewSuccessResponse(TemplateResponse{*template}))
+}
+
+// templateFromTask copies a task the request's user can see, and its
+// subtasks in the same project, into a template task. Due dates are counted
+// from the task's due date, or today if it has none, and due times are in
+// location.
+func (a *Application) templateFromTask(r *http.Request, taskID int, location *time.Location) (*TemplateTask, error) {
+	root, err := a.authorizeTask(r, taskID, ProjectRoleViewer, taskLive)
+	if err != nil {
+		if errors.Is(err, ErrTaskNotFound) {
+			return nil, clientError(ErrBadRequest("Invalid task"))
+		}
+		return nil, err
+	}
+
+	anchor := dueDay(time.Now(), location)
+	if root.DueAt.Valid {
+		anchor = dueDay(root.DueAt.Time, location)
+	}
+
+	var (
+		count          int
+		toTemplateTask func(task *Task) (*TemplateTask, error)
+	)
+	toTemplateTask = func(task *Task) (*TemplateTask, error) {
+		count++
+		if count > maxTemplateTasks {
+			return nil, clientError(ErrBadRequest(fmt.Sprintf("Templates can't have more than %d tasks", maxTemplateTasks)))
+		}
+
+		templateTask := &TemplateTask{
+			Title:       task.Title,
+			Description: task.Description,
+			Priority:    task.Priority,
+			Tags:        task.Tags,
+			Recurrence:  task.Recurrence,
+			Subtasks:    []TemplateTask{},
+		}
+		if task.DueAt.Valid {
+			day := dueDay(task.DueAt.Time, location)
+			templateTask.DueOffset = null.IntFrom(int64(day.Sub(anchor) / (24 * time.Hour)))
+			if !isAllDay(task.DueAt.Time) {
+				templateTask.DueTime = task.DueAt.Time.In(location).Format("15:04")
+			}
+		}
+
+		subtasks, err := a.store.Tasks().GetSubtasks(r.Context(), task.WorkspaceID, task.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, subtask := range subtasks {
+			// subtasks moved out of the parent's project before moving them
+			// was refused may not be visible
+			if subtask.ProjectID != root.ProjectID {
+				continue
+			}
+
+			templateSubtask, err := toTemplateTask(&subtask)
+			if err != nil {
+				return nil, err
+			}
+			templateTask.Subtasks = append(templateTask.Subtasks, *templateSubtask)
+		}
+
+		return templateTask, nil
+	}
+
+	templateTask, err := toTemplateTask(root)
+	if err != nil {
+		return nil, err
+	}
+
+	// tasks are validated the same way templates are, but offsets can still
+	// be out of range
+	count = 0
+	if err := normalizeTemplateTask(templateTask, &count); err != nil {
+		return nil, clientError(ErrBadRequest(fmt.Sprintf("Task can't be saved as a template: %s", err)))
+	}
+
+	return templateTask, nil
+}
+
+// isAllDay reports whether a due date has no time of day, which is how due
+// dates without one are stored
+func isAllDay(t time.Time) bool {
+	return t.Equal(t.UTC().Truncate(24 * time.Hour))
+}
+
+// dueDay returns the day a task is due on as midnight UTC. Times of day are
+// on their day in location, all day dates are on their own day.
+func dueDay(t time.Time, location *time.Location) time.Time {
+	if !isAllDay(t) {
+		t = t.In(location)
+	}
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// @Summary	Get Template
+// @Tags		Templates
+// @Id			GetTemplate
+// @Param		X-Workspace-ID	header		int	false	"workspace to act in, defaults to the personal workspace"
+// @Param		id				path		int	true	"template id"
+// @Success	200				{object}	SuccessResponse{data=TemplateResponse}
+// @Failure	401,403,404		{object}	ErrorResponse
+// @Security	BasicAuth
+// @Router		/templates/{id} [get]
+func (a *Application) GetTemplate(w http.ResponseWriter, r *http.Request) {
+	template, ok := a.getTemplate(w, r)
+	if !ok {
+		return
+	}
+
+	render.Render(w, r, NewSuccessResponse(TemplateResponse{*template}))
+}
+
+// getTemplate gets the template of the id in the url, rendering an error if
+// it can't
+func (a *Application) getTemplate(w http.ResponseWriter, r *http.Request) (*TaskTemplate, bool) {
+	user := a.getCtxUser(r)
+	workspace := a.getCtxWorkspace(r)
+
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		render.Render(w, r, ErrResourceNotFound("Template not found"))
+		return nil, false
+	}
+
+	template, err := a.store.Templates().GetTemplateByID(r.Context(), user.ID, workspace.ID, id)
+	if err != nil {
+		if errors.Is(err, ErrTemplateNotFound) {
+			render.Render(w, r, ErrResourceNotFound("Template not found"))
+			return nil, false
+		}
+
+		render.Render(w, r, ErrInternalServerError("An unexpected error occured"))
+		slog.Error(err.Error())
+		return nil, false
+	}
+
+	return template, true
+}
+
+// @Summary		Edit Template
+// @Description	A task replaces the template's task along with all of its subtasks.
+// @Tags			Templates
+// @Id				EditTemplate
+// @Param			X-Workspace-ID	header		int					false	"workspace to act in, defaults to the personal workspace"
+// @Param			id				path		int					true	"template id"
+// @Param			request			body		EditTemplateRequest	true	"request body"
+// @Success		200				{object}	SuccessResponse{data=TemplateResponse}
+// @Failure		400,401,403,404	{object}	ErrorResponse
+// @Security		BasicAuth
+// @Router			/templates/{id} [patch]
+func (a *Application) EditTemplate(w http.ResponseWriter, r *http.Request) {
+	var requestBody EditTemplateRequest
+	if err := render.Bind(r, &requestBody); err != nil {
+		render.Render(w, r, ErrBadRequest("Invalid request body"))
+		return
+	}
+
+	if err := requestBody.Validate(); err != nil {
+		render.Render(w, r, ErrBadRequest(err.Error()))
+		return
+	}
+
+	template, ok := a.getTemplate(w, r)
+	if !ok {
+		return
+	}
+
+	if requestBody.Name != nil {
+		template.Name = *requestBody.Name
+	}
+
+	if requestBody.Task != nil {
+		template.Task = *requestBody.Task
+	}
+
+	updatedTemplate, err := a.store.Templates().UpdateTemplate(r.Context(), template)
+	if err != nil {
+		if errors.Is(err, ErrTemplateNotFound) {
+			render.Render(w, r, ErrResourceNotFound("Template not found"))
+			return
+		}
+
+		render.Render(w, r, ErrInternalServerError("An unexpected error occured"))
+		slog.Error(err.Error())
+		return
+	}
+
+	render.Render(w, r, NewSuccessResponse(TemplateResponse{*updatedTemplate}))
+}
+
+// @Summary	Delete Template
+// @Tags		Templates
+// @Id			DeleteTemplate
+// @Param		X-Workspace-ID	header	int	false	"workspace to act in, defaults to the personal workspace"
+// @Param		id				path	int	true	"template id"
+// @Success	204
+// @Failure	401,403,404	{object}	ErrorResponse
+// @Security	BasicAuth
+// @Router		/templates/{id} [delete]
+func (a *Application) DeleteTemplate(w http.ResponseWriter, r *http.Request) {
+	user := a.getCtxUser(r)
+	workspace := a.getCtxWorkspace(r)
+
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		render.Render(w, r, ErrResourceNotFound("Template not found"))
+		return
+	}
+
+	if err := a.store.Templates().DeleteTemplate(r.Context(), user.ID, workspace.ID, id); err != nil {
+		if errors.Is(err, ErrTemplateNotFound) {
+			render.Render(w, r, ErrResourceNotFound("Template not found"))
+			return
+		}
+
+		render.Render(w, r, ErrInternalServerError("An unexpected error occured"))
+		slog.Error(err.Error())
+		return
+	}
+
+	render.NoContent(w, r)
+}
+
+// @Summary		Instantiate Template
+// @Description	Creates the template's task and its subtasks all at once. Tasks are due due_offset days after the anchor
+// @Description	date, at their due_time in the time zone if they have one and otherwise all day. A task's checklist is
+// @Description	added to the end of its description as unchecked "- [ ] " items.
+// @Tags			Templates
+// @Id				InstantiateTemplate
+// @Param			X-Workspace-ID	header		int							false	"workspace to act in, defaults to the personal workspace"
+// @Param			id				path		int							true	"template id"
+// @Param			request			body		InstantiateTemplateRequest	true	"request body"
+// @Success		201				{object}	SuccessResponse{data=InstantiateTemplateResponse}
+// @Failure		400,401,403,404	{object}	ErrorResponse
+// @Security		BasicAuth
+// @Router			/templates/{id}/instantiate [post]
+func (a *Application) InstantiateTemplate(w http.ResponseWriter, r *http.Request) {
+	user := a.getCtxUser(r)
+
+	var requestBody InstantiateTemplateRequest
+	if err := render.Bind(r, &requestBody); err != nil {
+		render.Render(w, r, ErrBadRequest("Invalid request body"))
+		return
+	}
+
+	if err := requestBody.Validate(); err != nil {
+		render.Render(w, r, ErrBadRequest(err.Error()))
+		return
+	}
+
+	template, ok := a.getTemplate(w, r)
+	if !ok {
+		return
+	}
+
+	location := time.UTC
+	if requestBody.Timezone != "" {
+		// validated above
+		location, _ = time.LoadLocation(requestBody.Timezone)
+	}
+
+	anchor := dueDay(time.Now().In(location), location)
+	if requestBody.AnchorDate != "" {
+		// validated above
+		anchor, _ = time.Parse(time.DateOnly, requestBody.AnchorDate)
+	}
+
+	// the ids of the users the tasks mention, parents first like the tasks
+	// are created
+	var mentionedIDs [][]int
+
+	var toTree func(templateTask *TemplateTask) (TaskTree, error)
+	toTree = func(templateTask *TemplateTask) (TaskTree, error) {
+		description, err := templateDescription(templateTask)
+		if err != nil {
+			return TaskTree{}, err
+		}
+
+		taskRequest := CreateTaskRequest{
+			Title:       templateTask.Title,
+			Description: description,
+			ProjectID:   requestBody.ProjectID,
+			Priority:    templateTask.Priority,
+			Tags:        templateTask.Tags,
+			Recurrence:  templateTask.Recurrence,
+		}
+		if templateTask.DueOffset.Valid {
+			day := anchor.AddDate(0, 0, int(templateTask.DueOffset.Int64))
+			taskRequest.DueAt = null.TimeFrom(day)
+			if clock, err := time.Parse("15:04", templateTask.DueTime); err == nil {
+				taskRequest.DueAt = null.TimeFrom(time.Date(day.Year(), day.Month(), day.Day(), clock.Hour(), clock.Minute(), 0, 0, location))
+			}
+		}
+
+		// templates are validated when they're saved, this only fills in
+		// defaults
+		if err := taskRequest.Validate(); err != nil {
+			return TaskTree{}, clientError(ErrBadRequest(err.Error()))
+		}
+
+		task, mentioned, err := a.newTaskFromRequest(r, &taskRequest, StatusCategoryTodo)
+		if err != nil {
+			return TaskTree{}, err
+		}
+		mentionedIDs = append(mentionedIDs, mentioned)
+
+		tree := TaskTree{Task: task, Subtasks: make([]TaskTree, len(templateTask.Subtasks))}
+		for i := range templateTask.Subtasks {
+			if tree.Subtasks[i], err = toTree(&templateTask.Subtasks[i]); err != nil {
+				return TaskTree{}, err
+			}
+		}
+
+		return tree, nil
+	}
+
+	tree, err := toTree(&template.Task)
+	if err != nil {
+		renderError(w, r, err)
+		return
+	}
+
+	newTasks, err := a.store.Tasks().CreateTaskTrees(r.Context(), []TaskTree{tree}, a.getEventMeta(r))
+	if err != nil {
+		render.Render(w, r, ErrInternalServerError("An unexpected error occured"))
+		slog.Error(err.Error())
+		return
+	}
+
+	for i := range newTasks {
+		a.notifyAssignee(r.Context(), &newTasks[i], user.ID)
+		a.saveMentions(r.Context(), &newTasks[i], null.Int{}, mentionedIDs[i], user.ID)
+	}
+
+	render.Status(r, http.StatusCreated)
+	render.Render(w, r, NewSuccessResponse(InstantiateTemplateResponse{newTasks}))
+}
+
+// templateDescription is the description of a task made from the template
+// task, with its checklist after the template task's description
+func templateDescription(templateTask *TemplateTask) (string, error) {
+	if len(templateTask.Checklist) == 0 {
+		return templateTask.Description, nil
+	}
+
+	items := make([]checklist.Item, len(templateTask.Checklist))
+	for i, item := range templateTask.Checklist {
+		items[i] = checklist.Item{Title: item}
+	}
+
+	var description strings.Builder
+	if templateTask.Description != "" {
+		description.WriteString(templateTask.Description + "\n\n")
+	}
+
+	if err := checklist.Write(&description, items); err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(description.String()), nil
+}
@@ -0,0 +1,355 @@
+package app
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/netip"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+)
+
+// WebhookEvents returns the webhook events a task event is sent as. Purging
+// isn't sent on its own since only tasks in the trash, which were already
+// sent as deleted, can be purged.
+func WebhookEvents(action TaskAction, changes map[string]FieldChange) []WebhookEventType {
+	var events []WebhookEventType
+	switch action {
+	case TaskCreated:
+		events = append(events, WebhookTaskCreated)
+	case TaskDeleted:
+		return []WebhookEventType{WebhookTaskDeleted}
+	case TaskPurged:
+		return nil
+	default:
+		events = append(events, WebhookTaskUpdated)
+	}
+
+	if change, ok := changes["is_completed"]; ok && bytes.Equal(change.To, []byte("true")) {
+		events = append(events, WebhookTaskCompleted)
+	}
+
+	return events
+}
+
+// IsForbiddenWebhookAddr reports whether webhooks can't be sent to addr.
+// Addresses of the server's own network are forbidden so users can't read
+// the responses of internal services through the delivery log.
+func IsForbiddenWebhookAddr(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	return addr.IsLoopback() || addr.IsPrivate() || addr.IsLinkLocalUnicast() ||
+		addr.IsLinkLocalMulticast() || addr.IsInterfaceLocalMulticast() ||
+		addr.IsMulticast() || addr.IsUnspecified()
+}
+
+// newWebhookSecret returns a random secret for signing deliveries
+func newWebhookSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(b), nil
+}
+
+// @Summary	Get Webhooks
+// @Tags		Webhooks
+// @Id			GetWebhooks
+// @Param		X-Workspace-ID	header		int	false	"workspace to act in, defaults to the personal workspace"
+// @Success	200				{object}	SuccessResponse{data=GetWebhooksResponse}
+// @Failure	401,403,404		{object}	ErrorResponse
+// @Security	BasicAuth
+// @Router		/webhooks [get]
+func (a *Application) GetWebhooks(w http.ResponseWriter, r *http.Request) {
+	user := a.getCtxUser(r)
+	workspace := a.getCtxWorkspace(r)
+
+	webhooks, err := a.store.Webhooks().GetWebhooks(r.Context(), workspace.ID, user.ID)
+	if err != nil {
+		render.Render(w, r, ErrInternalServerError("An unexpected error occured"))
+		slog.Error(err.Error())
+		return
+	}
+
+	render.Render(w, r, NewSuccessResponse(GetWebhooksResponse{webhooks}))
+}
+
+// @Summary		Create Webhook
+// @Description	Registers a url that is sent the chosen events for every task the user can see in the workspace. Deliveries are POSTed as json with the X-Webhook-Event, X-Webhook-Delivery and X-Webhook-Timestamp headers, and signed in X-Webhook-Signature as "sha256=" followed by the hex encoded HMAC-SHA256 of the timestamp, a ".", and the body, keyed with the secret. A secret is generated if none is given, it is only shown once. Changes made by background jobs, such as auto archiving, aren't sent.
+// @Tags			Webhooks
+// @Id				CreateWebhook
+// @Param			X-Workspace-ID	header		int						false	"workspace to act in, defaults to the personal workspace"
+// @Param			request			body		CreateWebhookRequest	true	"request body"
+// @Success		201				{object}	SuccessResponse{data=CreateWebhookResponse}
+// @Failure		400,401,403,404	{object}	ErrorResponse
+// @Security		BasicAuth
+// @Router			/webhooks [post]
+func (a *Application) CreateWebhook(w http.ResponseWriter, r *http.Request) {
+	user := a.getCtxUser(r)
+	workspace := a.getCtxWorkspace(r)
+
+	var requestBody CreateWebhookRequest
+	if err := render.Bind(r, &requestBody); err != nil {
+		render.Render(w, r, ErrBadRequest("Invalid request body"))
+		return
+	}
+
+	if err := requestBody.Validate(); err != nil {
+		render.Render(w, r, ErrBadRequest(err.Error()))
+		return
+	}
+
+	secret := requestBody.Secret
+	if secret == "" {
+		var err error
+		secret, err = newWebhookSecret()
+		if err != nil {
+			render.Render(w, r, ErrInternalServerError("An unexpected error occured"))
+			slog.Error(err.Error())
+			return
+		}
+	}
+
+	webhookPayload := &Webhook{
+		UserID:      user.ID,
+		WorkspaceID: workspace.ID,
+		URL:         requestBody.URL,
+		Secret:      secret,
+		EventTypes:  requestBody.EventTypes,
+	}
+
+	webhook, err := a.store.Webhooks().CreateWebhook(r.Context(), webhookPayload)
+	if err != nil {
+		render.Render(w, r, ErrInternalServerError("An unexpected error occured"))
+		slog.Error(err.Error())
+		return
+	}
+
+	render.Status(r, http.StatusCreated)
+	render.Render(w, r, NewSuccessResponse(CreateWebhookResponse{*webhook, secret}))
+}
+
+// @Summary		Edit Webhook
+// @Description	Deliveries of an inactive webhook are kept pending until it is activated again.
+// @Tags			Webhooks
+// @Id				EditWebhook
+// @Param			X-Workspace-ID	header		int					false	"workspace to act in, defaults to the personal workspace"
+// @Param			id				path		int					true	"webhook id"
+// @Param			request			body		EditWebhookRequest	true	"request body"
+// @Success		200				{object}	SuccessResponse{data=EditWebhookResponse}
+// @Failure		400,401,403,404	{object}	ErrorResponse
+// @Security		BasicAuth
+// @Router			/webhooks/{id} [patch]
+func (a *Application) EditWebhook(w http.ResponseWriter, r *http.Request) {
+	user := a.getCtxUser(r)
+	workspace := a.getCtxWorkspace(r)
+	rawID := chi.URLParam(r, "id")
+
+	id, err := strconv.Atoi(rawID)
+	if err != nil {
+		render.Render(w, r, ErrResourceNotFound("Webhook not found"))
+		return
+	}
+
+	var requestBody EditWebhookRequest
+	if err := render.Bind(r, &requestBody); err != nil {
+		render.Render(w, r, ErrBadRequest("Invalid request body"))
+		return
+	}
+
+	if err := requestBody.Validate(); err != nil {
+		render.Render(w, r, ErrBadRequest(err.Error()))
+		return
+	}
+
+	webhook, err := a.store.Webhooks().GetWebhookByID(r.Context(), workspace.ID, user.ID, id)
+	if err != nil {
+		if errors.Is(err, ErrWebhookNotFound) {
+			render.Render(w, r, ErrResourceNotFound("Webhook not found"))
+			return
+		}
+
+		render.Render(w, r, ErrInternalServerError("An unexpected error occured"))
+		slog.Error(err.Error())
+		return
+	}
+
+	if requestBody.URL != nil {
+		webhook.URL = *requestBody.URL
+	}
+
+	if requestBody.EventTypes != nil {
+		webhook.EventTypes = requestBody.EventTypes
+	}
+
+	if requestBody.IsActive != nil {
+		webhook.IsActive = *requestBody.IsActive
+	}
+
+	updatedWebhook, err := a.store.Webhooks().UpdateWebhook(r.Context(), webhook)
+	if err != nil {
+		if errors.Is(err, ErrWebhookNotFound) {
+			render.Render(w, r, ErrResourceNotFound("Webhook not found"))
+			return
+		}
+
+		render.Render(w, r, ErrInternalServerError("An unexpected error occured"))
+		slog.Error(err.Error())
+		return
+	}
+
+	render.Render(w, r, NewSuccessResponse(EditWebhookResponse{*updatedWebhook}))
+}
+
+// @Summary	Delete Webhook
+// @Tags		Webhooks
+// @Id			DeleteWebhook
+// @Param		X-Workspace-ID	header	int	false	"workspace to act in, defaults to the personal workspace"
+// @Param		id				path	int	true	"webhook id"
+// @Success	204
+// @Failure	401,403,404	{object}	ErrorResponse
+// @Security	BasicAuth
+// @Router		/webhooks/{id} [delete]
+func (a *Application) DeleteWebhook(w http.ResponseWriter, r *http.Request) {
+	user := a.getCtxUser(r)
+	workspace := a.getCtxWorkspace(r)
+	rawID := chi.URLParam(r, "id")
+
+	id, err := strconv.Atoi(rawID)
+	if err != nil {
+		render.Render(w, r, ErrResourceNotFound("Webhook not found"))
+		return
+	}
+
+	if err := a.store.Webhooks().DeleteWebhook(r.Context(), workspace.ID, user.ID, id); err != nil {
+		if errors.Is(err, ErrWebhookNotFound) {
+			render.Render(w, r, ErrResourceNotFound("Webhook not found"))
+			return
+		}
+
+		render.Render(w, r, ErrInternalServerError("An unexpected error occured"))
+		slog.Error(err.Error())
+		return
+	}
+
+	render.NoContent(w, r)
+}
+
+// @Summary	Get Webhook Deliveries
+// @Tags		Webhooks
+// @Id			GetWebhookDeliveries
+// @Param		X-Workspace-ID	header		int	false	"workspace to act in, defaults to the personal workspace"
+// @Param		id				path		int	true	"webhook id"
+// @Param		cursor			query		int	false	"cursor for forward pagination"
+// @Param		per_page		query		int	false	"maximum number of deliveries to return"
+// @Success	200				{object}	SuccessResponse{data=GetWebhookDeliveriesResponse,paging=PaginationData}
+// @Failure	401,403,404		{object}	ErrorResponse
+// @Security	BasicAuth
+// @Router		/webhooks/{id}/deliveries [get]
+func (a *Application) GetWebhookDeliveries(w http.ResponseWriter, r *http.Request) {
+	user := a.getCtxUser(r)
+	workspace := a.getCtxWorkspace(r)
+	paging := a.getCtxPaging(r)
+	rawID := chi.URLParam(r, "id")
+
+	id, err := strconv.Atoi(rawID)
+	if err != nil {
+		render.Render(w, r, ErrResourceNotFound("Webhook not found"))
+		return
+	}
+
+	webhook, err := a.store.Webhooks().GetWebhookByID(r.Context(), workspace.ID, user.ID, id)
+	if err != nil {
+		if errors.Is(err, ErrWebhookNotFound) {
+			render.Render(w, r, ErrResourceNotFound("Webhook not found"))
+			return
+		}
+
+		render.Render(w, r, ErrInternalServerError("An unexpected error occured"))
+		slog.Error(err.Error())
+		return
+	}
+
+	deliveries, paginationData, err := a.store.Webhooks().GetDeliveries(r.Context(), webhook.ID, paging)
+	if err != nil {
+		render.Render(w, r, ErrInternalServerError("An unexpected error occured"))
+		slog.Error(err.Error())
+		return
+	}
+
+	payload := NewSuccessResponse(GetWebhookDeliveriesResponse{deliveries}).WithPaginationData(paginationData)
+	render.Render(w, r, payload)
+}
+
+// @Summary		Redeliver Webhook Delivery
+// @Description	Queues a new delivery with the same payload as an earlier one. The earlier delivery is left as it is in the log.
+// @Tags			Webhooks
+// @Id				RedeliverWebhookDelivery
+// @Param			X-Workspace-ID	header		int	false	"workspace to act in, defaults to the personal workspace"
+// @Param			id				path		int	true	"webhook id"
+// @Param			deliveryID		path		int	true	"delivery id"
+// @Success		202				{object}	SuccessResponse{data=RedeliverWebhookResponse}
+// @Failure		401,403,404		{object}	ErrorResponse
+// @Security		BasicAuth
+// @Router			/webhooks/{id}/deliveries/{deliveryID}/redeliver [post]
+func (a *Application) RedeliverWebhookDelivery(w http.ResponseWriter, r *http.Request) {
+	user := a.getCtxUser(r)
+	workspace := a.getCtxWorkspace(r)
+	rawID := chi.URLParam(r, "id")
+	rawDeliveryID := chi.URLParam(r, "deliveryID")
+
+	id, err := strconv.Atoi(rawID)
+	if err != nil {
+		render.Render(w, r, ErrResourceNotFound("Webhook not found"))
+		return
+	}
+
+	deliveryID, err := strconv.Atoi(rawDeliveryID)
+	if err != nil {
+		render.Render(w, r, ErrResourceNotFound("Delivery not found"))
+		return
+	}
+
+	webhook, err := a.store.Webhooks().GetWebhookByID(r.Context(), workspace.ID, user.ID, id)
+	if err != nil {
+		if errors.Is(err, ErrWebhookNotFound) {
+			render.Render(w, r, ErrResourceNotFound("Webhook not found"))
+			return
+		}
+
+		render.Render(w, r, ErrInternalServerError("An unexpected error occured"))
+		slog.Error(err.Error())
+		return
+	}
+
+	delivery, err := a.store.Webhooks().GetDeliveryByID(r.Context(), webhook.ID, deliveryID)
+	if err != nil {
+		if errors.Is(err, ErrDeliveryNotFound) {
+			render.Render(w, r, ErrResourceNotFound("Delivery not found"))
+			return
+		}
+
+		render.Render(w, r, ErrInternalServerError("An unexpected error occured"))
+		slog.Error(err.Error())
+		return
+	}
+
+	redelivery, err := a.store.Webhooks().CreateDelivery(r.Context(), &WebhookDelivery{
+		WebhookID: webhook.ID,
+		EventType: delivery.EventType,
+		Payload:   delivery.Payload,
+	})
+	if err != nil {
+		render.Render(w, r, ErrInternalServerError("An unexpected error occured"))
+		slog.Error(err.Error())
+		return
+	}
+
+	render.Status(r, http.StatusAccepted)
+	render.Render(w, r, NewSuccessResponse(RedeliverWebhookResponse{*redelivery}))
+}
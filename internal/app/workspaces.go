@@ -0,0 +1,433 @@
+package app
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+)
+
+// @Summary	Get Workspaces
+// @Tags		Workspaces
+// @Id			GetWorkspaces
+// @Param		cursor		query		int	false	"cursor for forward pagination"
+// @Param		per_page	query		int	false	"maximum number of workspaces to return"
+// @Success	200			{object}	SuccessResponse{data=GetWorkspacesResponse,paging=PaginationData}
+// @Failure	401			{object}	ErrorResponse
+// @Security	BasicAuth
+// @Router		/workspaces [get]
+func (a *Application) GetWorkspaces(w http.ResponseWriter, r *http.Request) {
+	user := a.getCtxUser(r)
+	paging := a.getCtxPaging(r)
+
+	workspaces, paginationData, err := a.store.Workspaces().GetWorkspaces(r.Context(), user.ID, paging)
+	if err != nil {
+		render.Render(w, r, ErrInternalServerError("An unexpected error occured"))
+		slog.Error(err.Error())
+		return
+	}
+
+	payload := NewSuccessResponse(GetWorkspacesResponse{workspaces}).WithPaginationData(paginationData)
+	render.Render(w, r, payload)
+}
+
+// @Summary		Create Workspace
+// @Description	Creates a workspace owned by the authenticated user. Requests act in a workspace by passing its id in the X-Workspace-ID header.
+// @Tags			Workspaces
+// @Id				CreateWorkspace
+// @Param			request	body		CreateWorkspaceRequest	true	"request body"
+// @Success		201		{object}	SuccessResponse{data=CreateWorkspaceResponse}
+// @Failure		400,401	{object}	ErrorResponse
+// @Security		BasicAuth
+// @Router			/workspaces [post]
+func (a *Application) CreateWorkspace(w http.ResponseWriter, r *http.Request) {
+	user := a.getCtxUser(r)
+
+	var requestBody CreateWorkspaceRequest
+	if err := render.Bind(r, &requestBody); err != nil {
+		render.Render(w, r, ErrBadRequest("Invalid request body"))
+		return
+	}
+
+	if err := requestBody.Validate(); err != nil {
+		render.Render(w, r, ErrBadRequest(err.Error()))
+		return
+	}
+
+	workspace, err := a.store.Workspaces().CreateWorkspace(r.Context(), &Workspace{Name: requestBody.Name}, user.ID)
+	if err != nil {
+		render.Render(w, r, ErrInternalServerError("An unexpected error occured"))
+		slog.Error(err.Error())
+		return
+	}
+
+	render.Status(r, http.StatusCreated)
+	render.Render(w, r, NewSuccessResponse(CreateWorkspaceResponse{*workspace}))
+}
+
+// @Summary	Edit Workspace
+// @Tags		Workspaces
+// @Id			EditWorkspace
+// @Param		id				path		int						true	"workspace id"
+// @Param		request			body		EditWorkspaceRequest	true	"request body"
+// @Success	200				{object}	SuccessResponse{data=EditWorkspaceResponse}
+// @Failure	400,401,403,404	{object}	ErrorResponse
+// @Security	BasicAuth
+// @Router		/workspaces/{id} [patch]
+func (a *Application) EditWorkspace(w http.ResponseWriter, r *http.Request) {
+	rawID := chi.URLParam(r, "id")
+
+	id, err := strconv.Atoi(rawID)
+	if err != nil {
+		render.Render(w, r, ErrResourceNotFound("Workspace not found"))
+		return
+	}
+
+	workspace, err := a.authorizeWorkspace(r, id, WorkspaceRoleAdmin)
+	if err != nil {
+		a.renderWorkspaceAccessError(w, r, err)
+		return
+	}
+
+	var requestBody EditWorkspaceRequest
+	if err := render.Bind(r, &requestBody); err != nil {
+		render.Render(w, r, ErrBadRequest("Invalid request body"))
+		return
+	}
+
+	if err := requestBody.Validate(); err != nil {
+		render.Render(w, r, ErrBadRequest(err.Error()))
+		return
+	}
+
+	workspace.Name = requestBody.Name
+
+	updatedWorkspace, err := a.store.Workspaces().UpdateWorkspace(r.Context(), workspace)
+	if err != nil {
+		a.renderWorkspaceAccessError(w, r, err)
+		return
+	}
+	updatedWorkspace.Role = workspace.Role
+
+	render.Render(w, r, NewSuccessResponse(EditWorkspaceResponse{*updatedWorkspace}))
+}
+
+// @Summary	Get Workspace Members
+// @Tags		Workspaces
+// @Id			GetWorkspaceMembers
+// @Param		id		path		int	true	"workspace id"
+// @Success	200		{object}	SuccessResponse{data=GetWorkspaceMembersResponse}
+// @Failure	401,404	{object}	ErrorResponse
+// @Security	BasicAuth
+// @Router		/workspaces/{id}/members [get]
+func (a *Application) GetWorkspaceMembers(w http.ResponseWriter, r *http.Request) {
+	rawID := chi.URLParam(r, "id")
+
+	id, err := strconv.Atoi(rawID)
+	if err != nil {
+		render.Render(w, r, ErrResourceNotFound("Workspace not found"))
+		return
+	}
+
+	if _, err := a.authorizeWorkspace(r, id, WorkspaceRoleMember); err != nil {
+		a.renderWorkspaceAccessError(w, r, err)
+		return
+	}
+
+	members, err := a.store.Workspaces().GetWorkspaceMembers(r.Context(), id)
+	if err != nil {
+		render.Render(w, r, ErrInternalServerError("An unexpected error occured"))
+		slog.Error(err.Error())
+		return
+	}
+
+	render.Render(w, r, NewSuccessResponse(GetWorkspaceMembersResponse{members}))
+}
+
+// @Summary		Remove Workspace Member
+// @Description	Removes a member from the workspace, along with their membership of the workspace's projects, and unassigns them from its tasks. Admins can remove members and admins, only owners can remove owners, and anyone can leave. The last owner of a workspace can't be removed.
+// @Tags			Workspaces
+// @Id				RemoveWorkspaceMember
+// @Param			id		path	int	true	"workspace id"
+// @Param			userID	path	int	true	"user id of the member"
+// @Success		204
+// @Failure		401,403,404,409	{object}	ErrorResponse
+// @Security		BasicAuth
+// @Router			/workspaces/{id}/members/{userID} [delete]
+func (a *Application) RemoveWorkspaceMember(w http.ResponseWriter, r *http.Request) {
+	user := a.getCtxUser(r)
+	rawID := chi.URLParam(r, "id")
+	rawUserID := chi.URLParam(r, "userID")
+
+	id, err := strconv.Atoi(rawID)
+	if err != nil {
+		render.Render(w, r, ErrResourceNotFound("Workspace not found"))
+		return
+	}
+
+	memberID, err := strconv.Atoi(rawUserID)
+	if err != nil {
+		render.Render(w, r, ErrResourceNotFound("Member not found"))
+		return
+	}
+
+	if memberID != user.ID {
+		workspace, err := a.authorizeWorkspace(r, id, WorkspaceRoleAdmin)
+		if err != nil {
+			a.renderWorkspaceAccessError(w, r, err)
+			return
+		}
+
+		member, err := a.store.Workspaces().GetWorkspace(r.Context(), id, memberID)
+		if err != nil {
+			if errors.Is(err, ErrWorkspaceNotFound) {
+				render.Render(w, r, ErrResourceNotFound("Member not found"))
+				return
+			}
+
+			render.Render(w, r, ErrInternalServerError("An unexpected error occured"))
+			slog.Error(err.Error())
+			return
+		}
+
+		if !workspace.Role.AtLeast(member.Role) {
+			render.Render(w, r, ErrForbidden("Only owners can remove other owners"))
+			return
+		}
+	}
+
+	if err := a.store.Workspaces().RemoveWorkspaceMember(r.Context(), id, memberID); err != nil {
+		switch {
+		case errors.Is(err, ErrMemberNotFound):
+			render.Render(w, r, ErrResourceNotFound("Member not found"))
+		case errors.Is(err, ErrLastOwner):
+			render.Render(w, r, ErrConflict("The last owner of a workspace can't be removed"))
+		default:
+			render.Render(w, r, ErrInternalServerError("An unexpected error occured"))
+			slog.Error(err.Error())
+		}
+		return
+	}
+
+	render.NoContent(w, r)
+}
+
+// @Summary		Create Workspace Invitation
+// @Description	Invites someone to the workspace by email. The returned token is only shown once, whoever signs in with the invited email can join the workspace by accepting it. Only owners can invite owners.
+// @Tags			Workspaces
+// @Id				CreateWorkspaceInvitation
+// @Param			id				path		int						true	"workspace id"
+// @Param			request			body		CreateInvitationRequest	true	"request body"
+// @Success		201				{object}	SuccessResponse{data=CreateInvitationResponse}
+// @Failure		400,401,403,404	{object}	ErrorResponse
+// @Security		BasicAuth
+// @Router			/workspaces/{id}/invitations [post]
+func (a *Application) CreateWorkspaceInvitation(w http.ResponseWriter, r *http.Request) {
+	user := a.getCtxUser(r)
+	rawID := chi.URLParam(r, "id")
+
+	id, err := strconv.Atoi(rawID)
+	if err != nil {
+		render.Render(w, r, ErrResourceNotFound("Workspace not found"))
+		return
+	}
+
+	workspace, err := a.authorizeWorkspace(r, id, WorkspaceRoleAdmin)
+	if err != nil {
+		a.renderWorkspaceAccessError(w, r, err)
+		return
+	}
+
+	var requestBody CreateInvitationRequest
+	if err := render.Bind(r, &requestBody); err != nil {
+		render.Render(w, r, ErrBadRequest("Invalid request body"))
+		return
+	}
+
+	if err := requestBody.Validate(); err != nil {
+		render.Render(w, r, ErrBadRequest(err.Error()))
+		return
+	}
+
+	if !workspace.Role.AtLeast(requestBody.Role) {
+		render.Render(w, r, ErrForbidden("Only owners can invite other owners"))
+		return
+	}
+
+	token, err := newSecretToken()
+	if err != nil {
+		render.Render(w, r, ErrInternalServerError("An unexpected error occured"))
+		slog.Error(err.Error())
+		return
+	}
+
+	invitationPayload := &WorkspaceInvitation{
+		WorkspaceID: workspace.ID,
+		Email:       requestBody.Email,
+		Role:        requestBody.Role,
+		TokenHash:   hashSecretToken(token),
+		InvitedBy:   user.ID,
+		ExpiresAt:   time.Now().Add(a.config.INVITATION_TTL),
+	}
+
+	invitation, err := a.store.Workspaces().CreateInvitation(r.Context(), invitationPayload)
+	if err != nil {
+		render.Render(w, r, ErrInternalServerError("An unexpected error occured"))
+		slog.Error(err.Error())
+		return
+	}
+
+	url := fmt.Sprintf("%s/api/workspaces/invitations/%s/accept", strings.TrimSuffix(a.config.BASE_URL, "/"), token)
+
+	render.Status(r, http.StatusCreated)
+	render.Render(w, r, NewSuccessResponse(CreateInvitationResponse{*invitation, token, url}))
+}
+
+// @Summary	Get Workspace Invitations
+// @Tags		Workspaces
+// @Id			GetWorkspaceInvitations
+// @Param		id			path		int	true	"workspace id"
+// @Success	200			{object}	SuccessResponse{data=GetInvitationsResponse}
+// @Failure	401,403,404	{object}	ErrorResponse
+// @Security	BasicAuth
+// @Router		/workspaces/{id}/invitations [get]
+func (a *Application) GetWorkspaceInvitations(w http.ResponseWriter, r *http.Request) {
+	rawID := chi.URLParam(r, "id")
+
+	id, err := strconv.Atoi(rawID)
+	if err != nil {
+		render.Render(w, r, ErrResourceNotFound("Workspace not found"))
+		return
+	}
+
+	if _, err := a.authorizeWorkspace(r, id, WorkspaceRoleAdmin); err != nil {
+		a.renderWorkspaceAccessError(w, r, err)
+		return
+	}
+
+	invitations, err := a.store.Workspaces().GetInvitations(r.Context(), id)
+	if err != nil {
+		render.Render(w, r, ErrInternalServerError("An unexpected error occured"))
+		slog.Error(err.Error())
+		return
+	}
+
+	render.Render(w, r, NewSuccessResponse(GetInvitationsResponse{invitations}))
+}
+
+// @Summary	Revoke Workspace Invitation
+// @Tags		Workspaces
+// @Id			RevokeWorkspaceInvitation
+// @Param		id				path	int	true	"workspace id"
+// @Param		invitationID	path	int	true	"invitation id"
+// @Success	204
+// @Failure	401,403,404	{object}	ErrorResponse
+// @Security	BasicAuth
+// @Router		/workspaces/{id}/invitations/{invitationID} [delete]
+func (a *Application) RevokeWorkspaceInvitation(w http.ResponseWriter, r *http.Request) {
+	rawID := chi.URLParam(r, "id")
+	rawInvitationID := chi.URLParam(r, "invitationID")
+
+	id, err := strconv.Atoi(rawID)
+	if err != nil {
+		render.Render(w, r, ErrResourceNotFound("Workspace not found"))
+		return
+	}
+
+	invitationID, err := strconv.Atoi(rawInvitationID)
+	if err != nil {
+		render.Render(w, r, ErrResourceNotFound("Invitation not found"))
+		return
+	}
+
+	if _, err := a.authorizeWorkspace(r, id, WorkspaceRoleAdmin); err != nil {
+		a.renderWorkspaceAccessError(w, r, err)
+		return
+	}
+
+	if err := a.store.Workspaces().DeleteInvitation(r.Context(), id, invitationID); err != nil {
+		if errors.Is(err, ErrInvitationNotFound) {
+			render.Render(w, r, ErrResourceNotFound("Invitation not found"))
+			return
+		}
+
+		render.Render(w, r, ErrInternalServerError("An unexpected error occured"))
+		slog.Error(err.Error())
+		return
+	}
+
+	render.NoContent(w, r)
+}
+
+// @Summary		Accept Workspace Invitation
+// @Description	Joins the workspace the invitation is for. Invitations can only be accepted once, before they expire, by the user they were sent to.
+// @Tags			Workspaces
+// @Id				AcceptWorkspaceInvitation
+// @Param			token			path		string	true	"invitation token"
+// @Success		200				{object}	SuccessResponse{data=AcceptInvitationResponse}
+// @Failure		401,403,404,409	{object}	ErrorResponse
+// @Security		BasicAuth
+// @Router			/workspaces/invitations/{token}/accept [post]
+func (a *Application) AcceptWorkspaceInvitation(w http.ResponseWriter, r *http.Request) {
+	user := a.getCtxUser(r)
+	token := chi.URLParam(r, "token")
+
+	invitation, err := a.store.Workspaces().GetInvitationByTokenHash(r.Context(), hashSecretToken(token))
+	if err != nil {
+		if errors.Is(err, ErrInvitationNotFound) {
+			render.Render(w, r, ErrResourceNotFound("Invitation not found"))
+			return
+		}
+
+		render.Render(w, r, ErrInternalServerError("An unexpected error occured"))
+		slog.Error(err.Error())
+		return
+	}
+
+	if invitation.Email != strings.ToLower(user.Email) {
+		render.Render(w, r, ErrForbidden("This invitation was sent to someone else"))
+		return
+	}
+
+	member, err := a.store.Workspaces().AcceptInvitation(r.Context(), invitation.ID, user.ID)
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrInvitationNotFound):
+			render.Render(w, r, ErrResourceNotFound("Invitation not found"))
+		case errors.Is(err, ErrMemberExists):
+			render.Render(w, r, ErrConflict("You are already a member of the workspace"))
+		default:
+			render.Render(w, r, ErrInternalServerError("An unexpected error occured"))
+			slog.Error(err.Error())
+		}
+		return
+	}
+
+	render.Render(w, r, NewSuccessResponse(AcceptInvitationResponse{*member}))
+}
+
+// newSecretToken returns a random, url safe token, such as an invitation token
+func newSecretToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// hashSecretToken returns the hash secret tokens are stored and looked up by
+func hashSecretToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
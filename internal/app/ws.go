@@ -0,0 +1,417 @@
+package app
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"gopkg.in/guregu/null.v4"
+)
+
+const (
+	wsWriteWait      = 10 * time.Second
+	wsPongWait       = time.Minute
+	wsPingInterval   = wsPongWait * 9 / 10
+	wsMaxMessageSize = 64 << 10
+	wsSendBufferSize = 64
+
+	// presenceTTL is how long a viewer is shown after their session was last
+	// seen, sessions refresh their viewers well within it
+	presenceTTL             = time.Minute
+	presenceRefreshInterval = 20 * time.Second
+)
+
+var upgrader = websocket.Upgrader{
+	ReadBufferSize:  1024,
+	WriteBufferSize: 1024,
+}
+
+// wsClientMessage is a message sent by a websocket client
+type wsClientMessage struct {
+	Type        string          `json:"type"`
+	ID          string          `json:"id"`
+	ProjectID   int             `json:"project_id"`
+	Op          string          `json:"op"`
+	TaskID      int             `json:"task_id"`
+	BaseVersion int             `json:"base_version"`
+	Data        json.RawMessage `json:"data"`
+}
+
+type wsAckMessage struct {
+	Type    string          `json:"type"`
+	ID      string          `json:"id"`
+	Status  int             `json:"status"`
+	Version int             `json:"version,omitempty"`
+	Body    json.RawMessage `json:"body,omitempty"`
+}
+
+type wsErrorMessage struct {
+	Type    string `json:"type"`
+	ID      string `json:"id,omitempty"`
+	Status  int    `json:"status"`
+	Message string `json:"message"`
+}
+
+type wsTaskMessage struct {
+	Type  string          `json:"type"`
+	Event TaskStreamEvent `json:"event"`
+}
+
+type wsPresenceMessage struct {
+	Type      string          `json:"type"`
+	ProjectID int             `json:"project_id"`
+	Viewers   []ProjectViewer `json:"viewers"`
+}
+
+// wsSession is a websocket connection and the projects it is subscribed to
+type wsSession struct {
+	app       *Application
+	conn      *websocket.Conn
+	r         *http.Request
+	id        string
+	userID    int
+	workspace *Workspace
+	out       chan any
+
+	mu       sync.Mutex
+	projects map[int]bool
+	// viewers is the last presence sent for each project, to skip unchanged ones
+	viewers map[int]string
+}
+
+// @Summary		Open a websocket for live task changes and presence
+// @Description	Clients send JSON messages with a type and an id that is echoed back in the reply.
+// @Description	"subscribe" and "unsubscribe" take a project_id, subscribing requires viewer access and marks the user as viewing the project.
+// @Description	"mutate" takes an op (create_task, update_task, delete_task, restore_task or move_task), a task_id, the base_version the change was made
+// @Description	against and data with the same body as the REST endpoint. It is acknowledged with an "ack" holding the status, the task's
+// @Description	new version and the REST response body, or an "error" with a status of 412 if the task changed since base_version.
+// @Description	The server sends "task" messages with a TaskStreamEvent for changes to tasks in subscribed projects, and "presence"
+// @Description	messages with the users viewing a subscribed project whenever they change.
+// @Tags			tasks
+// @Param			X-Workspace-ID	header	int	false	"workspace to act in, defaults to the personal workspace"
+// @Param			workspace_id	query	int	false	"workspace to act in, used when the X-Workspace-ID header isn't set"
+// @Success		101
+// @Failure		400,401,403,404	{object}	ErrorResponse
+// @Security		BasicAuth
+// @Router			/ws [get]
+func (a *Application) ServeWebSocket(w http.ResponseWriter, r *http.Request) {
+	user := a.getCtxUser(r)
+	workspace := a.getCtxWorkspace(r)
+
+	sessionID, err := newSessionID()
+	if err != nil {
+		http.Error(w, "An unexpected error occured", http.StatusInternalServerError)
+		slog.Error(err.Error())
+		return
+	}
+
+	// the upgrader responds with an error itself when the upgrade fails
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return
+	}
+
+	session := &wsSession{
+		app:       a,
+		conn:      conn,
+		r:         r,
+		id:        sessionID,
+		userID:    user.ID,
+		workspace: workspace,
+		out:       make(chan any, wsSendBufferSize),
+		projects:  make(map[int]bool),
+		viewers:   make(map[int]string),
+	}
+	session.run()
+}
+
+func newSessionID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+func (s *wsSession) run() {
+	ctx, cancel := context.WithCancel(s.r.Context())
+	defer cancel()
+	defer s.conn.Close()
+
+	defer func() {
+		if err := s.app.store.Presence().RemoveSession(context.Background(), s.id); err != nil {
+			slog.Error(err.Error())
+		}
+	}()
+
+	// subscribing before reading any events makes sure none are missed in between
+	taskUpdates, unsubscribeTasks := s.app.feed.Subscribe(TopicTaskEvents)
+	defer unsubscribeTasks()
+
+	viewerUpdates, unsubscribeViewers := s.app.feed.Subscribe(TopicProjectViewers)
+	defer unsubscribeViewers()
+
+	last, err := s.app.store.Tasks().GetTaskEventWatermark(ctx)
+	if err != nil {
+		slog.Error(err.Error())
+		s.close(websocket.CloseInternalServerErr)
+		return
+	}
+
+	go s.writeMessages(ctx, cancel)
+	go s.readMessages(ctx, cancel)
+
+	refresh := time.NewTicker(presenceRefreshInterval)
+	defer refresh.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-s.app.shutdown:
+			s.close(websocket.CloseGoingAway)
+			return
+		case <-taskUpdates:
+			last, err = s.sendTaskChanges(ctx, last)
+			if err != nil {
+				if ctx.Err() == nil {
+					slog.Error(err.Error())
+				}
+				return
+			}
+		case <-viewerUpdates:
+			s.sendPresence(ctx, s.subscribedProjects()...)
+		case <-refresh.C:
+			if err := s.app.store.Presence().TouchSession(ctx, s.id); err != nil && ctx.Err() == nil {
+				slog.Error(err.Error())
+			}
+			// viewers whose sessions went away without a word only drop out
+			// once they are stale
+			s.sendPresence(ctx, s.subscribedProjects()...)
+		}
+	}
+}
+
+// close tells the client why the connection is being closed, the connection
+// itself is closed when the session ends
+func (s *wsSession) close(code int) {
+	message := websocket.FormatCloseMessage(code, "")
+	s.conn.WriteControl(websocket.CloseMessage, message, time.Now().Add(wsWriteWait))
+}
+
+// send queues a message for the writer, waiting for room if the client is slow
+func (s *wsSession) send(ctx context.Context, message any) {
+	select {
+	case s.out <- message:
+	case <-ctx.Done():
+	}
+}
+
+func (s *wsSession) sendError(ctx context.Context, id string, status int, message string) {
+	s.send(ctx, wsErrorMessage{Type: "error", ID: id, Status: status, Message: message})
+}
+
+// writeMessages is the only goroutine writing data messages to the
+// connection, it also keeps the connection alive with pings
+func (s *wsSession) writeMessages(ctx context.Context, cancel context.CancelFunc) {
+	defer cancel()
+
+	ping := time.NewTicker(wsPingInterval)
+	defer ping.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case message := <-s.out:
+			s.conn.SetWriteDeadline(time.Now().Add(wsWriteWait))
+			if err := s.conn.WriteJSON(message); err != nil {
+				return
+			}
+		case <-ping.C:
+			if err := s.conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(wsWriteWait)); err != nil {
+				return
+			}
+		}
+	}
+}
+
+func (s *wsSession) readMessages(ctx context.Context, cancel context.CancelFunc) {
+	defer cancel()
+
+	s.conn.SetReadLimit(wsMaxMessageSize)
+	s.conn.SetReadDeadline(time.Now().Add(wsPongWait))
+	s.conn.SetPongHandler(func(string) error {
+		return s.conn.SetReadDeadline(time.Now().Add(wsPongWait))
+	})
+
+	for {
+		_, data, err := s.conn.ReadMessage()
+		if err != nil {
+			return
+		}
+		s.conn.SetReadDeadline(time.Now().Add(wsPongWait))
+
+		var message wsClientMessage
+		if err := json.Unmarshal(data, &message); err != nil {
+			s.sendError(ctx, "", http.StatusBadRequest, "Invalid message")
+			continue
+		}
+
+		switch message.Type {
+		case "subscribe":
+			s.subscribe(ctx, message)
+		case "unsubscribe":
+			s.unsubscribe(ctx, message)
+		case "mutate":
+			s.mutate(ctx, message)
+		default:
+			s.sendError(ctx, message.ID, http.StatusBadRequest, "Unknown message type")
+		}
+	}
+}
+
+func (s *wsSession) subscribedProjects() []int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	projectIDs := make([]int, 0, len(s.projects))
+	for projectID := range s.projects {
+		projectIDs = append(projectIDs, projectID)
+	}
+	return projectIDs
+}
+
+func (s *wsSession) subscribe(ctx context.Context, message wsClientMessage) {
+	if _, err := s.app.authorizeProject(s.r, message.ProjectID, ProjectRoleViewer); err != nil {
+		switch {
+		case errors.Is(err, ErrProjectNotFound):
+			s.sendError(ctx, message.ID, http.StatusNotFound, "Project not found")
+		case errors.Is(err, ErrPermissionDenied):
+			s.sendError(ctx, message.ID, http.StatusForbidden, "You do not have permission to view this project")
+		default:
+			s.sendError(ctx, message.ID, http.StatusInternalServerError, "An unexpected error occured")
+			slog.Error(err.Error())
+		}
+		return
+	}
+
+	if err := s.app.store.Presence().AddViewer(ctx, s.id, message.ProjectID, s.userID); err != nil {
+		s.sendError(ctx, message.ID, http.StatusInternalServerError, "An unexpected error occured")
+		slog.Error(err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	s.projects[message.ProjectID] = true
+	s.mu.Unlock()
+
+	s.send(ctx, wsAckMessage{Type: "ack", ID: message.ID, Status: http.StatusOK})
+	s.sendPresence(ctx, message.ProjectID)
+}
+
+func (s *wsSession) unsubscribe(ctx context.Context, message wsClientMessage) {
+	s.mu.Lock()
+	delete(s.projects, message.ProjectID)
+	delete(s.viewers, message.ProjectID)
+	s.mu.Unlock()
+
+	if err := s.app.store.Presence().RemoveViewer(ctx, s.id, message.ProjectID); err != nil {
+		s.sendError(ctx, message.ID, http.StatusInternalServerError, "An unexpected error occured")
+		slog.Error(err.Error())
+		return
+	}
+
+	s.send(ctx, wsAckMessage{Type: "ack", ID: message.ID, Status: http.StatusOK})
+}
+
+func (s *wsSession) mutate(ctx context.Context, message wsClientMessage) {
+	result, ok := s.app.dispatchTaskMutation(s.r.WithContext(ctx), taskMutation{
+		Op:          message.Op,
+		TaskID:      message.TaskID,
+		BaseVersion: message.BaseVersion,
+		Body:        message.Data,
+		RequestID:   fmt.Sprintf("%s-%s", s.id, message.ID),
+	})
+	if !ok {
+		s.sendError(ctx, message.ID, http.StatusBadRequest, "Unknown mutation")
+		return
+	}
+
+	if result.Failed() {
+		s.sendError(ctx, message.ID, result.Status, result.Message)
+		return
+	}
+
+	ack := wsAckMessage{Type: "ack", ID: message.ID, Status: result.Status, Body: result.Body}
+	if result.Task != nil {
+		ack.Version = result.Task.Version
+	}
+
+	s.send(ctx, ack)
+}
+
+// sendTaskChanges sends the changes to tasks in, or moved out of, the
+// subscribed projects and returns the position to continue from
+func (s *wsSession) sendTaskChanges(ctx context.Context, last EventPosition) (EventPosition, error) {
+	return s.app.forEachTaskChange(ctx, s.workspace.ID, s.userID, last, func(change TaskChange) error {
+		if s.watches(change) {
+			s.send(ctx, wsTaskMessage{Type: "task", Event: newTaskStreamEvent(change)})
+		}
+		return nil
+	})
+}
+
+func (s *wsSession) watches(change TaskChange) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, projectID := range []null.Int{change.Event.ProjectID, change.Event.PreviousProjectID} {
+		if projectID.Valid && s.projects[int(projectID.Int64)] {
+			return true
+		}
+	}
+
+	return false
+}
+
+// sendPresence sends the viewers of the projects whose viewers changed since
+// they were last sent
+func (s *wsSession) sendPresence(ctx context.Context, projectIDs ...int) {
+	seenAfter := time.Now().Add(-presenceTTL)
+
+	for _, projectID := range projectIDs {
+		viewers, err := s.app.store.Presence().GetViewers(ctx, projectID, seenAfter)
+		if err != nil {
+			if ctx.Err() == nil {
+				slog.Error(err.Error())
+			}
+			return
+		}
+
+		key, err := json.Marshal(viewers)
+		if err != nil {
+			slog.Error(err.Error())
+			return
+		}
+
+		s.mu.Lock()
+		changed := s.projects[projectID] && s.viewers[projectID] != string(key)
+		if changed {
+			s.viewers[projectID] = string(key)
+		}
+		s.mu.Unlock()
+
+		if changed {
+			s.send(ctx, wsPresenceMessage{Type: "presence", ProjectID: projectID, Viewers: viewers})
+		}
+	}
+}
@@ -0,0 +1,101 @@
+// Package blob implements app.BlobStore on the local filesystem and on S3
+// compatible object stores.
+package blob
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ayo-awe/golang_todo_api/internal/app"
+)
+
+// FileStore keeps blobs as files under a directory on the local filesystem
+type FileStore struct {
+	root string
+}
+
+func NewFileStore(root string) (*FileStore, error) {
+	if err := os.MkdirAll(root, 0o750); err != nil {
+		return nil, err
+	}
+
+	return &FileStore{root: root}, nil
+}
+
+func (s *FileStore) Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return err
+	}
+
+	// write to a temporary file first so a failed upload never leaves a partial blob behind
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	written, err := io.Copy(tmp, body)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+
+	if written != size {
+		return fmt.Errorf("blob: wrote %d bytes, expected %d", written, size)
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
+
+func (s *FileStore) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, app.ErrBlobNotFound
+		}
+		return nil, err
+	}
+
+	return file, nil
+}
+
+func (s *FileStore) Delete(ctx context.Context, key string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+
+	return nil
+}
+
+// path maps a key to a file under the store's root, rejecting keys that would escape it
+func (s *FileStore) path(key string) (string, error) {
+	path := filepath.Join(s.root, filepath.FromSlash(key))
+	if !strings.HasPrefix(path, filepath.Clean(s.root)+string(filepath.Separator)) {
+		return "", fmt.Errorf("blob: invalid key %q", key)
+	}
+
+	return path, nil
+}
@@ -0,0 +1,104 @@
+package blob
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ayo-awe/golang_todo_api/internal/app"
+)
+
+func newTestFileStore(t *testing.T) (*FileStore, string) {
+	dir := t.TempDir()
+	root := filepath.Join(dir, "blobs")
+
+	store, err := NewFileStore(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return store, dir
+}
+
+func TestFileStoreRejectsKeysOutsideRoot(t *testing.T) {
+	store, dir := newTestFileStore(t)
+	ctx := context.Background()
+
+	for _, key := range []string{"", ".", "..", "../escape", "a/../../escape", "/../escape", "tasks/../../blobs-sibling/x"} {
+		if err := store.Put(ctx, key, strings.NewReader("x"), 1, "text/plain"); err == nil {
+			t.Errorf("Put(%q) succeeded, want an invalid key error", key)
+		}
+		if _, err := store.Get(ctx, key); err == nil || errors.Is(err, app.ErrBlobNotFound) {
+			t.Errorf("Get(%q): err = %v, want an invalid key error", key, err)
+		}
+		if err := store.Delete(ctx, key); err == nil {
+			t.Errorf("Delete(%q) succeeded, want an invalid key error", key)
+		}
+	}
+
+	// nothing was written next to the root
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || entries[0].Name() != "blobs" {
+		t.Errorf("entries beside the root: %v", entries)
+	}
+}
+
+func TestFileStore(t *testing.T) {
+	store, _ := newTestFileStore(t)
+	ctx := context.Background()
+
+	// keys that only look odd still resolve inside the root
+	for _, key := range []string{"tasks/1/report.pdf", "/tasks/2/abs", "tasks/3/../3/up-and-back", "tasks/4/my notes.txt"} {
+		body := []byte("contents of " + key)
+		if err := store.Put(ctx, key, bytes.NewReader(body), int64(len(body)), "text/plain"); err != nil {
+			t.Fatalf("Put(%q): %v", key, err)
+		}
+
+		reader, err := store.Get(ctx, key)
+		if err != nil {
+			t.Fatalf("Get(%q): %v", key, err)
+		}
+		got, _ := io.ReadAll(reader)
+		reader.Close()
+		if !bytes.Equal(got, body) {
+			t.Errorf("Get(%q) = %q, want %q", key, got, body)
+		}
+
+		if err := store.Delete(ctx, key); err != nil {
+			t.Fatalf("Delete(%q): %v", key, err)
+		}
+		if _, err := store.Get(ctx, key); !errors.Is(err, app.ErrBlobNotFound) {
+			t.Errorf("Get(%q) after delete: err = %v, want ErrBlobNotFound", key, err)
+		}
+	}
+
+	if err := store.Delete(ctx, "tasks/404"); err != nil {
+		t.Errorf("Delete of a missing blob: %v", err)
+	}
+}
+
+func TestFileStoreShortBody(t *testing.T) {
+	store, _ := newTestFileStore(t)
+	ctx := context.Background()
+
+	if err := store.Put(ctx, "tasks/1/short", strings.NewReader("abc"), 10, "text/plain"); err == nil {
+		t.Fatal("Put with fewer bytes than its size succeeded")
+	}
+
+	// a failed upload leaves neither the blob nor its temporary file behind
+	if _, err := store.Get(ctx, "tasks/1/short"); !errors.Is(err, app.ErrBlobNotFound) {
+		t.Errorf("Get after a failed Put: err = %v, want ErrBlobNotFound", err)
+	}
+	entries, _ := os.ReadDir(filepath.Join(store.root, "tasks", "1"))
+	if len(entries) != 0 {
+		t.Errorf("leftover files after a failed Put: %v", entries)
+	}
+}
@@ -0,0 +1,217 @@
+// Package checklist reads and writes Markdown task lists, the GitHub flavoured
+// "- [ ] task" / "- [x] done" items, nested by indentation. Tags are written
+// as #tag, with dashes for spaces, and due dates as due:2006-01-02 in an
+// item's text.
+package checklist
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Item is a checklist item along with the items nested under it
+type Item struct {
+	Title string
+	// Description is the text indented under the item that isn't an item itself
+	Description string
+	Done        bool
+	Tags        []string
+	DueAt       *time.Time
+	Items       []Item
+	// Line is the line the item starts on, from 1
+	Line int
+}
+
+var (
+	itemPattern = regexp.MustCompile(`^[-*+] \[([ xX])\](?:\s+(.*))?$`)
+	tagPattern  = regexp.MustCompile(`(?:^|\s)#([^\s#]+)`)
+	duePattern  = regexp.MustCompile(`(?:^|\s)due:(\S+)`)
+)
+
+// tabWidth is how many spaces a tab indents by
+const tabWidth = 4
+
+// Parse reads the items of the document's task lists. Other lines, such as
+// headings and paragraphs, are skipped, along with the items of plain lists
+// that aren't indented under a task.
+func Parse(r io.Reader) ([]Item, error) {
+	var (
+		items []Item
+		// stack is the path to the last item, with the indentation of each
+		stack   []*Item
+		indents []int
+		// blank counts the blank lines since the last line of a description
+		blank int
+	)
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimRight(scanner.Text(), " \t\r")
+		if n == 1 {
+			line = strings.TrimPrefix(line, "\ufeff")
+		}
+
+		if line == "" {
+			blank++
+			continue
+		}
+
+		indent, text := splitIndent(line)
+
+		if match := itemPattern.FindStringSubmatch(text); match != nil {
+			item, err := parseItem(match[2], match[1] != " ")
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", n, err)
+			}
+			item.Line = n
+
+			for len(stack) > 0 && indents[len(indents)-1] >= indent {
+				stack = stack[:len(stack)-1]
+				indents = indents[:len(indents)-1]
+			}
+
+			var added *Item
+			if len(stack) == 0 {
+				items = append(items, item)
+				added = &items[len(items)-1]
+			} else {
+				parent := stack[len(stack)-1]
+				parent.Items = append(parent.Items, item)
+				added = &parent.Items[len(parent.Items)-1]
+			}
+
+			// the pointers in the stack are to items in slices that are only
+			// appended to below the last item, so they stay valid
+			stack = append(stack, added)
+			indents = append(indents, indent)
+			blank = 0
+			continue
+		}
+
+		// text indented under an item describes it, anything else ends the list
+		for len(stack) > 0 && indents[len(indents)-1] >= indent {
+			stack = stack[:len(stack)-1]
+			indents = indents[:len(indents)-1]
+		}
+		if len(stack) == 0 {
+			blank = 0
+			continue
+		}
+
+		item := stack[len(stack)-1]
+		if item.Description != "" {
+			item.Description += strings.Repeat("\n", min(blank, 1)+1)
+		}
+		item.Description += text
+		blank = 0
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
+
+func splitIndent(line string) (int, string) {
+	indent := 0
+	for i, c := range line {
+		switch c {
+		case ' ':
+			indent++
+		case '\t':
+			indent += tabWidth - indent%tabWidth
+		default:
+			return indent, line[i:]
+		}
+	}
+	return indent, ""
+}
+
+// parseItem takes the tags and due date out of an item's text, what's left is
+// its title
+func parseItem(text string, done bool) (Item, error) {
+	item := Item{Done: done}
+
+	if match := duePattern.FindStringSubmatch(text); match != nil {
+		dueAt, err := parseDate(match[1])
+		if err != nil {
+			return Item{}, fmt.Errorf("invalid due date %q", match[1])
+		}
+		item.DueAt = &dueAt
+		text = duePattern.ReplaceAllString(text, "")
+	}
+
+	// issue references such as #123 are left in the title
+	text = tagPattern.ReplaceAllStringFunc(text, func(match string) string {
+		tag := strings.TrimRight(strings.TrimSpace(match)[1:], ".,;:!?)")
+		if tag == "" || strings.Trim(tag, "0123456789") == "" {
+			return match
+		}
+		item.Tags = append(item.Tags, tag)
+		return ""
+	})
+
+	item.Title = strings.Join(strings.Fields(text), " ")
+	return item, nil
+}
+
+// parseDate parses RFC 3339 timestamps, or dates as midnight UTC
+func parseDate(raw string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, raw); err == nil {
+		return t, nil
+	}
+	return time.Parse(time.DateOnly, raw)
+}
+
+// Write writes the items as a task list, nested items are indented by two
+// spaces for each level
+func Write(w io.Writer, items []Item) error {
+	bw := bufio.NewWriter(w)
+	writeItems(bw, items, "")
+	return bw.Flush()
+}
+
+func writeItems(w *bufio.Writer, items []Item, indent string) {
+	for _, item := range items {
+		mark := " "
+		if item.Done {
+			mark = "x"
+		}
+
+		line := fmt.Sprintf("%s- [%s] %s", indent, mark, strings.Join(strings.Fields(item.Title), " "))
+		for _, tag := range item.Tags {
+			line += " #" + strings.Join(strings.Fields(tag), "-")
+		}
+		if item.DueAt != nil {
+			line += " due:" + formatDate(*item.DueAt)
+		}
+		w.WriteString(line + "\n")
+
+		if description := strings.TrimSpace(item.Description); description != "" {
+			for _, descriptionLine := range strings.Split(description, "\n") {
+				if descriptionLine = strings.TrimRight(descriptionLine, " \t\r"); descriptionLine != "" {
+					descriptionLine = indent + "  " + descriptionLine
+				}
+				w.WriteString(descriptionLine + "\n")
+			}
+		}
+
+		writeItems(w, item.Items, indent+"  ")
+	}
+}
+
+// formatDate writes due dates at midnight UTC, which is how dates are read,
+// as just the date
+func formatDate(t time.Time) string {
+	t = t.UTC()
+	if t.Equal(t.Truncate(24 * time.Hour)) {
+		return t.Format(time.DateOnly)
+	}
+	return t.Format(time.RFC3339)
+}
@@ -0,0 +1,143 @@
+package database
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ayo-awe/golang_todo_api/internal/app"
+	"github.com/ayo-awe/golang_todo_api/internal/database/sqlc"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type attachmentRepo struct {
+	queries *sqlc.Queries
+	conn    *pgxpool.Pool
+}
+
+func NewAttachmentRepository(conn *pgxpool.Pool) app.AttachmentRepository {
+	return &attachmentRepo{conn: conn, queries: sqlc.New(conn)}
+}
+
+func (repo *attachmentRepo) toAppAttachment(sqlcAttachment *sqlc.TaskAttachment) *app.Attachment {
+	return &app.Attachment{
+		ID:          int(sqlcAttachment.ID),
+		TaskID:      int(sqlcAttachment.TaskID),
+		UploaderID:  int(sqlcAttachment.UserID),
+		Filename:    sqlcAttachment.Filename,
+		ContentType: sqlcAttachment.ContentType,
+		Size:        sqlcAttachment.Size,
+		BlobKey:     sqlcAttachment.BlobKey,
+		CreatedAt:   sqlcAttachment.CreatedAt.Time,
+	}
+}
+
+func (repo *attachmentRepo) GetAttachments(ctx context.Context, taskID int, paging app.Paging) ([]app.Attachment, app.PaginationData, error) {
+	arg := sqlc.GetTaskAttachmentsParams{
+		TaskID: int32(taskID),
+		Cursor: int32(paging.Cursor),
+		Limit:  int32(paging.Limit()),
+	}
+
+	sqlcAttachments, err := repo.queries.GetTaskAttachments(ctx, arg)
+	if err != nil {
+		return nil, app.PaginationData{}, err
+	}
+
+	attachments := make([]app.Attachment, len(sqlcAttachments))
+	for i, sqlcAttachment := range sqlcAttachments {
+		attachments[i] = *repo.toAppAttachment(&sqlcAttachment)
+	}
+
+	attachments, paginationData := paginate(attachments, paging, func(a app.Attachment) int { return a.ID })
+	return attachments, paginationData, nil
+}
+
+func (repo *attachmentRepo) GetAttachmentByID(ctx context.Context, taskID int, attachmentID int) (*app.Attachment, error) {
+	arg := sqlc.GetTaskAttachmentByIDParams{
+		TaskID: int32(taskID),
+		ID:     int32(attachmentID),
+	}
+
+	sqlcAttachment, err := repo.queries.GetTaskAttachmentByID(ctx, arg)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, app.ErrAttachmentNotFound
+		}
+		return nil, err
+	}
+
+	return repo.toAppAttachment(&sqlcAttachment), nil
+}
+
+func (repo *attachmentRepo) CreateAttachment(ctx context.Context, attachment *app.Attachment, quota int64) (*app.Attachment, error) {
+	arg := sqlc.CreateTaskAttachmentParams{
+		TaskID:      int32(attachment.TaskID),
+		UserID:      int32(attachment.UploaderID),
+		Filename:    attachment.Filename,
+		ContentType: attachment.ContentType,
+		Size:        attachment.Size,
+		BlobKey:     attachment.BlobKey,
+	}
+
+	var newAttachment *app.Attachment
+	err := withTx(ctx, repo.conn, func(q *sqlc.Queries) error {
+		// concurrent uploads by the same user wait on each other so they can't
+		// both fit under the quota on their own and exceed it together
+		if err := q.LockUserStorage(ctx, arg.UserID); err != nil {
+			return err
+		}
+
+		usage, err := q.GetUserStorageUsage(ctx, arg.UserID)
+		if err != nil {
+			return err
+		}
+
+		if usage+arg.Size > quota {
+			return app.ErrQuotaExceeded
+		}
+
+		sqlcAttachment, err := q.CreateTaskAttachment(ctx, arg)
+		if err != nil {
+			return err
+		}
+
+		newAttachment = repo.toAppAttachment(&sqlcAttachment)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return newAttachment, nil
+}
+
+func (repo *attachmentRepo) DeleteAttachment(ctx context.Context, taskID int, attachmentID int) error {
+	arg := sqlc.DeleteTaskAttachmentParams{
+		TaskID: int32(taskID),
+		ID:     int32(attachmentID),
+	}
+
+	count, err := repo.queries.DeleteTaskAttachment(ctx, arg)
+	if err != nil {
+		return err
+	}
+
+	if count == 0 {
+		return app.ErrAttachmentNotFound
+	}
+
+	return nil
+}
+
+func (repo *attachmentRepo) GetStorageUsage(ctx context.Context, userID int) (int64, error) {
+	return repo.queries.GetUserStorageUsage(ctx, int32(userID))
+}
+
+func (repo *attachmentRepo) GetOrphanedBlobs(ctx context.Context, limit int) ([]string, error) {
+	return repo.queries.GetOrphanedBlobs(ctx, int32(limit))
+}
+
+func (repo *attachmentRepo) DeleteOrphanedBlobs(ctx context.Context, keys []string) error {
+	return repo.queries.DeleteOrphanedBlobs(ctx, keys)
+}
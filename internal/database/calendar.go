@@ -0,0 +1,61 @@
+package database
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ayo-awe/golang_todo_api/internal/app"
+	"github.com/ayo-awe/golang_todo_api/internal/database/sqlc"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type calendarRepo struct {
+	queries *sqlc.Queries
+	conn    *pgxpool.Pool
+}
+
+func NewCalendarRepository(conn *pgxpool.Pool) app.CalendarRepository {
+	return &calendarRepo{conn: conn, queries: sqlc.New(conn)}
+}
+
+func (repo *calendarRepo) SetFeedToken(ctx context.Context, userID int, workspaceID int, tokenHash string) error {
+	return repo.queries.SetCalendarFeedToken(ctx, sqlc.SetCalendarFeedTokenParams{
+		UserID:      int32(userID),
+		WorkspaceID: int32(workspaceID),
+		TokenHash:   tokenHash,
+	})
+}
+
+func (repo *calendarRepo) GetFeedByTokenHash(ctx context.Context, tokenHash string) (*app.CalendarFeed, error) {
+	sqlcFeed, err := repo.queries.GetCalendarFeedByTokenHash(ctx, tokenHash)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, app.ErrCalendarFeedNotFound
+		}
+		return nil, err
+	}
+
+	return &app.CalendarFeed{
+		UserID:      int(sqlcFeed.UserID),
+		WorkspaceID: int(sqlcFeed.WorkspaceID),
+		TokenHash:   sqlcFeed.TokenHash,
+		CreatedAt:   sqlcFeed.CreatedAt.Time,
+	}, nil
+}
+
+func (repo *calendarRepo) DeleteFeed(ctx context.Context, userID int, workspaceID int) error {
+	count, err := repo.queries.DeleteCalendarFeed(ctx, sqlc.DeleteCalendarFeedParams{
+		UserID:      int32(userID),
+		WorkspaceID: int32(workspaceID),
+	})
+	if err != nil {
+		return err
+	}
+
+	if count == 0 {
+		return app.ErrCalendarFeedNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,133 @@
+package database
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ayo-awe/golang_todo_api/internal/app"
+	"github.com/ayo-awe/golang_todo_api/internal/database/sqlc"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"gopkg.in/guregu/null.v4"
+)
+
+type commentRepo struct {
+	queries *sqlc.Queries
+	conn    *pgxpool.Pool
+}
+
+func NewCommentRepository(conn *pgxpool.Pool) app.CommentRepository {
+	return &commentRepo{conn: conn, queries: sqlc.New(conn)}
+}
+
+func (repo *commentRepo) toAppComment(sqlcComment *sqlc.TaskComment) *app.Comment {
+	return &app.Comment{
+		ID:        int(sqlcComment.ID),
+		TaskID:    int(sqlcComment.TaskID),
+		AuthorID:  int(sqlcComment.UserID),
+		Body:      sqlcComment.Body,
+		CreatedAt: sqlcComment.CreatedAt.Time,
+		UpdatedAt: sqlcComment.UpdatedAt.Time,
+		EditedAt:  null.NewTime(sqlcComment.EditedAt.Time, sqlcComment.EditedAt.Valid),
+	}
+}
+
+func (repo *commentRepo) GetComments(ctx context.Context, taskID int, paging app.Paging) ([]app.Comment, app.PaginationData, error) {
+	arg := sqlc.GetTaskCommentsParams{
+		TaskID: int32(taskID),
+		Cursor: int32(paging.Cursor),
+		Limit:  int32(paging.Limit()),
+	}
+
+	sqlcComments, err := repo.queries.GetTaskComments(ctx, arg)
+	if err != nil {
+		return nil, app.PaginationData{}, err
+	}
+
+	comments := make([]app.Comment, len(sqlcComments))
+	for i, sqlcComment := range sqlcComments {
+		comments[i] = *repo.toAppComment(&sqlcComment)
+	}
+
+	comments, paginationData := paginate(comments, paging, func(c app.Comment) int { return c.ID })
+	return comments, paginationData, nil
+}
+
+func (repo *commentRepo) GetCommentByID(ctx context.Context, taskID int, commentID int) (*app.Comment, error) {
+	arg := sqlc.GetTaskCommentByIDParams{
+		TaskID: int32(taskID),
+		ID:     int32(commentID),
+	}
+
+	sqlcComment, err := repo.queries.GetTaskCommentByID(ctx, arg)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, app.ErrCommentNotFound
+		}
+		return nil, err
+	}
+
+	return repo.toAppComment(&sqlcComment), nil
+}
+
+func (repo *commentRepo) CreateComment(ctx context.Context, comment *app.Comment) (*app.Comment, error) {
+	arg := sqlc.CreateTaskCommentParams{
+		TaskID: int32(comment.TaskID),
+		UserID: int32(comment.AuthorID),
+		Body:   comment.Body,
+	}
+
+	var newComment *app.Comment
+	err := withTx(ctx, repo.conn, func(q *sqlc.Queries) error {
+		sqlcComment, err := q.CreateTaskComment(ctx, arg)
+		if err != nil {
+			return err
+		}
+
+		newComment = repo.toAppComment(&sqlcComment)
+		return q.AddTaskCommentCount(ctx, sqlc.AddTaskCommentCountParams{ID: arg.TaskID, Delta: 1})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return newComment, nil
+}
+
+func (repo *commentRepo) UpdateComment(ctx context.Context, comment *app.Comment) (*app.Comment, error) {
+	arg := sqlc.UpdateTaskCommentParams{
+		TaskID: int32(comment.TaskID),
+		ID:     int32(comment.ID),
+		Body:   comment.Body,
+	}
+
+	sqlcComment, err := repo.queries.UpdateTaskComment(ctx, arg)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, app.ErrCommentNotFound
+		}
+		return nil, err
+	}
+
+	return repo.toAppComment(&sqlcComment), nil
+}
+
+func (repo *commentRepo) DeleteComment(ctx context.Context, taskID int, commentID int) error {
+	arg := sqlc.DeleteTaskCommentParams{
+		TaskID: int32(taskID),
+		ID:     int32(commentID),
+	}
+
+	return withTx(ctx, repo.conn, func(q *sqlc.Queries) error {
+		count, err := q.DeleteTaskComment(ctx, arg)
+		if err != nil {
+			return err
+		}
+
+		if count == 0 {
+			return app.ErrCommentNotFound
+		}
+
+		return q.AddTaskCommentCount(ctx, sqlc.AddTaskCommentCountParams{ID: arg.TaskID, Delta: -1})
+	})
+}
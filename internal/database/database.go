@@ -9,9 +9,21 @@ import (
 
 // database is a concrete store
 type Database struct {
-	conn     *pgxpool.Pool
-	taskRepo app.TaskRepository
-	userRepo app.UserRepository
+	conn             *pgxpool.Pool
+	taskRepo         app.TaskRepository
+	userRepo         app.UserRepository
+	statusRepo       app.StatusRepository
+	projectRepo      app.ProjectRepository
+	commentRepo      app.CommentRepository
+	attachmentRepo   app.AttachmentRepository
+	workspaceRepo    app.WorkspaceRepository
+	notificationRepo app.NotificationRepository
+	mentionRepo      app.MentionRepository
+	webhookRepo      app.WebhookRepository
+	presenceRepo     app.PresenceRepository
+	importRepo       app.ImportRepository
+	calendarRepo     app.CalendarRepository
+	templateRepo     app.TemplateRepository
 }
 
 func (d *Database) Users() app.UserRepository {
@@ -22,6 +34,54 @@ func (d *Database) Tasks() app.TaskRepository {
 	return d.taskRepo
 }
 
+func (d *Database) Statuses() app.StatusRepository {
+	return d.statusRepo
+}
+
+func (d *Database) Projects() app.ProjectRepository {
+	return d.projectRepo
+}
+
+func (d *Database) Comments() app.CommentRepository {
+	return d.commentRepo
+}
+
+func (d *Database) Attachments() app.AttachmentRepository {
+	return d.attachmentRepo
+}
+
+func (d *Database) Workspaces() app.WorkspaceRepository {
+	return d.workspaceRepo
+}
+
+func (d *Database) Notifications() app.NotificationRepository {
+	return d.notificationRepo
+}
+
+func (d *Database) Mentions() app.MentionRepository {
+	return d.mentionRepo
+}
+
+func (d *Database) Webhooks() app.WebhookRepository {
+	return d.webhookRepo
+}
+
+func (d *Database) Presence() app.PresenceRepository {
+	return d.presenceRepo
+}
+
+func (d *Database) Imports() app.ImportRepository {
+	return d.importRepo
+}
+
+func (d *Database) Calendars() app.CalendarRepository {
+	return d.calendarRepo
+}
+
+func (d *Database) Templates() app.TemplateRepository {
+	return d.templateRepo
+}
+
 func New(dsn string) (*Database, error) {
 	conn, err := pgxpool.New(context.Background(), dsn)
 	if err != nil {
@@ -30,7 +90,35 @@ func New(dsn string) (*Database, error) {
 
 	userRepo := NewUserRepository(conn)
 	taskRepo := NewTaskRepository(conn)
+	statusRepo := NewStatusRepository(conn)
+	projectRepo := NewProjectRepository(conn)
+	commentRepo := NewCommentRepository(conn)
+	attachmentRepo := NewAttachmentRepository(conn)
+	workspaceRepo := NewWorkspaceRepository(conn)
+	notificationRepo := NewNotificationRepository(conn)
+	mentionRepo := NewMentionRepository(conn)
+	webhookRepo := NewWebhookRepository(conn)
+	presenceRepo := NewPresenceRepository(conn)
+	importRepo := NewImportRepository(conn)
+	calendarRepo := NewCalendarRepository(conn)
+	templateRepo := NewTemplateRepository(conn)
 
-	db := &Database{conn: conn, userRepo: userRepo, taskRepo: taskRepo}
+	db := &Database{
+		conn:             conn,
+		userRepo:         userRepo,
+		taskRepo:         taskRepo,
+		statusRepo:       statusRepo,
+		projectRepo:      projectRepo,
+		commentRepo:      commentRepo,
+		attachmentRepo:   attachmentRepo,
+		workspaceRepo:    workspaceRepo,
+		notificationRepo: notificationRepo,
+		mentionRepo:      mentionRepo,
+		webhookRepo:      webhookRepo,
+		presenceRepo:     presenceRepo,
+		importRepo:       importRepo,
+		calendarRepo:     calendarRepo,
+		templateRepo:     templateRepo,
+	}
 	return db, nil
 }
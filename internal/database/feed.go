@@ -0,0 +1,107 @@
+package database
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/ayo-awe/golang_todo_api/internal/app"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// listenRetryInterval is how long to wait before listening again after the
+// listening connection is lost
+const listenRetryInterval = 5 * time.Second
+
+// ChangeFeed relays notifications to subscribers. Triggers notify the channel
+// named after a topic whenever it changes.
+type ChangeFeed struct {
+	conn        *pgxpool.Pool
+	mu          sync.Mutex
+	subscribers map[app.FeedTopic]map[chan struct{}]struct{}
+}
+
+func NewChangeFeed(database *Database) app.ChangeFeed {
+	return &ChangeFeed{conn: database.conn, subscribers: map[app.FeedTopic]map[chan struct{}]struct{}{}}
+}
+
+func (f *ChangeFeed) Run(ctx context.Context) {
+	for {
+		err := f.listen(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		slog.Error(err.Error())
+
+		// changes could have been missed while the connection was down
+		for _, topic := range app.FeedTopics {
+			f.broadcast(topic)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(listenRetryInterval):
+		}
+	}
+}
+
+// listen holds on to a connection of its own, since notifications are only
+// delivered to the connection that is listening
+func (f *ChangeFeed) listen(ctx context.Context) error {
+	poolConn, err := f.conn.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+
+	conn := poolConn.Hijack()
+	defer conn.Close(context.Background())
+
+	for _, topic := range app.FeedTopics {
+		if _, err := conn.Exec(ctx, "LISTEN "+pgx.Identifier{string(topic)}.Sanitize()); err != nil {
+			return err
+		}
+	}
+
+	for {
+		notification, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+		f.broadcast(app.FeedTopic(notification.Channel))
+	}
+}
+
+func (f *ChangeFeed) broadcast(topic app.FeedTopic) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for subscriber := range f.subscribers[topic] {
+		// subscribers that haven't caught up yet already have a wake up pending
+		select {
+		case subscriber <- struct{}{}:
+		default:
+		}
+	}
+}
+
+func (f *ChangeFeed) Subscribe(topic app.FeedTopic) (<-chan struct{}, func()) {
+	subscriber := make(chan struct{}, 1)
+
+	f.mu.Lock()
+	if f.subscribers[topic] == nil {
+		f.subscribers[topic] = map[chan struct{}]struct{}{}
+	}
+	f.subscribers[topic][subscriber] = struct{}{}
+	f.mu.Unlock()
+
+	unsubscribe := func() {
+		f.mu.Lock()
+		delete(f.subscribers[topic], subscriber)
+		f.mu.Unlock()
+	}
+
+	return subscriber, unsubscribe
+}
@@ -0,0 +1,148 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/ayo-awe/golang_todo_api/internal/app"
+	"github.com/ayo-awe/golang_todo_api/internal/database/sqlc"
+	"github.com/ayo-awe/golang_todo_api/internal/importer"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"gopkg.in/guregu/null.v4"
+)
+
+type importRepo struct {
+	queries *sqlc.Queries
+	conn    *pgxpool.Pool
+	tasks   *taskRepo
+}
+
+func NewImportRepository(conn *pgxpool.Pool) app.ImportRepository {
+	return &importRepo{conn: conn, queries: sqlc.New(conn), tasks: &taskRepo{conn: conn, queries: sqlc.New(conn)}}
+}
+
+func (repo *importRepo) toAppImport(sqlcImport *sqlc.Import) (*app.Import, error) {
+	imp := &app.Import{
+		ID:          int(sqlcImport.ID),
+		UserID:      int(sqlcImport.UserID),
+		WorkspaceID: int(sqlcImport.WorkspaceID),
+		Format:      importer.Format(sqlcImport.Format),
+		Status:      app.ImportStatus(sqlcImport.Status),
+		Data:        &importer.Result{},
+		ProjectIDs:  map[string]int{},
+		Total:       int(sqlcImport.Total),
+		Processed:   int(sqlcImport.Processed),
+		Error:       sqlcImport.Error,
+		CreatedAt:   sqlcImport.CreatedAt.Time,
+		UpdatedAt:   sqlcImport.UpdatedAt.Time,
+		CompletedAt: null.NewTime(sqlcImport.CompletedAt.Time, sqlcImport.CompletedAt.Valid),
+	}
+
+	if err := json.Unmarshal(sqlcImport.Data, imp.Data); err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(sqlcImport.ProjectIds, &imp.ProjectIDs); err != nil {
+		return nil, err
+	}
+
+	return imp, nil
+}
+
+func (repo *importRepo) CreateImport(ctx context.Context, imp *app.Import) (*app.Import, error) {
+	data, err := json.Marshal(imp.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	sqlcImport, err := repo.queries.CreateImport(ctx, sqlc.CreateImportParams{
+		UserID:      int32(imp.UserID),
+		WorkspaceID: int32(imp.WorkspaceID),
+		Format:      string(imp.Format),
+		Data:        data,
+		Total:       int32(imp.Total),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return repo.toAppImport(&sqlcImport)
+}
+
+func (repo *importRepo) GetImport(ctx context.Context, userID int, importID int) (*app.Import, error) {
+	sqlcImport, err := repo.queries.GetImport(ctx, sqlc.GetImportParams{
+		UserID: int32(userID),
+		ID:     int32(importID),
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, app.ErrImportNotFound
+		}
+		return nil, err
+	}
+
+	return repo.toAppImport(&sqlcImport)
+}
+
+func (repo *importRepo) ClaimImport(ctx context.Context, leasedUntil time.Time) (*app.Import, error) {
+	sqlcImport, err := repo.queries.ClaimImport(ctx, pgtype.Timestamptz{Time: leasedUntil, Valid: true})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, app.ErrImportNotFound
+		}
+		return nil, err
+	}
+
+	return repo.toAppImport(&sqlcImport)
+}
+
+func (repo *importRepo) SetProjectIDs(ctx context.Context, importID int, projectIDs map[string]int) error {
+	ids, err := json.Marshal(projectIDs)
+	if err != nil {
+		return err
+	}
+
+	return repo.queries.SetImportProjectIDs(ctx, sqlc.SetImportProjectIDsParams{
+		ID:         int32(importID),
+		ProjectIds: ids,
+	})
+}
+
+func (repo *importRepo) ImportTasks(ctx context.Context, importID int, tasks []*app.Task, processed int, leasedUntil time.Time, meta app.EventMeta) error {
+	return withTx(ctx, repo.conn, func(q *sqlc.Queries) error {
+		for _, task := range tasks {
+			if _, err := repo.tasks.createTask(ctx, q, task, meta); err != nil {
+				return err
+			}
+		}
+
+		return q.SetImportProgress(ctx, sqlc.SetImportProgressParams{
+			ID:          int32(importID),
+			Processed:   int32(processed),
+			LeasedUntil: pgtype.Timestamptz{Time: leasedUntil, Valid: true},
+		})
+	})
+}
+
+func (repo *importRepo) CompleteImport(ctx context.Context, importID int) (*app.Import, error) {
+	sqlcImport, err := repo.queries.CompleteImport(ctx, int32(importID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, app.ErrImportNotFound
+		}
+		return nil, err
+	}
+
+	return repo.toAppImport(&sqlcImport)
+}
+
+func (repo *importRepo) FailImport(ctx context.Context, importID int, message string) error {
+	return repo.queries.FailImport(ctx, sqlc.FailImportParams{
+		ID:    int32(importID),
+		Error: message,
+	})
+}
@@ -0,0 +1,59 @@
+package database
+
+import (
+	"context"
+
+	"github.com/ayo-awe/golang_todo_api/internal/app"
+	"github.com/ayo-awe/golang_todo_api/internal/database/sqlc"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"gopkg.in/guregu/null.v4"
+)
+
+type mentionRepo struct {
+	queries *sqlc.Queries
+	conn    *pgxpool.Pool
+}
+
+func NewMentionRepository(conn *pgxpool.Pool) app.MentionRepository {
+	return &mentionRepo{conn: conn, queries: sqlc.New(conn)}
+}
+
+func (repo *mentionRepo) SetMentions(ctx context.Context, taskID int, commentID null.Int, userIDs []int) ([]int, error) {
+	ids := make([]int32, len(userIDs))
+	for i, userID := range userIDs {
+		ids[i] = int32(userID)
+	}
+
+	var added []int
+	err := withTx(ctx, repo.conn, func(q *sqlc.Queries) error {
+		err := q.DeleteTaskMentions(ctx, sqlc.DeleteTaskMentionsParams{
+			TaskID:    int32(taskID),
+			CommentID: pgtype.Int4{Int32: int32(commentID.Int64), Valid: commentID.Valid},
+			UserIds:   ids,
+		})
+		if err != nil {
+			return err
+		}
+
+		// mentions that already exist are skipped, so only new ones are returned
+		newIDs, err := q.CreateTaskMentions(ctx, sqlc.CreateTaskMentionsParams{
+			TaskID:    int32(taskID),
+			CommentID: pgtype.Int4{Int32: int32(commentID.Int64), Valid: commentID.Valid},
+			UserIds:   ids,
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, id := range newIDs {
+			added = append(added, int(id))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return added, nil
+}
@@ -0,0 +1,143 @@
+package database
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ayo-awe/golang_todo_api/internal/app"
+	"github.com/ayo-awe/golang_todo_api/internal/database/sqlc"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"gopkg.in/guregu/null.v4"
+)
+
+type notificationRepo struct {
+	queries *sqlc.Queries
+	conn    *pgxpool.Pool
+}
+
+func NewNotificationRepository(conn *pgxpool.Pool) app.NotificationRepository {
+	return &notificationRepo{conn: conn, queries: sqlc.New(conn)}
+}
+
+func (repo *notificationRepo) toAppNotification(sqlcNotification *sqlc.Notification) *app.Notification {
+	return &app.Notification{
+		ID:        int(sqlcNotification.ID),
+		UserID:    int(sqlcNotification.UserID),
+		Type:      app.NotificationType(sqlcNotification.Type),
+		TaskID:    int(sqlcNotification.TaskID),
+		TaskTitle: sqlcNotification.TaskTitle,
+		ActorID:   null.NewInt(int64(sqlcNotification.ActorID.Int32), sqlcNotification.ActorID.Valid),
+		ReadAt:    null.NewTime(sqlcNotification.ReadAt.Time, sqlcNotification.ReadAt.Valid),
+		CreatedAt: sqlcNotification.CreatedAt.Time,
+	}
+}
+
+func (repo *notificationRepo) CreateNotification(ctx context.Context, notification *app.Notification) (*app.Notification, error) {
+	arg := sqlc.CreateNotificationParams{
+		UserID:    int32(notification.UserID),
+		Type:      string(notification.Type),
+		TaskID:    int32(notification.TaskID),
+		TaskTitle: notification.TaskTitle,
+		ActorID:   pgtype.Int4{Int32: int32(notification.ActorID.Int64), Valid: notification.ActorID.Valid},
+	}
+
+	sqlcNotification, err := repo.queries.CreateNotification(ctx, arg)
+	if err != nil {
+		return nil, err
+	}
+
+	return repo.toAppNotification(&sqlcNotification), nil
+}
+
+func (repo *notificationRepo) GetNotifications(ctx context.Context, userID int, unreadOnly bool, paging app.Paging) ([]app.Notification, app.PaginationData, error) {
+	arg := sqlc.GetNotificationsParams{
+		UserID:     int32(userID),
+		Cursor:     int32(paging.Cursor),
+		UnreadOnly: unreadOnly,
+		Limit:      int32(paging.Limit()),
+	}
+
+	sqlcNotifications, err := repo.queries.GetNotifications(ctx, arg)
+	if err != nil {
+		return nil, app.PaginationData{}, err
+	}
+
+	notifications := make([]app.Notification, len(sqlcNotifications))
+	for i, sqlcNotification := range sqlcNotifications {
+		notifications[i] = *repo.toAppNotification(&sqlcNotification)
+	}
+
+	notifications, paginationData := paginate(notifications, paging, func(n app.Notification) int { return n.ID })
+	return notifications, paginationData, nil
+}
+
+func (repo *notificationRepo) CountUnreadNotifications(ctx context.Context, userID int) (int, error) {
+	count, err := repo.queries.CountUnreadNotifications(ctx, int32(userID))
+	if err != nil {
+		return 0, err
+	}
+
+	return int(count), nil
+}
+
+func (repo *notificationRepo) MarkNotificationRead(ctx context.Context, userID int, notificationID int) (*app.Notification, error) {
+	sqlcNotification, err := repo.queries.MarkNotificationRead(ctx, sqlc.MarkNotificationReadParams{
+		UserID: int32(userID),
+		ID:     int32(notificationID),
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, app.ErrNotificationNotFound
+		}
+		return nil, err
+	}
+
+	return repo.toAppNotification(&sqlcNotification), nil
+}
+
+func (repo *notificationRepo) MarkAllNotificationsRead(ctx context.Context, userID int) (int, error) {
+	count, err := repo.queries.MarkAllNotificationsRead(ctx, int32(userID))
+	if err != nil {
+		return 0, err
+	}
+
+	return int(count), nil
+}
+
+func (repo *notificationRepo) GetNotificationPreferences(ctx context.Context, userID int) ([]app.NotificationPreference, error) {
+	sqlcPreferences, err := repo.queries.GetNotificationPreferences(ctx, int32(userID))
+	if err != nil {
+		return nil, err
+	}
+
+	preferences := make([]app.NotificationPreference, len(sqlcPreferences))
+	for i, sqlcPreference := range sqlcPreferences {
+		preferences[i] = app.NotificationPreference{
+			Type:    app.NotificationType(sqlcPreference.Type),
+			Channel: app.NotificationChannel(sqlcPreference.Channel),
+			Enabled: sqlcPreference.Enabled,
+		}
+	}
+
+	return preferences, nil
+}
+
+func (repo *notificationRepo) SetNotificationPreferences(ctx context.Context, userID int, preferences []app.NotificationPreference) error {
+	return withTx(ctx, repo.conn, func(q *sqlc.Queries) error {
+		for _, preference := range preferences {
+			err := q.SetNotificationPreference(ctx, sqlc.SetNotificationPreferenceParams{
+				UserID:  int32(userID),
+				Type:    string(preference.Type),
+				Channel: string(preference.Channel),
+				Enabled: preference.Enabled,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
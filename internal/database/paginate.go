@@ -0,0 +1,27 @@
+package database
+
+import (
+	"github.com/ayo-awe/golang_todo_api/internal/app"
+	"gopkg.in/guregu/null.v4"
+)
+
+// paginate trims the extra item fetched with paging.Limit() and uses it
+// as the cursor for the next page
+func paginate[T any](items []T, paging app.Paging, cursor func(T) int) ([]T, app.PaginationData) {
+	var nextCursor null.Int
+	if len(items) == paging.Limit() {
+		lastIndex := len(items) - 1
+		last := items[lastIndex]
+
+		nextCursor = null.IntFrom(int64(cursor(last)))
+		items = items[:lastIndex]
+	}
+
+	paginationData := app.PaginationData{
+		NextCursor: nextCursor,
+		ItemCount:  len(items),
+		PerPage:    paging.PerPage,
+	}
+
+	return items, paginationData
+}
@@ -0,0 +1,74 @@
+package database
+
+import (
+	"context"
+	"time"
+
+	"github.com/ayo-awe/golang_todo_api/internal/app"
+	"github.com/ayo-awe/golang_todo_api/internal/database/sqlc"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type presenceRepo struct {
+	queries *sqlc.Queries
+	conn    *pgxpool.Pool
+}
+
+func NewPresenceRepository(conn *pgxpool.Pool) app.PresenceRepository {
+	return &presenceRepo{conn: conn, queries: sqlc.New(conn)}
+}
+
+func (repo *presenceRepo) AddViewer(ctx context.Context, sessionID string, projectID int, userID int) error {
+	return repo.queries.AddProjectViewer(ctx, sqlc.AddProjectViewerParams{
+		SessionID: sessionID,
+		ProjectID: int32(projectID),
+		UserID:    int32(userID),
+	})
+}
+
+func (repo *presenceRepo) RemoveViewer(ctx context.Context, sessionID string, projectID int) error {
+	return repo.queries.RemoveProjectViewer(ctx, sqlc.RemoveProjectViewerParams{
+		SessionID: sessionID,
+		ProjectID: int32(projectID),
+	})
+}
+
+func (repo *presenceRepo) RemoveSession(ctx context.Context, sessionID string) error {
+	return repo.queries.RemoveSessionViewers(ctx, sessionID)
+}
+
+func (repo *presenceRepo) TouchSession(ctx context.Context, sessionID string) error {
+	return repo.queries.TouchSessionViewers(ctx, sessionID)
+}
+
+func (repo *presenceRepo) GetViewers(ctx context.Context, projectID int, seenAfter time.Time) ([]app.ProjectViewer, error) {
+	rows, err := repo.queries.GetProjectViewers(ctx, sqlc.GetProjectViewersParams{
+		ProjectID:  int32(projectID),
+		LastSeenAt: pgtype.Timestamptz{Time: seenAfter, Valid: true},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	viewers := make([]app.ProjectViewer, len(rows))
+	for i, row := range rows {
+		viewers[i] = app.ProjectViewer{
+			UserID:    int(row.ID),
+			Firstname: row.FirstName,
+			Lastname:  row.LastName,
+			Email:     row.Email,
+		}
+	}
+
+	return viewers, nil
+}
+
+func (repo *presenceRepo) DeleteStaleViewers(ctx context.Context, seenBefore time.Time) (int, error) {
+	count, err := repo.queries.DeleteStaleProjectViewers(ctx, pgtype.Timestamptz{Time: seenBefore, Valid: true})
+	if err != nil {
+		return 0, err
+	}
+
+	return int(count), nil
+}
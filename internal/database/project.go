@@ -0,0 +1,256 @@
+package database
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ayo-awe/golang_todo_api/internal/app"
+	"github.com/ayo-awe/golang_todo_api/internal/database/sqlc"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type projectRepo struct {
+	queries *sqlc.Queries
+	conn    *pgxpool.Pool
+}
+
+func NewProjectRepository(conn *pgxpool.Pool) app.ProjectRepository {
+	return &projectRepo{conn: conn, queries: sqlc.New(conn)}
+}
+
+func (repo *projectRepo) toAppProject(sqlcProject *sqlc.Project) *app.Project {
+	return &app.Project{
+		ID:          int(sqlcProject.ID),
+		UserID:      int(sqlcProject.UserID),
+		WorkspaceID: int(sqlcProject.WorkspaceID),
+		Name:        sqlcProject.Name,
+		Description: sqlcProject.Description,
+		CreatedAt:   sqlcProject.CreatedAt.Time,
+		UpdatedAt:   sqlcProject.UpdatedAt.Time,
+	}
+}
+
+func (repo *projectRepo) GetProjects(ctx context.Context, workspaceID int, userID int, paging app.Paging) ([]app.Project, app.PaginationData, error) {
+	arg := sqlc.GetProjectsParams{
+		WorkspaceID: int32(workspaceID),
+		UserID:      int32(userID),
+		Cursor:      int32(paging.Cursor),
+		Limit:       int32(paging.Limit()),
+	}
+
+	sqlcProjects, err := repo.queries.GetProjects(ctx, arg)
+	if err != nil {
+		return nil, app.PaginationData{}, err
+	}
+
+	projects := make([]app.Project, len(sqlcProjects))
+	for i, sqlcProject := range sqlcProjects {
+		projects[i] = *repo.toAppProject(&sqlcProject)
+	}
+
+	projects, paginationData := paginate(projects, paging, func(p app.Project) int { return p.ID })
+	return projects, paginationData, nil
+}
+
+func (repo *projectRepo) GetProjectByID(ctx context.Context, workspaceID int, projectID int) (*app.Project, error) {
+	sqlcProject, err := repo.queries.GetProjectByID(ctx, sqlc.GetProjectByIDParams{
+		WorkspaceID: int32(workspaceID),
+		ID:          int32(projectID),
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, app.ErrProjectNotFound
+		}
+		return nil, err
+	}
+
+	return repo.toAppProject(&sqlcProject), nil
+}
+
+func (repo *projectRepo) CreateProject(ctx context.Context, project *app.Project) (*app.Project, error) {
+	arg := sqlc.CreateProjectParams{
+		UserID:      int32(project.UserID),
+		Name:        project.Name,
+		Description: project.Description,
+		WorkspaceID: int32(project.WorkspaceID),
+	}
+
+	var newProject *app.Project
+	err := withTx(ctx, repo.conn, func(q *sqlc.Queries) error {
+		sqlcProject, err := q.CreateProject(ctx, arg)
+		if err != nil {
+			return err
+		}
+
+		newProject = repo.toAppProject(&sqlcProject)
+		_, err = q.AddProjectMember(ctx, sqlc.AddProjectMemberParams{
+			ProjectID: sqlcProject.ID,
+			UserID:    sqlcProject.UserID,
+			Role:      string(app.ProjectRoleOwner),
+		})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return newProject, nil
+}
+
+func (repo *projectRepo) UpdateProject(ctx context.Context, project *app.Project) (*app.Project, error) {
+	arg := sqlc.UpdateProjectParams{
+		ID:          int32(project.ID),
+		Name:        project.Name,
+		Description: project.Description,
+	}
+
+	sqlcProject, err := repo.queries.UpdateProject(ctx, arg)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, app.ErrProjectNotFound
+		}
+		return nil, err
+	}
+
+	return repo.toAppProject(&sqlcProject), nil
+}
+
+// DeleteProject deletes a project. Its tasks are kept and no longer belong to a project.
+// Tasks that used the statuses of the project's creator are moved into their own
+// creator's default status of the same category, and tasks assigned to someone
+// other than their creator are unassigned.
+func (repo *projectRepo) DeleteProject(ctx context.Context, projectID int) error {
+	return withTx(ctx, repo.conn, func(q *sqlc.Queries) error {
+		if err := q.CreateProjectDeletedEvents(ctx, int32(projectID)); err != nil {
+			return err
+		}
+
+		if err := q.ResetProjectTaskStatuses(ctx, int32(projectID)); err != nil {
+			return err
+		}
+
+		if err := q.UnassignProjectTasks(ctx, sqlc.UnassignProjectTasksParams{ProjectID: int32(projectID)}); err != nil {
+			return err
+		}
+
+		count, err := q.DeleteProject(ctx, int32(projectID))
+		if err != nil {
+			return err
+		}
+
+		if count == 0 {
+			return app.ErrProjectNotFound
+		}
+
+		return nil
+	})
+}
+
+func (repo *projectRepo) GetProjectMembers(ctx context.Context, projectID int) ([]app.ProjectMember, error) {
+	rows, err := repo.queries.GetProjectMembers(ctx, int32(projectID))
+	if err != nil {
+		return nil, err
+	}
+
+	members := make([]app.ProjectMember, len(rows))
+	for i, row := range rows {
+		members[i] = *repo.toAppProjectMember(sqlc.AddProjectMemberRow(row))
+	}
+
+	return members, nil
+}
+
+func (repo *projectRepo) toAppProjectMember(row sqlc.AddProjectMemberRow) *app.ProjectMember {
+	return &app.ProjectMember{
+		ProjectID: int(row.ProjectID),
+		UserID:    int(row.UserID),
+		Email:     row.Email,
+		Firstname: row.FirstName,
+		Lastname:  row.LastName,
+		Role:      app.ProjectRole(row.Role),
+		CreatedAt: row.CreatedAt.Time,
+	}
+}
+
+func (repo *projectRepo) GetProjectRole(ctx context.Context, projectID int, userID int) (app.ProjectRole, error) {
+	arg := sqlc.GetProjectMemberRoleParams{
+		ProjectID: int32(projectID),
+		UserID:    int32(userID),
+	}
+
+	role, err := repo.queries.GetProjectMemberRole(ctx, arg)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", app.ErrMemberNotFound
+		}
+		return "", err
+	}
+
+	return app.ProjectRole(role), nil
+}
+
+func (repo *projectRepo) AddProjectMember(ctx context.Context, projectID int, userID int, role app.ProjectRole) (*app.ProjectMember, error) {
+	arg := sqlc.AddProjectMemberParams{
+		ProjectID: int32(projectID),
+		UserID:    int32(userID),
+		Role:      string(role),
+	}
+
+	row, err := repo.queries.AddProjectMember(ctx, arg)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return nil, app.ErrMemberExists
+		}
+		return nil, err
+	}
+
+	return repo.toAppProjectMember(row), nil
+}
+
+func (repo *projectRepo) RemoveProjectMember(ctx context.Context, projectID int, userID int) error {
+	return withTx(ctx, repo.conn, func(q *sqlc.Queries) error {
+		// serializes removals so that two owners can't remove each other at once
+		if err := q.LockProjectMembers(ctx, int32(projectID)); err != nil {
+			return err
+		}
+
+		arg := sqlc.RemoveProjectMemberParams{
+			ProjectID: int32(projectID),
+			UserID:    int32(userID),
+		}
+
+		count, err := q.RemoveProjectMember(ctx, arg)
+		if err != nil {
+			return err
+		}
+
+		if count == 0 {
+			return app.ErrMemberNotFound
+		}
+
+		owners, err := q.CountProjectOwners(ctx, int32(projectID))
+		if err != nil {
+			return err
+		}
+
+		if owners == 0 {
+			return app.ErrLastOwner
+		}
+
+		// former members can't see the project's tasks anymore
+		err = q.UnassignProjectTasks(ctx, sqlc.UnassignProjectTasksParams{
+			ProjectID:  int32(projectID),
+			AssigneeID: pgtype.Int4{Int32: int32(userID), Valid: true},
+		})
+		if err != nil {
+			return err
+		}
+
+		return q.CreateAccessLostEvents(ctx, sqlc.CreateAccessLostEventsParams{
+			ViewerID:  int32(userID),
+			ProjectID: int32(projectID),
+		})
+	})
+}
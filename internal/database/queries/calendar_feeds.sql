@@ -0,0 +1,13 @@
+-- name: SetCalendarFeedToken :exec
+INSERT INTO "calendar_feeds" (user_id, workspace_id, token_hash) VALUES
+($1,$2,$3)
+ON CONFLICT (user_id, workspace_id) DO UPDATE
+SET token_hash = EXCLUDED.token_hash, created_at = CURRENT_TIMESTAMP;
+
+-- name: GetCalendarFeedByTokenHash :one
+SELECT * FROM "calendar_feeds"
+WHERE token_hash = $1;
+
+-- name: DeleteCalendarFeed :execrows
+DELETE FROM "calendar_feeds"
+WHERE user_id = $1 AND workspace_id = $2;
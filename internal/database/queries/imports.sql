@@ -0,0 +1,40 @@
+-- name: CreateImport :one
+INSERT INTO "imports" (user_id, workspace_id, format, data, total) VALUES
+($1,$2,$3,$4,$5) RETURNING *;
+
+-- name: GetImport :one
+SELECT * FROM "imports"
+WHERE user_id = $1 AND id = $2;
+
+-- name: ClaimImport :one
+UPDATE "imports"
+SET status = 'running', leased_until = sqlc.arg('leased_until'), updated_at = CURRENT_TIMESTAMP
+WHERE id = (
+	SELECT id FROM "imports"
+	WHERE status IN ('pending', 'running') AND (leased_until IS NULL OR leased_until < CURRENT_TIMESTAMP)
+	ORDER BY id
+	LIMIT 1
+	FOR UPDATE SKIP LOCKED
+)
+RETURNING *;
+
+-- name: SetImportProjectIDs :exec
+UPDATE "imports"
+SET project_ids = $2, updated_at = CURRENT_TIMESTAMP
+WHERE id = $1;
+
+-- name: SetImportProgress :exec
+UPDATE "imports"
+SET processed = $2, leased_until = $3, updated_at = CURRENT_TIMESTAMP
+WHERE id = $1;
+
+-- name: CompleteImport :one
+UPDATE "imports"
+SET status = 'completed', leased_until = NULL, updated_at = CURRENT_TIMESTAMP, completed_at = CURRENT_TIMESTAMP
+WHERE id = $1
+RETURNING *;
+
+-- name: FailImport :exec
+UPDATE "imports"
+SET status = 'failed', error = $2, leased_until = NULL, updated_at = CURRENT_TIMESTAMP, completed_at = CURRENT_TIMESTAMP
+WHERE id = $1;
@@ -0,0 +1,51 @@
+-- name: CreateNotification :one
+INSERT INTO "notifications" (user_id, type, task_id, task_title, actor_id) VALUES
+($1,$2,$3,$4,$5) RETURNING *;
+
+-- name: GetNotifications :many
+SELECT * FROM "notifications"
+WHERE user_id = sqlc.arg('user_id') AND id <= sqlc.arg('cursor') AND (read_at IS NULL OR NOT sqlc.arg('unread_only')::bool)
+ORDER BY id DESC
+LIMIT sqlc.arg('limit');
+
+-- name: CountUnreadNotifications :one
+SELECT count(*) FROM "notifications"
+WHERE user_id = $1 AND read_at IS NULL;
+
+-- name: MarkNotificationRead :one
+UPDATE "notifications"
+SET read_at = COALESCE(read_at, CURRENT_TIMESTAMP)
+WHERE user_id = $1 AND id = $2
+RETURNING *;
+
+-- name: MarkAllNotificationsRead :execrows
+UPDATE "notifications"
+SET read_at = CURRENT_TIMESTAMP
+WHERE user_id = $1 AND read_at IS NULL;
+
+-- name: GetNotificationPreferences :many
+SELECT * FROM "notification_preferences"
+WHERE user_id = $1
+ORDER BY type, channel;
+
+-- name: SetNotificationPreference :exec
+INSERT INTO "notification_preferences" (user_id, type, channel, enabled) VALUES
+($1,$2,$3,$4)
+ON CONFLICT (user_id, type, channel) DO UPDATE
+SET enabled = EXCLUDED.enabled,
+	updated_at = CURRENT_TIMESTAMP;
+
+-- name: ClaimTaskReminders :many
+-- ClaimTaskReminders claims the reminders of pending tasks due in the window,
+-- a reminder already claimed for a task's due date is never returned again.
+WITH claimed AS (
+	INSERT INTO "task_reminders" (task_id, due_at)
+	SELECT id, due_at FROM "tasks"
+	WHERE due_at > sqlc.arg('due_after') AND due_at <= sqlc.arg('due_before')
+		AND NOT is_completed AND deleted_at IS NULL AND archived_at IS NULL
+	ON CONFLICT DO NOTHING
+	RETURNING task_id
+)
+SELECT "tasks".* FROM "tasks"
+JOIN claimed ON claimed.task_id = "tasks".id
+ORDER BY "tasks".id;
@@ -0,0 +1,28 @@
+-- name: AddProjectViewer :exec
+INSERT INTO "project_viewers" (session_id, project_id, user_id) VALUES
+($1,$2,$3)
+ON CONFLICT (session_id, project_id) DO UPDATE
+SET last_seen_at = CURRENT_TIMESTAMP;
+
+-- name: RemoveProjectViewer :exec
+DELETE FROM "project_viewers"
+WHERE session_id = $1 AND project_id = $2;
+
+-- name: RemoveSessionViewers :exec
+DELETE FROM "project_viewers"
+WHERE session_id = $1;
+
+-- name: TouchSessionViewers :exec
+UPDATE "project_viewers"
+SET last_seen_at = CURRENT_TIMESTAMP
+WHERE session_id = $1;
+
+-- name: GetProjectViewers :many
+SELECT DISTINCT u.id, u.first_name, u.last_name, u.email FROM "project_viewers" v
+JOIN "users" u ON u.id = v.user_id
+WHERE v.project_id = $1 AND v.last_seen_at > $2
+ORDER BY u.id;
+
+-- name: DeleteStaleProjectViewers :execrows
+DELETE FROM "project_viewers"
+WHERE last_seen_at <= $1;
@@ -0,0 +1,73 @@
+-- name: CreateProject :one
+INSERT INTO "projects" (user_id, name, description, workspace_id) VALUES
+($1,$2,$3,$4) RETURNING *;
+
+-- name: GetProjects :many
+SELECT * FROM "projects"
+WHERE workspace_id = sqlc.arg('workspace_id') AND (id IN (SELECT project_id FROM "project_members" WHERE user_id = sqlc.arg('user_id')) OR EXISTS (SELECT 1 FROM "workspace_members" WHERE workspace_id = sqlc.arg('workspace_id') AND user_id = sqlc.arg('user_id') AND role IN ('admin', 'owner'))) AND id <= sqlc.arg('cursor')
+ORDER BY id DESC
+LIMIT sqlc.arg('limit');
+
+-- name: GetProjectByID :one
+SELECT * FROM "projects"
+WHERE workspace_id = $1 AND id = $2;
+
+-- name: UpdateProject :one
+UPDATE "projects"
+SET name = $2,
+	description = $3,
+	updated_at = CURRENT_TIMESTAMP
+WHERE id = $1
+RETURNING *;
+
+-- name: ResetProjectTaskStatuses :exec
+UPDATE "tasks" AS t
+SET status_id = (
+	SELECT d.id FROM "task_statuses" d
+	WHERE d.user_id = t.user_id AND d.workspace_id = t.workspace_id AND d.category = s.category
+	ORDER BY d.position, d.id
+	LIMIT 1
+)
+FROM "task_statuses" AS s
+WHERE t.project_id = $1 AND s.id = t.status_id AND s.user_id <> t.user_id;
+
+-- name: UnassignProjectTasks :exec
+UPDATE "tasks"
+SET assignee_id = NULL
+WHERE project_id = sqlc.arg('project_id') AND assignee_id IS NOT NULL
+	AND (assignee_id = sqlc.narg('assignee_id') OR (sqlc.narg('assignee_id')::int IS NULL AND assignee_id <> user_id));
+
+-- name: DeleteProject :execrows
+DELETE FROM "projects"
+WHERE id = $1;
+
+-- name: GetProjectMembers :many
+SELECT m.project_id, m.user_id, u.email, u.first_name, u.last_name, m.role, m.created_at
+FROM "project_members" m
+JOIN "users" u ON u.id = m.user_id
+WHERE m.project_id = $1
+ORDER BY m.created_at, m.user_id;
+
+-- name: GetProjectMemberRole :one
+SELECT role FROM "project_members"
+WHERE project_id = $1 AND user_id = $2;
+
+-- name: AddProjectMember :one
+WITH member AS (
+	INSERT INTO "project_members" (project_id, user_id, role) VALUES
+	($1,$2,$3) RETURNING *
+)
+SELECT m.project_id, m.user_id, u.email, u.first_name, u.last_name, m.role, m.created_at
+FROM member m
+JOIN "users" u ON u.id = m.user_id;
+
+-- name: RemoveProjectMember :execrows
+DELETE FROM "project_members"
+WHERE project_id = $1 AND user_id = $2;
+
+-- name: LockProjectMembers :exec
+SELECT pg_advisory_xact_lock(hashtext('project_members'), sqlc.arg('project_id')::int);
+
+-- name: CountProjectOwners :one
+SELECT count(*) FROM "project_members"
+WHERE project_id = $1 AND role = 'owner';
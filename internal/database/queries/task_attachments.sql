@@ -0,0 +1,33 @@
+-- name: CreateTaskAttachment :one
+INSERT INTO "task_attachments" (task_id, user_id, filename, content_type, size, blob_key) VALUES
+($1,$2,$3,$4,$5,$6) RETURNING *;
+
+-- name: GetTaskAttachments :many
+SELECT * FROM "task_attachments"
+WHERE task_id = sqlc.arg('task_id') AND id <= sqlc.arg('cursor')
+ORDER BY id DESC
+LIMIT sqlc.arg('limit');
+
+-- name: GetTaskAttachmentByID :one
+SELECT * FROM "task_attachments"
+WHERE task_id = $1 AND id = $2;
+
+-- name: DeleteTaskAttachment :execrows
+DELETE FROM "task_attachments"
+WHERE task_id = $1 AND id = $2;
+
+-- name: LockUserStorage :exec
+SELECT pg_advisory_xact_lock(hashtext('storage'), sqlc.arg('user_id')::int);
+
+-- name: GetUserStorageUsage :one
+SELECT COALESCE(SUM(size), 0)::bigint AS usage FROM "task_attachments"
+WHERE user_id = $1;
+
+-- name: GetOrphanedBlobs :many
+SELECT blob_key FROM "orphaned_blobs"
+ORDER BY created_at
+LIMIT $1;
+
+-- name: DeleteOrphanedBlobs :exec
+DELETE FROM "orphaned_blobs"
+WHERE blob_key = ANY(sqlc.arg('blob_keys')::text[]);
@@ -0,0 +1,30 @@
+-- name: CreateTaskComment :one
+INSERT INTO "task_comments" (task_id, user_id, body) VALUES
+($1,$2,$3) RETURNING *;
+
+-- name: GetTaskComments :many
+SELECT * FROM "task_comments"
+WHERE task_id = sqlc.arg('task_id') AND id <= sqlc.arg('cursor')
+ORDER BY id DESC
+LIMIT sqlc.arg('limit');
+
+-- name: GetTaskCommentByID :one
+SELECT * FROM "task_comments"
+WHERE task_id = $1 AND id = $2;
+
+-- name: UpdateTaskComment :one
+UPDATE "task_comments"
+SET body = $3,
+	edited_at = CURRENT_TIMESTAMP,
+	updated_at = CURRENT_TIMESTAMP
+WHERE task_id = $1 AND id = $2
+RETURNING *;
+
+-- name: DeleteTaskComment :execrows
+DELETE FROM "task_comments"
+WHERE task_id = $1 AND id = $2;
+
+-- name: AddTaskCommentCount :exec
+UPDATE "tasks"
+SET comment_count = comment_count + sqlc.arg('delta')::int
+WHERE id = sqlc.arg('id');
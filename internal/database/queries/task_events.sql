@@ -0,0 +1,85 @@
+-- name: CreateTaskEvent :one
+INSERT INTO "task_events" (task_id, user_id, actor_id, action, changes, request_id, workspace_id, project_id, previous_project_id)
+VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9) RETURNING *;
+
+-- name: CreateAccessLostEvents :exec
+-- CreateAccessLostEvents tells viewer_id about each of the project's tasks
+-- they can't see anymore, once they were removed from the project
+INSERT INTO "task_events" (task_id, user_id, action, workspace_id, project_id, previous_project_id, viewer_id)
+SELECT id, user_id, 'access_changed', workspace_id, project_id, project_id, sqlc.arg('viewer_id')::int
+FROM "tasks"
+WHERE project_id = sqlc.arg('project_id')::int AND NOT task_visible_to(workspace_id, project_id, user_id, sqlc.arg('viewer_id')::int);
+
+-- name: CreateProjectDeletedEvents :exec
+-- CreateProjectDeletedEvents tells everyone who can see the project's tasks
+-- that they are leaving the project, before it is deleted. Only their
+-- creators can see them afterwards.
+INSERT INTO "task_events" (task_id, user_id, action, workspace_id, previous_project_id, viewer_id)
+SELECT t.id, t.user_id, 'access_changed', t.workspace_id, t.project_id, m.user_id
+FROM "tasks" t
+JOIN "workspace_members" m ON m.workspace_id = t.workspace_id
+WHERE t.project_id = sqlc.arg('project_id')::int AND task_visible_to(t.workspace_id, t.project_id, t.user_id, m.user_id);
+
+-- name: GetTaskEvents :many
+SELECT * FROM "task_events"
+WHERE task_id = sqlc.arg('task_id') AND workspace_id = sqlc.arg('workspace_id') AND viewer_id IS NULL AND id <= sqlc.arg('cursor')
+ORDER BY id DESC
+LIMIT sqlc.arg('limit');
+
+-- name: GetUndoableTaskEvents :many
+SELECT * FROM "task_events"
+WHERE actor_id = sqlc.arg('actor_id') AND reverted_by IS NULL AND action IN ('updated', 'deleted', 'archived', 'unarchived') AND id <= sqlc.arg('cursor')
+	AND workspace_id = sqlc.arg('workspace_id')
+	-- changes to a task that was changed again since can't be undone
+	AND NOT EXISTS (
+		SELECT 1 FROM "task_events" newer
+		WHERE newer.task_id = task_events.task_id AND newer.id > task_events.id AND newer.reverted_by IS NULL AND newer.action <> 'reverted' AND newer.viewer_id IS NULL
+	)
+ORDER BY id DESC
+LIMIT sqlc.arg('limit');
+
+-- name: GetLatestUndoableTaskEvent :one
+SELECT * FROM "task_events"
+WHERE actor_id = sqlc.arg('actor_id') AND (task_id = sqlc.narg('task_id') OR sqlc.narg('task_id') IS NULL) AND reverted_by IS NULL AND action IN ('updated', 'deleted', 'archived', 'unarchived')
+	AND NOT EXISTS (
+		SELECT 1 FROM "task_events" newer
+		WHERE newer.task_id = task_events.task_id AND newer.id > task_events.id AND newer.reverted_by IS NULL AND newer.action <> 'reverted' AND newer.viewer_id IS NULL
+	)
+ORDER BY id DESC
+LIMIT 1
+FOR UPDATE;
+
+-- name: HasNewerTaskEvents :one
+SELECT EXISTS (
+	SELECT 1 FROM "task_events"
+	WHERE task_id = $1 AND id > $2 AND reverted_by IS NULL AND action <> 'reverted' AND viewer_id IS NULL
+);
+
+-- name: SetTaskEventRevertedBy :exec
+UPDATE "task_events"
+SET reverted_by = $2
+WHERE id = $1;
+
+-- name: GetTaskEventsAfter :many
+-- GetTaskEventsAfter returns the events of the tasks the user can see where
+-- they were, or where they were moved from, when the events were recorded,
+-- along with the events meant for the user
+SELECT * FROM "task_events"
+WHERE workspace_id = sqlc.arg('workspace_id')
+	AND (xact_id, id) > (sqlc.arg('after_xact_id')::bigint, sqlc.arg('after_id')::int)
+	AND (xact_id, id) <= (sqlc.arg('up_to_xact_id')::bigint, sqlc.arg('up_to_id')::int)
+	AND (
+		viewer_id = sqlc.arg('user_id')::int
+		OR (viewer_id IS NULL AND (
+			task_visible_to(workspace_id, project_id, user_id, sqlc.arg('user_id'))
+			OR task_visible_to(workspace_id, previous_project_id, user_id, sqlc.arg('user_id'))
+		))
+	)
+ORDER BY xact_id, id
+LIMIT sqlc.arg('limit');
+
+-- name: GetTaskEventWatermark :one
+-- GetTaskEventWatermark returns the oldest transaction still running. Every
+-- event recorded by a transaction before it has committed, and events recorded
+-- from now on belong to it or to a later transaction.
+SELECT pg_snapshot_xmin(pg_current_snapshot())::text::bigint AS xact_id;
@@ -0,0 +1,9 @@
+-- name: DeleteTaskMentions :exec
+DELETE FROM "task_mentions"
+WHERE task_id = sqlc.arg('task_id') AND comment_id IS NOT DISTINCT FROM sqlc.narg('comment_id')::int AND NOT (user_id = ANY(sqlc.arg('user_ids')::int[]));
+
+-- name: CreateTaskMentions :many
+INSERT INTO "task_mentions" (task_id, comment_id, user_id)
+SELECT sqlc.arg('task_id'), sqlc.narg('comment_id')::int, unnest(sqlc.arg('user_ids')::int[])
+ON CONFLICT DO NOTHING
+RETURNING user_id;
@@ -0,0 +1,59 @@
+-- name: CreateDefaultTaskStatuses :exec
+-- CreateDefaultTaskStatuses gives a user the default statuses in a workspace,
+-- unless they have statuses there from an earlier membership.
+INSERT INTO "task_statuses" (user_id, workspace_id, name, category, position)
+SELECT sqlc.arg('user_id')::int, sqlc.arg('workspace_id')::int, s.name, s.category, s.position
+FROM (VALUES
+	('To Do', 'todo', 0),
+	('In Progress', 'in_progress', 1),
+	('In Review', 'in_progress', 2),
+	('Done', 'done', 3),
+	('Won''t Do', 'cancelled', 4)
+) AS s(name, category, position)
+WHERE NOT EXISTS (
+	SELECT 1 FROM "task_statuses"
+	WHERE user_id = sqlc.arg('user_id') AND workspace_id = sqlc.arg('workspace_id')
+);
+
+-- name: GetTaskStatuses :many
+SELECT * FROM "task_statuses"
+WHERE workspace_id = $1 AND user_id = $2
+ORDER BY position, id;
+
+-- name: GetTaskStatusByID :one
+SELECT * FROM "task_statuses"
+WHERE workspace_id = $1 AND user_id = $2 AND id = $3;
+
+-- name: GetTaskStatusesByIDs :many
+SELECT * FROM "task_statuses"
+WHERE workspace_id = sqlc.arg('workspace_id') AND id = ANY(sqlc.arg('ids')::int[]);
+
+-- name: GetDefaultTaskStatus :one
+SELECT * FROM "task_statuses"
+WHERE workspace_id = $1 AND user_id = $2 AND category = $3
+ORDER BY position, id
+LIMIT 1;
+
+-- name: CreateTaskStatus :one
+INSERT INTO "task_statuses" (user_id, workspace_id, name, category, position) VALUES
+($1,$2,$3,$4,$5) RETURNING *;
+
+-- name: UpdateTaskStatus :one
+UPDATE "task_statuses"
+SET name = $4,
+	position = $5,
+	updated_at = CURRENT_TIMESTAMP
+WHERE workspace_id = $1 AND user_id = $2 AND id = $3
+RETURNING *;
+
+-- name: DeleteTaskStatus :exec
+DELETE FROM "task_statuses"
+WHERE workspace_id = $1 AND user_id = $2 AND id = $3;
+
+-- name: CountTasksWithStatus :one
+SELECT count(*) FROM "tasks"
+WHERE status_id = $1;
+
+-- name: CountTaskStatusesInCategory :one
+SELECT count(*) FROM "task_statuses"
+WHERE workspace_id = $1 AND user_id = $2 AND category = $3;
@@ -0,0 +1,22 @@
+-- name: CreateTaskTemplate :one
+INSERT INTO "task_templates" (user_id, workspace_id, name, task) VALUES
+($1,$2,$3,$4) RETURNING *;
+
+-- name: GetTaskTemplates :many
+SELECT * FROM "task_templates"
+WHERE user_id = $1 AND workspace_id = $2
+ORDER BY name, id;
+
+-- name: GetTaskTemplateByID :one
+SELECT * FROM "task_templates"
+WHERE user_id = $1 AND workspace_id = $2 AND id = $3;
+
+-- name: UpdateTaskTemplate :one
+UPDATE "task_templates"
+SET name = $4, task = $5, updated_at = CURRENT_TIMESTAMP
+WHERE user_id = $1 AND workspace_id = $2 AND id = $3
+RETURNING *;
+
+-- name: DeleteTaskTemplate :execrows
+DELETE FROM "task_templates"
+WHERE user_id = $1 AND workspace_id = $2 AND id = $3;
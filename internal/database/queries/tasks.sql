@@ -1,27 +1,181 @@
 -- name: CreateTask :one
-INSERT INTO "tasks" (title, description, user_id) VALUES
-($1,$2,$3) RETURNING *;
+INSERT INTO "tasks" (title, description, user_id, status_id, position, project_id, assignee_id, workspace_id, due_at, priority, tags, recurrence, uid, parent_id) VALUES
+($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$14) RETURNING *;
 
 -- name: GetTasks :many
-SELECT * FROM "tasks"
-WHERE user_id = sqlc.arg('user_id') AND id <= sqlc.arg('cursor') AND (is_completed = sqlc.narg('is_completed') OR sqlc.narg('is_completed') IS NULL)
+SELECT * FROM visible_tasks(sqlc.arg('workspace_id'), sqlc.arg('user_id'))
+WHERE id <= sqlc.arg('cursor') AND deleted_at IS NULL AND (archived_at IS NOT NULL) = sqlc.arg('is_archived')::bool AND (is_completed = sqlc.narg('is_completed') OR sqlc.narg('is_completed') IS NULL)
+	AND (status_id = sqlc.narg('status_id') OR sqlc.narg('status_id') IS NULL)
+	AND (status_id IN (SELECT id FROM "task_statuses" WHERE category = sqlc.narg('status_category')) OR sqlc.narg('status_category')::text IS NULL)
+	AND (project_id = sqlc.narg('project_id') OR sqlc.narg('project_id') IS NULL)
+	AND (assignee_id = sqlc.narg('assignee_id') OR sqlc.narg('assignee_id') IS NULL)
+	AND (id IN (SELECT task_id FROM "task_mentions" WHERE user_id = sqlc.narg('mentioned_user_id')) OR sqlc.narg('mentioned_user_id')::int IS NULL)
+	AND (sqlc.narg('tag')::text = ANY(tags) OR sqlc.narg('tag')::text IS NULL)
 ORDER BY id DESC
 LIMIT sqlc.arg('limit');
 
+-- name: GetTasksByPosition :many
+SELECT * FROM visible_tasks(sqlc.arg('workspace_id'), sqlc.arg('user_id'))
+WHERE deleted_at IS NULL AND (archived_at IS NOT NULL) = sqlc.arg('is_archived')::bool AND (is_completed = sqlc.narg('is_completed') OR sqlc.narg('is_completed') IS NULL)
+	AND (status_id = sqlc.narg('status_id') OR sqlc.narg('status_id') IS NULL)
+	AND (status_id IN (SELECT id FROM "task_statuses" WHERE category = sqlc.narg('status_category')) OR sqlc.narg('status_category')::text IS NULL)
+	AND (project_id = sqlc.narg('project_id') OR sqlc.narg('project_id') IS NULL)
+	AND (assignee_id = sqlc.narg('assignee_id') OR sqlc.narg('assignee_id') IS NULL)
+	AND (id IN (SELECT task_id FROM "task_mentions" WHERE user_id = sqlc.narg('mentioned_user_id')) OR sqlc.narg('mentioned_user_id')::int IS NULL)
+	AND (sqlc.narg('tag')::text = ANY(tags) OR sqlc.narg('tag')::text IS NULL)
+	AND (position, id) >= (COALESCE(sqlc.narg('cursor_position')::text, (SELECT position FROM "tasks" WHERE id = sqlc.arg('cursor')), ''), sqlc.arg('cursor'))
+ORDER BY position, id
+LIMIT sqlc.arg('limit');
+
 -- name: GetTaskByID :one
 SELECT * FROM "tasks"
-WHERE user_id = $1 AND id = $2;
+WHERE workspace_id = $1 AND id = $2;
+
+-- name: GetTaskForUpdate :one
+SELECT * FROM "tasks"
+WHERE workspace_id = $1 AND id = $2
+FOR UPDATE;
 
 -- name: UpdateTask :one
 UPDATE "tasks"
 SET	title = $2,
 	description = $3,
-	is_completed = $4,
+	status_id = $4,
+	deleted_at = $5,
+	archived_at = $6,
+	project_id = $7,
+	assignee_id = $8,
+	due_at = $9,
+	priority = $10,
+	tags = $11,
+	recurrence = $12,
 	updated_at = CURRENT_TIMESTAMP
 WHERE id = $1
 RETURNING *;
 
 
--- name: DeleteTask :exec
+-- name: DeleteTask :one
+UPDATE "tasks"
+SET deleted_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND deleted_at IS NULL
+RETURNING *;
+
+-- name: GetDeletedTasks :many
+SELECT * FROM visible_tasks(sqlc.arg('workspace_id'), sqlc.arg('user_id'))
+WHERE id <= sqlc.arg('cursor') AND deleted_at IS NOT NULL
+ORDER BY id DESC
+LIMIT sqlc.arg('limit');
+
+-- name: RestoreTask :one
+UPDATE "tasks"
+SET deleted_at = NULL,
+	updated_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND deleted_at IS NOT NULL
+RETURNING *;
+
+-- name: PurgeTask :one
 DELETE FROM "tasks"
-WHERE id = $1 AND user_id = $2;
+WHERE id = $1 AND deleted_at IS NOT NULL
+RETURNING *;
+
+-- name: GetExpiredDeletedTasksForUpdate :many
+-- GetExpiredDeletedTasksForUpdate locks a batch of the tasks trashed before
+-- deleted_before, skipping those another transaction is changing.
+SELECT * FROM "tasks"
+WHERE deleted_at < sqlc.arg('deleted_before')
+ORDER BY id
+LIMIT sqlc.arg('limit')
+FOR UPDATE SKIP LOCKED;
+
+-- name: ArchiveTask :one
+UPDATE "tasks"
+SET archived_at = COALESCE(archived_at, CURRENT_TIMESTAMP),
+	updated_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND deleted_at IS NULL
+RETURNING *;
+
+-- name: UnarchiveTask :one
+UPDATE "tasks"
+SET archived_at = NULL,
+	updated_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND deleted_at IS NULL
+RETURNING *;
+
+-- name: GetExpiredCompletedTasksForUpdate :many
+-- GetExpiredCompletedTasksForUpdate locks a batch of the unarchived tasks
+-- completed before completed_before, skipping those another transaction is changing.
+SELECT * FROM "tasks"
+WHERE is_completed AND completed_at < sqlc.arg('completed_before') AND archived_at IS NULL AND deleted_at IS NULL
+ORDER BY id
+LIMIT sqlc.arg('limit')
+FOR UPDATE SKIP LOCKED;
+
+-- name: GetLastTaskPosition :one
+SELECT position FROM "tasks"
+WHERE project_id IS NOT DISTINCT FROM sqlc.narg('project_id')::int AND (sqlc.narg('project_id')::int IS NOT NULL OR (user_id = sqlc.arg('user_id') AND workspace_id = sqlc.arg('workspace_id'))) AND deleted_at IS NULL
+ORDER BY position DESC, id DESC
+LIMIT 1;
+
+-- name: GetNextTaskPosition :one
+SELECT position FROM "tasks"
+WHERE project_id IS NOT DISTINCT FROM sqlc.narg('project_id')::int AND (sqlc.narg('project_id')::int IS NOT NULL OR (user_id = sqlc.arg('user_id') AND workspace_id = sqlc.arg('workspace_id'))) AND id <> sqlc.arg('task_id') AND (position, id) > (sqlc.arg('position')::text, sqlc.arg('neighbour_id')::int)
+ORDER BY position, id
+LIMIT 1;
+
+-- name: GetPreviousTaskPosition :one
+SELECT position FROM "tasks"
+WHERE project_id IS NOT DISTINCT FROM sqlc.narg('project_id')::int AND (sqlc.narg('project_id')::int IS NOT NULL OR (user_id = sqlc.arg('user_id') AND workspace_id = sqlc.arg('workspace_id'))) AND id <> sqlc.arg('task_id') AND (position, id) < (sqlc.arg('position')::text, sqlc.arg('neighbour_id')::int)
+ORDER BY position DESC, id DESC
+LIMIT 1;
+
+-- name: SetTaskPosition :one
+UPDATE "tasks"
+SET position = sqlc.arg('position'),
+	status_id = COALESCE(sqlc.narg('status_id'), status_id),
+	updated_at = CURRENT_TIMESTAMP
+WHERE workspace_id = sqlc.arg('workspace_id') AND id = sqlc.arg('id') AND deleted_at IS NULL
+RETURNING *;
+
+-- name: GetListTasksForUpdate :many
+SELECT * FROM "tasks"
+WHERE project_id IS NOT DISTINCT FROM sqlc.narg('project_id')::int AND (sqlc.narg('project_id')::int IS NOT NULL OR (user_id = sqlc.arg('user_id') AND workspace_id = sqlc.arg('workspace_id')))
+ORDER BY position, id
+FOR UPDATE;
+
+-- name: SetTaskPositions :many
+UPDATE "tasks"
+SET position = p.position,
+	updated_at = CURRENT_TIMESTAMP
+FROM unnest(sqlc.arg('ids')::int[], sqlc.arg('positions')::text[]) AS p(id, position)
+WHERE "tasks".id = p.id
+RETURNING "tasks".*;
+
+-- name: GetTasksByIDs :many
+SELECT * FROM visible_tasks(sqlc.arg('workspace_id'), sqlc.arg('user_id'))
+WHERE id = ANY(sqlc.arg('ids')::int[]);
+
+-- name: GetTaskByUID :one
+SELECT * FROM "tasks"
+WHERE workspace_id = $1 AND uid = $2;
+
+-- name: GetCalendarTasks :many
+SELECT * FROM visible_tasks(sqlc.arg('workspace_id'), sqlc.arg('user_id'))
+WHERE deleted_at IS NULL AND archived_at IS NULL AND due_at IS NOT NULL
+	AND (NOT is_completed OR due_at >= sqlc.arg('completed_due_after'))
+ORDER BY due_at, id
+LIMIT sqlc.arg('limit');
+
+-- name: GetSyncTasks :many
+SELECT * FROM visible_tasks(sqlc.arg('workspace_id'), sqlc.arg('user_id'))
+WHERE deleted_at IS NULL AND id > sqlc.arg('after_id')
+ORDER BY id
+LIMIT sqlc.arg('limit');
+
+-- name: GetSubtasks :many
+SELECT * FROM "tasks"
+WHERE workspace_id = $1 AND parent_id = $2 AND deleted_at IS NULL
+ORDER BY position, id;
+
+-- name: CountSubtasks :one
+SELECT count(*) FROM "tasks"
+WHERE workspace_id = $1 AND parent_id = $2;
@@ -4,3 +4,6 @@ VALUES ($1,$2,$3,$4) RETURNING *;
 
 -- name: GetUserByEmail :one
 SELECT * FROM users WHERE email ILIKE $1;
+
+-- name: GetUsersByEmails :many
+SELECT * FROM users WHERE lower(email) = ANY(sqlc.arg('emails')::text[]);
@@ -0,0 +1,62 @@
+-- name: CreateWebhook :one
+INSERT INTO "webhooks" (user_id, workspace_id, url, secret, event_types) VALUES
+($1,$2,$3,$4,$5) RETURNING *;
+
+-- name: GetWebhooks :many
+SELECT * FROM "webhooks"
+WHERE workspace_id = $1 AND user_id = $2
+ORDER BY id;
+
+-- name: GetWebhookByID :one
+SELECT * FROM "webhooks"
+WHERE workspace_id = $1 AND user_id = $2 AND id = $3;
+
+-- name: UpdateWebhook :one
+UPDATE "webhooks"
+SET url = $4, event_types = $5, is_active = $6, updated_at = CURRENT_TIMESTAMP
+WHERE workspace_id = $1 AND user_id = $2 AND id = $3
+RETURNING *;
+
+-- name: DeleteWebhook :execrows
+DELETE FROM "webhooks"
+WHERE workspace_id = $1 AND user_id = $2 AND id = $3;
+
+-- name: EnqueueWebhookDeliveries :execrows
+INSERT INTO "webhook_deliveries" (webhook_id, event_type, payload)
+SELECT id, sqlc.arg('event_type')::text, sqlc.arg('payload')::jsonb
+FROM "webhooks"
+WHERE workspace_id = sqlc.arg('workspace_id') AND is_active AND sqlc.arg('event_type')::text = ANY(event_types)
+	AND task_visible_to(sqlc.arg('workspace_id'), sqlc.narg('project_id'), sqlc.arg('user_id'), user_id);
+
+-- name: CreateWebhookDelivery :one
+INSERT INTO "webhook_deliveries" (webhook_id, event_type, payload) VALUES
+($1,$2,$3) RETURNING *;
+
+-- name: GetWebhookDeliveries :many
+SELECT * FROM "webhook_deliveries"
+WHERE webhook_id = sqlc.arg('webhook_id') AND id <= sqlc.arg('cursor')
+ORDER BY id DESC
+LIMIT sqlc.arg('limit');
+
+-- name: GetWebhookDeliveryByID :one
+SELECT * FROM "webhook_deliveries"
+WHERE webhook_id = $1 AND id = $2;
+
+-- name: ClaimWebhookDeliveries :many
+UPDATE "webhook_deliveries" d
+SET next_attempt_at = sqlc.arg('leased_until')
+FROM "webhooks" w
+WHERE w.id = d.webhook_id AND d.id IN (
+	SELECT id FROM "webhook_deliveries"
+	WHERE status = 'pending' AND next_attempt_at <= CURRENT_TIMESTAMP
+		AND webhook_id IN (SELECT id FROM "webhooks" WHERE is_active)
+	ORDER BY next_attempt_at, id
+	LIMIT sqlc.arg('limit')
+	FOR UPDATE SKIP LOCKED
+)
+RETURNING d.id, d.webhook_id, d.event_type, d.payload, d.status, d.attempts, d.next_attempt_at, d.last_attempt_at, d.response_status, d.response_body, d.error, d.created_at, w.url, w.secret;
+
+-- name: RecordWebhookDeliveryAttempt :exec
+UPDATE "webhook_deliveries"
+SET status = $2, attempts = attempts + 1, next_attempt_at = $3, last_attempt_at = CURRENT_TIMESTAMP, response_status = $4, response_body = $5, error = $6
+WHERE id = $1;
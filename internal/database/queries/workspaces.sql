@@ -0,0 +1,89 @@
+-- name: CreateWorkspace :one
+INSERT INTO "workspaces" (name, personal_user_id) VALUES
+($1,$2) RETURNING *;
+
+-- name: GetWorkspaces :many
+SELECT w.id, w.name, w.personal_user_id, w.created_at, w.updated_at, m.role
+FROM "workspaces" w
+JOIN "workspace_members" m ON m.workspace_id = w.id
+WHERE m.user_id = sqlc.arg('user_id') AND w.id <= sqlc.arg('cursor')
+ORDER BY w.id DESC
+LIMIT sqlc.arg('limit');
+
+-- name: GetWorkspace :one
+SELECT w.id, w.name, w.personal_user_id, w.created_at, w.updated_at, m.role
+FROM "workspaces" w
+JOIN "workspace_members" m ON m.workspace_id = w.id
+WHERE w.id = $1 AND m.user_id = $2;
+
+-- name: GetPersonalWorkspace :one
+SELECT w.id, w.name, w.personal_user_id, w.created_at, w.updated_at, m.role
+FROM "workspaces" w
+JOIN "workspace_members" m ON m.workspace_id = w.id
+WHERE w.personal_user_id = sqlc.arg('user_id')::int AND m.user_id = sqlc.arg('user_id')::int;
+
+-- name: UpdateWorkspace :one
+UPDATE "workspaces"
+SET name = $2,
+	updated_at = CURRENT_TIMESTAMP
+WHERE id = $1
+RETURNING *;
+
+-- name: GetWorkspaceMembers :many
+SELECT m.workspace_id, m.user_id, u.email, u.first_name, u.last_name, m.role, m.created_at
+FROM "workspace_members" m
+JOIN "users" u ON u.id = m.user_id
+WHERE m.workspace_id = $1
+ORDER BY m.created_at, m.user_id;
+
+-- name: AddWorkspaceMember :one
+WITH member AS (
+	INSERT INTO "workspace_members" (workspace_id, user_id, role) VALUES
+	($1,$2,$3) RETURNING *
+)
+SELECT m.workspace_id, m.user_id, u.email, u.first_name, u.last_name, m.role, m.created_at
+FROM member m
+JOIN "users" u ON u.id = m.user_id;
+
+-- name: RemoveWorkspaceMember :execrows
+DELETE FROM "workspace_members"
+WHERE workspace_id = $1 AND user_id = $2;
+
+-- name: LockWorkspaceMembers :exec
+SELECT pg_advisory_xact_lock(hashtext('workspace_members'), sqlc.arg('workspace_id')::int);
+
+-- name: CountWorkspaceOwners :one
+SELECT count(*) FROM "workspace_members"
+WHERE workspace_id = $1 AND role = 'owner';
+
+-- name: RemoveUserFromWorkspaceProjects :exec
+DELETE FROM "project_members"
+WHERE user_id = sqlc.arg('user_id') AND project_id IN (SELECT id FROM "projects" WHERE workspace_id = sqlc.arg('workspace_id'));
+
+-- name: UnassignUserWorkspaceTasks :exec
+UPDATE "tasks"
+SET assignee_id = NULL
+WHERE workspace_id = $1 AND assignee_id = $2;
+
+-- name: CreateWorkspaceInvitation :one
+INSERT INTO "workspace_invitations" (workspace_id, email, role, token_hash, invited_by, expires_at) VALUES
+($1,$2,$3,$4,$5,$6) RETURNING *;
+
+-- name: GetWorkspaceInvitations :many
+SELECT * FROM "workspace_invitations"
+WHERE workspace_id = $1 AND accepted_at IS NULL AND expires_at > CURRENT_TIMESTAMP
+ORDER BY id DESC;
+
+-- name: GetWorkspaceInvitationByToken :one
+SELECT * FROM "workspace_invitations"
+WHERE token_hash = $1 AND accepted_at IS NULL AND expires_at > CURRENT_TIMESTAMP;
+
+-- name: AcceptWorkspaceInvitation :one
+UPDATE "workspace_invitations"
+SET accepted_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND accepted_at IS NULL AND expires_at > CURRENT_TIMESTAMP
+RETURNING *;
+
+-- name: DeleteWorkspaceInvitation :execrows
+DELETE FROM "workspace_invitations"
+WHERE workspace_id = $1 AND id = $2 AND accepted_at IS NULL;
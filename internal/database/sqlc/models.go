@@ -8,12 +8,174 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
-type Task struct {
+type CalendarFeed struct {
+	UserID      int32
+	WorkspaceID int32
+	TokenHash   string
+	CreatedAt   pgtype.Timestamptz
+}
+
+type Import struct {
+	ID          int32
+	UserID      int32
+	WorkspaceID int32
+	Format      string
+	Status      string
+	Data        []byte
+	ProjectIds  []byte
+	Total       int32
+	Processed   int32
+	Error       string
+	LeasedUntil pgtype.Timestamptz
+	CreatedAt   pgtype.Timestamptz
+	UpdatedAt   pgtype.Timestamptz
+	CompletedAt pgtype.Timestamptz
+}
+
+type Notification struct {
+	ID        int32
+	UserID    int32
+	Type      string
+	TaskID    int32
+	TaskTitle string
+	ActorID   pgtype.Int4
+	ReadAt    pgtype.Timestamptz
+	CreatedAt pgtype.Timestamptz
+}
+
+type NotificationPreference struct {
+	UserID    int32
+	Type      string
+	Channel   string
+	Enabled   bool
+	UpdatedAt pgtype.Timestamptz
+}
+
+type OrphanedBlob struct {
+	BlobKey   string
+	CreatedAt pgtype.Timestamptz
+}
+
+type Project struct {
 	ID          int32
-	Title       string
+	UserID      int32
+	Name        string
 	Description string
-	IsCompleted bool
+	CreatedAt   pgtype.Timestamptz
+	UpdatedAt   pgtype.Timestamptz
+	WorkspaceID int32
+}
+
+type ProjectMember struct {
+	ProjectID int32
+	UserID    int32
+	Role      string
+	CreatedAt pgtype.Timestamptz
+	UpdatedAt pgtype.Timestamptz
+}
+
+type ProjectViewer struct {
+	SessionID  string
+	ProjectID  int32
+	UserID     int32
+	LastSeenAt pgtype.Timestamptz
+}
+
+type Task struct {
+	ID           int32
+	Title        string
+	Description  string
+	IsCompleted  bool
+	UserID       int32
+	CreatedAt    pgtype.Timestamptz
+	UpdatedAt    pgtype.Timestamptz
+	DeletedAt    pgtype.Timestamptz
+	CompletedAt  pgtype.Timestamptz
+	ArchivedAt   pgtype.Timestamptz
+	StatusID     int32
+	Position     string
+	ProjectID    pgtype.Int4
+	CommentCount int32
+	AssigneeID   pgtype.Int4
+	WorkspaceID  int32
+	Version      int32
+	DueAt        pgtype.Timestamptz
+	Priority     string
+	Tags         []string
+	Recurrence   string
+	Uid          pgtype.Text
+	ParentID     pgtype.Int4
+}
+
+type TaskAttachment struct {
+	ID          int32
+	TaskID      int32
+	UserID      int32
+	Filename    string
+	ContentType string
+	Size        int64
+	BlobKey     string
+	CreatedAt   pgtype.Timestamptz
+}
+
+type TaskComment struct {
+	ID        int32
+	TaskID    int32
+	UserID    int32
+	Body      string
+	CreatedAt pgtype.Timestamptz
+	UpdatedAt pgtype.Timestamptz
+	EditedAt  pgtype.Timestamptz
+}
+
+type TaskEvent struct {
+	ID                int32
+	TaskID            int32
+	UserID            int32
+	ActorID           pgtype.Int4
+	Action            string
+	Changes           []byte
+	RequestID         string
+	CreatedAt         pgtype.Timestamptz
+	RevertedBy        pgtype.Int4
+	XactID            int64
+	WorkspaceID       int32
+	ProjectID         pgtype.Int4
+	PreviousProjectID pgtype.Int4
+	ViewerID          pgtype.Int4
+}
+
+type TaskMention struct {
+	ID        int32
+	TaskID    int32
+	CommentID pgtype.Int4
+	UserID    int32
+	CreatedAt pgtype.Timestamptz
+}
+
+type TaskReminder struct {
+	TaskID    int32
+	DueAt     pgtype.Timestamptz
+	CreatedAt pgtype.Timestamptz
+}
+
+type TaskStatus struct {
+	ID          int32
+	UserID      int32
+	Name        string
+	Category    string
+	Position    int32
+	CreatedAt   pgtype.Timestamptz
+	UpdatedAt   pgtype.Timestamptz
+	WorkspaceID int32
+}
+
+type TaskTemplate struct {
+	ID          int32
 	UserID      int32
+	WorkspaceID int32
+	Name        string
+	Task        []byte
 	CreatedAt   pgtype.Timestamptz
 	UpdatedAt   pgtype.Timestamptz
 }
@@ -27,3 +189,58 @@ type User struct {
 	CreatedAt pgtype.Timestamptz
 	UpdatedAt pgtype.Timestamptz
 }
+
+type Webhook struct {
+	ID          int32
+	UserID      int32
+	Url         string
+	Secret      string
+	EventTypes  []string
+	IsActive    bool
+	CreatedAt   pgtype.Timestamptz
+	UpdatedAt   pgtype.Timestamptz
+	WorkspaceID int32
+}
+
+type WebhookDelivery struct {
+	ID             int32
+	WebhookID      int32
+	EventType      string
+	Payload        []byte
+	Status         string
+	Attempts       int32
+	NextAttemptAt  pgtype.Timestamptz
+	LastAttemptAt  pgtype.Timestamptz
+	ResponseStatus pgtype.Int4
+	ResponseBody   string
+	Error          string
+	CreatedAt      pgtype.Timestamptz
+}
+
+type Workspace struct {
+	ID             int32
+	Name           string
+	PersonalUserID pgtype.Int4
+	CreatedAt      pgtype.Timestamptz
+	UpdatedAt      pgtype.Timestamptz
+}
+
+type WorkspaceInvitation struct {
+	ID          int32
+	WorkspaceID int32
+	Email       string
+	Role        string
+	TokenHash   string
+	InvitedBy   int32
+	CreatedAt   pgtype.Timestamptz
+	ExpiresAt   pgtype.Timestamptz
+	AcceptedAt  pgtype.Timestamptz
+}
+
+type WorkspaceMember struct {
+	WorkspaceID int32
+	UserID      int32
+	Role        string
+	CreatedAt   pgtype.Timestamptz
+	UpdatedAt   pgtype.Timestamptz
+}
@@ -11,19 +11,101 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const archiveTask = `-- name: ArchiveTask :one
+UPDATE "tasks"
+SET archived_at = COALESCE(archived_at, CURRENT_TIMESTAMP),
+	updated_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND deleted_at IS NULL
+RETURNING id, title, description, is_completed, user_id, created_at, updated_at, deleted_at, completed_at, archived_at, status_id, position, project_id, comment_count, assignee_id, workspace_id, version, due_at, priority, tags, recurrence, uid, parent_id
+`
+
+func (q *Queries) ArchiveTask(ctx context.Context, id int32) (Task, error) {
+	row := q.db.QueryRow(ctx, archiveTask, id)
+	var i Task
+	err := row.Scan(
+		&i.ID,
+		&i.Title,
+		&i.Description,
+		&i.IsCompleted,
+		&i.UserID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.CompletedAt,
+		&i.ArchivedAt,
+		&i.StatusID,
+		&i.Position,
+		&i.ProjectID,
+		&i.CommentCount,
+		&i.AssigneeID,
+		&i.WorkspaceID,
+		&i.Version,
+		&i.DueAt,
+		&i.Priority,
+		&i.Tags,
+		&i.Recurrence,
+		&i.Uid,
+		&i.ParentID,
+	)
+	return i, err
+}
+
+const countSubtasks = `-- name: CountSubtasks :one
+SELECT count(*) FROM "tasks"
+WHERE workspace_id = $1 AND parent_id = $2
+`
+
+type CountSubtasksParams struct {
+	WorkspaceID int32
+	ParentID    pgtype.Int4
+}
+
+func (q *Queries) CountSubtasks(ctx context.Context, arg CountSubtasksParams) (int64, error) {
+	row := q.db.QueryRow(ctx, countSubtasks, arg.WorkspaceID, arg.ParentID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createTask = `-- name: CreateTask :one
-INSERT INTO "tasks" (title, description, user_id) VALUES
-($1,$2,$3) RETURNING id, title, description, is_completed, user_id, created_at, updated_at
+INSERT INTO "tasks" (title, description, user_id, status_id, position, project_id, assignee_id, workspace_id, due_at, priority, tags, recurrence, uid, parent_id) VALUES
+($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$14) RETURNING id, title, description, is_completed, user_id, created_at, updated_at, deleted_at, completed_at, archived_at, status_id, position, project_id, comment_count, assignee_id, workspace_id, version, due_at, priority, tags, recurrence, uid, parent_id
 `
 
 type CreateTaskParams struct {
 	Title       string
 	Description string
 	UserID      int32
+	StatusID    int32
+	Position    string
+	ProjectID   pgtype.Int4
+	AssigneeID  pgtype.Int4
+	WorkspaceID int32
+	DueAt       pgtype.Timestamptz
+	Priority    string
+	Tags        []string
+	Recurrence  string
+	Uid         pgtype.Text
+	ParentID    pgtype.Int4
 }
 
 func (q *Queries) CreateTask(ctx context.Context, arg CreateTaskParams) (Task, error) {
-	row := q.db.QueryRow(ctx, createTask, arg.Title, arg.Description, arg.UserID)
+	row := q.db.QueryRow(ctx, createTask,
+		arg.Title,
+		arg.Description,
+		arg.UserID,
+		arg.StatusID,
+		arg.Position,
+		arg.ProjectID,
+		arg.AssigneeID,
+		arg.WorkspaceID,
+		arg.DueAt,
+		arg.Priority,
+		arg.Tags,
+		arg.Recurrence,
+		arg.Uid,
+		arg.ParentID,
+	)
 	var i Task
 	err := row.Scan(
 		&i.ID,
@@ -33,37 +115,659 @@ func (q *Queries) CreateTask(ctx context.Context, arg CreateTaskParams) (Task, e
 		&i.UserID,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.CompletedAt,
+		&i.ArchivedAt,
+		&i.StatusID,
+		&i.Position,
+		&i.ProjectID,
+		&i.CommentCount,
+		&i.AssigneeID,
+		&i.WorkspaceID,
+		&i.Version,
+		&i.DueAt,
+		&i.Priority,
+		&i.Tags,
+		&i.Recurrence,
+		&i.Uid,
+		&i.ParentID,
 	)
 	return i, err
 }
 
-const deleteTask = `-- name: DeleteTask :exec
-DELETE FROM "tasks"
-WHERE id = $1 AND user_id = $2
+const deleteTask = `-- name: DeleteTask :one
+UPDATE "tasks"
+SET deleted_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND deleted_at IS NULL
+RETURNING id, title, description, is_completed, user_id, created_at, updated_at, deleted_at, completed_at, archived_at, status_id, position, project_id, comment_count, assignee_id, workspace_id, version, due_at, priority, tags, recurrence, uid, parent_id
+`
+
+func (q *Queries) DeleteTask(ctx context.Context, id int32) (Task, error) {
+	row := q.db.QueryRow(ctx, deleteTask, id)
+	var i Task
+	err := row.Scan(
+		&i.ID,
+		&i.Title,
+		&i.Description,
+		&i.IsCompleted,
+		&i.UserID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.CompletedAt,
+		&i.ArchivedAt,
+		&i.StatusID,
+		&i.Position,
+		&i.ProjectID,
+		&i.CommentCount,
+		&i.AssigneeID,
+		&i.WorkspaceID,
+		&i.Version,
+		&i.DueAt,
+		&i.Priority,
+		&i.Tags,
+		&i.Recurrence,
+		&i.Uid,
+		&i.ParentID,
+	)
+	return i, err
+}
+
+const getCalendarTasks = `-- name: GetCalendarTasks :many
+SELECT id, title, description, is_completed, user_id, created_at, updated_at, deleted_at, completed_at, archived_at, status_id, position, project_id, comment_count, assignee_id, workspace_id, version, due_at, priority, tags, recurrence, uid, parent_id FROM visible_tasks($1, $2)
+WHERE deleted_at IS NULL AND archived_at IS NULL AND due_at IS NOT NULL
+	AND (NOT is_completed OR due_at >= $3)
+ORDER BY due_at, id
+LIMIT $4
+`
+
+type GetCalendarTasksParams struct {
+	WorkspaceID       int32
+	UserID            int32
+	CompletedDueAfter pgtype.Timestamptz
+	Limit             int32
+}
+
+func (q *Queries) GetCalendarTasks(ctx context.Context, arg GetCalendarTasksParams) ([]Task, error) {
+	rows, err := q.db.Query(ctx, getCalendarTasks,
+		arg.WorkspaceID,
+		arg.UserID,
+		arg.CompletedDueAfter,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Task
+	for rows.Next() {
+		var i Task
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.Description,
+			&i.IsCompleted,
+			&i.UserID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+			&i.CompletedAt,
+			&i.ArchivedAt,
+			&i.StatusID,
+			&i.Position,
+			&i.ProjectID,
+			&i.CommentCount,
+			&i.AssigneeID,
+			&i.WorkspaceID,
+			&i.Version,
+			&i.DueAt,
+			&i.Priority,
+			&i.Tags,
+			&i.Recurrence,
+			&i.Uid,
+			&i.ParentID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getDeletedTasks = `-- name: GetDeletedTasks :many
+SELECT id, title, description, is_completed, user_id, created_at, updated_at, deleted_at, completed_at, archived_at, status_id, position, project_id, comment_count, assignee_id, workspace_id, version, due_at, priority, tags, recurrence, uid, parent_id FROM visible_tasks($1, $2)
+WHERE id <= $3 AND deleted_at IS NOT NULL
+ORDER BY id DESC
+LIMIT $4
+`
+
+type GetDeletedTasksParams struct {
+	WorkspaceID int32
+	UserID      int32
+	Cursor      int32
+	Limit       int32
+}
+
+func (q *Queries) GetDeletedTasks(ctx context.Context, arg GetDeletedTasksParams) ([]Task, error) {
+	rows, err := q.db.Query(ctx, getDeletedTasks,
+		arg.WorkspaceID,
+		arg.UserID,
+		arg.Cursor,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Task
+	for rows.Next() {
+		var i Task
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.Description,
+			&i.IsCompleted,
+			&i.UserID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+			&i.CompletedAt,
+			&i.ArchivedAt,
+			&i.StatusID,
+			&i.Position,
+			&i.ProjectID,
+			&i.CommentCount,
+			&i.AssigneeID,
+			&i.WorkspaceID,
+			&i.Version,
+			&i.DueAt,
+			&i.Priority,
+			&i.Tags,
+			&i.Recurrence,
+			&i.Uid,
+			&i.ParentID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getExpiredCompletedTasksForUpdate = `-- name: GetExpiredCompletedTasksForUpdate :many
+SELECT id, title, description, is_completed, user_id, created_at, updated_at, deleted_at, completed_at, archived_at, status_id, position, project_id, comment_count, assignee_id, workspace_id, version, due_at, priority, tags, recurrence, uid, parent_id FROM "tasks"
+WHERE is_completed AND completed_at < $1 AND archived_at IS NULL AND deleted_at IS NULL
+ORDER BY id
+LIMIT $2
+FOR UPDATE SKIP LOCKED
+`
+
+type GetExpiredCompletedTasksForUpdateParams struct {
+	CompletedBefore pgtype.Timestamptz
+	Limit           int32
+}
+
+// GetExpiredCompletedTasksForUpdate locks a batch of the unarchived tasks
+// completed before completed_before, skipping those another transaction is changing.
+func (q *Queries) GetExpiredCompletedTasksForUpdate(ctx context.Context, arg GetExpiredCompletedTasksForUpdateParams) ([]Task, error) {
+	rows, err := q.db.Query(ctx, getExpiredCompletedTasksForUpdate, arg.CompletedBefore, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Task
+	for rows.Next() {
+		var i Task
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.Description,
+			&i.IsCompleted,
+			&i.UserID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+			&i.CompletedAt,
+			&i.ArchivedAt,
+			&i.StatusID,
+			&i.Position,
+			&i.ProjectID,
+			&i.CommentCount,
+			&i.AssigneeID,
+			&i.WorkspaceID,
+			&i.Version,
+			&i.DueAt,
+			&i.Priority,
+			&i.Tags,
+			&i.Recurrence,
+			&i.Uid,
+			&i.ParentID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getExpiredDeletedTasksForUpdate = `-- name: GetExpiredDeletedTasksForUpdate :many
+SELECT id, title, description, is_completed, user_id, created_at, updated_at, deleted_at, completed_at, archived_at, status_id, position, project_id, comment_count, assignee_id, workspace_id, version, due_at, priority, tags, recurrence, uid, parent_id FROM "tasks"
+WHERE deleted_at < $1
+ORDER BY id
+LIMIT $2
+FOR UPDATE SKIP LOCKED
+`
+
+type GetExpiredDeletedTasksForUpdateParams struct {
+	DeletedBefore pgtype.Timestamptz
+	Limit         int32
+}
+
+// GetExpiredDeletedTasksForUpdate locks a batch of the tasks trashed before
+// deleted_before, skipping those another transaction is changing.
+func (q *Queries) GetExpiredDeletedTasksForUpdate(ctx context.Context, arg GetExpiredDeletedTasksForUpdateParams) ([]Task, error) {
+	rows, err := q.db.Query(ctx, getExpiredDeletedTasksForUpdate, arg.DeletedBefore, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Task
+	for rows.Next() {
+		var i Task
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.Description,
+			&i.IsCompleted,
+			&i.UserID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+			&i.CompletedAt,
+			&i.ArchivedAt,
+			&i.StatusID,
+			&i.Position,
+			&i.ProjectID,
+			&i.CommentCount,
+			&i.AssigneeID,
+			&i.WorkspaceID,
+			&i.Version,
+			&i.DueAt,
+			&i.Priority,
+			&i.Tags,
+			&i.Recurrence,
+			&i.Uid,
+			&i.ParentID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getLastTaskPosition = `-- name: GetLastTaskPosition :one
+SELECT position FROM "tasks"
+WHERE project_id IS NOT DISTINCT FROM $1::int AND ($1::int IS NOT NULL OR (user_id = $2 AND workspace_id = $3)) AND deleted_at IS NULL
+ORDER BY position DESC, id DESC
+LIMIT 1
+`
+
+type GetLastTaskPositionParams struct {
+	ProjectID   pgtype.Int4
+	UserID      int32
+	WorkspaceID int32
+}
+
+func (q *Queries) GetLastTaskPosition(ctx context.Context, arg GetLastTaskPositionParams) (string, error) {
+	row := q.db.QueryRow(ctx, getLastTaskPosition, arg.ProjectID, arg.UserID, arg.WorkspaceID)
+	var position string
+	err := row.Scan(&position)
+	return position, err
+}
+
+const getListTasksForUpdate = `-- name: GetListTasksForUpdate :many
+SELECT id, title, description, is_completed, user_id, created_at, updated_at, deleted_at, completed_at, archived_at, status_id, position, project_id, comment_count, assignee_id, workspace_id, version, due_at, priority, tags, recurrence, uid, parent_id FROM "tasks"
+WHERE project_id IS NOT DISTINCT FROM $1::int AND ($1::int IS NOT NULL OR (user_id = $2 AND workspace_id = $3))
+ORDER BY position, id
+FOR UPDATE
+`
+
+type GetListTasksForUpdateParams struct {
+	ProjectID   pgtype.Int4
+	UserID      int32
+	WorkspaceID int32
+}
+
+func (q *Queries) GetListTasksForUpdate(ctx context.Context, arg GetListTasksForUpdateParams) ([]Task, error) {
+	rows, err := q.db.Query(ctx, getListTasksForUpdate, arg.ProjectID, arg.UserID, arg.WorkspaceID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Task
+	for rows.Next() {
+		var i Task
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.Description,
+			&i.IsCompleted,
+			&i.UserID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+			&i.CompletedAt,
+			&i.ArchivedAt,
+			&i.StatusID,
+			&i.Position,
+			&i.ProjectID,
+			&i.CommentCount,
+			&i.AssigneeID,
+			&i.WorkspaceID,
+			&i.Version,
+			&i.DueAt,
+			&i.Priority,
+			&i.Tags,
+			&i.Recurrence,
+			&i.Uid,
+			&i.ParentID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getNextTaskPosition = `-- name: GetNextTaskPosition :one
+SELECT position FROM "tasks"
+WHERE project_id IS NOT DISTINCT FROM $1::int AND ($1::int IS NOT NULL OR (user_id = $2 AND workspace_id = $3)) AND id <> $4 AND (position, id) > ($5::text, $6::int)
+ORDER BY position, id
+LIMIT 1
+`
+
+type GetNextTaskPositionParams struct {
+	ProjectID   pgtype.Int4
+	UserID      int32
+	WorkspaceID int32
+	TaskID      int32
+	Position    string
+	NeighbourID int32
+}
+
+func (q *Queries) GetNextTaskPosition(ctx context.Context, arg GetNextTaskPositionParams) (string, error) {
+	row := q.db.QueryRow(ctx, getNextTaskPosition,
+		arg.ProjectID,
+		arg.UserID,
+		arg.WorkspaceID,
+		arg.TaskID,
+		arg.Position,
+		arg.NeighbourID,
+	)
+	var position string
+	err := row.Scan(&position)
+	return position, err
+}
+
+const getPreviousTaskPosition = `-- name: GetPreviousTaskPosition :one
+SELECT position FROM "tasks"
+WHERE project_id IS NOT DISTINCT FROM $1::int AND ($1::int IS NOT NULL OR (user_id = $2 AND workspace_id = $3)) AND id <> $4 AND (position, id) < ($5::text, $6::int)
+ORDER BY position DESC, id DESC
+LIMIT 1
+`
+
+type GetPreviousTaskPositionParams struct {
+	ProjectID   pgtype.Int4
+	UserID      int32
+	WorkspaceID int32
+	TaskID      int32
+	Position    string
+	NeighbourID int32
+}
+
+func (q *Queries) GetPreviousTaskPosition(ctx context.Context, arg GetPreviousTaskPositionParams) (string, error) {
+	row := q.db.QueryRow(ctx, getPreviousTaskPosition,
+		arg.ProjectID,
+		arg.UserID,
+		arg.WorkspaceID,
+		arg.TaskID,
+		arg.Position,
+		arg.NeighbourID,
+	)
+	var position string
+	err := row.Scan(&position)
+	return position, err
+}
+
+const getSubtasks = `-- name: GetSubtasks :many
+SELECT id, title, description, is_completed, user_id, created_at, updated_at, deleted_at, completed_at, archived_at, status_id, position, project_id, comment_count, assignee_id, workspace_id, version, due_at, priority, tags, recurrence, uid, parent_id FROM "tasks"
+WHERE workspace_id = $1 AND parent_id = $2 AND deleted_at IS NULL
+ORDER BY position, id
+`
+
+type GetSubtasksParams struct {
+	WorkspaceID int32
+	ParentID    pgtype.Int4
+}
+
+func (q *Queries) GetSubtasks(ctx context.Context, arg GetSubtasksParams) ([]Task, error) {
+	rows, err := q.db.Query(ctx, getSubtasks, arg.WorkspaceID, arg.ParentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Task
+	for rows.Next() {
+		var i Task
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.Description,
+			&i.IsCompleted,
+			&i.UserID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+			&i.CompletedAt,
+			&i.ArchivedAt,
+			&i.StatusID,
+			&i.Position,
+			&i.ProjectID,
+			&i.CommentCount,
+			&i.AssigneeID,
+			&i.WorkspaceID,
+			&i.Version,
+			&i.DueAt,
+			&i.Priority,
+			&i.Tags,
+			&i.Recurrence,
+			&i.Uid,
+			&i.ParentID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getSyncTasks = `-- name: GetSyncTasks :many
+SELECT id, title, description, is_completed, user_id, created_at, updated_at, deleted_at, completed_at, archived_at, status_id, position, project_id, comment_count, assignee_id, workspace_id, version, due_at, priority, tags, recurrence, uid, parent_id FROM visible_tasks($1, $2)
+WHERE deleted_at IS NULL AND id > $3
+ORDER BY id
+LIMIT $4
 `
 
-type DeleteTaskParams struct {
-	ID     int32
-	UserID int32
+type GetSyncTasksParams struct {
+	WorkspaceID int32
+	UserID      int32
+	AfterID     int32
+	Limit       int32
 }
 
-func (q *Queries) DeleteTask(ctx context.Context, arg DeleteTaskParams) error {
-	_, err := q.db.Exec(ctx, deleteTask, arg.ID, arg.UserID)
-	return err
+func (q *Queries) GetSyncTasks(ctx context.Context, arg GetSyncTasksParams) ([]Task, error) {
+	rows, err := q.db.Query(ctx, getSyncTasks,
+		arg.WorkspaceID,
+		arg.UserID,
+		arg.AfterID,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Task
+	for rows.Next() {
+		var i Task
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.Description,
+			&i.IsCompleted,
+			&i.UserID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+			&i.CompletedAt,
+			&i.ArchivedAt,
+			&i.StatusID,
+			&i.Position,
+			&i.ProjectID,
+			&i.CommentCount,
+			&i.AssigneeID,
+			&i.WorkspaceID,
+			&i.Version,
+			&i.DueAt,
+			&i.Priority,
+			&i.Tags,
+			&i.Recurrence,
+			&i.Uid,
+			&i.ParentID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
 }
 
 const getTaskByID = `-- name: GetTaskByID :one
-SELECT id, title, description, is_completed, user_id, created_at, updated_at FROM "tasks"
-WHERE user_id = $1 AND id = $2
+SELECT id, title, description, is_completed, user_id, created_at, updated_at, deleted_at, completed_at, archived_at, status_id, position, project_id, comment_count, assignee_id, workspace_id, version, due_at, priority, tags, recurrence, uid, parent_id FROM "tasks"
+WHERE workspace_id = $1 AND id = $2
 `
 
 type GetTaskByIDParams struct {
-	UserID int32
-	ID     int32
+	WorkspaceID int32
+	ID          int32
 }
 
 func (q *Queries) GetTaskByID(ctx context.Context, arg GetTaskByIDParams) (Task, error) {
-	row := q.db.QueryRow(ctx, getTaskByID, arg.UserID, arg.ID)
+	row := q.db.QueryRow(ctx, getTaskByID, arg.WorkspaceID, arg.ID)
+	var i Task
+	err := row.Scan(
+		&i.ID,
+		&i.Title,
+		&i.Description,
+		&i.IsCompleted,
+		&i.UserID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.CompletedAt,
+		&i.ArchivedAt,
+		&i.StatusID,
+		&i.Position,
+		&i.ProjectID,
+		&i.CommentCount,
+		&i.AssigneeID,
+		&i.WorkspaceID,
+		&i.Version,
+		&i.DueAt,
+		&i.Priority,
+		&i.Tags,
+		&i.Recurrence,
+		&i.Uid,
+		&i.ParentID,
+	)
+	return i, err
+}
+
+const getTaskByUID = `-- name: GetTaskByUID :one
+SELECT id, title, description, is_completed, user_id, created_at, updated_at, deleted_at, completed_at, archived_at, status_id, position, project_id, comment_count, assignee_id, workspace_id, version, due_at, priority, tags, recurrence, uid, parent_id FROM "tasks"
+WHERE workspace_id = $1 AND uid = $2
+`
+
+type GetTaskByUIDParams struct {
+	WorkspaceID int32
+	Uid         pgtype.Text
+}
+
+func (q *Queries) GetTaskByUID(ctx context.Context, arg GetTaskByUIDParams) (Task, error) {
+	row := q.db.QueryRow(ctx, getTaskByUID, arg.WorkspaceID, arg.Uid)
+	var i Task
+	err := row.Scan(
+		&i.ID,
+		&i.Title,
+		&i.Description,
+		&i.IsCompleted,
+		&i.UserID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.CompletedAt,
+		&i.ArchivedAt,
+		&i.StatusID,
+		&i.Position,
+		&i.ProjectID,
+		&i.CommentCount,
+		&i.AssigneeID,
+		&i.WorkspaceID,
+		&i.Version,
+		&i.DueAt,
+		&i.Priority,
+		&i.Tags,
+		&i.Recurrence,
+		&i.Uid,
+		&i.ParentID,
+	)
+	return i, err
+}
+
+const getTaskForUpdate = `-- name: GetTaskForUpdate :one
+SELECT id, title, description, is_completed, user_id, created_at, updated_at, deleted_at, completed_at, archived_at, status_id, position, project_id, comment_count, assignee_id, workspace_id, version, due_at, priority, tags, recurrence, uid, parent_id FROM "tasks"
+WHERE workspace_id = $1 AND id = $2
+FOR UPDATE
+`
+
+type GetTaskForUpdateParams struct {
+	WorkspaceID int32
+	ID          int32
+}
+
+func (q *Queries) GetTaskForUpdate(ctx context.Context, arg GetTaskForUpdateParams) (Task, error) {
+	row := q.db.QueryRow(ctx, getTaskForUpdate, arg.WorkspaceID, arg.ID)
 	var i Task
 	err := row.Scan(
 		&i.ID,
@@ -73,29 +777,210 @@ func (q *Queries) GetTaskByID(ctx context.Context, arg GetTaskByIDParams) (Task,
 		&i.UserID,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.CompletedAt,
+		&i.ArchivedAt,
+		&i.StatusID,
+		&i.Position,
+		&i.ProjectID,
+		&i.CommentCount,
+		&i.AssigneeID,
+		&i.WorkspaceID,
+		&i.Version,
+		&i.DueAt,
+		&i.Priority,
+		&i.Tags,
+		&i.Recurrence,
+		&i.Uid,
+		&i.ParentID,
 	)
 	return i, err
 }
 
 const getTasks = `-- name: GetTasks :many
-SELECT id, title, description, is_completed, user_id, created_at, updated_at FROM "tasks"
-WHERE user_id = $1 AND id <= $2 AND (is_completed = $3 OR $3 IS NULL)
+SELECT id, title, description, is_completed, user_id, created_at, updated_at, deleted_at, completed_at, archived_at, status_id, position, project_id, comment_count, assignee_id, workspace_id, version, due_at, priority, tags, recurrence, uid, parent_id FROM visible_tasks($1, $2)
+WHERE id <= $3 AND deleted_at IS NULL AND (archived_at IS NOT NULL) = $4::bool AND (is_completed = $5 OR $5 IS NULL)
+	AND (status_id = $6 OR $6 IS NULL)
+	AND (status_id IN (SELECT id FROM "task_statuses" WHERE category = $7) OR $7::text IS NULL)
+	AND (project_id = $8 OR $8 IS NULL)
+	AND (assignee_id = $9 OR $9 IS NULL)
+	AND (id IN (SELECT task_id FROM "task_mentions" WHERE user_id = $10) OR $10::int IS NULL)
+	AND ($11::text = ANY(tags) OR $11::text IS NULL)
 ORDER BY id DESC
-LIMIT $4
+LIMIT $12
 `
 
 type GetTasksParams struct {
-	UserID      int32
-	Cursor      int32
-	IsCompleted pgtype.Bool
-	Limit       int32
+	WorkspaceID     int32
+	UserID          int32
+	Cursor          int32
+	IsArchived      bool
+	IsCompleted     pgtype.Bool
+	StatusID        pgtype.Int4
+	StatusCategory  pgtype.Text
+	ProjectID       pgtype.Int4
+	AssigneeID      pgtype.Int4
+	MentionedUserID pgtype.Int4
+	Tag             pgtype.Text
+	Limit           int32
 }
 
 func (q *Queries) GetTasks(ctx context.Context, arg GetTasksParams) ([]Task, error) {
 	rows, err := q.db.Query(ctx, getTasks,
+		arg.WorkspaceID,
 		arg.UserID,
 		arg.Cursor,
+		arg.IsArchived,
+		arg.IsCompleted,
+		arg.StatusID,
+		arg.StatusCategory,
+		arg.ProjectID,
+		arg.AssigneeID,
+		arg.MentionedUserID,
+		arg.Tag,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Task
+	for rows.Next() {
+		var i Task
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.Description,
+			&i.IsCompleted,
+			&i.UserID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+			&i.CompletedAt,
+			&i.ArchivedAt,
+			&i.StatusID,
+			&i.Position,
+			&i.ProjectID,
+			&i.CommentCount,
+			&i.AssigneeID,
+			&i.WorkspaceID,
+			&i.Version,
+			&i.DueAt,
+			&i.Priority,
+			&i.Tags,
+			&i.Recurrence,
+			&i.Uid,
+			&i.ParentID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getTasksByIDs = `-- name: GetTasksByIDs :many
+SELECT id, title, description, is_completed, user_id, created_at, updated_at, deleted_at, completed_at, archived_at, status_id, position, project_id, comment_count, assignee_id, workspace_id, version, due_at, priority, tags, recurrence, uid, parent_id FROM visible_tasks($1, $2)
+WHERE id = ANY($3::int[])
+`
+
+type GetTasksByIDsParams struct {
+	WorkspaceID int32
+	UserID      int32
+	Ids         []int32
+}
+
+func (q *Queries) GetTasksByIDs(ctx context.Context, arg GetTasksByIDsParams) ([]Task, error) {
+	rows, err := q.db.Query(ctx, getTasksByIDs, arg.WorkspaceID, arg.UserID, arg.Ids)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Task
+	for rows.Next() {
+		var i Task
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.Description,
+			&i.IsCompleted,
+			&i.UserID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+			&i.CompletedAt,
+			&i.ArchivedAt,
+			&i.StatusID,
+			&i.Position,
+			&i.ProjectID,
+			&i.CommentCount,
+			&i.AssigneeID,
+			&i.WorkspaceID,
+			&i.Version,
+			&i.DueAt,
+			&i.Priority,
+			&i.Tags,
+			&i.Recurrence,
+			&i.Uid,
+			&i.ParentID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getTasksByPosition = `-- name: GetTasksByPosition :many
+SELECT id, title, description, is_completed, user_id, created_at, updated_at, deleted_at, completed_at, archived_at, status_id, position, project_id, comment_count, assignee_id, workspace_id, version, due_at, priority, tags, recurrence, uid, parent_id FROM visible_tasks($1, $2)
+WHERE deleted_at IS NULL AND (archived_at IS NOT NULL) = $3::bool AND (is_completed = $4 OR $4 IS NULL)
+	AND (status_id = $5 OR $5 IS NULL)
+	AND (status_id IN (SELECT id FROM "task_statuses" WHERE category = $6) OR $6::text IS NULL)
+	AND (project_id = $7 OR $7 IS NULL)
+	AND (assignee_id = $8 OR $8 IS NULL)
+	AND (id IN (SELECT task_id FROM "task_mentions" WHERE user_id = $9) OR $9::int IS NULL)
+	AND ($10::text = ANY(tags) OR $10::text IS NULL)
+	AND (position, id) >= (COALESCE($11::text, (SELECT position FROM "tasks" WHERE id = $12), ''), $12)
+ORDER BY position, id
+LIMIT $13
+`
+
+type GetTasksByPositionParams struct {
+	WorkspaceID     int32
+	UserID          int32
+	IsArchived      bool
+	IsCompleted     pgtype.Bool
+	StatusID        pgtype.Int4
+	StatusCategory  pgtype.Text
+	ProjectID       pgtype.Int4
+	AssigneeID      pgtype.Int4
+	MentionedUserID pgtype.Int4
+	Tag             pgtype.Text
+	CursorPosition  pgtype.Text
+	Cursor          int32
+	Limit           int32
+}
+
+func (q *Queries) GetTasksByPosition(ctx context.Context, arg GetTasksByPositionParams) ([]Task, error) {
+	rows, err := q.db.Query(ctx, getTasksByPosition,
+		arg.WorkspaceID,
+		arg.UserID,
+		arg.IsArchived,
 		arg.IsCompleted,
+		arg.StatusID,
+		arg.StatusCategory,
+		arg.ProjectID,
+		arg.AssigneeID,
+		arg.MentionedUserID,
+		arg.Tag,
+		arg.CursorPosition,
+		arg.Cursor,
 		arg.Limit,
 	)
 	if err != nil {
@@ -113,6 +998,208 @@ func (q *Queries) GetTasks(ctx context.Context, arg GetTasksParams) ([]Task, err
 			&i.UserID,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.DeletedAt,
+			&i.CompletedAt,
+			&i.ArchivedAt,
+			&i.StatusID,
+			&i.Position,
+			&i.ProjectID,
+			&i.CommentCount,
+			&i.AssigneeID,
+			&i.WorkspaceID,
+			&i.Version,
+			&i.DueAt,
+			&i.Priority,
+			&i.Tags,
+			&i.Recurrence,
+			&i.Uid,
+			&i.ParentID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const purgeTask = `-- name: PurgeTask :one
+DELETE FROM "tasks"
+WHERE id = $1 AND deleted_at IS NOT NULL
+RETURNING id, title, description, is_completed, user_id, created_at, updated_at, deleted_at, completed_at, archived_at, status_id, position, project_id, comment_count, assignee_id, workspace_id, version, due_at, priority, tags, recurrence, uid, parent_id
+`
+
+func (q *Queries) PurgeTask(ctx context.Context, id int32) (Task, error) {
+	row := q.db.QueryRow(ctx, purgeTask, id)
+	var i Task
+	err := row.Scan(
+		&i.ID,
+		&i.Title,
+		&i.Description,
+		&i.IsCompleted,
+		&i.UserID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.CompletedAt,
+		&i.ArchivedAt,
+		&i.StatusID,
+		&i.Position,
+		&i.ProjectID,
+		&i.CommentCount,
+		&i.AssigneeID,
+		&i.WorkspaceID,
+		&i.Version,
+		&i.DueAt,
+		&i.Priority,
+		&i.Tags,
+		&i.Recurrence,
+		&i.Uid,
+		&i.ParentID,
+	)
+	return i, err
+}
+
+const restoreTask = `-- name: RestoreTask :one
+UPDATE "tasks"
+SET deleted_at = NULL,
+	updated_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND deleted_at IS NOT NULL
+RETURNING id, title, description, is_completed, user_id, created_at, updated_at, deleted_at, completed_at, archived_at, status_id, position, project_id, comment_count, assignee_id, workspace_id, version, due_at, priority, tags, recurrence, uid, parent_id
+`
+
+func (q *Queries) RestoreTask(ctx context.Context, id int32) (Task, error) {
+	row := q.db.QueryRow(ctx, restoreTask, id)
+	var i Task
+	err := row.Scan(
+		&i.ID,
+		&i.Title,
+		&i.Description,
+		&i.IsCompleted,
+		&i.UserID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.CompletedAt,
+		&i.ArchivedAt,
+		&i.StatusID,
+		&i.Position,
+		&i.ProjectID,
+		&i.CommentCount,
+		&i.AssigneeID,
+		&i.WorkspaceID,
+		&i.Version,
+		&i.DueAt,
+		&i.Priority,
+		&i.Tags,
+		&i.Recurrence,
+		&i.Uid,
+		&i.ParentID,
+	)
+	return i, err
+}
+
+const setTaskPosition = `-- name: SetTaskPosition :one
+UPDATE "tasks"
+SET position = $1,
+	status_id = COALESCE($2, status_id),
+	updated_at = CURRENT_TIMESTAMP
+WHERE workspace_id = $3 AND id = $4 AND deleted_at IS NULL
+RETURNING id, title, description, is_completed, user_id, created_at, updated_at, deleted_at, completed_at, archived_at, status_id, position, project_id, comment_count, assignee_id, workspace_id, version, due_at, priority, tags, recurrence, uid, parent_id
+`
+
+type SetTaskPositionParams struct {
+	Position    string
+	StatusID    pgtype.Int4
+	WorkspaceID int32
+	ID          int32
+}
+
+func (q *Queries) SetTaskPosition(ctx context.Context, arg SetTaskPositionParams) (Task, error) {
+	row := q.db.QueryRow(ctx, setTaskPosition,
+		arg.Position,
+		arg.StatusID,
+		arg.WorkspaceID,
+		arg.ID,
+	)
+	var i Task
+	err := row.Scan(
+		&i.ID,
+		&i.Title,
+		&i.Description,
+		&i.IsCompleted,
+		&i.UserID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.CompletedAt,
+		&i.ArchivedAt,
+		&i.StatusID,
+		&i.Position,
+		&i.ProjectID,
+		&i.CommentCount,
+		&i.AssigneeID,
+		&i.WorkspaceID,
+		&i.Version,
+		&i.DueAt,
+		&i.Priority,
+		&i.Tags,
+		&i.Recurrence,
+		&i.Uid,
+		&i.ParentID,
+	)
+	return i, err
+}
+
+const setTaskPositions = `-- name: SetTaskPositions :many
+UPDATE "tasks"
+SET position = p.position,
+	updated_at = CURRENT_TIMESTAMP
+FROM unnest($1::int[], $2::text[]) AS p(id, position)
+WHERE "tasks".id = p.id
+RETURNING tasks.id, tasks.title, tasks.description, tasks.is_completed, tasks.user_id, tasks.created_at, tasks.updated_at, tasks.deleted_at, tasks.completed_at, tasks.archived_at, tasks.status_id, tasks.position, tasks.project_id, tasks.comment_count, tasks.assignee_id, tasks.workspace_id, tasks.version, tasks.due_at, tasks.priority, tasks.tags, tasks.recurrence, tasks.uid, tasks.parent_id
+`
+
+type SetTaskPositionsParams struct {
+	Ids       []int32
+	Positions []string
+}
+
+func (q *Queries) SetTaskPositions(ctx context.Context, arg SetTaskPositionsParams) ([]Task, error) {
+	rows, err := q.db.Query(ctx, setTaskPositions, arg.Ids, arg.Positions)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Task
+	for rows.Next() {
+		var i Task
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.Description,
+			&i.IsCompleted,
+			&i.UserID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+			&i.CompletedAt,
+			&i.ArchivedAt,
+			&i.StatusID,
+			&i.Position,
+			&i.ProjectID,
+			&i.CommentCount,
+			&i.AssigneeID,
+			&i.WorkspaceID,
+			&i.Version,
+			&i.DueAt,
+			&i.Priority,
+			&i.Tags,
+			&i.Recurrence,
+			&i.Uid,
+			&i.ParentID,
 		); err != nil {
 			return nil, err
 		}
@@ -124,21 +1211,76 @@ func (q *Queries) GetTasks(ctx context.Context, arg GetTasksParams) ([]Task, err
 	return items, nil
 }
 
+const unarchiveTask = `-- name: UnarchiveTask :one
+UPDATE "tasks"
+SET archived_at = NULL,
+	updated_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND deleted_at IS NULL
+RETURNING id, title, description, is_completed, user_id, created_at, updated_at, deleted_at, completed_at, archived_at, status_id, position, project_id, comment_count, assignee_id, workspace_id, version, due_at, priority, tags, recurrence, uid, parent_id
+`
+
+func (q *Queries) UnarchiveTask(ctx context.Context, id int32) (Task, error) {
+	row := q.db.QueryRow(ctx, unarchiveTask, id)
+	var i Task
+	err := row.Scan(
+		&i.ID,
+		&i.Title,
+		&i.Description,
+		&i.IsCompleted,
+		&i.UserID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.CompletedAt,
+		&i.ArchivedAt,
+		&i.StatusID,
+		&i.Position,
+		&i.ProjectID,
+		&i.CommentCount,
+		&i.AssigneeID,
+		&i.WorkspaceID,
+		&i.Version,
+		&i.DueAt,
+		&i.Priority,
+		&i.Tags,
+		&i.Recurrence,
+		&i.Uid,
+		&i.ParentID,
+	)
+	return i, err
+}
+
 const updateTask = `-- name: UpdateTask :one
 UPDATE "tasks"
 SET	title = $2,
 	description = $3,
-	is_completed = $4,
+	status_id = $4,
+	deleted_at = $5,
+	archived_at = $6,
+	project_id = $7,
+	assignee_id = $8,
+	due_at = $9,
+	priority = $10,
+	tags = $11,
+	recurrence = $12,
 	updated_at = CURRENT_TIMESTAMP
 WHERE id = $1
-RETURNING id, title, description, is_completed, user_id, created_at, updated_at
+RETURNING id, title, description, is_completed, user_id, created_at, updated_at, deleted_at, completed_at, archived_at, status_id, position, project_id, comment_count, assignee_id, workspace_id, version, due_at, priority, tags, recurrence, uid, parent_id
 `
 
 type UpdateTaskParams struct {
 	ID          int32
 	Title       string
 	Description string
-	IsCompleted bool
+	StatusID    int32
+	DeletedAt   pgtype.Timestamptz
+	ArchivedAt  pgtype.Timestamptz
+	ProjectID   pgtype.Int4
+	AssigneeID  pgtype.Int4
+	DueAt       pgtype.Timestamptz
+	Priority    string
+	Tags        []string
+	Recurrence  string
 }
 
 func (q *Queries) UpdateTask(ctx context.Context, arg UpdateTaskParams) (Task, error) {
@@ -146,7 +1288,15 @@ func (q *Queries) UpdateTask(ctx context.Context, arg UpdateTaskParams) (Task, e
 		arg.ID,
 		arg.Title,
 		arg.Description,
-		arg.IsCompleted,
+		arg.StatusID,
+		arg.DeletedAt,
+		arg.ArchivedAt,
+		arg.ProjectID,
+		arg.AssigneeID,
+		arg.DueAt,
+		arg.Priority,
+		arg.Tags,
+		arg.Recurrence,
 	)
 	var i Task
 	err := row.Scan(
@@ -157,6 +1307,22 @@ func (q *Queries) UpdateTask(ctx context.Context, arg UpdateTaskParams) (Task, e
 		&i.UserID,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.CompletedAt,
+		&i.ArchivedAt,
+		&i.StatusID,
+		&i.Position,
+		&i.ProjectID,
+		&i.CommentCount,
+		&i.AssigneeID,
+		&i.WorkspaceID,
+		&i.Version,
+		&i.DueAt,
+		&i.Priority,
+		&i.Tags,
+		&i.Recurrence,
+		&i.Uid,
+		&i.ParentID,
 	)
 	return i, err
 }
@@ -59,3 +59,35 @@ func (q *Queries) GetUserByEmail(ctx context.Context, email string) (User, error
 	)
 	return i, err
 }
+
+const getUsersByEmails = `-- name: GetUsersByEmails :many
+SELECT id, first_name, last_name, email, password, created_at, updated_at FROM users WHERE lower(email) = ANY($1::text[])
+`
+
+func (q *Queries) GetUsersByEmails(ctx context.Context, emails []string) ([]User, error) {
+	rows, err := q.db.Query(ctx, getUsersByEmails, emails)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []User
+	for rows.Next() {
+		var i User
+		if err := rows.Scan(
+			&i.ID,
+			&i.FirstName,
+			&i.LastName,
+			&i.Email,
+			&i.Password,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
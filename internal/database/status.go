@@ -0,0 +1,209 @@
+package database
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ayo-awe/golang_todo_api/internal/app"
+	"github.com/ayo-awe/golang_todo_api/internal/database/sqlc"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const uniqueViolationCode = "23505"
+
+type statusRepo struct {
+	queries *sqlc.Queries
+	conn    *pgxpool.Pool
+}
+
+func NewStatusRepository(conn *pgxpool.Pool) app.StatusRepository {
+	return &statusRepo{conn: conn, queries: sqlc.New(conn)}
+}
+
+func (repo *statusRepo) toAppStatus(sqlcStatus *sqlc.TaskStatus) *app.Status {
+	return &app.Status{
+		ID:          int(sqlcStatus.ID),
+		UserID:      int(sqlcStatus.UserID),
+		WorkspaceID: int(sqlcStatus.WorkspaceID),
+		Name:        sqlcStatus.Name,
+		Category:    app.StatusCategory(sqlcStatus.Category),
+		Position:    int(sqlcStatus.Position),
+		CreatedAt:   sqlcStatus.CreatedAt.Time,
+		UpdatedAt:   sqlcStatus.UpdatedAt.Time,
+	}
+}
+
+func (repo *statusRepo) GetStatuses(ctx context.Context, workspaceID int, userID int) ([]app.Status, error) {
+	sqlcStatuses, err := repo.queries.GetTaskStatuses(ctx, sqlc.GetTaskStatusesParams{
+		WorkspaceID: int32(workspaceID),
+		UserID:      int32(userID),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]app.Status, len(sqlcStatuses))
+	for i, sqlcStatus := range sqlcStatuses {
+		statuses[i] = *repo.toAppStatus(&sqlcStatus)
+	}
+
+	return statuses, nil
+}
+
+func (repo *statusRepo) GetStatusByID(ctx context.Context, workspaceID int, userID int, statusID int) (*app.Status, error) {
+	arg := sqlc.GetTaskStatusByIDParams{
+		WorkspaceID: int32(workspaceID),
+		UserID:      int32(userID),
+		ID:          int32(statusID),
+	}
+
+	sqlcStatus, err := repo.queries.GetTaskStatusByID(ctx, arg)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, app.ErrStatusNotFound
+		}
+		return nil, err
+	}
+
+	return repo.toAppStatus(&sqlcStatus), nil
+}
+
+func (repo *statusRepo) GetStatusesByIDs(ctx context.Context, workspaceID int, statusIDs []int) ([]app.Status, error) {
+	ids := make([]int32, len(statusIDs))
+	for i, id := range statusIDs {
+		ids[i] = int32(id)
+	}
+
+	sqlcStatuses, err := repo.queries.GetTaskStatusesByIDs(ctx, sqlc.GetTaskStatusesByIDsParams{
+		WorkspaceID: int32(workspaceID),
+		Ids:         ids,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]app.Status, len(sqlcStatuses))
+	for i, sqlcStatus := range sqlcStatuses {
+		statuses[i] = *repo.toAppStatus(&sqlcStatus)
+	}
+
+	return statuses, nil
+}
+
+func (repo *statusRepo) GetDefaultStatus(ctx context.Context, workspaceID int, userID int, category app.StatusCategory) (*app.Status, error) {
+	arg := sqlc.GetDefaultTaskStatusParams{
+		WorkspaceID: int32(workspaceID),
+		UserID:      int32(userID),
+		Category:    string(category),
+	}
+
+	sqlcStatus, err := repo.queries.GetDefaultTaskStatus(ctx, arg)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, app.ErrStatusNotFound
+		}
+		return nil, err
+	}
+
+	return repo.toAppStatus(&sqlcStatus), nil
+}
+
+func (repo *statusRepo) CreateStatus(ctx context.Context, status *app.Status) (*app.Status, error) {
+	arg := sqlc.CreateTaskStatusParams{
+		UserID:      int32(status.UserID),
+		WorkspaceID: int32(status.WorkspaceID),
+		Name:        status.Name,
+		Category:    string(status.Category),
+		Position:    int32(status.Position),
+	}
+
+	sqlcStatus, err := repo.queries.CreateTaskStatus(ctx, arg)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return nil, app.ErrStatusExists
+		}
+		return nil, err
+	}
+
+	return repo.toAppStatus(&sqlcStatus), nil
+}
+
+func (repo *statusRepo) UpdateStatus(ctx context.Context, status *app.Status) (*app.Status, error) {
+	arg := sqlc.UpdateTaskStatusParams{
+		WorkspaceID: int32(status.WorkspaceID),
+		UserID:      int32(status.UserID),
+		ID:          int32(status.ID),
+		Name:        status.Name,
+		Position:    int32(status.Position),
+	}
+
+	sqlcStatus, err := repo.queries.UpdateTaskStatus(ctx, arg)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, app.ErrStatusNotFound
+		}
+		if isUniqueViolation(err) {
+			return nil, app.ErrStatusExists
+		}
+		return nil, err
+	}
+
+	return repo.toAppStatus(&sqlcStatus), nil
+}
+
+// DeleteStatus deletes a status that isn't used by any task. The last todo and done
+// statuses can't be deleted since tasks are moved into them when they are created
+// or marked as completed.
+func (repo *statusRepo) DeleteStatus(ctx context.Context, workspaceID int, userID int, statusID int) error {
+	return withTx(ctx, repo.conn, func(q *sqlc.Queries) error {
+		sqlcStatus, err := q.GetTaskStatusByID(ctx, sqlc.GetTaskStatusByIDParams{
+			WorkspaceID: int32(workspaceID),
+			UserID:      int32(userID),
+			ID:          int32(statusID),
+		})
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return app.ErrStatusNotFound
+			}
+			return err
+		}
+
+		taskCount, err := q.CountTasksWithStatus(ctx, sqlcStatus.ID)
+		if err != nil {
+			return err
+		}
+
+		if taskCount > 0 {
+			return app.ErrStatusInUse
+		}
+
+		category := app.StatusCategory(sqlcStatus.Category)
+		if category == app.StatusCategoryTodo || category == app.StatusCategoryDone {
+			statusCount, err := q.CountTaskStatusesInCategory(ctx, sqlc.CountTaskStatusesInCategoryParams{
+				WorkspaceID: int32(workspaceID),
+				UserID:      int32(userID),
+				Category:    sqlcStatus.Category,
+			})
+			if err != nil {
+				return err
+			}
+
+			if statusCount <= 1 {
+				return app.ErrStatusRequired
+			}
+		}
+
+		return q.DeleteTaskStatus(ctx, sqlc.DeleteTaskStatusParams{
+			WorkspaceID: int32(workspaceID),
+			UserID:      int32(userID),
+			ID:          int32(statusID),
+		})
+	})
+}
+
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == uniqueViolationCode
+}
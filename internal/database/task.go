@@ -2,16 +2,22 @@ package database
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"time"
 
 	"github.com/ayo-awe/golang_todo_api/internal/app"
 	"github.com/ayo-awe/golang_todo_api/internal/database/sqlc"
+	"github.com/ayo-awe/golang_todo_api/internal/rank"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"gopkg.in/guregu/null.v4"
 )
 
+// taskBatchSize is how many tasks the background jobs change per transaction
+const taskBatchSize = 100
+
 type taskRepo struct {
 	queries *sqlc.Queries
 	conn    *pgxpool.Pool
@@ -21,30 +27,155 @@ func NewTaskRepository(conn *pgxpool.Pool) app.TaskRepository {
 	return &taskRepo{conn: conn, queries: sqlc.New(conn)}
 }
 
-func (repo *taskRepo) CreateTask(ctx context.Context, task *app.Task) (*app.Task, error) {
+func (repo *taskRepo) CreateTask(ctx context.Context, task *app.Task, meta app.EventMeta) (*app.Task, error) {
+	var newTask *app.Task
+	err := withTx(ctx, repo.conn, func(q *sqlc.Queries) error {
+		var err error
+		newTask, err = repo.createTask(ctx, q, task, meta)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return newTask, nil
+}
+
+func (repo *taskRepo) CreateTasks(ctx context.Context, tasks []*app.Task, meta app.EventMeta) ([]app.Task, error) {
+	newTasks := make([]app.Task, len(tasks))
+	err := withTx(ctx, repo.conn, func(q *sqlc.Queries) error {
+		for i, task := range tasks {
+			newTask, err := repo.createTask(ctx, q, task, meta)
+			if err != nil {
+				return err
+			}
+			newTasks[i] = *newTask
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return newTasks, nil
+}
+
+func (repo *taskRepo) CreateTaskTrees(ctx context.Context, trees []app.TaskTree, meta app.EventMeta) ([]app.Task, error) {
+	var newTasks []app.Task
+
+	var createTrees func(q *sqlc.Queries, trees []app.TaskTree, parentID null.Int) error
+	createTrees = func(q *sqlc.Queries, trees []app.TaskTree, parentID null.Int) error {
+		for _, tree := range trees {
+			task := *tree.Task
+			if parentID.Valid {
+				task.ParentID = parentID
+			}
+
+			newTask, err := repo.createTask(ctx, q, &task, meta)
+			if err != nil {
+				return err
+			}
+			newTasks = append(newTasks, *newTask)
+
+			if err := createTrees(q, tree.Subtasks, null.IntFrom(int64(newTask.ID))); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	err := withTx(ctx, repo.conn, func(q *sqlc.Queries) error {
+		return createTrees(q, trees, null.Int{})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return newTasks, nil
+}
+
+func (repo *taskRepo) createTask(ctx context.Context, q *sqlc.Queries, task *app.Task, meta app.EventMeta) (*app.Task, error) {
 	arg := sqlc.CreateTaskParams{
 		Title:       task.Title,
 		Description: task.Description,
 		UserID:      int32(task.UserID),
+		StatusID:    int32(task.StatusID),
+		ProjectID:   pgtype.Int4{Int32: int32(task.ProjectID.Int64), Valid: task.ProjectID.Valid},
+		AssigneeID:  pgtype.Int4{Int32: int32(task.AssigneeID.Int64), Valid: task.AssigneeID.Valid},
+		WorkspaceID: int32(task.WorkspaceID),
+		DueAt:       pgtype.Timestamptz{Time: task.DueAt.Time, Valid: task.DueAt.Valid},
+		Priority:    string(taskPriority(task.Priority)),
+		Tags:        taskTags(task.Tags),
+		Recurrence:  task.Recurrence,
+		Uid:         pgtype.Text(task.UID.NullString),
+		ParentID:    pgtype.Int4{Int32: int32(task.ParentID.Int64), Valid: task.ParentID.Valid},
 	}
 
-	sqlcTask, err := repo.queries.CreateTask(ctx, arg)
+	// new tasks go to the end of their project's list, or the user's list
+	// if they are not in a project
+	position, err := repo.appendPosition(ctx, q, &sqlc.Task{
+		ProjectID:   arg.ProjectID,
+		UserID:      arg.UserID,
+		WorkspaceID: arg.WorkspaceID,
+	}, meta)
 	if err != nil {
 		return nil, err
 	}
+	arg.Position = position
 
-	return repo.toAppTask(&sqlcTask), nil
+	sqlcTask, err := q.CreateTask(ctx, arg)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return nil, app.ErrTaskUIDExists
+		}
+		return nil, err
+	}
+
+	newTask := repo.toAppTask(&sqlcTask)
+	if _, err := repo.recordEvent(ctx, q, app.TaskCreated, nil, newTask, meta); err != nil {
+		return nil, err
+	}
+
+	return newTask, nil
 }
 
-func (repo *taskRepo) GetTasks(ctx context.Context, userID int, filter app.TaskFilter, paging app.Paging) ([]app.Task, app.PaginationData, error) {
+func (repo *taskRepo) GetTasks(ctx context.Context, workspaceID int, userID int, filter app.TaskFilter, paging app.Paging) ([]app.Task, app.PaginationData, error) {
 	arg := sqlc.GetTasksParams{
-		UserID:      int32(userID),
-		Cursor:      int32(paging.Cursor),
-		Limit:       int32(paging.Limit()),
-		IsCompleted: pgtype.Bool(filter.IsCompleted.NullBool),
+		WorkspaceID:     int32(workspaceID),
+		UserID:          int32(userID),
+		Cursor:          int32(paging.Cursor),
+		Limit:           int32(paging.Limit()),
+		IsArchived:      filter.IsArchived,
+		IsCompleted:     pgtype.Bool(filter.IsCompleted.NullBool),
+		StatusID:        pgtype.Int4{Int32: int32(filter.StatusID.Int64), Valid: filter.StatusID.Valid},
+		StatusCategory:  pgtype.Text(filter.StatusCategory.NullString),
+		ProjectID:       pgtype.Int4{Int32: int32(filter.ProjectID.Int64), Valid: filter.ProjectID.Valid},
+		AssigneeID:      pgtype.Int4{Int32: int32(filter.AssigneeID.Int64), Valid: filter.AssigneeID.Valid},
+		MentionedUserID: pgtype.Int4{Int32: int32(filter.MentionedUserID.Int64), Valid: filter.MentionedUserID.Valid},
+		Tag:             pgtype.Text(filter.Tag.NullString),
 	}
 
-	sqlcTasks, err := repo.queries.GetTasks(ctx, arg)
+	var sqlcTasks []sqlc.Task
+	var err error
+	if filter.Sort == app.TaskSortPosition {
+		sqlcTasks, err = repo.queries.GetTasksByPosition(ctx, sqlc.GetTasksByPositionParams{
+			WorkspaceID:     arg.WorkspaceID,
+			UserID:          arg.UserID,
+			IsArchived:      arg.IsArchived,
+			IsCompleted:     arg.IsCompleted,
+			StatusID:        arg.StatusID,
+			StatusCategory:  arg.StatusCategory,
+			ProjectID:       arg.ProjectID,
+			AssigneeID:      arg.AssigneeID,
+			MentionedUserID: arg.MentionedUserID,
+			Tag:             arg.Tag,
+			CursorPosition:  pgtype.Text(paging.CursorPosition.NullString),
+			Cursor:          arg.Cursor,
+			Limit:           arg.Limit,
+		})
+	} else {
+		sqlcTasks, err = repo.queries.GetTasks(ctx, arg)
+	}
 	if err != nil {
 		return nil, app.PaginationData{}, err
 	}
@@ -54,43 +185,66 @@ func (repo *taskRepo) GetTasks(ctx context.Context, userID int, filter app.TaskF
 		tasks[i] = *repo.toAppTask(&sqlcTask)
 	}
 
-	var nextCursor null.Int
+	var nextPosition string
 	if len(tasks) == paging.Limit() {
-		lastIndex := len(tasks) - 1
-		last := tasks[lastIndex]
-
-		nextCursor = null.IntFrom(int64(last.ID))
-		tasks = tasks[:lastIndex]
+		nextPosition = tasks[len(tasks)-1].Position
 	}
 
-	paginationData := app.PaginationData{
-		NextCursor: nextCursor,
-		ItemCount:  len(tasks),
-		PerPage:    paging.PerPage,
+	tasks, paginationData := paginate(tasks, paging, func(t app.Task) int { return t.ID })
+	if filter.Sort == app.TaskSortPosition {
+		paginationData.NextCursorPosition = nextPosition
 	}
-
 	return tasks, paginationData, nil
 }
 
 func (repo *taskRepo) toAppTask(sqlcTask *sqlc.Task) *app.Task {
 	return &app.Task{
-		ID:          int(sqlcTask.ID),
-		Title:       sqlcTask.Title,
-		Description: sqlcTask.Description,
-		IsCompleted: sqlcTask.IsCompleted,
-		UserID:      int(sqlcTask.UserID),
-		CreatedAt:   sqlcTask.CreatedAt.Time,
-		UpdatedAt:   sqlcTask.UpdatedAt.Time,
+		ID:           int(sqlcTask.ID),
+		Title:        sqlcTask.Title,
+		Description:  sqlcTask.Description,
+		IsCompleted:  sqlcTask.IsCompleted,
+		UserID:       int(sqlcTask.UserID),
+		CreatedAt:    sqlcTask.CreatedAt.Time,
+		UpdatedAt:    sqlcTask.UpdatedAt.Time,
+		DeletedAt:    null.NewTime(sqlcTask.DeletedAt.Time, sqlcTask.DeletedAt.Valid),
+		CompletedAt:  null.NewTime(sqlcTask.CompletedAt.Time, sqlcTask.CompletedAt.Valid),
+		ArchivedAt:   null.NewTime(sqlcTask.ArchivedAt.Time, sqlcTask.ArchivedAt.Valid),
+		StatusID:     int(sqlcTask.StatusID),
+		Position:     sqlcTask.Position,
+		ProjectID:    null.NewInt(int64(sqlcTask.ProjectID.Int32), sqlcTask.ProjectID.Valid),
+		CommentCount: int(sqlcTask.CommentCount),
+		AssigneeID:   null.NewInt(int64(sqlcTask.AssigneeID.Int32), sqlcTask.AssigneeID.Valid),
+		WorkspaceID:  int(sqlcTask.WorkspaceID),
+		Version:      int(sqlcTask.Version),
+		DueAt:        null.NewTime(sqlcTask.DueAt.Time, sqlcTask.DueAt.Valid),
+		Priority:     app.TaskPriority(sqlcTask.Priority),
+		Tags:         sqlcTask.Tags,
+		Recurrence:   sqlcTask.Recurrence,
+		UID:          null.NewString(sqlcTask.Uid.String, sqlcTask.Uid.Valid),
+		ParentID:     null.NewInt(int64(sqlcTask.ParentID.Int32), sqlcTask.ParentID.Valid),
 	}
 }
 
-func (repo *taskRepo) GetTaskByID(ctx context.Context, userID int, taskID int) (*app.Task, error) {
-	arg := sqlc.GetTaskByIDParams{
-		UserID: int32(userID),
-		ID:     int32(taskID),
+func (repo *taskRepo) GetTaskByID(ctx context.Context, workspaceID int, taskID int) (*app.Task, error) {
+	sqlcTask, err := repo.queries.GetTaskByID(ctx, sqlc.GetTaskByIDParams{
+		WorkspaceID: int32(workspaceID),
+		ID:          int32(taskID),
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, app.ErrTaskNotFound
+		}
+		return nil, err
 	}
 
-	sqlcTask, err := repo.queries.GetTaskByID(ctx, arg)
+	return repo.toAppTask(&sqlcTask), nil
+}
+
+func (repo *taskRepo) GetTaskByUID(ctx context.Context, workspaceID int, uid string) (*app.Task, error) {
+	sqlcTask, err := repo.queries.GetTaskByUID(ctx, sqlc.GetTaskByUIDParams{
+		WorkspaceID: int32(workspaceID),
+		Uid:         pgtype.Text{String: uid, Valid: true},
+	})
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, app.ErrTaskNotFound
@@ -101,28 +255,738 @@ func (repo *taskRepo) GetTaskByID(ctx context.Context, userID int, taskID int) (
 	return repo.toAppTask(&sqlcTask), nil
 }
 
-func (repo *taskRepo) UpdateTask(ctx context.Context, task *app.Task) (*app.Task, error) {
-	arg := sqlc.UpdateTaskParams{
+func (repo *taskRepo) GetSubtasks(ctx context.Context, workspaceID int, parentID int) ([]app.Task, error) {
+	sqlcTasks, err := repo.queries.GetSubtasks(ctx, sqlc.GetSubtasksParams{
+		WorkspaceID: int32(workspaceID),
+		ParentID:    pgtype.Int4{Int32: int32(parentID), Valid: true},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	tasks := make([]app.Task, len(sqlcTasks))
+	for i, sqlcTask := range sqlcTasks {
+		tasks[i] = *repo.toAppTask(&sqlcTask)
+	}
+
+	return tasks, nil
+}
+
+func (repo *taskRepo) HasSubtasks(ctx context.Context, workspaceID int, parentID int) (bool, error) {
+	count, err := repo.queries.CountSubtasks(ctx, sqlc.CountSubtasksParams{
+		WorkspaceID: int32(workspaceID),
+		ParentID:    pgtype.Int4{Int32: int32(parentID), Valid: true},
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+func (repo *taskRepo) UpdateTask(ctx context.Context, task *app.Task, meta app.EventMeta) (*app.Task, error) {
+	return repo.mutateTask(ctx, task.WorkspaceID, task.ID, app.TaskUpdated, meta, func(q *sqlc.Queries) (sqlc.Task, error) {
+		return q.UpdateTask(ctx, repo.updateTaskParams(task))
+	})
+}
+
+func (repo *taskRepo) updateTaskParams(task *app.Task) sqlc.UpdateTaskParams {
+	return sqlc.UpdateTaskParams{
 		ID:          int32(task.ID),
 		Title:       task.Title,
 		Description: task.Description,
-		IsCompleted: task.IsCompleted,
+		StatusID:    int32(task.StatusID),
+		DeletedAt:   pgtype.Timestamptz{Time: task.DeletedAt.Time, Valid: task.DeletedAt.Valid},
+		ArchivedAt:  pgtype.Timestamptz{Time: task.ArchivedAt.Time, Valid: task.ArchivedAt.Valid},
+		ProjectID:   pgtype.Int4{Int32: int32(task.ProjectID.Int64), Valid: task.ProjectID.Valid},
+		AssigneeID:  pgtype.Int4{Int32: int32(task.AssigneeID.Int64), Valid: task.AssigneeID.Valid},
+		DueAt:       pgtype.Timestamptz{Time: task.DueAt.Time, Valid: task.DueAt.Valid},
+		Priority:    string(taskPriority(task.Priority)),
+		Tags:        taskTags(task.Tags),
+		Recurrence:  task.Recurrence,
+	}
+}
+
+// taskPriority returns the priority of tasks that were given none
+func taskPriority(priority app.TaskPriority) app.TaskPriority {
+	if priority == "" {
+		return app.PriorityNone
+	}
+	return priority
+}
+
+// taskTags keeps tasks without tags from being saved with a null array
+func taskTags(tags []string) []string {
+	if tags == nil {
+		return []string{}
+	}
+	return tags
+}
+
+func (repo *taskRepo) DeleteTask(ctx context.Context, workspaceID int, taskID int, meta app.EventMeta) error {
+	_, err := repo.mutateTask(ctx, workspaceID, taskID, app.TaskDeleted, meta, func(q *sqlc.Queries) (sqlc.Task, error) {
+		return q.DeleteTask(ctx, int32(taskID))
+	})
+	return err
+}
+
+func (repo *taskRepo) GetDeletedTasks(ctx context.Context, workspaceID int, userID int, paging app.Paging) ([]app.Task, app.PaginationData, error) {
+	arg := sqlc.GetDeletedTasksParams{
+		WorkspaceID: int32(workspaceID),
+		UserID:      int32(userID),
+		Cursor:      int32(paging.Cursor),
+		Limit:       int32(paging.Limit()),
 	}
 
-	sqlcTask, err := repo.queries.UpdateTask(ctx, arg)
+	sqlcTasks, err := repo.queries.GetDeletedTasks(ctx, arg)
+	if err != nil {
+		return nil, app.PaginationData{}, err
+	}
+
+	tasks := make([]app.Task, len(sqlcTasks))
+	for i, sqlcTask := range sqlcTasks {
+		tasks[i] = *repo.toAppTask(&sqlcTask)
+	}
+
+	tasks, paginationData := paginate(tasks, paging, func(t app.Task) int { return t.ID })
+	return tasks, paginationData, nil
+}
+
+func (repo *taskRepo) RestoreTask(ctx context.Context, workspaceID int, taskID int, meta app.EventMeta) (*app.Task, error) {
+	return repo.mutateTask(ctx, workspaceID, taskID, app.TaskRestored, meta, func(q *sqlc.Queries) (sqlc.Task, error) {
+		return q.RestoreTask(ctx, int32(taskID))
+	})
+}
+
+func (repo *taskRepo) PurgeTask(ctx context.Context, workspaceID int, taskID int, meta app.EventMeta) error {
+	_, err := repo.mutateTask(ctx, workspaceID, taskID, app.TaskPurged, meta, func(q *sqlc.Queries) (sqlc.Task, error) {
+		return q.PurgeTask(ctx, int32(taskID))
+	})
+	return err
+}
+
+// PurgeDeletedTasks purges the tasks trashed before deletedBefore in batches,
+// recording an event for each like a purge made through the API
+func (repo *taskRepo) PurgeDeletedTasks(ctx context.Context, deletedBefore time.Time, meta app.EventMeta) (int, error) {
+	return repo.mutateTaskBatches(ctx, app.TaskPurged, meta, func(q *sqlc.Queries) ([]sqlc.Task, error) {
+		return q.GetExpiredDeletedTasksForUpdate(ctx, sqlc.GetExpiredDeletedTasksForUpdateParams{
+			DeletedBefore: pgtype.Timestamptz{Time: deletedBefore, Valid: true},
+			Limit:         taskBatchSize,
+		})
+	}, func(q *sqlc.Queries, taskID int32) (sqlc.Task, error) {
+		return q.PurgeTask(ctx, taskID)
+	})
+}
+
+func (repo *taskRepo) ArchiveTask(ctx context.Context, workspaceID int, taskID int, meta app.EventMeta) (*app.Task, error) {
+	return repo.mutateTask(ctx, workspaceID, taskID, app.TaskArchived, meta, func(q *sqlc.Queries) (sqlc.Task, error) {
+		return q.ArchiveTask(ctx, int32(taskID))
+	})
+}
+
+func (repo *taskRepo) UnarchiveTask(ctx context.Context, workspaceID int, taskID int, meta app.EventMeta) (*app.Task, error) {
+	return repo.mutateTask(ctx, workspaceID, taskID, app.TaskUnarchived, meta, func(q *sqlc.Queries) (sqlc.Task, error) {
+		return q.UnarchiveTask(ctx, int32(taskID))
+	})
+}
+
+// ArchiveCompletedTasks archives the tasks completed before completedBefore in
+// batches, recording an event for each like an archive made through the API
+func (repo *taskRepo) ArchiveCompletedTasks(ctx context.Context, completedBefore time.Time, meta app.EventMeta) (int, error) {
+	return repo.mutateTaskBatches(ctx, app.TaskArchived, meta, func(q *sqlc.Queries) ([]sqlc.Task, error) {
+		return q.GetExpiredCompletedTasksForUpdate(ctx, sqlc.GetExpiredCompletedTasksForUpdateParams{
+			CompletedBefore: pgtype.Timestamptz{Time: completedBefore, Valid: true},
+			Limit:           taskBatchSize,
+		})
+	}, func(q *sqlc.Queries, taskID int32) (sqlc.Task, error) {
+		return q.ArchiveTask(ctx, taskID)
+	})
+}
+
+func (repo *taskRepo) ClaimTaskReminders(ctx context.Context, dueAfter time.Time, dueBefore time.Time) ([]app.Task, error) {
+	sqlcTasks, err := repo.queries.ClaimTaskReminders(ctx, sqlc.ClaimTaskRemindersParams{
+		DueAfter:  pgtype.Timestamptz{Time: dueAfter, Valid: true},
+		DueBefore: pgtype.Timestamptz{Time: dueBefore, Valid: true},
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	return repo.toAppTask(&sqlcTask), nil
+	tasks := make([]app.Task, len(sqlcTasks))
+	for i, sqlcTask := range sqlcTasks {
+		tasks[i] = *repo.toAppTask(&sqlcTask)
+	}
+
+	return tasks, nil
+}
+
+func (repo *taskRepo) GetTaskEvents(ctx context.Context, workspaceID int, taskID int, paging app.Paging) ([]app.TaskEvent, app.PaginationData, error) {
+	arg := sqlc.GetTaskEventsParams{
+		TaskID:      int32(taskID),
+		WorkspaceID: int32(workspaceID),
+		Cursor:      int32(paging.Cursor),
+		Limit:       int32(paging.Limit()),
+	}
+
+	sqlcEvents, err := repo.queries.GetTaskEvents(ctx, arg)
+	if err != nil {
+		return nil, app.PaginationData{}, err
+	}
+
+	events := make([]app.TaskEvent, len(sqlcEvents))
+	for i, sqlcEvent := range sqlcEvents {
+		event, err := repo.toAppTaskEvent(&sqlcEvent)
+		if err != nil {
+			return nil, app.PaginationData{}, err
+		}
+		events[i] = *event
+	}
+
+	events, paginationData := paginate(events, paging, func(e app.TaskEvent) int { return e.ID })
+	return events, paginationData, nil
+}
+
+func (repo *taskRepo) toAppTaskEvent(sqlcEvent *sqlc.TaskEvent) (*app.TaskEvent, error) {
+	var changes map[string]app.FieldChange
+	if err := json.Unmarshal(sqlcEvent.Changes, &changes); err != nil {
+		return nil, err
+	}
+
+	return &app.TaskEvent{
+		ID:                int(sqlcEvent.ID),
+		TaskID:            int(sqlcEvent.TaskID),
+		ActorID:           null.NewInt(int64(sqlcEvent.ActorID.Int32), sqlcEvent.ActorID.Valid),
+		Action:            app.TaskAction(sqlcEvent.Action),
+		Changes:           changes,
+		RequestID:         sqlcEvent.RequestID,
+		CreatedAt:         sqlcEvent.CreatedAt.Time,
+		RevertedBy:        null.NewInt(int64(sqlcEvent.RevertedBy.Int32), sqlcEvent.RevertedBy.Valid),
+		Position:          app.EventPosition{XactID: sqlcEvent.XactID, EventID: int(sqlcEvent.ID)},
+		ProjectID:         null.NewInt(int64(sqlcEvent.ProjectID.Int32), sqlcEvent.ProjectID.Valid),
+		PreviousProjectID: null.NewInt(int64(sqlcEvent.PreviousProjectID.Int32), sqlcEvent.PreviousProjectID.Valid),
+	}, nil
+}
+
+func (repo *taskRepo) GetTaskChanges(ctx context.Context, workspaceID int, userID int, after app.EventPosition, upTo app.EventPosition, limit int) ([]app.TaskChange, error) {
+	sqlcEvents, err := repo.queries.GetTaskEventsAfter(ctx, sqlc.GetTaskEventsAfterParams{
+		AfterXactID: after.XactID,
+		AfterID:     int32(after.EventID),
+		UpToXactID:  upTo.XactID,
+		UpToID:      int32(upTo.EventID),
+		UserID:      int32(userID),
+		WorkspaceID: int32(workspaceID),
+		Limit:       int32(limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	taskIDs := make([]int32, len(sqlcEvents))
+	for i, sqlcEvent := range sqlcEvents {
+		taskIDs[i] = sqlcEvent.TaskID
+	}
+
+	// tasks the user can't see anymore are left out, and sent as tombstones
+	sqlcTasks, err := repo.queries.GetTasksByIDs(ctx, sqlc.GetTasksByIDsParams{
+		WorkspaceID: int32(workspaceID),
+		UserID:      int32(userID),
+		Ids:         taskIDs,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	tasks := make(map[int]*app.Task, len(sqlcTasks))
+	for _, sqlcTask := range sqlcTasks {
+		tasks[int(sqlcTask.ID)] = repo.toAppTask(&sqlcTask)
+	}
+
+	changes := make([]app.TaskChange, len(sqlcEvents))
+	for i, sqlcEvent := range sqlcEvents {
+		event, err := repo.toAppTaskEvent(&sqlcEvent)
+		if err != nil {
+			return nil, err
+		}
+		changes[i] = app.TaskChange{Event: *event, Task: tasks[event.TaskID]}
+	}
+
+	return changes, nil
+}
+
+func (repo *taskRepo) GetTaskEventWatermark(ctx context.Context) (app.EventPosition, error) {
+	xactID, err := repo.queries.GetTaskEventWatermark(ctx)
+	if err != nil {
+		return app.EventPosition{}, err
+	}
+
+	return app.EventPosition{XactID: xactID}, nil
+}
+
+func (repo *taskRepo) GetSyncTasks(ctx context.Context, workspaceID int, userID int, afterID int, limit int) ([]app.Task, error) {
+	sqlcTasks, err := repo.queries.GetSyncTasks(ctx, sqlc.GetSyncTasksParams{
+		WorkspaceID: int32(workspaceID),
+		UserID:      int32(userID),
+		AfterID:     int32(afterID),
+		Limit:       int32(limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	tasks := make([]app.Task, len(sqlcTasks))
+	for i, sqlcTask := range sqlcTasks {
+		tasks[i] = *repo.toAppTask(&sqlcTask)
+	}
+
+	return tasks, nil
+}
+
+func (repo *taskRepo) GetCalendarTasks(ctx context.Context, workspaceID int, userID int, completedDueAfter time.Time, limit int) ([]app.Task, error) {
+	sqlcTasks, err := repo.queries.GetCalendarTasks(ctx, sqlc.GetCalendarTasksParams{
+		WorkspaceID:       int32(workspaceID),
+		UserID:            int32(userID),
+		CompletedDueAfter: pgtype.Timestamptz{Time: completedDueAfter, Valid: true},
+		Limit:             int32(limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	tasks := make([]app.Task, len(sqlcTasks))
+	for i, sqlcTask := range sqlcTasks {
+		tasks[i] = *repo.toAppTask(&sqlcTask)
+	}
+
+	return tasks, nil
+}
+
+func (repo *taskRepo) GetUndoableTaskEvents(ctx context.Context, workspaceID int, actorID int, paging app.Paging) ([]app.TaskEvent, app.PaginationData, error) {
+	arg := sqlc.GetUndoableTaskEventsParams{
+		WorkspaceID: int32(workspaceID),
+		ActorID:     pgtype.Int4{Int32: int32(actorID), Valid: true},
+		Cursor:      int32(paging.Cursor),
+		Limit:       int32(paging.Limit()),
+	}
+
+	sqlcEvents, err := repo.queries.GetUndoableTaskEvents(ctx, arg)
+	if err != nil {
+		return nil, app.PaginationData{}, err
+	}
+
+	events := make([]app.TaskEvent, len(sqlcEvents))
+	for i, sqlcEvent := range sqlcEvents {
+		event, err := repo.toAppTaskEvent(&sqlcEvent)
+		if err != nil {
+			return nil, app.PaginationData{}, err
+		}
+		events[i] = *event
+	}
+
+	events, paginationData := paginate(events, paging, func(e app.TaskEvent) int { return e.ID })
+	return events, paginationData, nil
+}
+
+func (repo *taskRepo) UndoLastTaskChange(ctx context.Context, workspaceID int, actorID int, taskID int, meta app.EventMeta) (*app.Task, *app.TaskEvent, error) {
+	return repo.undo(ctx, workspaceID, actorID, pgtype.Int4{Int32: int32(taskID), Valid: true}, meta)
+}
+
+// undo reverts the latest undoable event made by actorID, optionally limited to a
+// single task. The revert is recorded as a new event and the undone event is marked
+// as reverted by it so that repeated undos walk back through the history.
+func (repo *taskRepo) undo(ctx context.Context, workspaceID int, actorID int, taskID pgtype.Int4, meta app.EventMeta) (*app.Task, *app.TaskEvent, error) {
+	var task *app.Task
+	var undone *app.TaskEvent
+
+	err := withTx(ctx, repo.conn, func(q *sqlc.Queries) error {
+		sqlcEvent, err := q.GetLatestUndoableTaskEvent(ctx, sqlc.GetLatestUndoableTaskEventParams{
+			ActorID: pgtype.Int4{Int32: int32(actorID), Valid: true},
+			TaskID:  taskID,
+		})
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return app.ErrNothingToUndo
+			}
+			return err
+		}
+
+		undone, err = repo.toAppTaskEvent(&sqlcEvent)
+		if err != nil {
+			return err
+		}
+
+		sqlcCurrent, err := q.GetTaskForUpdate(ctx, sqlc.GetTaskForUpdateParams{
+			WorkspaceID: int32(workspaceID),
+			ID:          sqlcEvent.TaskID,
+		})
+		if err != nil {
+			// the task has been purged since
+			if errors.Is(err, pgx.ErrNoRows) {
+				return app.ErrUndoConflict
+			}
+			return err
+		}
+
+		hasNewer, err := q.HasNewerTaskEvents(ctx, sqlc.HasNewerTaskEventsParams{
+			TaskID: sqlcEvent.TaskID,
+			ID:     sqlcEvent.ID,
+		})
+		if err != nil {
+			return err
+		}
+
+		if hasNewer {
+			return app.ErrUndoConflict
+		}
+
+		current := repo.toAppTask(&sqlcCurrent)
+		reverted, err := app.RevertTask(current, undone)
+		if err != nil {
+			return err
+		}
+
+		sqlcTask, err := q.UpdateTask(ctx, repo.updateTaskParams(reverted))
+		if err != nil {
+			return err
+		}
+
+		task = repo.toAppTask(&sqlcTask)
+		revertEvent, err := repo.recordEvent(ctx, q, app.TaskReverted, current, task, meta)
+		if err != nil {
+			return err
+		}
+
+		undone.RevertedBy = null.IntFrom(int64(revertEvent.ID))
+		return q.SetTaskEventRevertedBy(ctx, sqlc.SetTaskEventRevertedByParams{
+			ID:         sqlcEvent.ID,
+			RevertedBy: pgtype.Int4{Int32: revertEvent.ID, Valid: true},
+		})
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return task, undone, nil
+}
+
+// MoveTask places a task between its new neighbours in its list, which
+// rebalances the list if there's no room left between them
+func (repo *taskRepo) MoveTask(ctx context.Context, workspaceID int, taskID int, move app.TaskMove, meta app.EventMeta) (*app.Task, error) {
+	return repo.mutateTask(ctx, workspaceID, taskID, app.TaskMoved, meta, func(q *sqlc.Queries) (sqlc.Task, error) {
+		sqlcTask, err := q.GetTaskForUpdate(ctx, sqlc.GetTaskForUpdateParams{
+			WorkspaceID: int32(workspaceID),
+			ID:          int32(taskID),
+		})
+		if err != nil {
+			return sqlc.Task{}, err
+		}
+
+		position, err := repo.movePosition(ctx, q, &sqlcTask, move, meta)
+		if err != nil {
+			return sqlc.Task{}, err
+		}
+
+		return q.SetTaskPosition(ctx, sqlc.SetTaskPositionParams{
+			WorkspaceID: int32(workspaceID),
+			ID:          int32(taskID),
+			Position:    position,
+			StatusID:    pgtype.Int4{Int32: int32(move.StatusID.Int64), Valid: move.StatusID.Valid},
+		})
+	})
+}
+
+// movePosition returns a position between the task's new neighbours, rebalancing
+// the task's list once if there is no room left between them
+func (repo *taskRepo) movePosition(ctx context.Context, q *sqlc.Queries, task *sqlc.Task, move app.TaskMove, meta app.EventMeta) (string, error) {
+	for rebalanced := false; ; rebalanced = true {
+		lower, upper, err := repo.moveBounds(ctx, q, task, move)
+		if err != nil {
+			return "", err
+		}
+
+		position, err := rank.Between(lower, upper)
+		if err == nil && (len(position) <= rank.MaxLength || rebalanced) {
+			return position, nil
+		}
+
+		if err != nil && (rebalanced || !errors.Is(err, rank.ErrInvalidRange)) {
+			return "", err
+		}
+
+		if err := repo.rebalancePositions(ctx, q, task, meta); err != nil {
+			return "", err
+		}
+	}
+}
+
+// appendPosition returns a position after the last task in the task's list,
+// rebalancing the list once if the position would get too long
+func (repo *taskRepo) appendPosition(ctx context.Context, q *sqlc.Queries, task *sqlc.Task, meta app.EventMeta) (string, error) {
+	for rebalanced := false; ; rebalanced = true {
+		last, err := q.GetLastTaskPosition(ctx, sqlc.GetLastTaskPositionParams{
+			ProjectID:   task.ProjectID,
+			UserID:      task.UserID,
+			WorkspaceID: task.WorkspaceID,
+		})
+		if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+			return "", err
+		}
+
+		position, err := rank.After(last)
+		if err != nil || len(position) <= rank.MaxLength || rebalanced {
+			return position, err
+		}
 
+		if err := repo.rebalancePositions(ctx, q, task, meta); err != nil {
+			return "", err
+		}
+	}
+}
+
+// moveBounds returns the positions a moved task has to be placed between.
+// Tasks are ordered within their project, or within their owner's tasks
+// outside of projects in the workspace, so neighbours have to come from the same list.
+func (repo *taskRepo) moveBounds(ctx context.Context, q *sqlc.Queries, task *sqlc.Task, move app.TaskMove) (string, string, error) {
+	var after, before *sqlc.Task
+	for _, neighbour := range []struct {
+		id   null.Int
+		task **sqlc.Task
+	}{{move.AfterID, &after}, {move.BeforeID, &before}} {
+		if !neighbour.id.Valid {
+			continue
+		}
+
+		if int32(neighbour.id.Int64) == task.ID {
+			return "", "", app.ErrInvalidMove
+		}
+
+		sqlcTask, err := q.GetTaskByID(ctx, sqlc.GetTaskByIDParams{
+			WorkspaceID: task.WorkspaceID,
+			ID:          int32(neighbour.id.Int64),
+		})
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return "", "", app.ErrInvalidMove
+			}
+			return "", "", err
+		}
+
+		if sqlcTask.DeletedAt.Valid || !inSameList(task, &sqlcTask) {
+			return "", "", app.ErrInvalidMove
+		}
+
+		*neighbour.task = &sqlcTask
+	}
+
+	switch {
+	case after != nil && before != nil:
+		if after.Position > before.Position || (after.Position == before.Position && after.ID > before.ID) {
+			return "", "", app.ErrInvalidMove
+		}
+		return after.Position, before.Position, nil
+
+	case after != nil:
+		upper, err := q.GetNextTaskPosition(ctx, sqlc.GetNextTaskPositionParams{
+			ProjectID:   task.ProjectID,
+			UserID:      task.UserID,
+			WorkspaceID: task.WorkspaceID,
+			TaskID:      task.ID,
+			Position:    after.Position,
+			NeighbourID: after.ID,
+		})
+		if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+			return "", "", err
+		}
+		return after.Position, upper, nil
+
+	case before != nil:
+		lower, err := q.GetPreviousTaskPosition(ctx, sqlc.GetPreviousTaskPositionParams{
+			ProjectID:   task.ProjectID,
+			UserID:      task.UserID,
+			WorkspaceID: task.WorkspaceID,
+			TaskID:      task.ID,
+			Position:    before.Position,
+			NeighbourID: before.ID,
+		})
+		if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+			return "", "", err
+		}
+		return lower, before.Position, nil
+	}
+
+	last, err := q.GetLastTaskPosition(ctx, sqlc.GetLastTaskPositionParams{
+		ProjectID:   task.ProjectID,
+		UserID:      task.UserID,
+		WorkspaceID: task.WorkspaceID,
+	})
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return "", "", err
+	}
+	return last, "", nil
+}
+
+func inSameList(a, b *sqlc.Task) bool {
+	if a.ProjectID.Valid || b.ProjectID.Valid {
+		return a.ProjectID == b.ProjectID
+	}
+
+	return a.UserID == b.UserID && a.WorkspaceID == b.WorkspaceID
+}
+
+// rebalancePositions spreads the positions of all the tasks in the task's list
+// evenly, keeping their order. Every rebalanced task gets a moved event, except
+// the task itself whose change is recorded by the caller.
+func (repo *taskRepo) rebalancePositions(ctx context.Context, q *sqlc.Queries, task *sqlc.Task, meta app.EventMeta) error {
+	sqlcOlds, err := q.GetListTasksForUpdate(ctx, sqlc.GetListTasksForUpdateParams{
+		ProjectID:   task.ProjectID,
+		UserID:      task.UserID,
+		WorkspaceID: task.WorkspaceID,
+	})
+	if err != nil {
+		return err
+	}
+
+	ids := make([]int32, len(sqlcOlds))
+	for i, sqlcOld := range sqlcOlds {
+		ids[i] = sqlcOld.ID
+	}
+
+	sqlcTasks, err := q.SetTaskPositions(ctx, sqlc.SetTaskPositionsParams{
+		Ids:       ids,
+		Positions: rank.Spread(len(ids)),
+	})
+	if err != nil {
+		return err
+	}
+
+	olds := make(map[int32]*sqlc.Task, len(sqlcOlds))
+	for i := range sqlcOlds {
+		olds[sqlcOlds[i].ID] = &sqlcOlds[i]
+	}
+
+	for _, sqlcTask := range sqlcTasks {
+		if sqlcTask.ID == task.ID {
+			continue
+		}
+
+		if _, err := repo.recordEvent(ctx, q, app.TaskMoved, repo.toAppTask(olds[sqlcTask.ID]), repo.toAppTask(&sqlcTask), meta); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// mutateTask locks a task, applies change to it and records the resulting event
+// in the same transaction
+func (repo *taskRepo) mutateTask(ctx context.Context, workspaceID int, taskID int, action app.TaskAction, meta app.EventMeta, change func(*sqlc.Queries) (sqlc.Task, error)) (*app.Task, error) {
+	var task *app.Task
+	err := withTx(ctx, repo.conn, func(q *sqlc.Queries) error {
+		sqlcOld, err := q.GetTaskForUpdate(ctx, sqlc.GetTaskForUpdateParams{
+			WorkspaceID: int32(workspaceID),
+			ID:          int32(taskID),
+		})
+		if err != nil {
+			return err
+		}
+
+		// the task is locked, so it can't change between this check and the change
+		if meta.ExpectedVersion != 0 && int(sqlcOld.Version) != meta.ExpectedVersion {
+			return app.ErrVersionConflict
+		}
+
+		sqlcTask, err := change(q)
+		if err != nil {
+			return err
+		}
+
+		task = repo.toAppTask(&sqlcTask)
+		_, err = repo.recordEvent(ctx, q, action, repo.toAppTask(&sqlcOld), task, meta)
+		return err
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, app.ErrTaskNotFound
+		}
+		return nil, err
+	}
+
+	return task, nil
 }
 
-func (repo *taskRepo) DeleteTask(ctx context.Context, userID int, taskID int) error {
-	arg := sqlc.DeleteTaskParams{
-		UserID: int32(userID),
-		ID:     int32(taskID),
+// mutateTaskBatches applies change to every task returned by batch, one batch
+// per transaction, recording the resulting events like mutateTask. batch has to
+// lock the tasks it returns and return at most taskBatchSize of them. It returns
+// how many tasks were changed.
+func (repo *taskRepo) mutateTaskBatches(ctx context.Context, action app.TaskAction, meta app.EventMeta, batch func(*sqlc.Queries) ([]sqlc.Task, error), change func(*sqlc.Queries, int32) (sqlc.Task, error)) (int, error) {
+	count := 0
+	for {
+		var changed int
+		err := withTx(ctx, repo.conn, func(q *sqlc.Queries) error {
+			sqlcOlds, err := batch(q)
+			if err != nil {
+				return err
+			}
+
+			for _, sqlcOld := range sqlcOlds {
+				sqlcTask, err := change(q, sqlcOld.ID)
+				if err != nil {
+					return err
+				}
+
+				if _, err := repo.recordEvent(ctx, q, action, repo.toAppTask(&sqlcOld), repo.toAppTask(&sqlcTask), meta); err != nil {
+					return err
+				}
+			}
+
+			changed = len(sqlcOlds)
+			return nil
+		})
+		if err != nil {
+			return count, err
+		}
+
+		count += changed
+		if changed < taskBatchSize {
+			return count, nil
+		}
+	}
+}
+
+func (repo *taskRepo) recordEvent(ctx context.Context, q *sqlc.Queries, action app.TaskAction, old, new *app.Task, meta app.EventMeta) (sqlc.TaskEvent, error) {
+	diff, err := app.DiffTasks(old, new)
+	if err != nil {
+		return sqlc.TaskEvent{}, err
+	}
+
+	changes, err := json.Marshal(diff)
+	if err != nil {
+		return sqlc.TaskEvent{}, err
+	}
+
+	// the event is sent to those who could see the task before the change too
+	previousProjectID := new.ProjectID
+	if old != nil {
+		previousProjectID = old.ProjectID
+	}
+
+	event, err := q.CreateTaskEvent(ctx, sqlc.CreateTaskEventParams{
+		TaskID:            int32(new.ID),
+		UserID:            int32(new.UserID),
+		ActorID:           pgtype.Int4{Int32: int32(meta.ActorID), Valid: meta.ActorID != 0},
+		Action:            string(action),
+		Changes:           changes,
+		RequestID:         meta.RequestID,
+		WorkspaceID:       int32(new.WorkspaceID),
+		ProjectID:         pgtype.Int4{Int32: int32(new.ProjectID.Int64), Valid: new.ProjectID.Valid},
+		PreviousProjectID: pgtype.Int4{Int32: int32(previousProjectID.Int64), Valid: previousProjectID.Valid},
+	})
+	if err != nil {
+		return sqlc.TaskEvent{}, err
+	}
+
+	if err := enqueueWebhookDeliveries(ctx, q, new, event, diff); err != nil {
+		return sqlc.TaskEvent{}, err
 	}
 
-	return repo.queries.DeleteTask(ctx, arg)
+	return event, nil
 }
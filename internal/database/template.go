@@ -0,0 +1,134 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/ayo-awe/golang_todo_api/internal/app"
+	"github.com/ayo-awe/golang_todo_api/internal/database/sqlc"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type templateRepo struct {
+	queries *sqlc.Queries
+	conn    *pgxpool.Pool
+}
+
+func NewTemplateRepository(conn *pgxpool.Pool) app.TemplateRepository {
+	return &templateRepo{conn: conn, queries: sqlc.New(conn)}
+}
+
+func (repo *templateRepo) toAppTemplate(sqlcTemplate *sqlc.TaskTemplate) (*app.TaskTemplate, error) {
+	template := &app.TaskTemplate{
+		ID:          int(sqlcTemplate.ID),
+		UserID:      int(sqlcTemplate.UserID),
+		WorkspaceID: int(sqlcTemplate.WorkspaceID),
+		Name:        sqlcTemplate.Name,
+		CreatedAt:   sqlcTemplate.CreatedAt.Time,
+		UpdatedAt:   sqlcTemplate.UpdatedAt.Time,
+	}
+
+	if err := json.Unmarshal(sqlcTemplate.Task, &template.Task); err != nil {
+		return nil, err
+	}
+
+	return template, nil
+}
+
+func (repo *templateRepo) GetTemplates(ctx context.Context, userID int, workspaceID int) ([]app.TaskTemplate, error) {
+	sqlcTemplates, err := repo.queries.GetTaskTemplates(ctx, sqlc.GetTaskTemplatesParams{
+		UserID:      int32(userID),
+		WorkspaceID: int32(workspaceID),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	templates := make([]app.TaskTemplate, len(sqlcTemplates))
+	for i, sqlcTemplate := range sqlcTemplates {
+		template, err := repo.toAppTemplate(&sqlcTemplate)
+		if err != nil {
+			return nil, err
+		}
+		templates[i] = *template
+	}
+
+	return templates, nil
+}
+
+func (repo *templateRepo) GetTemplateByID(ctx context.Context, userID int, workspaceID int, templateID int) (*app.TaskTemplate, error) {
+	sqlcTemplate, err := repo.queries.GetTaskTemplateByID(ctx, sqlc.GetTaskTemplateByIDParams{
+		UserID:      int32(userID),
+		WorkspaceID: int32(workspaceID),
+		ID:          int32(templateID),
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, app.ErrTemplateNotFound
+		}
+		return nil, err
+	}
+
+	return repo.toAppTemplate(&sqlcTemplate)
+}
+
+func (repo *templateRepo) CreateTemplate(ctx context.Context, template *app.TaskTemplate) (*app.TaskTemplate, error) {
+	task, err := json.Marshal(template.Task)
+	if err != nil {
+		return nil, err
+	}
+
+	sqlcTemplate, err := repo.queries.CreateTaskTemplate(ctx, sqlc.CreateTaskTemplateParams{
+		UserID:      int32(template.UserID),
+		WorkspaceID: int32(template.WorkspaceID),
+		Name:        template.Name,
+		Task:        task,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return repo.toAppTemplate(&sqlcTemplate)
+}
+
+func (repo *templateRepo) UpdateTemplate(ctx context.Context, template *app.TaskTemplate) (*app.TaskTemplate, error) {
+	task, err := json.Marshal(template.Task)
+	if err != nil {
+		return nil, err
+	}
+
+	sqlcTemplate, err := repo.queries.UpdateTaskTemplate(ctx, sqlc.UpdateTaskTemplateParams{
+		UserID:      int32(template.UserID),
+		WorkspaceID: int32(template.WorkspaceID),
+		ID:          int32(template.ID),
+		Name:        template.Name,
+		Task:        task,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, app.ErrTemplateNotFound
+		}
+		return nil, err
+	}
+
+	return repo.toAppTemplate(&sqlcTemplate)
+}
+
+func (repo *templateRepo) DeleteTemplate(ctx context.Context, userID int, workspaceID int, templateID int) error {
+	count, err := repo.queries.DeleteTaskTemplate(ctx, sqlc.DeleteTaskTemplateParams{
+		UserID:      int32(userID),
+		WorkspaceID: int32(workspaceID),
+		ID:          int32(templateID),
+	})
+	if err != nil {
+		return err
+	}
+
+	if count == 0 {
+		return app.ErrTemplateNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,24 @@
+package database
+
+import (
+	"context"
+
+	"github.com/ayo-awe/golang_todo_api/internal/database/sqlc"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// withTx runs fn in a transaction which is committed if fn returns a nil error
+// and rolled back otherwise
+//
+// Workspaces are isolated by the workspace_id every query filters on, not by
+// row-level security. Policies keyed on a setting like app.workspace_id would
+// only see it inside withTx, while most reads run on the pool outside of a
+// transaction, and the background jobs and change feed work across every
+// workspace. Backing the filters with RLS needs every query to go through a
+// transaction that knows its workspace first.
+func withTx(ctx context.Context, conn *pgxpool.Pool, fn func(*sqlc.Queries) error) error {
+	return pgx.BeginFunc(ctx, conn, func(tx pgx.Tx) error {
+		return fn(sqlc.New(tx))
+	})
+}
@@ -20,12 +20,24 @@ func NewUserRepository(conn *pgxpool.Pool) app.UserRepository {
 	return &userRepo{queries: queries, conn: conn}
 }
 
+// CreateUser creates a user along with their default task statuses and
+// their personal workspace
 func (repo *userRepo) CreateUser(ctx context.Context, user *app.User) (*app.User, error) {
-	sqlcUser, err := repo.queries.CreateUser(ctx, sqlc.CreateUserParams{
-		FirstName: user.Firstname,
-		LastName:  user.Lastname,
-		Email:     user.Email,
-		Password:  user.Password,
+	var sqlcUser sqlc.User
+	err := withTx(ctx, repo.conn, func(q *sqlc.Queries) error {
+		var err error
+		sqlcUser, err = q.CreateUser(ctx, sqlc.CreateUserParams{
+			FirstName: user.Firstname,
+			LastName:  user.Lastname,
+			Email:     user.Email,
+			Password:  user.Password,
+		})
+		if err != nil {
+			return err
+		}
+
+		_, err = createWorkspace(ctx, q, "Personal", int(sqlcUser.ID), true)
+		return err
 	})
 
 	if err != nil {
@@ -47,6 +59,20 @@ func (repo *userRepo) toAppUser(sqlcUser sqlc.User) *app.User {
 	}
 }
 
+func (repo *userRepo) GetUsersByEmails(ctx context.Context, emails []string) ([]app.User, error) {
+	sqlcUsers, err := repo.queries.GetUsersByEmails(ctx, emails)
+	if err != nil {
+		return nil, err
+	}
+
+	users := make([]app.User, len(sqlcUsers))
+	for i, sqlcUser := range sqlcUsers {
+		users[i] = *repo.toAppUser(sqlcUser)
+	}
+
+	return users, nil
+}
+
 func (repo *userRepo) GetUserByEmail(ctx context.Context, email string) (*app.User, error) {
 	sqlcUser, err := repo.queries.GetUserByEmail(ctx, email)
 	if err != nil {
@@ -0,0 +1,286 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/ayo-awe/golang_todo_api/internal/app"
+	"github.com/ayo-awe/golang_todo_api/internal/database/sqlc"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"gopkg.in/guregu/null.v4"
+)
+
+type webhookRepo struct {
+	queries *sqlc.Queries
+	conn    *pgxpool.Pool
+}
+
+func NewWebhookRepository(conn *pgxpool.Pool) app.WebhookRepository {
+	return &webhookRepo{conn: conn, queries: sqlc.New(conn)}
+}
+
+func (repo *webhookRepo) toAppWebhook(sqlcWebhook *sqlc.Webhook) *app.Webhook {
+	eventTypes := make([]app.WebhookEventType, len(sqlcWebhook.EventTypes))
+	for i, eventType := range sqlcWebhook.EventTypes {
+		eventTypes[i] = app.WebhookEventType(eventType)
+	}
+
+	return &app.Webhook{
+		ID:          int(sqlcWebhook.ID),
+		UserID:      int(sqlcWebhook.UserID),
+		WorkspaceID: int(sqlcWebhook.WorkspaceID),
+		URL:         sqlcWebhook.Url,
+		Secret:      sqlcWebhook.Secret,
+		EventTypes:  eventTypes,
+		IsActive:    sqlcWebhook.IsActive,
+		CreatedAt:   sqlcWebhook.CreatedAt.Time,
+		UpdatedAt:   sqlcWebhook.UpdatedAt.Time,
+	}
+}
+
+func (repo *webhookRepo) toAppDelivery(sqlcDelivery *sqlc.WebhookDelivery) *app.WebhookDelivery {
+	return &app.WebhookDelivery{
+		ID:             int(sqlcDelivery.ID),
+		WebhookID:      int(sqlcDelivery.WebhookID),
+		EventType:      app.WebhookEventType(sqlcDelivery.EventType),
+		Payload:        sqlcDelivery.Payload,
+		Status:         app.WebhookDeliveryStatus(sqlcDelivery.Status),
+		Attempts:       int(sqlcDelivery.Attempts),
+		NextAttemptAt:  sqlcDelivery.NextAttemptAt.Time,
+		LastAttemptAt:  null.NewTime(sqlcDelivery.LastAttemptAt.Time, sqlcDelivery.LastAttemptAt.Valid),
+		ResponseStatus: null.NewInt(int64(sqlcDelivery.ResponseStatus.Int32), sqlcDelivery.ResponseStatus.Valid),
+		ResponseBody:   sqlcDelivery.ResponseBody,
+		Error:          sqlcDelivery.Error,
+		CreatedAt:      sqlcDelivery.CreatedAt.Time,
+	}
+}
+
+func eventTypeStrings(eventTypes []app.WebhookEventType) []string {
+	strs := make([]string, len(eventTypes))
+	for i, eventType := range eventTypes {
+		strs[i] = string(eventType)
+	}
+
+	return strs
+}
+
+func (repo *webhookRepo) GetWebhooks(ctx context.Context, workspaceID int, userID int) ([]app.Webhook, error) {
+	sqlcWebhooks, err := repo.queries.GetWebhooks(ctx, sqlc.GetWebhooksParams{
+		WorkspaceID: int32(workspaceID),
+		UserID:      int32(userID),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	webhooks := make([]app.Webhook, len(sqlcWebhooks))
+	for i, sqlcWebhook := range sqlcWebhooks {
+		webhooks[i] = *repo.toAppWebhook(&sqlcWebhook)
+	}
+
+	return webhooks, nil
+}
+
+func (repo *webhookRepo) GetWebhookByID(ctx context.Context, workspaceID int, userID int, webhookID int) (*app.Webhook, error) {
+	sqlcWebhook, err := repo.queries.GetWebhookByID(ctx, sqlc.GetWebhookByIDParams{
+		WorkspaceID: int32(workspaceID),
+		UserID:      int32(userID),
+		ID:          int32(webhookID),
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, app.ErrWebhookNotFound
+		}
+		return nil, err
+	}
+
+	return repo.toAppWebhook(&sqlcWebhook), nil
+}
+
+func (repo *webhookRepo) CreateWebhook(ctx context.Context, webhook *app.Webhook) (*app.Webhook, error) {
+	arg := sqlc.CreateWebhookParams{
+		UserID:      int32(webhook.UserID),
+		WorkspaceID: int32(webhook.WorkspaceID),
+		Url:         webhook.URL,
+		Secret:      webhook.Secret,
+		EventTypes:  eventTypeStrings(webhook.EventTypes),
+	}
+
+	sqlcWebhook, err := repo.queries.CreateWebhook(ctx, arg)
+	if err != nil {
+		return nil, err
+	}
+
+	return repo.toAppWebhook(&sqlcWebhook), nil
+}
+
+func (repo *webhookRepo) UpdateWebhook(ctx context.Context, webhook *app.Webhook) (*app.Webhook, error) {
+	arg := sqlc.UpdateWebhookParams{
+		WorkspaceID: int32(webhook.WorkspaceID),
+		UserID:      int32(webhook.UserID),
+		ID:          int32(webhook.ID),
+		Url:         webhook.URL,
+		EventTypes:  eventTypeStrings(webhook.EventTypes),
+		IsActive:    webhook.IsActive,
+	}
+
+	sqlcWebhook, err := repo.queries.UpdateWebhook(ctx, arg)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, app.ErrWebhookNotFound
+		}
+		return nil, err
+	}
+
+	return repo.toAppWebhook(&sqlcWebhook), nil
+}
+
+func (repo *webhookRepo) DeleteWebhook(ctx context.Context, workspaceID int, userID int, webhookID int) error {
+	count, err := repo.queries.DeleteWebhook(ctx, sqlc.DeleteWebhookParams{
+		WorkspaceID: int32(workspaceID),
+		UserID:      int32(userID),
+		ID:          int32(webhookID),
+	})
+	if err != nil {
+		return err
+	}
+
+	if count == 0 {
+		return app.ErrWebhookNotFound
+	}
+
+	return nil
+}
+
+func (repo *webhookRepo) GetDeliveries(ctx context.Context, webhookID int, paging app.Paging) ([]app.WebhookDelivery, app.PaginationData, error) {
+	arg := sqlc.GetWebhookDeliveriesParams{
+		WebhookID: int32(webhookID),
+		Cursor:    int32(paging.Cursor),
+		Limit:     int32(paging.Limit()),
+	}
+
+	sqlcDeliveries, err := repo.queries.GetWebhookDeliveries(ctx, arg)
+	if err != nil {
+		return nil, app.PaginationData{}, err
+	}
+
+	deliveries := make([]app.WebhookDelivery, len(sqlcDeliveries))
+	for i, sqlcDelivery := range sqlcDeliveries {
+		deliveries[i] = *repo.toAppDelivery(&sqlcDelivery)
+	}
+
+	deliveries, paginationData := paginate(deliveries, paging, func(d app.WebhookDelivery) int { return d.ID })
+	return deliveries, paginationData, nil
+}
+
+func (repo *webhookRepo) GetDeliveryByID(ctx context.Context, webhookID int, deliveryID int) (*app.WebhookDelivery, error) {
+	sqlcDelivery, err := repo.queries.GetWebhookDeliveryByID(ctx, sqlc.GetWebhookDeliveryByIDParams{
+		WebhookID: int32(webhookID),
+		ID:        int32(deliveryID),
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, app.ErrDeliveryNotFound
+		}
+		return nil, err
+	}
+
+	return repo.toAppDelivery(&sqlcDelivery), nil
+}
+
+func (repo *webhookRepo) CreateDelivery(ctx context.Context, delivery *app.WebhookDelivery) (*app.WebhookDelivery, error) {
+	arg := sqlc.CreateWebhookDeliveryParams{
+		WebhookID: int32(delivery.WebhookID),
+		EventType: string(delivery.EventType),
+		Payload:   delivery.Payload,
+	}
+
+	sqlcDelivery, err := repo.queries.CreateWebhookDelivery(ctx, arg)
+	if err != nil {
+		return nil, err
+	}
+
+	return repo.toAppDelivery(&sqlcDelivery), nil
+}
+
+func (repo *webhookRepo) ClaimDeliveries(ctx context.Context, limit int, leasedUntil time.Time) ([]app.OutgoingWebhook, error) {
+	rows, err := repo.queries.ClaimWebhookDeliveries(ctx, sqlc.ClaimWebhookDeliveriesParams{
+		LeasedUntil: pgtype.Timestamptz{Time: leasedUntil, Valid: true},
+		Limit:       int32(limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	webhooks := make([]app.OutgoingWebhook, len(rows))
+	for i, row := range rows {
+		delivery := sqlc.WebhookDelivery{
+			ID:             row.ID,
+			WebhookID:      row.WebhookID,
+			EventType:      row.EventType,
+			Payload:        row.Payload,
+			Status:         row.Status,
+			Attempts:       row.Attempts,
+			NextAttemptAt:  row.NextAttemptAt,
+			LastAttemptAt:  row.LastAttemptAt,
+			ResponseStatus: row.ResponseStatus,
+			ResponseBody:   row.ResponseBody,
+			Error:          row.Error,
+			CreatedAt:      row.CreatedAt,
+		}
+
+		webhooks[i] = app.OutgoingWebhook{
+			URL:      row.Url,
+			Secret:   row.Secret,
+			Delivery: *repo.toAppDelivery(&delivery),
+		}
+	}
+
+	return webhooks, nil
+}
+
+func (repo *webhookRepo) RecordAttempt(ctx context.Context, delivery *app.WebhookDelivery) error {
+	return repo.queries.RecordWebhookDeliveryAttempt(ctx, sqlc.RecordWebhookDeliveryAttemptParams{
+		ID:             int32(delivery.ID),
+		Status:         string(delivery.Status),
+		NextAttemptAt:  pgtype.Timestamptz{Time: delivery.NextAttemptAt, Valid: true},
+		ResponseStatus: pgtype.Int4{Int32: int32(delivery.ResponseStatus.Int64), Valid: delivery.ResponseStatus.Valid},
+		ResponseBody:   delivery.ResponseBody,
+		Error:          delivery.Error,
+	})
+}
+
+// enqueueWebhookDeliveries adds the deliveries of a task event to the outbox.
+// It runs in the transaction of the change, so deliveries are only sent for
+// changes that were committed.
+func enqueueWebhookDeliveries(ctx context.Context, q *sqlc.Queries, task *app.Task, event sqlc.TaskEvent, changes map[string]app.FieldChange) error {
+	for _, eventType := range app.WebhookEvents(app.TaskAction(event.Action), changes) {
+		payload, err := json.Marshal(app.WebhookPayload{
+			Event:       eventType,
+			TaskEventID: int(event.ID),
+			Task:        *task,
+			Changes:     changes,
+			CreatedAt:   event.CreatedAt.Time,
+		})
+		if err != nil {
+			return err
+		}
+
+		_, err = q.EnqueueWebhookDeliveries(ctx, sqlc.EnqueueWebhookDeliveriesParams{
+			EventType:   string(eventType),
+			Payload:     payload,
+			ProjectID:   pgtype.Int4{Int32: int32(task.ProjectID.Int64), Valid: task.ProjectID.Valid},
+			UserID:      int32(task.UserID),
+			WorkspaceID: int32(task.WorkspaceID),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
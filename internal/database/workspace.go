@@ -0,0 +1,333 @@
+package database
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ayo-awe/golang_todo_api/internal/app"
+	"github.com/ayo-awe/golang_todo_api/internal/database/sqlc"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type workspaceRepo struct {
+	queries *sqlc.Queries
+	conn    *pgxpool.Pool
+}
+
+func NewWorkspaceRepository(conn *pgxpool.Pool) app.WorkspaceRepository {
+	return &workspaceRepo{conn: conn, queries: sqlc.New(conn)}
+}
+
+func (repo *workspaceRepo) toAppWorkspace(row sqlc.GetWorkspaceRow) *app.Workspace {
+	return &app.Workspace{
+		ID:         int(row.ID),
+		Name:       row.Name,
+		IsPersonal: row.PersonalUserID.Valid,
+		Role:       app.WorkspaceRole(row.Role),
+		CreatedAt:  row.CreatedAt.Time,
+		UpdatedAt:  row.UpdatedAt.Time,
+	}
+}
+
+func (repo *workspaceRepo) GetWorkspaces(ctx context.Context, userID int, paging app.Paging) ([]app.Workspace, app.PaginationData, error) {
+	arg := sqlc.GetWorkspacesParams{
+		UserID: int32(userID),
+		Cursor: int32(paging.Cursor),
+		Limit:  int32(paging.Limit()),
+	}
+
+	rows, err := repo.queries.GetWorkspaces(ctx, arg)
+	if err != nil {
+		return nil, app.PaginationData{}, err
+	}
+
+	workspaces := make([]app.Workspace, len(rows))
+	for i, row := range rows {
+		workspaces[i] = *repo.toAppWorkspace(sqlc.GetWorkspaceRow(row))
+	}
+
+	workspaces, paginationData := paginate(workspaces, paging, func(w app.Workspace) int { return w.ID })
+	return workspaces, paginationData, nil
+}
+
+func (repo *workspaceRepo) GetWorkspace(ctx context.Context, workspaceID int, userID int) (*app.Workspace, error) {
+	row, err := repo.queries.GetWorkspace(ctx, sqlc.GetWorkspaceParams{
+		ID:     int32(workspaceID),
+		UserID: int32(userID),
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, app.ErrWorkspaceNotFound
+		}
+		return nil, err
+	}
+
+	return repo.toAppWorkspace(row), nil
+}
+
+func (repo *workspaceRepo) GetPersonalWorkspace(ctx context.Context, userID int) (*app.Workspace, error) {
+	row, err := repo.queries.GetPersonalWorkspace(ctx, int32(userID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, app.ErrWorkspaceNotFound
+		}
+		return nil, err
+	}
+
+	return repo.toAppWorkspace(sqlc.GetWorkspaceRow(row)), nil
+}
+
+func (repo *workspaceRepo) CreateWorkspace(ctx context.Context, workspace *app.Workspace, ownerID int) (*app.Workspace, error) {
+	var newWorkspace *app.Workspace
+	err := withTx(ctx, repo.conn, func(q *sqlc.Queries) error {
+		var err error
+		newWorkspace, err = createWorkspace(ctx, q, workspace.Name, ownerID, false)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return newWorkspace, nil
+}
+
+// createWorkspace creates a workspace owned by ownerID, with the owner's
+// default statuses. Personal workspaces are tied to their owner and can't be shared.
+func createWorkspace(ctx context.Context, q *sqlc.Queries, name string, ownerID int, personal bool) (*app.Workspace, error) {
+	sqlcWorkspace, err := q.CreateWorkspace(ctx, sqlc.CreateWorkspaceParams{
+		Name:           name,
+		PersonalUserID: pgtype.Int4{Int32: int32(ownerID), Valid: personal},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	member, err := q.AddWorkspaceMember(ctx, sqlc.AddWorkspaceMemberParams{
+		WorkspaceID: sqlcWorkspace.ID,
+		UserID:      int32(ownerID),
+		Role:        string(app.WorkspaceRoleOwner),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = q.CreateDefaultTaskStatuses(ctx, sqlc.CreateDefaultTaskStatusesParams{
+		UserID:      int32(ownerID),
+		WorkspaceID: sqlcWorkspace.ID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &app.Workspace{
+		ID:         int(sqlcWorkspace.ID),
+		Name:       sqlcWorkspace.Name,
+		IsPersonal: sqlcWorkspace.PersonalUserID.Valid,
+		Role:       app.WorkspaceRole(member.Role),
+		CreatedAt:  sqlcWorkspace.CreatedAt.Time,
+		UpdatedAt:  sqlcWorkspace.UpdatedAt.Time,
+	}, nil
+}
+
+// UpdateWorkspace renames a workspace. The returned workspace has no Role.
+func (repo *workspaceRepo) UpdateWorkspace(ctx context.Context, workspace *app.Workspace) (*app.Workspace, error) {
+	sqlcWorkspace, err := repo.queries.UpdateWorkspace(ctx, sqlc.UpdateWorkspaceParams{
+		ID:   int32(workspace.ID),
+		Name: workspace.Name,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, app.ErrWorkspaceNotFound
+		}
+		return nil, err
+	}
+
+	return &app.Workspace{
+		ID:         int(sqlcWorkspace.ID),
+		Name:       sqlcWorkspace.Name,
+		IsPersonal: sqlcWorkspace.PersonalUserID.Valid,
+		CreatedAt:  sqlcWorkspace.CreatedAt.Time,
+		UpdatedAt:  sqlcWorkspace.UpdatedAt.Time,
+	}, nil
+}
+
+func (repo *workspaceRepo) GetWorkspaceMembers(ctx context.Context, workspaceID int) ([]app.WorkspaceMember, error) {
+	rows, err := repo.queries.GetWorkspaceMembers(ctx, int32(workspaceID))
+	if err != nil {
+		return nil, err
+	}
+
+	members := make([]app.WorkspaceMember, len(rows))
+	for i, row := range rows {
+		members[i] = *repo.toAppWorkspaceMember(sqlc.AddWorkspaceMemberRow(row))
+	}
+
+	return members, nil
+}
+
+func (repo *workspaceRepo) toAppWorkspaceMember(row sqlc.AddWorkspaceMemberRow) *app.WorkspaceMember {
+	return &app.WorkspaceMember{
+		WorkspaceID: int(row.WorkspaceID),
+		UserID:      int(row.UserID),
+		Email:       row.Email,
+		Firstname:   row.FirstName,
+		Lastname:    row.LastName,
+		Role:        app.WorkspaceRole(row.Role),
+		CreatedAt:   row.CreatedAt.Time,
+	}
+}
+
+func (repo *workspaceRepo) RemoveWorkspaceMember(ctx context.Context, workspaceID int, userID int) error {
+	return withTx(ctx, repo.conn, func(q *sqlc.Queries) error {
+		// serializes removals so that two owners can't remove each other at once
+		if err := q.LockWorkspaceMembers(ctx, int32(workspaceID)); err != nil {
+			return err
+		}
+
+		count, err := q.RemoveWorkspaceMember(ctx, sqlc.RemoveWorkspaceMemberParams{
+			WorkspaceID: int32(workspaceID),
+			UserID:      int32(userID),
+		})
+		if err != nil {
+			return err
+		}
+
+		if count == 0 {
+			return app.ErrMemberNotFound
+		}
+
+		owners, err := q.CountWorkspaceOwners(ctx, int32(workspaceID))
+		if err != nil {
+			return err
+		}
+
+		if owners == 0 {
+			return app.ErrLastOwner
+		}
+
+		err = q.RemoveUserFromWorkspaceProjects(ctx, sqlc.RemoveUserFromWorkspaceProjectsParams{
+			UserID:      int32(userID),
+			WorkspaceID: int32(workspaceID),
+		})
+		if err != nil {
+			return err
+		}
+
+		return q.UnassignUserWorkspaceTasks(ctx, sqlc.UnassignUserWorkspaceTasksParams{
+			WorkspaceID: int32(workspaceID),
+			AssigneeID:  pgtype.Int4{Int32: int32(userID), Valid: true},
+		})
+	})
+}
+
+func (repo *workspaceRepo) toAppInvitation(sqlcInvitation *sqlc.WorkspaceInvitation) *app.WorkspaceInvitation {
+	return &app.WorkspaceInvitation{
+		ID:          int(sqlcInvitation.ID),
+		WorkspaceID: int(sqlcInvitation.WorkspaceID),
+		Email:       sqlcInvitation.Email,
+		Role:        app.WorkspaceRole(sqlcInvitation.Role),
+		TokenHash:   sqlcInvitation.TokenHash,
+		InvitedBy:   int(sqlcInvitation.InvitedBy),
+		CreatedAt:   sqlcInvitation.CreatedAt.Time,
+		ExpiresAt:   sqlcInvitation.ExpiresAt.Time,
+	}
+}
+
+func (repo *workspaceRepo) CreateInvitation(ctx context.Context, invitation *app.WorkspaceInvitation) (*app.WorkspaceInvitation, error) {
+	arg := sqlc.CreateWorkspaceInvitationParams{
+		WorkspaceID: int32(invitation.WorkspaceID),
+		Email:       invitation.Email,
+		Role:        string(invitation.Role),
+		TokenHash:   invitation.TokenHash,
+		InvitedBy:   int32(invitation.InvitedBy),
+		ExpiresAt:   pgtype.Timestamptz{Time: invitation.ExpiresAt, Valid: true},
+	}
+
+	sqlcInvitation, err := repo.queries.CreateWorkspaceInvitation(ctx, arg)
+	if err != nil {
+		return nil, err
+	}
+
+	return repo.toAppInvitation(&sqlcInvitation), nil
+}
+
+func (repo *workspaceRepo) GetInvitations(ctx context.Context, workspaceID int) ([]app.WorkspaceInvitation, error) {
+	sqlcInvitations, err := repo.queries.GetWorkspaceInvitations(ctx, int32(workspaceID))
+	if err != nil {
+		return nil, err
+	}
+
+	invitations := make([]app.WorkspaceInvitation, len(sqlcInvitations))
+	for i, sqlcInvitation := range sqlcInvitations {
+		invitations[i] = *repo.toAppInvitation(&sqlcInvitation)
+	}
+
+	return invitations, nil
+}
+
+func (repo *workspaceRepo) GetInvitationByTokenHash(ctx context.Context, tokenHash string) (*app.WorkspaceInvitation, error) {
+	sqlcInvitation, err := repo.queries.GetWorkspaceInvitationByToken(ctx, tokenHash)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, app.ErrInvitationNotFound
+		}
+		return nil, err
+	}
+
+	return repo.toAppInvitation(&sqlcInvitation), nil
+}
+
+func (repo *workspaceRepo) AcceptInvitation(ctx context.Context, invitationID int, userID int) (*app.WorkspaceMember, error) {
+	var member *app.WorkspaceMember
+	err := withTx(ctx, repo.conn, func(q *sqlc.Queries) error {
+		// accepting marks the invitation as used, so a token only works once
+		sqlcInvitation, err := q.AcceptWorkspaceInvitation(ctx, int32(invitationID))
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return app.ErrInvitationNotFound
+			}
+			return err
+		}
+
+		row, err := q.AddWorkspaceMember(ctx, sqlc.AddWorkspaceMemberParams{
+			WorkspaceID: sqlcInvitation.WorkspaceID,
+			UserID:      int32(userID),
+			Role:        sqlcInvitation.Role,
+		})
+		if err != nil {
+			if isUniqueViolation(err) {
+				return app.ErrMemberExists
+			}
+			return err
+		}
+
+		member = repo.toAppWorkspaceMember(row)
+		return q.CreateDefaultTaskStatuses(ctx, sqlc.CreateDefaultTaskStatusesParams{
+			UserID:      int32(userID),
+			WorkspaceID: sqlcInvitation.WorkspaceID,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return member, nil
+}
+
+func (repo *workspaceRepo) DeleteInvitation(ctx context.Context, workspaceID int, invitationID int) error {
+	count, err := repo.queries.DeleteWorkspaceInvitation(ctx, sqlc.DeleteWorkspaceInvitationParams{
+		WorkspaceID: int32(workspaceID),
+		ID:          int32(invitationID),
+	})
+	if err != nil {
+		return err
+	}
+
+	if count == 0 {
+		return app.ErrInvitationNotFound
+	}
+
+	return nil
+}
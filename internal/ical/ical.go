@@ -0,0 +1,139 @@
+// Package ical reads and writes iCalendar (RFC 5545) data. It only knows about the
+// structure of the format, components and their properties, not about what
+// the components mean.
+package ical
+
+import (
+	"bufio"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// maxLineLength is the length in octets past which content lines are folded
+const maxLineLength = 75
+
+const (
+	dateTimeLayout = "20060102T150405Z"
+	dateLayout     = "20060102"
+)
+
+// Property is a single content line. Params are written as given, e.g.
+// "VALUE=DATE".
+type Property struct {
+	Name   string
+	Params []string
+	Value  string
+}
+
+// Component is a BEGIN/END block, such as a VCALENDAR or a VTODO
+type Component struct {
+	Name       string
+	Properties []Property
+	Components []*Component
+}
+
+func NewComponent(name string) *Component {
+	return &Component{Name: name}
+}
+
+// Set adds a property with a value that is written as is
+func (c *Component) Set(name string, value string, params ...string) {
+	c.Properties = append(c.Properties, Property{Name: name, Params: params, Value: value})
+}
+
+// SetText adds a property with text values, which are escaped and, if there
+// are more than one, separated with commas
+func (c *Component) SetText(name string, values ...string) {
+	escaped := make([]string, len(values))
+	for i, value := range values {
+		escaped[i] = EscapeText(value)
+	}
+	c.Set(name, strings.Join(escaped, ","))
+}
+
+// SetDateTime adds a property with a UTC date-time value
+func (c *Component) SetDateTime(name string, t time.Time) {
+	c.Set(name, t.UTC().Format(dateTimeLayout))
+}
+
+// SetDate adds a property with a date value
+func (c *Component) SetDate(name string, t time.Time) {
+	c.Set(name, t.Format(dateLayout), "VALUE=DATE")
+}
+
+func (c *Component) Add(child *Component) {
+	c.Components = append(c.Components, child)
+}
+
+// WriteTo writes the component with CRLF line endings, folding long lines
+func (c *Component) WriteTo(w io.Writer) (int64, error) {
+	bw := bufio.NewWriter(w)
+	cw := &countingWriter{w: bw}
+	c.write(cw)
+	if cw.err != nil {
+		return cw.n, cw.err
+	}
+
+	return cw.n, bw.Flush()
+}
+
+func (c *Component) write(w *countingWriter) {
+	w.line("BEGIN:" + c.Name)
+	for _, property := range c.Properties {
+		line := property.Name
+		for _, param := range property.Params {
+			line += ";" + param
+		}
+		w.line(line + ":" + property.Value)
+	}
+	for _, child := range c.Components {
+		child.write(w)
+	}
+	w.line("END:" + c.Name)
+}
+
+// EscapeText escapes a TEXT value
+func EscapeText(text string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+		"\r", `\n`,
+	).Replace(text)
+}
+
+type countingWriter struct {
+	w   io.Writer
+	n   int64
+	err error
+}
+
+func (cw *countingWriter) write(s string) {
+	if cw.err != nil {
+		return
+	}
+	n, err := io.WriteString(cw.w, s)
+	cw.n += int64(n)
+	cw.err = err
+}
+
+// line writes a content line, folded so that no line is longer than
+// maxLineLength octets and multi-byte characters aren't split
+func (cw *countingWriter) line(s string) {
+	limit := maxLineLength
+	for len(s) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(s[cut]) {
+			cut--
+		}
+		cw.write(s[:cut] + "\r\n ")
+		s = s[cut:]
+		// the leading space of a continuation line counts towards its length
+		limit = maxLineLength - 1
+	}
+	cw.write(s + "\r\n")
+}
@@ -0,0 +1,226 @@
+package ical
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// Parse reads a single component, such as a VCALENDAR, and the components
+// nested in it
+func Parse(r io.Reader) (*Component, error) {
+	lines, err := unfold(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		root  *Component
+		stack []*Component
+	)
+	for i, line := range lines {
+		property, err := parseLine(line)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", i+1, err)
+		}
+
+		switch property.Name {
+		case "BEGIN":
+			component := NewComponent(strings.ToUpper(property.Value))
+			if len(stack) > 0 {
+				stack[len(stack)-1].Add(component)
+			} else if root != nil {
+				return nil, fmt.Errorf("line %d: more than one component", i+1)
+			} else {
+				root = component
+			}
+			stack = append(stack, component)
+		case "END":
+			if len(stack) == 0 || stack[len(stack)-1].Name != strings.ToUpper(property.Value) {
+				return nil, fmt.Errorf("line %d: unexpected END:%s", i+1, property.Value)
+			}
+			stack = stack[:len(stack)-1]
+		default:
+			if len(stack) == 0 {
+				return nil, fmt.Errorf("line %d: property outside of a component", i+1)
+			}
+			current := stack[len(stack)-1]
+			current.Properties = append(current.Properties, property)
+		}
+	}
+
+	if root == nil {
+		return nil, errors.New("no component")
+	}
+
+	if len(stack) > 0 {
+		return nil, fmt.Errorf("%s isn't ended", stack[len(stack)-1].Name)
+	}
+
+	return root, nil
+}
+
+// unfold joins folded content lines and drops empty ones
+func unfold(r io.Reader) ([]string, error) {
+	var lines []string
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimSuffix(scanner.Text(), "\r")
+		if len(lines) > 0 && (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+
+	return lines, scanner.Err()
+}
+
+// parseLine splits a content line into its name, params and value. Colons
+// and semicolons in quoted param values don't end them.
+func parseLine(line string) (Property, error) {
+	var (
+		property Property
+		start    int
+		quoted   bool
+		inName   = true
+	)
+
+	for i := 0; i < len(line); i++ {
+		switch c := line[i]; {
+		case c == '"':
+			quoted = !quoted
+		case quoted:
+		case c == ';' || c == ':':
+			part := line[start:i]
+			if inName {
+				property.Name = strings.ToUpper(part)
+				inName = false
+			} else {
+				property.Params = append(property.Params, part)
+			}
+			start = i + 1
+
+			if c == ':' {
+				property.Value = line[start:]
+				if property.Name == "" {
+					return Property{}, errors.New("property has no name")
+				}
+				return property, nil
+			}
+		}
+	}
+
+	return Property{}, errors.New("property has no value")
+}
+
+// Get returns the first property with the name, or nil if there isn't one
+func (c *Component) Get(name string) *Property {
+	for i := range c.Properties {
+		if c.Properties[i].Name == name {
+			return &c.Properties[i]
+		}
+	}
+	return nil
+}
+
+// GetAll returns every property with the name
+func (c *Component) GetAll(name string) []Property {
+	var properties []Property
+	for _, property := range c.Properties {
+		if property.Name == name {
+			properties = append(properties, property)
+		}
+	}
+	return properties
+}
+
+// Children returns the nested components with the name
+func (c *Component) Children(name string) []*Component {
+	var children []*Component
+	for _, child := range c.Components {
+		if child.Name == name {
+			children = append(children, child)
+		}
+	}
+	return children
+}
+
+// Param returns the value of the param with the name, unquoted
+func (p *Property) Param(name string) string {
+	for _, param := range p.Params {
+		key, value, ok := strings.Cut(param, "=")
+		if ok && strings.EqualFold(key, name) {
+			return strings.Trim(value, `"`)
+		}
+	}
+	return ""
+}
+
+// Text returns the property's value as unescaped text
+func (p *Property) Text() string {
+	values := p.TextValues()
+	return strings.Join(values, ",")
+}
+
+// TextValues returns the property's value as a list of unescaped texts,
+// separated by unescaped commas
+func (p *Property) TextValues() []string {
+	var (
+		values  []string
+		current strings.Builder
+	)
+	for i := 0; i < len(p.Value); i++ {
+		c := p.Value[i]
+		switch {
+		case c == '\\' && i+1 < len(p.Value):
+			i++
+			switch p.Value[i] {
+			case 'n', 'N':
+				current.WriteByte('\n')
+			default:
+				current.WriteByte(p.Value[i])
+			}
+		case c == ',':
+			values = append(values, current.String())
+			current.Reset()
+		default:
+			current.WriteByte(c)
+		}
+	}
+	return append(values, current.String())
+}
+
+// Time parses a DATE or DATE-TIME value. Local times are in the zone of the
+// TZID param if Go knows it, and otherwise, like floating times, in UTC.
+// isDate is set for DATE values, which are midnight UTC.
+func (p *Property) Time() (t time.Time, isDate bool, err error) {
+	value := strings.TrimSpace(p.Value)
+
+	if strings.EqualFold(p.Param("VALUE"), "DATE") || len(value) == len(dateLayout) {
+		t, err = time.Parse(dateLayout, value)
+		return t, true, err
+	}
+
+	if strings.HasSuffix(value, "Z") {
+		t, err = time.Parse(dateTimeLayout, value)
+		return t, false, err
+	}
+
+	location := time.UTC
+	if tzid := p.Param("TZID"); tzid != "" {
+		if loc, err := time.LoadLocation(tzid); err == nil {
+			location = loc
+		}
+	}
+
+	t, err = time.ParseInLocation(strings.TrimSuffix(dateTimeLayout, "Z"), value, location)
+	return t, false, err
+}
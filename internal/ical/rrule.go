@@ -0,0 +1,97 @@
+package ical
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+var frequencies = []string{"DAILY", "WEEKLY", "MONTHLY", "YEARLY"}
+
+var (
+	weekdayPattern = regexp.MustCompile(`^([+-]?\d{1,2})?(MO|TU|WE|TH|FR|SA|SU)$`)
+	untilPattern   = regexp.MustCompile(`^\d{8}(T\d{6}Z?)?$`)
+)
+
+// NormalizeRRule checks that rule is a recurrence rule (RFC 5545 3.3.10) and
+// returns it in upper case. Only rules that repeat daily or less often are
+// allowed, and only the parts a task's recurrence is described with.
+func NormalizeRRule(rule string) (string, error) {
+	rule = strings.ToUpper(strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(rule), "RRULE:")))
+	if rule == "" {
+		return "", errors.New("rule is empty")
+	}
+
+	seen := map[string]bool{}
+	for _, part := range strings.Split(rule, ";") {
+		name, value, ok := strings.Cut(part, "=")
+		if !ok || value == "" {
+			return "", fmt.Errorf("invalid rule part %q", part)
+		}
+
+		if seen[name] {
+			return "", fmt.Errorf("%s is repeated", name)
+		}
+		seen[name] = true
+
+		var err error
+		switch name {
+		case "FREQ":
+			if !slices.Contains(frequencies, value) {
+				err = fmt.Errorf("unsupported frequency %q", value)
+			}
+		case "INTERVAL", "COUNT":
+			err = checkNumbers(value, 1, 1000)
+		case "UNTIL":
+			if !untilPattern.MatchString(value) {
+				err = fmt.Errorf("invalid UNTIL %q", value)
+			}
+		case "BYDAY":
+			for _, day := range strings.Split(value, ",") {
+				if !weekdayPattern.MatchString(day) {
+					err = fmt.Errorf("invalid BYDAY %q", day)
+				}
+			}
+		case "BYMONTHDAY":
+			err = checkNumbers(value, -31, 31)
+		case "BYMONTH":
+			err = checkNumbers(value, 1, 12)
+		case "BYSETPOS":
+			err = checkNumbers(value, -366, 366)
+		case "WKST":
+			if !weekdayPattern.MatchString(value) || len(value) != 2 {
+				err = fmt.Errorf("invalid WKST %q", value)
+			}
+		default:
+			err = fmt.Errorf("unsupported rule part %s", name)
+		}
+
+		if err != nil {
+			return "", err
+		}
+	}
+
+	if !seen["FREQ"] {
+		return "", errors.New("FREQ is required")
+	}
+
+	if seen["COUNT"] && seen["UNTIL"] {
+		return "", errors.New("COUNT and UNTIL can't both be set")
+	}
+
+	return rule, nil
+}
+
+// checkNumbers checks a comma separated list of non zero numbers in [lo, hi]
+func checkNumbers(value string, lo, hi int) error {
+	for _, raw := range strings.Split(value, ",") {
+		n, err := strconv.Atoi(raw)
+		if err != nil || n == 0 || n < lo || n > hi {
+			return fmt.Errorf("invalid number %q", raw)
+		}
+	}
+	return nil
+}
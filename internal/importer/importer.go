@@ -0,0 +1,136 @@
+// Package importer parses the exports of other todo apps into tasks that can
+// be created in this one. It only reads the exports, the app decides how the
+// tasks are stored.
+package importer
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"time"
+)
+
+type Format string
+
+const (
+	FormatTodoTxt     Format = "todotxt"
+	FormatTodoistCSV  Format = "todoist_csv"
+	FormatTodoistJSON Format = "todoist_json"
+	FormatTrelloJSON  Format = "trello_json"
+)
+
+var Formats = []Format{FormatTodoTxt, FormatTodoistCSV, FormatTodoistJSON, FormatTrelloJSON}
+
+func (f Format) IsValid() bool {
+	for _, format := range Formats {
+		if f == format {
+			return true
+		}
+	}
+	return false
+}
+
+// Priority uses the same names as the app's task priorities
+type Priority string
+
+const (
+	PriorityNone   Priority = "none"
+	PriorityLow    Priority = "low"
+	PriorityMedium Priority = "medium"
+	PriorityHigh   Priority = "high"
+	PriorityUrgent Priority = "urgent"
+)
+
+var ErrUnsupportedFormat = errors.New("unsupported import format")
+
+// Task is a task read from an export. Project is the name of the project the
+// task belongs to, empty if it doesn't belong to one.
+type Task struct {
+	Title       string     `json:"title"`
+	Description string     `json:"description"`
+	Completed   bool       `json:"completed"`
+	Project     string     `json:"project"`
+	Tags        []string   `json:"tags"`
+	Priority    Priority   `json:"priority"`
+	DueAt       *time.Time `json:"due_at"`
+}
+
+type Result struct {
+	// Projects are the names of the tasks' projects, in the order they first appear
+	Projects []string `json:"projects"`
+	Tasks    []Task   `json:"tasks"`
+}
+
+// Parse reads an export in the given format. name is the name of the
+// uploaded file, which some formats use to name the project.
+func Parse(format Format, name string, r io.Reader) (*Result, error) {
+	var (
+		tasks []Task
+		err   error
+	)
+
+	switch format {
+	case FormatTodoTxt:
+		tasks, err = parseTodoTxt(r)
+	case FormatTodoistCSV:
+		tasks, err = parseTodoistCSV(name, r)
+	case FormatTodoistJSON:
+		tasks, err = parseTodoistJSON(r)
+	case FormatTrelloJSON:
+		tasks, err = parseTrelloJSON(r)
+	default:
+		return nil, ErrUnsupportedFormat
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Result{Projects: []string{}, Tasks: []Task{}}
+	seen := make(map[string]bool)
+	for _, task := range tasks {
+		task.Title = strings.TrimSpace(task.Title)
+		task.Description = strings.TrimSpace(task.Description)
+		task.Project = strings.TrimSpace(task.Project)
+		if task.Tags == nil {
+			task.Tags = []string{}
+		}
+		if task.Priority == "" {
+			task.Priority = PriorityNone
+		}
+
+		if task.Project != "" && !seen[task.Project] {
+			seen[task.Project] = true
+			result.Projects = append(result.Projects, task.Project)
+		}
+
+		result.Tasks = append(result.Tasks, task)
+	}
+
+	return result, nil
+}
+
+// parseDate parses the dates and date-times the exports use, dates without a
+// time are midnight UTC
+func parseDate(value string) (*time.Time, bool) {
+	value = strings.TrimSpace(value)
+	for _, layout := range []string{time.RFC3339, "2006-01-02T15:04:05", "2006-01-02"} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return &t, true
+		}
+	}
+	return nil, false
+}
+
+func appendTag(tags []string, tag string) []string {
+	tag = strings.TrimSpace(tag)
+	if tag == "" {
+		return tags
+	}
+	for _, t := range tags {
+		if strings.EqualFold(t, tag) {
+			return tags
+		}
+	}
+	return append(tags, tag)
+}
@@ -0,0 +1,258 @@
+package importer
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+)
+
+const todoistDefaultProject = "Todoist"
+
+// parseTodoistCSV reads a project exported from Todoist as CSV. The export
+// is of a single project, named after the file. Sections become a tag on
+// the tasks under them and notes are added to the description of the task
+// above them.
+func parseTodoistCSV(name string, r io.Reader) ([]Task, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, errors.New("file is empty")
+		}
+		return nil, err
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, column := range header {
+		columns[strings.ToUpper(strings.TrimSpace(strings.TrimPrefix(column, "\ufeff")))] = i
+	}
+
+	for _, column := range []string{"TYPE", "CONTENT"} {
+		if _, ok := columns[column]; !ok {
+			return nil, fmt.Errorf("file is missing the %s column", column)
+		}
+	}
+
+	get := func(record []string, column string) string {
+		i, ok := columns[column]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	project := strings.TrimSuffix(filepath.Base(name), filepath.Ext(name))
+	if project == "" || project == "." {
+		project = todoistDefaultProject
+	}
+
+	var (
+		tasks   []Task
+		section string
+	)
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		line, _ := reader.FieldPos(0)
+		content := get(record, "CONTENT")
+
+		switch strings.ToLower(get(record, "TYPE")) {
+		case "section":
+			section = content
+		case "note":
+			if len(tasks) > 0 && content != "" {
+				last := &tasks[len(tasks)-1]
+				last.Description = strings.TrimSpace(last.Description + "\n\n" + content)
+			}
+		case "task":
+			task := Task{
+				Description: get(record, "DESCRIPTION"),
+				Project:     project,
+				Priority:    todoistCSVPriority(get(record, "PRIORITY")),
+			}
+
+			task.Title, task.Tags = todoistLabels(content)
+			if task.Title == "" {
+				return nil, fmt.Errorf("line %d: task has no title", line)
+			}
+
+			if section != "" {
+				task.Tags = appendTag(task.Tags, section)
+			}
+
+			// dates can be in natural language, only exact dates are kept
+			if dueAt, ok := parseDate(get(record, "DATE")); ok {
+				task.DueAt = dueAt
+			}
+
+			tasks = append(tasks, task)
+		}
+	}
+
+	return tasks, nil
+}
+
+// todoistLabels splits the @labels out of a task's content
+func todoistLabels(content string) (string, []string) {
+	var (
+		words []string
+		tags  []string
+	)
+	for _, field := range strings.Fields(content) {
+		if len(field) > 1 && field[0] == '@' {
+			tags = appendTag(tags, field[1:])
+			continue
+		}
+		words = append(words, field)
+	}
+	return strings.Join(words, " "), tags
+}
+
+// in the CSV export priority 1 is the highest
+func todoistCSVPriority(value string) Priority {
+	switch value {
+	case "1":
+		return PriorityUrgent
+	case "2":
+		return PriorityHigh
+	case "3":
+		return PriorityMedium
+	default:
+		return PriorityNone
+	}
+}
+
+// todoistID is an id in a Todoist backup, older backups use numbers
+type todoistID string
+
+func (id *todoistID) UnmarshalJSON(data []byte) error {
+	var value any
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	switch v := value.(type) {
+	case nil:
+		*id = ""
+	case string:
+		*id = todoistID(v)
+	default:
+		*id = todoistID(strings.TrimSpace(string(data)))
+	}
+	return nil
+}
+
+type todoistItem struct {
+	ID          todoistID `json:"id"`
+	Content     string    `json:"content"`
+	Description string    `json:"description"`
+	ProjectID   todoistID `json:"project_id"`
+	SectionID   todoistID `json:"section_id"`
+	Priority    int       `json:"priority"`
+	Labels      []string  `json:"labels"`
+	Checked     bool      `json:"checked"`
+	IsCompleted bool      `json:"is_completed"`
+	IsDeleted   bool      `json:"is_deleted"`
+	Due         *struct {
+		Date     string `json:"date"`
+		Datetime string `json:"datetime"`
+	} `json:"due"`
+}
+
+type todoistBackup struct {
+	Projects []struct {
+		ID           todoistID `json:"id"`
+		Name         string    `json:"name"`
+		InboxProject bool      `json:"inbox_project"`
+		IsInbox      bool      `json:"is_inbox_project"`
+	} `json:"projects"`
+	Sections []struct {
+		ID   todoistID `json:"id"`
+		Name string    `json:"name"`
+	} `json:"sections"`
+	Items []todoistItem `json:"items"`
+	Tasks []todoistItem `json:"tasks"`
+}
+
+// parseTodoistJSON reads a Todoist backup in the format of its sync API.
+// Tasks in the inbox don't belong to a project.
+func parseTodoistJSON(r io.Reader) ([]Task, error) {
+	var backup todoistBackup
+	if err := json.NewDecoder(r).Decode(&backup); err != nil {
+		return nil, fmt.Errorf("file is not valid JSON: %w", err)
+	}
+
+	projects := make(map[todoistID]string, len(backup.Projects))
+	for _, project := range backup.Projects {
+		if project.InboxProject || project.IsInbox {
+			continue
+		}
+		projects[project.ID] = project.Name
+	}
+
+	sections := make(map[todoistID]string, len(backup.Sections))
+	for _, section := range backup.Sections {
+		sections[section.ID] = section.Name
+	}
+
+	var tasks []Task
+	for i, item := range append(backup.Items, backup.Tasks...) {
+		if item.IsDeleted {
+			continue
+		}
+
+		task := Task{
+			Description: item.Description,
+			Completed:   item.Checked || item.IsCompleted,
+			Project:     projects[item.ProjectID],
+			Priority:    todoistJSONPriority(item.Priority),
+		}
+
+		task.Title, task.Tags = todoistLabels(item.Content)
+		if task.Title == "" {
+			return nil, fmt.Errorf("task %d: task has no title", i+1)
+		}
+
+		for _, label := range item.Labels {
+			task.Tags = appendTag(task.Tags, label)
+		}
+		task.Tags = appendTag(task.Tags, sections[item.SectionID])
+
+		if item.Due != nil {
+			if dueAt, ok := parseDate(item.Due.Datetime); ok {
+				task.DueAt = dueAt
+			} else if dueAt, ok := parseDate(item.Due.Date); ok {
+				task.DueAt = dueAt
+			}
+		}
+
+		tasks = append(tasks, task)
+	}
+
+	return tasks, nil
+}
+
+// in the API priority 4 is the highest
+func todoistJSONPriority(value int) Priority {
+	switch value {
+	case 4:
+		return PriorityUrgent
+	case 3:
+		return PriorityHigh
+	case 2:
+		return PriorityMedium
+	default:
+		return PriorityNone
+	}
+}
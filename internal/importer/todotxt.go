@@ -0,0 +1,106 @@
+package importer
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+)
+
+var (
+	todoTxtPriority = regexp.MustCompile(`^\(([A-Z])\)$`)
+	todoTxtDate     = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}$`)
+)
+
+// parseTodoTxt reads the todo.txt format (https://github.com/todotxt/todo.txt).
+// The first +project of a task is its project, other +projects and @contexts
+// become tags.
+func parseTodoTxt(r io.Reader) ([]Task, error) {
+	var tasks []Task
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+
+		task := Task{}
+
+		if fields[0] == "x" {
+			task.Completed = true
+			fields = fields[1:]
+			// completion date, then creation date
+			for i := 0; i < 2 && len(fields) > 0 && todoTxtDate.MatchString(fields[0]); i++ {
+				fields = fields[1:]
+			}
+		}
+
+		if len(fields) > 0 {
+			if match := todoTxtPriority.FindStringSubmatch(fields[0]); match != nil {
+				task.Priority = todoTxtPriorityOf(match[1])
+				fields = fields[1:]
+			}
+		}
+
+		if len(fields) > 0 && todoTxtDate.MatchString(fields[0]) {
+			fields = fields[1:]
+		}
+
+		var words []string
+		for _, field := range fields {
+			switch {
+			case len(field) > 1 && field[0] == '+':
+				if task.Project == "" {
+					task.Project = field[1:]
+				} else {
+					task.Tags = appendTag(task.Tags, field[1:])
+				}
+			case len(field) > 1 && field[0] == '@':
+				task.Tags = appendTag(task.Tags, field[1:])
+			case strings.HasPrefix(field, "due:"):
+				if dueAt, ok := parseDate(strings.TrimPrefix(field, "due:")); ok {
+					task.DueAt = dueAt
+				} else {
+					words = append(words, field)
+				}
+			case strings.HasPrefix(field, "pri:") && len(field) == 5:
+				task.Priority = todoTxtPriorityOf(strings.ToUpper(field[4:]))
+			default:
+				words = append(words, field)
+			}
+		}
+
+		task.Title = strings.Join(words, " ")
+		if task.Title == "" {
+			return nil, fmt.Errorf("line %d: task has no title", lineNumber)
+		}
+
+		tasks = append(tasks, task)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return tasks, nil
+}
+
+func todoTxtPriorityOf(letter string) Priority {
+	switch letter {
+	case "A":
+		return PriorityUrgent
+	case "B":
+		return PriorityHigh
+	case "C":
+		return PriorityMedium
+	default:
+		return PriorityLow
+	}
+}
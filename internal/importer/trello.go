@@ -0,0 +1,104 @@
+package importer
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+type trelloBoard struct {
+	Name  string `json:"name"`
+	Lists []struct {
+		ID     string `json:"id"`
+		Name   string `json:"name"`
+		Closed bool   `json:"closed"`
+	} `json:"lists"`
+	Cards []struct {
+		ID          string `json:"id"`
+		Name        string `json:"name"`
+		Desc        string `json:"desc"`
+		Closed      bool   `json:"closed"`
+		IDList      string `json:"idList"`
+		Due         string `json:"due"`
+		DueComplete bool   `json:"dueComplete"`
+		Labels      []struct {
+			Name  string `json:"name"`
+			Color string `json:"color"`
+		} `json:"labels"`
+	} `json:"cards"`
+	Checklists []struct {
+		IDCard     string `json:"idCard"`
+		Name       string `json:"name"`
+		CheckItems []struct {
+			Name  string  `json:"name"`
+			State string  `json:"state"`
+			Pos   float64 `json:"pos"`
+		} `json:"checkItems"`
+	} `json:"checklists"`
+}
+
+// parseTrelloJSON reads a board exported from Trello as JSON. The board is
+// the project, and each card's list and labels become its tags. Archived
+// cards and lists are skipped.
+func parseTrelloJSON(r io.Reader) ([]Task, error) {
+	var board trelloBoard
+	if err := json.NewDecoder(r).Decode(&board); err != nil {
+		return nil, fmt.Errorf("file is not valid JSON: %w", err)
+	}
+
+	lists := make(map[string]string, len(board.Lists))
+	closedLists := make(map[string]bool)
+	for _, list := range board.Lists {
+		lists[list.ID] = list.Name
+		closedLists[list.ID] = list.Closed
+	}
+
+	checklists := make(map[string][]string)
+	for _, checklist := range board.Checklists {
+		lines := []string{checklist.Name}
+		for _, item := range checklist.CheckItems {
+			box := "[ ]"
+			if item.State == "complete" {
+				box = "[x]"
+			}
+			lines = append(lines, fmt.Sprintf("- %s %s", box, item.Name))
+		}
+		checklists[checklist.IDCard] = append(checklists[checklist.IDCard], strings.Join(lines, "\n"))
+	}
+
+	var tasks []Task
+	for i, card := range board.Cards {
+		if card.Closed || closedLists[card.IDList] {
+			continue
+		}
+
+		task := Task{
+			Title:       card.Name,
+			Description: strings.Join(append([]string{card.Desc}, checklists[card.ID]...), "\n\n"),
+			Completed:   card.DueComplete,
+			Project:     board.Name,
+		}
+
+		if strings.TrimSpace(task.Title) == "" {
+			return nil, fmt.Errorf("card %d: card has no name", i+1)
+		}
+
+		task.Tags = appendTag(task.Tags, lists[card.IDList])
+		for _, label := range card.Labels {
+			if label.Name != "" {
+				task.Tags = appendTag(task.Tags, label.Name)
+			} else {
+				task.Tags = appendTag(task.Tags, label.Color)
+			}
+		}
+
+		if dueAt, ok := parseDate(card.Due); ok {
+			task.DueAt = dueAt
+		}
+
+		tasks = append(tasks, task)
+	}
+
+	return tasks, nil
+}
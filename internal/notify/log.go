@@ -0,0 +1,27 @@
+// Package notify implements app.Notifier.
+package notify
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/ayo-awe/golang_todo_api/internal/app"
+)
+
+// LogNotifier writes notifications to the application log
+type LogNotifier struct{}
+
+func NewLogNotifier() *LogNotifier {
+	return &LogNotifier{}
+}
+
+func (n *LogNotifier) Notify(ctx context.Context, notification app.Notification) error {
+	slog.InfoContext(ctx, "notification",
+		"user_id", notification.UserID,
+		"type", notification.Type,
+		"task_id", notification.TaskID,
+		"task_title", notification.TaskTitle,
+		"actor_id", notification.ActorID.ValueOrZero(),
+	)
+	return nil
+}
@@ -0,0 +1,613 @@
+// Package quickadd reads a task out of a line of text such as
+// "Pay rent every month on the 1st #finance !high tomorrow 9am". Tags are
+// written #tag and priorities !high, or !1 to !4 from urgent to low. Due dates,
+// times of day and recurrences are written in English. What isn't recognised
+// is the task's title.
+package quickadd
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Kinds of the phrases a Match can be
+const (
+	KindTag        = "tag"
+	KindPriority   = "priority"
+	KindDate       = "date"
+	KindTime       = "time"
+	KindRecurrence = "recurrence"
+)
+
+// Result is how a line of text was read
+type Result struct {
+	Title    string
+	Tags     []string
+	Priority string
+	// DueAt is nil when the text has no date, time or recurrence. Due dates
+	// without a time of day are midnight UTC, with AllDay set.
+	DueAt  *time.Time
+	AllDay bool
+	// Recurrence is an iCalendar RRULE
+	Recurrence string
+	Matches    []Match
+}
+
+// Match is a phrase that was recognised, in the order they appear in the text
+type Match struct {
+	Kind string
+	Text string
+}
+
+var priorities = map[string]string{
+	"urgent": "urgent", "high": "high", "medium": "medium", "low": "low", "none": "none",
+	"1": "urgent", "2": "high", "3": "medium", "4": "low",
+}
+
+var weekdays = map[string]time.Weekday{
+	"sunday": time.Sunday, "sun": time.Sunday,
+	"monday": time.Monday, "mon": time.Monday,
+	"tuesday": time.Tuesday, "tue": time.Tuesday, "tues": time.Tuesday,
+	"wednesday": time.Wednesday, "wed": time.Wednesday,
+	"thursday": time.Thursday, "thu": time.Thursday, "thur": time.Thursday, "thurs": time.Thursday,
+	"friday": time.Friday, "fri": time.Friday,
+	"saturday": time.Saturday, "sat": time.Saturday,
+}
+
+var weekdayCodes = [...]string{"SU", "MO", "TU", "WE", "TH", "FR", "SA"}
+
+var months = map[string]time.Month{
+	"january": time.January, "jan": time.January,
+	"february": time.February, "feb": time.February,
+	"march": time.March, "mar": time.March,
+	"april": time.April, "apr": time.April,
+	"may":  time.May,
+	"june": time.June, "jun": time.June,
+	"july": time.July, "jul": time.July,
+	"august": time.August, "aug": time.August,
+	"september": time.September, "sep": time.September, "sept": time.September,
+	"october": time.October, "oct": time.October,
+	"november": time.November, "nov": time.November,
+	"december": time.December, "dec": time.December,
+}
+
+var numbers = map[string]int{
+	"a": 1, "an": 1, "one": 1, "two": 2, "three": 3, "four": 4, "five": 5,
+	"six": 6, "seven": 7, "eight": 8, "nine": 9, "ten": 10,
+}
+
+// units are the lengths of intervals, by their RRULE frequency
+var units = map[string]string{
+	"day": "DAILY", "days": "DAILY",
+	"week": "WEEKLY", "weeks": "WEEKLY",
+	"month": "MONTHLY", "months": "MONTHLY",
+	"year": "YEARLY", "years": "YEARLY",
+}
+
+var (
+	isoDatePattern  = regexp.MustCompile(`^(\d{4})-(\d{2})-(\d{2})$`)
+	ordinalPattern  = regexp.MustCompile(`^(\d{1,2})(st|nd|rd|th)?$`)
+	clockPattern    = regexp.MustCompile(`^(\d{1,2})(?::(\d{2}))?(am|pm)?$`)
+	meridiemPattern = regexp.MustCompile(`^(am|pm|a\.m\.|p\.m\.)$`)
+)
+
+// date is a day without a location
+type date struct {
+	year  int
+	month time.Month
+	day   int
+}
+
+func dateOf(t time.Time) date {
+	return date{t.Year(), t.Month(), t.Day()}
+}
+
+func (d date) in(loc *time.Location) time.Time {
+	return time.Date(d.year, d.month, d.day, 0, 0, 0, 0, loc)
+}
+
+func (d date) addDays(days int) date {
+	return dateOf(d.in(time.UTC).AddDate(0, 0, days))
+}
+
+type clock struct {
+	hour, minute int
+}
+
+type recurrence struct {
+	freq       string
+	interval   int
+	byDay      []time.Weekday
+	byMonthDay int
+}
+
+func (r *recurrence) String() string {
+	rule := "FREQ=" + r.freq
+	if r.interval > 1 {
+		rule += fmt.Sprintf(";INTERVAL=%d", r.interval)
+	}
+	if len(r.byDay) > 0 {
+		codes := make([]string, len(r.byDay))
+		for i, day := range r.byDay {
+			codes[i] = weekdayCodes[day]
+		}
+		rule += ";BYDAY=" + strings.Join(codes, ",")
+	}
+	if r.byMonthDay != 0 {
+		rule += fmt.Sprintf(";BYMONTHDAY=%d", r.byMonthDay)
+	}
+	return rule
+}
+
+// matches reports whether the recurrence has an occurrence on the day
+func (r *recurrence) matches(d date) bool {
+	t := d.in(time.UTC)
+	if len(r.byDay) > 0 && !slices.Contains(r.byDay, t.Weekday()) {
+		return false
+	}
+	switch {
+	case r.byMonthDay > 0:
+		return t.Day() == r.byMonthDay
+	case r.byMonthDay < 0:
+		return t.AddDate(0, 0, 1).Day() == 1
+	}
+	return true
+}
+
+// parser holds what has been read so far. Only the first date, time and
+// recurrence are read, later ones are left in the title.
+type parser struct {
+	now   time.Time
+	words []string
+	// lower are the words in lower case, without trailing punctuation
+	lower []string
+
+	date       *date
+	clock      *clock
+	recurrence *recurrence
+	result     Result
+}
+
+// Parse reads the text. now is the time the text is read at, relative dates
+// such as tomorrow are relative to it and times of day are in its location.
+func Parse(text string, now time.Time) Result {
+	p := &parser{now: now, words: strings.Fields(text)}
+	p.lower = make([]string, len(p.words))
+	for i, word := range p.words {
+		p.lower[i] = strings.TrimRight(strings.ToLower(word), ",;")
+	}
+
+	var title []string
+	for i := 0; i < len(p.words); {
+		n, kind := p.match(i)
+		if n == 0 {
+			title = append(title, p.words[i])
+			i++
+			continue
+		}
+
+		p.result.Matches = append(p.result.Matches, Match{Kind: kind, Text: strings.Join(p.words[i:i+n], " ")})
+		i += n
+	}
+
+	p.result.Title = strings.Join(title, " ")
+	if p.recurrence != nil {
+		p.result.Recurrence = p.recurrence.String()
+	}
+	p.resolveDue()
+
+	return p.result
+}
+
+// match reads the phrase starting at the ith word, returning how many words
+// it is and its kind, or 0 if there is no phrase there
+func (p *parser) match(i int) (int, string) {
+	word := p.lower[i]
+
+	if tag, ok := strings.CutPrefix(word, "#"); ok {
+		tag = strings.TrimRight(tag, ".!?)")
+		// issue references such as #123 are left in the title
+		if tag != "" && strings.Trim(tag, "0123456789") != "" {
+			p.result.Tags = append(p.result.Tags, tag)
+			return 1, KindTag
+		}
+	}
+
+	if name, ok := strings.CutPrefix(word, "!"); ok && p.result.Priority == "" {
+		if priority, ok := priorities[name]; ok {
+			p.result.Priority = priority
+			return 1, KindPriority
+		}
+	}
+
+	if p.recurrence == nil {
+		if n := p.matchRecurrence(i); n > 0 {
+			return n, KindRecurrence
+		}
+	}
+
+	// connectives are part of the phrase they introduce
+	skip := 0
+	switch word {
+	case "on", "by", "due", "at":
+		skip = 1
+	}
+	if i+skip >= len(p.words) {
+		return 0, ""
+	}
+
+	if p.date == nil {
+		if n := p.matchDate(i+skip, skip > 0); n > 0 {
+			return skip + n, KindDate
+		}
+	}
+
+	if p.clock == nil {
+		if n := p.matchClock(i+skip, word == "at"); n > 0 {
+			return skip + n, KindTime
+		}
+	}
+
+	return 0, ""
+}
+
+func (p *parser) word(i int) string {
+	if i < len(p.lower) {
+		return p.lower[i]
+	}
+	return ""
+}
+
+// matchDate reads a date such as today, next friday, in 3 days, 2026-10-20 or
+// oct 20th. introduced is set when the date follows a connective such as on.
+func (p *parser) matchDate(i int, introduced bool) int {
+	today := dateOf(p.now)
+
+	set := func(d date, n int) int {
+		p.date = &d
+		return n
+	}
+
+	word := p.word(i)
+	switch word {
+	case "today", "tonight":
+		return set(today, 1)
+	case "tomorrow", "tmr", "tmrw":
+		return set(today.addDays(1), 1)
+	case "next", "this":
+		next := p.word(i + 1)
+		if weekday, ok := weekdays[next]; ok {
+			days := (int(weekday) - int(p.now.Weekday()) + 7) % 7
+			if word == "next" && days == 0 {
+				days = 7
+			}
+			return set(today.addDays(days), 2)
+		}
+		if word == "next" {
+			switch next {
+			case "week":
+				return set(today.addDays(7), 2)
+			case "month":
+				return set(dateOf(today.in(time.UTC).AddDate(0, 1, 0)), 2)
+			case "year":
+				return set(dateOf(today.in(time.UTC).AddDate(1, 0, 0)), 2)
+			}
+		}
+	case "in":
+		amount, ok := parseNumber(p.word(i + 1))
+		freq, isUnit := units[p.word(i+2)]
+		if ok && isUnit {
+			t := today.in(time.UTC)
+			switch freq {
+			case "DAILY":
+				t = t.AddDate(0, 0, amount)
+			case "WEEKLY":
+				t = t.AddDate(0, 0, 7*amount)
+			case "MONTHLY":
+				t = t.AddDate(0, amount, 0)
+			case "YEARLY":
+				t = t.AddDate(amount, 0, 0)
+			}
+			return set(dateOf(t), 3)
+		}
+	}
+
+	// abbreviations such as sun or wed are only read as a weekday after a
+	// connective, on their own they're likely just words
+	if weekday, ok := weekdays[word]; ok && (introduced || word == strings.ToLower(weekday.String())) {
+		return set(today.addDays((int(weekday)-int(p.now.Weekday())+7)%7), 1)
+	}
+
+	if match := isoDatePattern.FindStringSubmatch(word); match != nil {
+		year, _ := strconv.Atoi(match[1])
+		month, _ := strconv.Atoi(match[2])
+		day, _ := strconv.Atoi(match[3])
+		if d, ok := validDate(year, time.Month(month), day); ok {
+			return set(d, 1)
+		}
+		return 0
+	}
+
+	// october 20th, oct 20 2027, 20th october, 20 of oct
+	month, day, n := time.Month(0), 0, 0
+	if m, ok := months[word]; ok {
+		if d, ok := parseOrdinal(p.word(i + 1)); ok {
+			month, day, n = m, d, 2
+		}
+	} else if d, ok := parseOrdinal(word); ok {
+		offset := 1
+		if p.word(i+1) == "of" {
+			offset = 2
+		}
+		if m, ok := months[p.word(i+offset)]; ok {
+			month, day, n = m, d, offset+1
+		}
+	}
+	if n == 0 {
+		return 0
+	}
+
+	year := today.year
+	if y, err := strconv.Atoi(p.word(i + n)); err == nil && y >= 1000 && y <= 9999 {
+		year = y
+		n++
+	} else if (date{year, month, day}).in(time.UTC).Before(today.in(time.UTC)) {
+		// dates that have passed this year are next year's
+		year++
+	}
+
+	if d, ok := validDate(year, month, day); ok {
+		return set(d, n)
+	}
+	return 0
+}
+
+// matchClock reads a time of day such as 9am, 9:30 pm, 21:00 or noon. Bare
+// hours are only read after "at".
+func (p *parser) matchClock(i int, afterAt bool) int {
+	word := p.word(i)
+
+	set := func(hour, minute, n int) int {
+		p.clock = &clock{hour, minute}
+		return n
+	}
+
+	switch word {
+	case "noon", "midday":
+		return set(12, 0, 1)
+	case "midnight":
+		// due by the end of the day
+		return set(23, 59, 1)
+	}
+
+	match := clockPattern.FindStringSubmatch(word)
+	if match == nil {
+		return 0
+	}
+
+	hour, _ := strconv.Atoi(match[1])
+	minute, _ := strconv.Atoi(match[2])
+	meridiem, n := match[3], 1
+	if meridiem == "" && meridiemPattern.MatchString(p.word(i+1)) {
+		meridiem, n = p.word(i + 1)[:1]+"m", 2
+	}
+
+	switch {
+	case meridiem != "":
+		if hour < 1 || hour > 12 {
+			return 0
+		}
+		hour %= 12
+		if meridiem == "pm" {
+			hour += 12
+		}
+	case match[2] == "" && !afterAt:
+		// a bare number could be anything
+		return 0
+	case hour > 23:
+		return 0
+	}
+
+	if minute > 59 {
+		return 0
+	}
+
+	return set(hour, minute, n)
+}
+
+// matchRecurrence reads phrases such as daily, every other week, every
+// weekday, every mon and thu, every 15th or every month on the last day
+func (p *parser) matchRecurrence(i int) int {
+	word := p.word(i)
+
+	switch word {
+	case "daily", "weekly", "monthly", "yearly", "annually":
+		freq := map[string]string{"daily": "DAILY", "weekly": "WEEKLY", "monthly": "MONTHLY", "yearly": "YEARLY", "annually": "YEARLY"}[word]
+		p.recurrence = &recurrence{freq: freq}
+		return 1 + p.matchMonthDay(i+1)
+	case "every", "each":
+	default:
+		return 0
+	}
+
+	n := 1
+	rec := &recurrence{interval: 1}
+
+	if p.word(i+n) == "other" {
+		rec.interval = 2
+		n++
+	} else if amount, err := strconv.Atoi(p.word(i + n)); err == nil && amount > 1 {
+		if _, ok := units[p.word(i+n+1)]; ok {
+			rec.interval = amount
+			n++
+		}
+	}
+
+	next := p.word(i + n)
+	switch {
+	case units[next] != "":
+		rec.freq = units[next]
+		n++
+	case next == "weekday" || next == "weekdays":
+		rec.freq = "WEEKLY"
+		rec.byDay = []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday}
+		n++
+	case next == "weekend" || next == "weekends":
+		rec.freq = "WEEKLY"
+		rec.byDay = []time.Weekday{time.Saturday, time.Sunday}
+		n++
+	case isWeekday(next):
+		rec.freq = "WEEKLY"
+		// mon, wed and fri
+		for {
+			weekday, ok := parseWeekday(p.word(i + n))
+			if !ok {
+				break
+			}
+			if !slices.Contains(rec.byDay, weekday) {
+				rec.byDay = append(rec.byDay, weekday)
+			}
+			n++
+			if p.word(i+n) == "and" && isWeekday(p.word(i+n+1)) {
+				n++
+			}
+		}
+	default:
+		// every 15th
+		if day, ok := parseOrdinal(next); ok && strings.Trim(next, "0123456789") != "" {
+			rec.freq = "MONTHLY"
+			rec.byMonthDay = day
+			n++
+		} else {
+			return 0
+		}
+	}
+
+	p.recurrence = rec
+	if rec.freq == "MONTHLY" && rec.byMonthDay == 0 {
+		n += p.matchMonthDay(i + n)
+	}
+
+	return n
+}
+
+// matchMonthDay reads "on the 1st" or "on the last day" after a monthly
+// recurrence
+func (p *parser) matchMonthDay(i int) int {
+	if p.recurrence.freq != "MONTHLY" {
+		return 0
+	}
+
+	n := 0
+	if p.word(i) == "on" {
+		n++
+	}
+	if p.word(i+n) != "the" {
+		return 0
+	}
+	n++
+
+	if p.word(i+n) == "last" {
+		n++
+		if p.word(i+n) == "day" {
+			n++
+		}
+		p.recurrence.byMonthDay = -1
+		return n
+	}
+
+	day, ok := parseOrdinal(p.word(i + n))
+	if !ok {
+		return 0
+	}
+	p.recurrence.byMonthDay = day
+	return n + 1
+}
+
+// resolveDue works out the due date. A recurrence without a date is due on its
+// first occurrence, and times without a date are due when they next come.
+func (p *parser) resolveDue() {
+	if p.date == nil && p.clock == nil && p.recurrence == nil {
+		return
+	}
+
+	loc := p.now.Location()
+	today := dateOf(p.now)
+
+	day := today
+	if p.date != nil {
+		day = *p.date
+	}
+
+	at := func(d date) time.Time {
+		return time.Date(d.year, d.month, d.day, p.clock.hour, p.clock.minute, 0, 0, loc)
+	}
+
+	if p.date == nil {
+		// a year of days is enough to find any occurrence
+		first := day
+		for i := 0; i < 366; i++ {
+			candidate := day.addDays(i)
+			passed := p.clock != nil && at(candidate).Before(p.now)
+			if !passed && (p.recurrence == nil || p.recurrence.matches(candidate)) {
+				first = candidate
+				break
+			}
+		}
+		day = first
+	}
+
+	if p.clock == nil {
+		dueAt := day.in(time.UTC)
+		p.result.DueAt = &dueAt
+		p.result.AllDay = true
+		return
+	}
+
+	dueAt := at(day)
+	p.result.DueAt = &dueAt
+}
+
+// parseWeekday reads a weekday, or its plural as in "every mondays"
+func parseWeekday(word string) (time.Weekday, bool) {
+	if weekday, ok := weekdays[word]; ok {
+		return weekday, true
+	}
+	weekday, ok := weekdays[strings.TrimSuffix(word, "s")]
+	return weekday, ok
+}
+
+func isWeekday(word string) bool {
+	_, ok := parseWeekday(word)
+	return ok
+}
+
+func parseNumber(word string) (int, bool) {
+	if n, ok := numbers[word]; ok {
+		return n, true
+	}
+	n, err := strconv.Atoi(word)
+	return n, err == nil && n > 0
+}
+
+// parseOrdinal reads a day of the month such as 1st or 21
+func parseOrdinal(word string) (int, bool) {
+	match := ordinalPattern.FindStringSubmatch(word)
+	if match == nil {
+		return 0, false
+	}
+	day, _ := strconv.Atoi(match[1])
+	return day, day >= 1 && day <= 31
+}
+
+func validDate(year int, month time.Month, day int) (date, bool) {
+	t := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	if t.Month() != month || t.Day() != day {
+		return date{}, false
+	}
+	return dateOf(t), true
+}
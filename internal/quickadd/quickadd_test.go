@@ -0,0 +1,178 @@
+package quickadd
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	london, err := time.LoadLocation("Europe/London")
+	if err != nil {
+		t.Skip("time zone database isn't available")
+	}
+	// a Monday evening, after British Summer Time
+	now := time.Date(2026, 10, 19, 18, 0, 0, 0, london)
+
+	at := func(month time.Month, day, hour, minute int) string {
+		return time.Date(2026, month, day, hour, minute, 0, 0, london).Format(time.RFC3339)
+	}
+	allDay := func(year int, month time.Month, day int) string {
+		return time.Date(year, month, day, 0, 0, 0, 0, time.UTC).Format(time.RFC3339)
+	}
+
+	tests := []struct {
+		text       string
+		title      string
+		tags       []string
+		priority   string
+		due        string
+		allDay     bool
+		recurrence string
+		matches    []string
+	}{
+		{
+			text:       "Pay rent every month on the 1st #finance !high tomorrow 9am",
+			title:      "Pay rent",
+			tags:       []string{"finance"},
+			priority:   "high",
+			due:        at(time.October, 20, 9, 0),
+			recurrence: "FREQ=MONTHLY;BYMONTHDAY=1",
+			matches:    []string{"every month on the 1st", "#finance", "!high", "tomorrow", "9am"},
+		},
+		{
+			text:    "Just a title",
+			title:   "Just a title",
+			matches: []string{},
+		},
+		// times that have passed today roll over to tomorrow
+		{text: "Call mum 5pm", title: "Call mum", due: at(time.October, 20, 17, 0), matches: []string{"5pm"}},
+		{text: "Call mum at 7pm", title: "Call mum", due: at(time.October, 19, 19, 0), matches: []string{"at 7pm"}},
+		{text: "Lunch at noon", title: "Lunch", due: at(time.October, 20, 12, 0), matches: []string{"at noon"}},
+		{text: "Ship by 17:30", title: "Ship", due: at(time.October, 20, 17, 30), matches: []string{"by 17:30"}},
+		// weekdays are the next one, today included, and next skips this week
+		{text: "Report friday", title: "Report", due: allDay(2026, time.October, 23), allDay: true, matches: []string{"friday"}},
+		{text: "Sync monday", title: "Sync", due: allDay(2026, time.October, 19), allDay: true, matches: []string{"monday"}},
+		{text: "Plan next monday", title: "Plan", due: allDay(2026, time.October, 26), allDay: true, matches: []string{"next monday"}},
+		{text: "Report due fri 5pm", title: "Report", due: at(time.October, 23, 17, 0), matches: []string{"due fri", "5pm"}},
+		// abbreviations are only weekdays after a connective
+		{text: "Buy sun cream on sun", title: "Buy sun cream", due: allDay(2026, time.October, 25), allDay: true, matches: []string{"on sun"}},
+		{text: "Review in 3 days", title: "Review", due: allDay(2026, time.October, 22), allDay: true, matches: []string{"in 3 days"}},
+		{text: "Read in a week", title: "Read", due: allDay(2026, time.October, 26), allDay: true, matches: []string{"in a week"}},
+		{text: "Taxes 2026-12-31 !1", title: "Taxes", priority: "urgent", due: allDay(2026, time.December, 31), allDay: true, matches: []string{"2026-12-31", "!1"}},
+		{text: "Dentist oct 30th 3pm", title: "Dentist", due: at(time.October, 30, 15, 0), matches: []string{"oct 30th", "3pm"}},
+		// dates that have passed this year are next year's
+		{text: "Renew jan 5", title: "Renew", due: allDay(2027, time.January, 5), allDay: true, matches: []string{"jan 5"}},
+		// invalid dates aren't dates
+		{text: "Visit feb 30", title: "Visit feb 30", matches: []string{}},
+		{text: "Visit 2026-02-30", title: "Visit 2026-02-30", matches: []string{}},
+		// recurrences are due on their first occurrence
+		{
+			text:       "Gym every mon and thu",
+			title:      "Gym",
+			due:        allDay(2026, time.October, 19),
+			allDay:     true,
+			recurrence: "FREQ=WEEKLY;BYDAY=MO,TH",
+			matches:    []string{"every mon and thu"},
+		},
+		{
+			text:       "Gym every mon, wed and fri at 7am",
+			title:      "Gym",
+			due:        at(time.October, 21, 7, 0),
+			recurrence: "FREQ=WEEKLY;BYDAY=MO,WE,FR",
+			matches:    []string{"every mon, wed and fri", "at 7am"},
+		},
+		{
+			text:       "Pay every month on the 1st",
+			title:      "Pay",
+			due:        allDay(2026, time.November, 1),
+			allDay:     true,
+			recurrence: "FREQ=MONTHLY;BYMONTHDAY=1",
+			matches:    []string{"every month on the 1st"},
+		},
+		{
+			text:       "Pay card every month on the last day",
+			title:      "Pay card",
+			due:        allDay(2026, time.October, 31),
+			allDay:     true,
+			recurrence: "FREQ=MONTHLY;BYMONTHDAY=-1",
+			matches:    []string{"every month on the last day"},
+		},
+		{
+			text:       "Standup every weekday at 9:30",
+			title:      "Standup",
+			due:        at(time.October, 20, 9, 30),
+			recurrence: "FREQ=WEEKLY;BYDAY=MO,TU,WE,TH,FR",
+			matches:    []string{"every weekday", "at 9:30"},
+		},
+		{
+			text:       "Water plants every 2 weeks",
+			title:      "Water plants",
+			due:        allDay(2026, time.October, 19),
+			allDay:     true,
+			recurrence: "FREQ=WEEKLY;INTERVAL=2",
+			matches:    []string{"every 2 weeks"},
+		},
+		// only the first priority is read, issue references aren't tags
+		{text: "Plan !urgent !low", title: "Plan !low", priority: "urgent", matches: []string{"!urgent"}},
+		{text: "Fix #123 #bug", title: "Fix #123", tags: []string{"bug"}, matches: []string{"#bug"}},
+		{text: "Call Tom", title: "Call Tom", matches: []string{}},
+		// text with only a date has no title
+		{text: "tomorrow 9am", title: "", due: at(time.October, 20, 9, 0), matches: []string{"tomorrow", "9am"}},
+		{text: "in 3 days", title: "", due: allDay(2026, time.October, 22), allDay: true, matches: []string{"in 3 days"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.text, func(t *testing.T) {
+			result := Parse(test.text, now)
+
+			if result.Title != test.title {
+				t.Errorf("title = %q, want %q", result.Title, test.title)
+			}
+			if !slices.Equal(result.Tags, test.tags) {
+				t.Errorf("tags = %q, want %q", result.Tags, test.tags)
+			}
+			if result.Priority != test.priority {
+				t.Errorf("priority = %q, want %q", result.Priority, test.priority)
+			}
+
+			due := ""
+			if result.DueAt != nil {
+				due = result.DueAt.Format(time.RFC3339)
+			}
+			if due != test.due {
+				t.Errorf("due = %q, want %q", due, test.due)
+			}
+			if result.AllDay != test.allDay {
+				t.Errorf("all day = %t, want %t", result.AllDay, test.allDay)
+			}
+			if result.Recurrence != test.recurrence {
+				t.Errorf("recurrence = %q, want %q", result.Recurrence, test.recurrence)
+			}
+
+			matches := []string{}
+			for _, match := range result.Matches {
+				matches = append(matches, match.Text)
+			}
+			if !slices.Equal(matches, test.matches) {
+				t.Errorf("matches = %q, want %q", matches, test.matches)
+			}
+		})
+	}
+}
+
+func TestParseTimesAreInNowsLocation(t *testing.T) {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Skip("time zone database isn't available")
+	}
+	now := time.Date(2026, 10, 19, 8, 0, 0, 0, tokyo)
+
+	result := Parse("Standup 9am", now)
+	if result.DueAt == nil || !result.DueAt.Equal(time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("due = %v, want 09:00 in Tokyo", result.DueAt)
+	}
+	if result.AllDay {
+		t.Error("times of day aren't all day")
+	}
+}
@@ -0,0 +1,126 @@
+// Package rank generates keys that sort lexicographically, so an item can be
+// placed between two others by giving it a key between theirs without
+// renumbering the rest of the list.
+//
+// Keys are base 62 fractions written with the digits 0-9A-Za-z, which sort in
+// the same order as their bytes. Stored keys must be compared bytewise (the
+// "C" collation in Postgres).
+package rank
+
+import (
+	"errors"
+	"strings"
+)
+
+const digits = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+
+const base = len(digits)
+
+// MaxLength is the key length past which a list should be rebalanced
+const MaxLength = 16
+
+var (
+	ErrInvalidKey   = errors.New("rank: key contains an invalid digit")
+	ErrInvalidRange = errors.New("rank: no key exists between the given keys")
+)
+
+// Between returns a key that sorts strictly after lower and before upper.
+// An empty lower means the start of the list and an empty upper its end.
+func Between(lower, upper string) (string, error) {
+	if !valid(lower) || !valid(upper) {
+		return "", ErrInvalidKey
+	}
+
+	// trailing zeros don't change the value of a fraction, so "1" and "10"
+	// leave no room between them
+	lo := strings.TrimRight(lower, "0")
+	hi := strings.TrimRight(upper, "0")
+	if upper != "" && (hi == "" || lo >= hi) {
+		return "", ErrInvalidRange
+	}
+
+	bounded := upper != ""
+	var key []byte
+	for i := 0; ; i++ {
+		loDigit := digitAt(lo, i)
+		hiDigit := base
+		if bounded {
+			hiDigit = digitAt(hi, i)
+		}
+
+		if hiDigit-loDigit > 1 {
+			return string(append(key, digits[(loDigit+hiDigit)/2])), nil
+		}
+
+		key = append(key, digits[loDigit])
+
+		// once the key sorts below upper, any digits that follow keep it there
+		if hiDigit-loDigit == 1 {
+			bounded = false
+		}
+	}
+}
+
+// After returns the shortest key that sorts after lower, for appending to
+// the end of a list. Unlike Between(lower, "") it steps over the gap at the
+// end instead of halving it, so keys only grow by a digit once every digit
+// before it has been used up.
+func After(lower string) (string, error) {
+	if !valid(lower) {
+		return "", ErrInvalidKey
+	}
+
+	// the first digit that can be incremented, keys of only z's get a digit
+	// appended
+	for i := 0; ; i++ {
+		if digit := digitAt(lower, i); digit < base-1 {
+			return lower[:i] + string(digits[digit+1]), nil
+		}
+	}
+}
+
+// Spread returns n keys in ascending order, evenly spaced so that every gap
+// leaves room for further inserts
+func Spread(n int) []string {
+	width, space := 1, base
+	for space < (n+1)*base && width < 10 {
+		width++
+		space *= base
+	}
+
+	step := space / (n + 1)
+	keys := make([]string, n)
+	for i := range keys {
+		keys[i] = encode((i+1)*step, width)
+	}
+
+	return keys
+}
+
+func encode(value, width int) string {
+	key := make([]byte, width)
+	for i := width - 1; i >= 0; i-- {
+		key[i] = digits[value%base]
+		value /= base
+	}
+
+	return strings.TrimRight(string(key), "0")
+}
+
+func digitAt(key string, i int) int {
+	if i >= len(key) {
+		return 0
+	}
+
+	return strings.IndexByte(digits, key[i])
+}
+
+func valid(key string) bool {
+	for i := 0; i < len(key); i++ {
+		if strings.IndexByte(digits, key[i]) < 0 {
+			return false
+		}
+	}
+
+	return true
+}
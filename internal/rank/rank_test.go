@@ -0,0 +1,156 @@
+package rank
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestBetween(t *testing.T) {
+	tests := []struct {
+		lower, upper string
+		err          error
+	}{
+		{"", "", nil},
+		{"", "1", nil},
+		{"1", "", nil},
+		{"1", "2", nil},
+		{"1", "11", nil},
+		{"V", "W", nil},
+		{"z", "", nil},
+		{"0z", "1", nil},
+		{"1", "10", ErrInvalidRange},
+		{"2", "1", ErrInvalidRange},
+		{"1", "1", ErrInvalidRange},
+		{"a-b", "", ErrInvalidKey},
+	}
+
+	for _, test := range tests {
+		key, err := Between(test.lower, test.upper)
+		if !errors.Is(err, test.err) {
+			t.Errorf("Between(%q, %q) error = %v, want %v", test.lower, test.upper, err, test.err)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+
+		if key <= test.lower || (test.upper != "" && key >= test.upper) {
+			t.Errorf("Between(%q, %q) = %q, which isn't between them", test.lower, test.upper, key)
+		}
+	}
+}
+
+func TestAfter(t *testing.T) {
+	tests := []struct {
+		lower, want string
+	}{
+		{"", "1"},
+		{"1", "2"},
+		{"V", "W"},
+		{"Vk", "W"},
+		{"y", "z"},
+		{"z", "z1"},
+		{"zz", "zz1"},
+		{"zy5", "zz"},
+	}
+
+	for _, test := range tests {
+		if got, err := After(test.lower); err != nil || got != test.want {
+			t.Errorf("After(%q) = %q, %v, want %q", test.lower, got, err, test.want)
+		}
+	}
+
+	if _, err := After("a b"); !errors.Is(err, ErrInvalidKey) {
+		t.Errorf("After(%q) error = %v, want %v", "a b", err, ErrInvalidKey)
+	}
+}
+
+func TestRepeatedAppends(t *testing.T) {
+	const n = 1000
+
+	last := ""
+	for i := 0; i < n; i++ {
+		key, err := After(last)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if key <= last {
+			t.Fatalf("append %d: %q doesn't sort after %q", i, key, last)
+		}
+		last = key
+	}
+
+	// a digit is added once the previous one runs out, every base-1 appends
+	if want := n/(base-1) + 1; len(last) > want {
+		t.Errorf("key after %d appends is %d long, want at most %d", n, len(last), want)
+	}
+}
+
+func TestRepeatedPrepends(t *testing.T) {
+	const n = 1000
+
+	first := Spread(1)[0]
+	for i := 0; i < n; i++ {
+		key, err := Between("", first)
+		if err != nil {
+			t.Fatalf("prepend %d before %q: %v", i, first, err)
+		}
+		if key >= first {
+			t.Fatalf("prepend %d: %q doesn't sort before %q", i, key, first)
+		}
+		first = key
+	}
+}
+
+// TestAppendsWithRebalancing appends the way tasks are created, spreading the
+// list once keys get longer than MaxLength
+func TestAppendsWithRebalancing(t *testing.T) {
+	var keys []string
+	for i := 0; i < 5000; i++ {
+		last := ""
+		if len(keys) > 0 {
+			last = keys[len(keys)-1]
+		}
+
+		key, err := After(last)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(key) > MaxLength {
+			keys = Spread(len(keys))
+			if key, err = After(keys[len(keys)-1]); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if len(key) > MaxLength {
+			t.Fatalf("append %d: key %q is still too long after rebalancing", i, key)
+		}
+
+		keys = append(keys, key)
+	}
+
+	if !slices.IsSorted(keys) {
+		t.Error("keys aren't sorted")
+	}
+}
+
+func TestSpread(t *testing.T) {
+	for _, n := range []int{0, 1, 2, 61, 62, 1000, 100000} {
+		keys := Spread(n)
+		if len(keys) != n {
+			t.Fatalf("Spread(%d) returned %d keys", n, len(keys))
+		}
+
+		for i := range keys {
+			lower := ""
+			if i > 0 {
+				lower = keys[i-1]
+			}
+			// every gap has room for another key
+			if _, err := Between(lower, keys[i]); err != nil {
+				t.Fatalf("Spread(%d): no room between %q and %q: %v", n, lower, keys[i], err)
+			}
+		}
+	}
+}
@@ -0,0 +1,124 @@
+// Package webhook implements app.WebhookSender.
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/ayo-awe/golang_todo_api/internal/app"
+)
+
+// maxResponseBodySize is how much of a receiver's response is kept in the delivery log
+const maxResponseBodySize = 4096
+
+// ErrForbiddenAddress is returned for deliveries to urls that resolve to
+// addresses of the server's own network
+var ErrForbiddenAddress = errors.New("webhook url resolves to a forbidden address")
+
+// HTTPSender POSTs deliveries to their webhook's url. Receivers can't be on
+// loopback, private, link-local, multicast or unspecified addresses, which
+// would let users read the responses of internal services, and redirects
+// aren't followed.
+type HTTPSender struct {
+	client *http.Client
+}
+
+func NewHTTPSender(timeout time.Duration) *HTTPSender {
+	dialer := &net.Dialer{
+		Timeout: timeout,
+		// checked once the address is resolved, so hosts can't resolve to a
+		// public address when validated and a private one when sent to
+		Control: func(network, address string, _ syscall.RawConn) error {
+			return checkAddress(address)
+		},
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return newHTTPSender(&http.Client{Transport: transport, Timeout: timeout})
+}
+
+func newHTTPSender(client *http.Client) *HTTPSender {
+	client.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		return http.ErrUseLastResponse
+	}
+	return &HTTPSender{client: client}
+}
+
+// checkAddress fails for a host:port that isn't publicly routable
+func checkAddress(address string) error {
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return err
+	}
+
+	if app.IsForbiddenWebhookAddr(addrPort.Addr()) {
+		return fmt.Errorf("%w %s", ErrForbiddenAddress, addrPort.Addr())
+	}
+
+	return nil
+}
+
+func (s *HTTPSender) Send(ctx context.Context, webhook app.OutgoingWebhook) (*app.WebhookResponse, error) {
+	body := []byte(webhook.Delivery.Payload)
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "golang-todo-api-webhooks")
+	req.Header.Set("X-Webhook-Event", string(webhook.Delivery.EventType))
+	req.Header.Set("X-Webhook-Delivery", strconv.Itoa(webhook.Delivery.ID))
+	req.Header.Set("X-Webhook-Timestamp", timestamp)
+	req.Header.Set("X-Webhook-Signature", Sign(webhook.Secret, timestamp, body))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseBodySize))
+	if err != nil {
+		return nil, err
+	}
+
+	// the body is stored as text, which can't hold invalid utf-8 or null bytes
+	text := strings.ReplaceAll(strings.ToValidUTF8(string(respBody), ""), "\x00", "")
+
+	return &app.WebhookResponse{StatusCode: resp.StatusCode, Body: text}, nil
+}
+
+// Sign returns the X-Webhook-Signature of a delivery body sent at timestamp
+func Sign(secret string, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify reports whether signature is the signature of a delivery body sent at
+// timestamp. Receivers should also reject timestamps that are too old.
+func Verify(secret string, timestamp string, body []byte, signature string) bool {
+	return hmac.Equal([]byte(Sign(secret, timestamp, body)), []byte(signature))
+}
@@ -0,0 +1,112 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ayo-awe/golang_todo_api/internal/app"
+)
+
+func TestSendSignsDeliveries(t *testing.T) {
+	const secret = "0123456789abcdef"
+	payload := `{"event":"task.created"}`
+
+	received := make(chan *http.Request, 1)
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		if !Verify(secret, r.Header.Get("X-Webhook-Timestamp"), body, r.Header.Get("X-Webhook-Signature")) {
+			t.Errorf("signature %q doesn't verify", r.Header.Get("X-Webhook-Signature"))
+		}
+		if string(body) != payload {
+			t.Errorf("body = %q, want %q", body, payload)
+		}
+
+		received <- r
+		w.WriteHeader(http.StatusAccepted)
+		io.WriteString(w, "ok")
+	}))
+	defer receiver.Close()
+
+	// the receiver is on loopback, which NewHTTPSender refuses
+	sender := newHTTPSender(receiver.Client())
+	response, err := sender.Send(context.Background(), app.OutgoingWebhook{
+		URL:    receiver.URL,
+		Secret: secret,
+		Delivery: app.WebhookDelivery{
+			ID:        7,
+			EventType: app.WebhookTaskCreated,
+			Payload:   []byte(payload),
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if response.StatusCode != http.StatusAccepted || response.Body != "ok" {
+		t.Errorf("response = %+v", response)
+	}
+
+	r := <-received
+	if got := r.Header.Get("X-Webhook-Event"); got != "task.created" {
+		t.Errorf("X-Webhook-Event = %q", got)
+	}
+	if got := r.Header.Get("X-Webhook-Delivery"); got != "7" {
+		t.Errorf("X-Webhook-Delivery = %q", got)
+	}
+}
+
+func TestVerifyRejectsTamperedBodies(t *testing.T) {
+	signature := Sign("secret", "1700000000", []byte("body"))
+
+	if !Verify("secret", "1700000000", []byte("body"), signature) {
+		t.Error("signature doesn't verify")
+	}
+	if Verify("secret", "1700000000", []byte("b0dy"), signature) {
+		t.Error("tampered body verifies")
+	}
+	if Verify("secret", "1700000001", []byte("body"), signature) {
+		t.Error("tampered timestamp verifies")
+	}
+	if Verify("other", "1700000000", []byte("body"), signature) {
+		t.Error("other secret verifies")
+	}
+}
+
+func TestSendRefusesLocalAddresses(t *testing.T) {
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("delivery reached a loopback receiver")
+	}))
+	defer receiver.Close()
+
+	sender := NewHTTPSender(5 * time.Second)
+	_, err := sender.Send(context.Background(), app.OutgoingWebhook{URL: receiver.URL, Secret: "secret"})
+	if !errors.Is(err, ErrForbiddenAddress) {
+		t.Errorf("err = %v, want ErrForbiddenAddress", err)
+	}
+}
+
+func TestSendDoesNotFollowRedirects(t *testing.T) {
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/" {
+			t.Errorf("redirect to %s was followed", r.URL.Path)
+		}
+		http.Redirect(w, r, "/internal", http.StatusFound)
+	}))
+	defer receiver.Close()
+
+	sender := newHTTPSender(receiver.Client())
+	response, err := sender.Send(context.Background(), app.OutgoingWebhook{URL: receiver.URL, Secret: "secret"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if response.StatusCode != http.StatusFound {
+		t.Errorf("status = %d, want %d", response.StatusCode, http.StatusFound)
+	}
+}
@@ -5,7 +5,10 @@ import (
 	"log"
 
 	"github.com/ayo-awe/golang_todo_api/internal/app"
+	"github.com/ayo-awe/golang_todo_api/internal/blob"
 	"github.com/ayo-awe/golang_todo_api/internal/database"
+	"github.com/ayo-awe/golang_todo_api/internal/notify"
+	"github.com/ayo-awe/golang_todo_api/internal/webhook"
 )
 
 //	@title			Task Managment API
@@ -20,14 +23,40 @@ func main() {
 		log.Fatal(err)
 	}
 
-	database, err := database.New(cfg.DB_URL)
+	db, err := database.New(cfg.DB_URL)
 	if err != nil {
 		log.Fatal(err)
 	}
 
-	app := app.NewApplication(cfg, database)
+	blobs, err := newBlobStore(cfg)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	webhookSender := webhook.NewHTTPSender(cfg.WEBHOOK_TIMEOUT)
+
+	feed := database.NewChangeFeed(db)
+
+	app := app.NewApplication(cfg, db, blobs, notify.NewLogNotifier(), webhookSender, feed)
 
 	if err := app.Start(); err != nil {
 		fmt.Print(err)
 	}
 }
+
+func newBlobStore(cfg *app.Config) (app.BlobStore, error) {
+	switch cfg.BLOB_STORE {
+	case "local":
+		return blob.NewFileStore(cfg.BLOB_DIR)
+	case "s3":
+		return blob.NewS3Store(blob.S3Config{
+			Endpoint:  cfg.S3_ENDPOINT,
+			Region:    cfg.S3_REGION,
+			Bucket:    cfg.S3_BUCKET,
+			AccessKey: cfg.S3_ACCESS_KEY,
+			SecretKey: cfg.S3_SECRET_KEY,
+		}), nil
+	default:
+		return nil, fmt.Errorf("unknown blob store %q", cfg.BLOB_STORE)
+	}
+}
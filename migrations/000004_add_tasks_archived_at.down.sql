@@ -0,0 +1,3 @@
+ALTER TABLE "tasks"
+DROP COLUMN IF EXISTS archived_at,
+DROP COLUMN IF EXISTS completed_at;
@@ -0,0 +1,5 @@
+ALTER TABLE "tasks"
+ADD COLUMN completed_at TIMESTAMPTZ,
+ADD COLUMN archived_at TIMESTAMPTZ;
+
+UPDATE "tasks" SET completed_at = updated_at WHERE is_completed;
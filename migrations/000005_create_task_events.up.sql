@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS "task_events" (
+	id SERIAL PRIMARY KEY,
+	task_id INT NOT NULL,
+	user_id INT NOT NULL,
+	actor_id INT,
+	action VARCHAR(32) NOT NULL,
+	changes JSONB NOT NULL DEFAULT('{}'),
+	request_id VARCHAR(255) NOT NULL DEFAULT(''),
+	created_at TIMESTAMPTZ NOT NULL DEFAULT(CURRENT_TIMESTAMP),
+
+	CONSTRAINT fk_task_events_user_id FOREIGN KEY (user_id) REFERENCES "users" (id) ON DELETE CASCADE,
+	CONSTRAINT fk_task_events_actor_id FOREIGN KEY (actor_id) REFERENCES "users" (id) ON DELETE SET NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_task_events_task_id ON "task_events" (task_id, id);
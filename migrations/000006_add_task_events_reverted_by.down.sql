@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_task_events_actor_id;
+ALTER TABLE "task_events" DROP COLUMN IF EXISTS reverted_by;
@@ -0,0 +1,5 @@
+ALTER TABLE "task_events"
+ADD COLUMN reverted_by INT,
+ADD CONSTRAINT fk_task_events_reverted_by FOREIGN KEY (reverted_by) REFERENCES "task_events" (id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_task_events_actor_id ON "task_events" (actor_id, id) WHERE reverted_by IS NULL;
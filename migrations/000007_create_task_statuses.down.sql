@@ -0,0 +1,4 @@
+DROP TRIGGER IF EXISTS trigger_tasks_sync_completion ON "tasks";
+DROP FUNCTION IF EXISTS sync_task_completion;
+ALTER TABLE "tasks" DROP COLUMN IF EXISTS status_id;
+DROP TABLE IF EXISTS "task_statuses";
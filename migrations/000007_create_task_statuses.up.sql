@@ -0,0 +1,56 @@
+CREATE TABLE IF NOT EXISTS "task_statuses" (
+	id SERIAL PRIMARY KEY,
+	user_id INT NOT NULL,
+	name VARCHAR(255) NOT NULL,
+	category VARCHAR(32) NOT NULL,
+	position INT NOT NULL DEFAULT(0),
+	created_at TIMESTAMPTZ NOT NULL DEFAULT(CURRENT_TIMESTAMP),
+	updated_at TIMESTAMPTZ NOT NULL DEFAULT(CURRENT_TIMESTAMP),
+
+	CONSTRAINT fk_task_statuses_user_id FOREIGN KEY (user_id) REFERENCES "users" (id) ON DELETE CASCADE,
+	CONSTRAINT unique_task_statuses_name UNIQUE (user_id, name),
+	CONSTRAINT check_task_statuses_category CHECK (category IN ('todo', 'in_progress', 'done', 'cancelled'))
+);
+
+INSERT INTO "task_statuses" (user_id, name, category, position)
+SELECT u.id, s.name, s.category, s.position
+FROM "users" u
+CROSS JOIN (VALUES
+	('To Do', 'todo', 0),
+	('In Progress', 'in_progress', 1),
+	('In Review', 'in_progress', 2),
+	('Done', 'done', 3),
+	('Won''t Do', 'cancelled', 4)
+) AS s(name, category, position);
+
+ALTER TABLE "tasks"
+ADD COLUMN status_id INT,
+ADD CONSTRAINT fk_tasks_status_id FOREIGN KEY (status_id) REFERENCES "task_statuses" (id);
+
+UPDATE "tasks" t
+SET status_id = s.id
+FROM "task_statuses" s
+WHERE s.user_id = t.user_id AND s.name = CASE WHEN t.is_completed THEN 'Done' ELSE 'To Do' END;
+
+ALTER TABLE "tasks" ALTER COLUMN status_id SET NOT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_tasks_status_id ON "tasks" (status_id);
+
+-- is_completed and completed_at are derived from the category of a task's status
+CREATE OR REPLACE FUNCTION sync_task_completion() RETURNS TRIGGER AS $$
+BEGIN
+	NEW.is_completed := (SELECT category = 'done' FROM "task_statuses" WHERE id = NEW.status_id);
+
+	IF NEW.is_completed THEN
+		NEW.completed_at := COALESCE(NEW.completed_at, CURRENT_TIMESTAMP);
+	ELSE
+		NEW.completed_at := NULL;
+	END IF;
+
+	RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trigger_tasks_sync_completion
+BEFORE INSERT OR UPDATE OF status_id ON "tasks"
+FOR EACH ROW EXECUTE FUNCTION sync_task_completion();
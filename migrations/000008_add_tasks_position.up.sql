@@ -0,0 +1,15 @@
+ALTER TABLE "tasks"
+ADD COLUMN position TEXT COLLATE "C" NOT NULL DEFAULT '';
+
+-- fixed width keys keep the existing oldest-first order
+UPDATE "tasks" t
+SET position = lpad(o.row_number::text, 10, '0')
+FROM (
+	SELECT id, row_number() OVER (PARTITION BY user_id ORDER BY id)
+	FROM "tasks"
+) o
+WHERE t.id = o.id;
+
+ALTER TABLE "tasks" ALTER COLUMN position DROP DEFAULT;
+
+CREATE INDEX IF NOT EXISTS idx_tasks_user_id_position ON "tasks" (user_id, position, id);
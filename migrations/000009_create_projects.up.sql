@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS "projects" (
+	id SERIAL PRIMARY KEY,
+	user_id INT NOT NULL,
+	name VARCHAR(255) NOT NULL,
+	description TEXT NOT NULL DEFAULT(''),
+	created_at TIMESTAMPTZ NOT NULL DEFAULT(CURRENT_TIMESTAMP),
+	updated_at TIMESTAMPTZ NOT NULL DEFAULT(CURRENT_TIMESTAMP),
+
+	CONSTRAINT fk_projects_user_id FOREIGN KEY (user_id) REFERENCES "users" (id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_projects_user_id ON "projects" (user_id, id);
+
+ALTER TABLE "tasks"
+ADD COLUMN project_id INT,
+ADD CONSTRAINT fk_tasks_project_id FOREIGN KEY (project_id) REFERENCES "projects" (id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_tasks_project_id ON "tasks" (project_id, status_id, position, id);
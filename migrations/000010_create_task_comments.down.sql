@@ -0,0 +1,4 @@
+ALTER TABLE "tasks"
+DROP COLUMN IF EXISTS comment_count;
+
+DROP TABLE IF EXISTS "task_comments";
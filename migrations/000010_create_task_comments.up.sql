@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS "task_comments" (
+	id SERIAL PRIMARY KEY,
+	task_id INT NOT NULL,
+	user_id INT NOT NULL,
+	body TEXT NOT NULL,
+	created_at TIMESTAMPTZ NOT NULL DEFAULT(CURRENT_TIMESTAMP),
+	updated_at TIMESTAMPTZ NOT NULL DEFAULT(CURRENT_TIMESTAMP),
+	edited_at TIMESTAMPTZ,
+
+	CONSTRAINT fk_task_comments_task_id FOREIGN KEY (task_id) REFERENCES "tasks" (id) ON DELETE CASCADE,
+	CONSTRAINT fk_task_comments_user_id FOREIGN KEY (user_id) REFERENCES "users" (id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_task_comments_task_id ON "task_comments" (task_id, id);
+
+ALTER TABLE "tasks"
+ADD COLUMN comment_count INT NOT NULL DEFAULT(0);
@@ -0,0 +1,5 @@
+DROP TRIGGER IF EXISTS trigger_task_attachments_queue_orphaned_blob ON "task_attachments";
+DROP FUNCTION IF EXISTS queue_orphaned_blob;
+
+DROP TABLE IF EXISTS "orphaned_blobs";
+DROP TABLE IF EXISTS "task_attachments";
@@ -0,0 +1,34 @@
+CREATE TABLE IF NOT EXISTS "task_attachments" (
+	id SERIAL PRIMARY KEY,
+	task_id INT NOT NULL,
+	user_id INT NOT NULL,
+	filename VARCHAR(255) NOT NULL,
+	content_type VARCHAR(255) NOT NULL,
+	size BIGINT NOT NULL,
+	blob_key TEXT NOT NULL,
+	created_at TIMESTAMPTZ NOT NULL DEFAULT(CURRENT_TIMESTAMP),
+
+	CONSTRAINT fk_task_attachments_task_id FOREIGN KEY (task_id) REFERENCES "tasks" (id) ON DELETE CASCADE,
+	CONSTRAINT fk_task_attachments_user_id FOREIGN KEY (user_id) REFERENCES "users" (id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_task_attachments_task_id ON "task_attachments" (task_id, id);
+CREATE INDEX IF NOT EXISTS idx_task_attachments_user_id ON "task_attachments" (user_id);
+
+-- blobs of deleted attachments, including those removed when their task is purged,
+-- are queued here until a background job deletes them from blob storage
+CREATE TABLE IF NOT EXISTS "orphaned_blobs" (
+	blob_key TEXT PRIMARY KEY,
+	created_at TIMESTAMPTZ NOT NULL DEFAULT(CURRENT_TIMESTAMP)
+);
+
+CREATE OR REPLACE FUNCTION queue_orphaned_blob() RETURNS TRIGGER AS $$
+BEGIN
+	INSERT INTO "orphaned_blobs" (blob_key) VALUES (OLD.blob_key) ON CONFLICT DO NOTHING;
+	RETURN OLD;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trigger_task_attachments_queue_orphaned_blob
+AFTER DELETE ON "task_attachments"
+FOR EACH ROW EXECUTE FUNCTION queue_orphaned_blob();
@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS "project_members" (
+	project_id INT NOT NULL,
+	user_id INT NOT NULL,
+	role VARCHAR(32) NOT NULL,
+	created_at TIMESTAMPTZ NOT NULL DEFAULT(CURRENT_TIMESTAMP),
+	updated_at TIMESTAMPTZ NOT NULL DEFAULT(CURRENT_TIMESTAMP),
+
+	PRIMARY KEY (project_id, user_id),
+	CONSTRAINT fk_project_members_project_id FOREIGN KEY (project_id) REFERENCES "projects" (id) ON DELETE CASCADE,
+	CONSTRAINT fk_project_members_user_id FOREIGN KEY (user_id) REFERENCES "users" (id) ON DELETE CASCADE,
+	CONSTRAINT check_project_members_role CHECK (role IN ('viewer', 'editor', 'owner'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_project_members_user_id ON "project_members" (user_id, project_id);
+
+-- the creator of each existing project becomes its owner
+INSERT INTO "project_members" (project_id, user_id, role)
+SELECT id, user_id, 'owner' FROM "projects";
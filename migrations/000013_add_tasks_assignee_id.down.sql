@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_tasks_assignee_id;
+
+ALTER TABLE "tasks"
+DROP COLUMN IF EXISTS assignee_id;
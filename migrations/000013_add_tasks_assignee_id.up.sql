@@ -0,0 +1,5 @@
+ALTER TABLE "tasks"
+ADD COLUMN assignee_id INT,
+ADD CONSTRAINT fk_tasks_assignee_id FOREIGN KEY (assignee_id) REFERENCES "users" (id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_tasks_assignee_id ON "tasks" (assignee_id, id);
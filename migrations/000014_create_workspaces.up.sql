@@ -0,0 +1,85 @@
+CREATE TABLE IF NOT EXISTS "workspaces" (
+	id SERIAL PRIMARY KEY,
+	name VARCHAR(255) NOT NULL,
+	personal_user_id INT UNIQUE,
+	created_at TIMESTAMPTZ NOT NULL DEFAULT(CURRENT_TIMESTAMP),
+	updated_at TIMESTAMPTZ NOT NULL DEFAULT(CURRENT_TIMESTAMP),
+
+	CONSTRAINT fk_workspaces_personal_user_id FOREIGN KEY (personal_user_id) REFERENCES "users" (id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS "workspace_members" (
+	workspace_id INT NOT NULL,
+	user_id INT NOT NULL,
+	role VARCHAR(32) NOT NULL,
+	created_at TIMESTAMPTZ NOT NULL DEFAULT(CURRENT_TIMESTAMP),
+	updated_at TIMESTAMPTZ NOT NULL DEFAULT(CURRENT_TIMESTAMP),
+
+	PRIMARY KEY (workspace_id, user_id),
+	CONSTRAINT fk_workspace_members_workspace_id FOREIGN KEY (workspace_id) REFERENCES "workspaces" (id) ON DELETE CASCADE,
+	CONSTRAINT fk_workspace_members_user_id FOREIGN KEY (user_id) REFERENCES "users" (id) ON DELETE CASCADE,
+	CONSTRAINT check_workspace_members_role CHECK (role IN ('member', 'admin', 'owner'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_workspace_members_user_id ON "workspace_members" (user_id, workspace_id);
+
+CREATE TABLE IF NOT EXISTS "workspace_invitations" (
+	id SERIAL PRIMARY KEY,
+	workspace_id INT NOT NULL,
+	email VARCHAR(255) NOT NULL,
+	role VARCHAR(32) NOT NULL,
+	token_hash VARCHAR(64) NOT NULL UNIQUE,
+	invited_by INT NOT NULL,
+	created_at TIMESTAMPTZ NOT NULL DEFAULT(CURRENT_TIMESTAMP),
+	expires_at TIMESTAMPTZ NOT NULL,
+	accepted_at TIMESTAMPTZ,
+
+	CONSTRAINT fk_workspace_invitations_workspace_id FOREIGN KEY (workspace_id) REFERENCES "workspaces" (id) ON DELETE CASCADE,
+	CONSTRAINT fk_workspace_invitations_invited_by FOREIGN KEY (invited_by) REFERENCES "users" (id) ON DELETE CASCADE,
+	CONSTRAINT check_workspace_invitations_role CHECK (role IN ('member', 'admin', 'owner'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_workspace_invitations_workspace_id ON "workspace_invitations" (workspace_id, id);
+
+-- every user gets a personal workspace that holds their existing projects and tasks
+INSERT INTO "workspaces" (name, personal_user_id)
+SELECT 'Personal', id FROM "users";
+
+INSERT INTO "workspace_members" (workspace_id, user_id, role)
+SELECT id, personal_user_id, 'owner' FROM "workspaces";
+
+ALTER TABLE "projects"
+ADD COLUMN workspace_id INT;
+
+UPDATE "projects" AS p
+SET workspace_id = w.id
+FROM "workspaces" AS w
+WHERE w.personal_user_id = p.user_id;
+
+ALTER TABLE "projects"
+ALTER COLUMN workspace_id SET NOT NULL,
+ADD CONSTRAINT fk_projects_workspace_id FOREIGN KEY (workspace_id) REFERENCES "workspaces" (id) ON DELETE CASCADE;
+
+CREATE INDEX IF NOT EXISTS idx_projects_workspace_id ON "projects" (workspace_id, id);
+
+-- members of shared projects keep their access by joining the project's workspace
+INSERT INTO "workspace_members" (workspace_id, user_id, role)
+SELECT DISTINCT p.workspace_id, m.user_id, 'member'
+FROM "project_members" AS m
+JOIN "projects" AS p ON p.id = m.project_id
+ON CONFLICT DO NOTHING;
+
+ALTER TABLE "tasks"
+ADD COLUMN workspace_id INT;
+
+UPDATE "tasks" AS t
+SET workspace_id = COALESCE(
+	(SELECT p.workspace_id FROM "projects" AS p WHERE p.id = t.project_id),
+	(SELECT w.id FROM "workspaces" AS w WHERE w.personal_user_id = t.user_id)
+);
+
+ALTER TABLE "tasks"
+ALTER COLUMN workspace_id SET NOT NULL,
+ADD CONSTRAINT fk_tasks_workspace_id FOREIGN KEY (workspace_id) REFERENCES "workspaces" (id) ON DELETE CASCADE;
+
+CREATE INDEX IF NOT EXISTS idx_tasks_workspace_id ON "tasks" (workspace_id, id);
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS "notification_preferences";
+
+DROP INDEX IF EXISTS idx_notifications_user_id_unread;
+DROP INDEX IF EXISTS idx_notifications_user_id;
+DROP TABLE IF EXISTS "notifications";
@@ -0,0 +1,29 @@
+CREATE TABLE IF NOT EXISTS "notifications" (
+	id SERIAL PRIMARY KEY,
+	user_id INT NOT NULL,
+	type VARCHAR(64) NOT NULL,
+	task_id INT NOT NULL,
+	task_title VARCHAR(255) NOT NULL DEFAULT(''),
+	actor_id INT,
+	read_at TIMESTAMPTZ,
+	created_at TIMESTAMPTZ NOT NULL DEFAULT(CURRENT_TIMESTAMP),
+
+	CONSTRAINT fk_notifications_user_id FOREIGN KEY (user_id) REFERENCES "users" (id) ON DELETE CASCADE,
+	CONSTRAINT fk_notifications_task_id FOREIGN KEY (task_id) REFERENCES "tasks" (id) ON DELETE CASCADE,
+	CONSTRAINT fk_notifications_actor_id FOREIGN KEY (actor_id) REFERENCES "users" (id) ON DELETE SET NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_notifications_user_id ON "notifications" (user_id, id);
+CREATE INDEX IF NOT EXISTS idx_notifications_user_id_unread ON "notifications" (user_id, id) WHERE read_at IS NULL;
+
+-- notifications are delivered on every channel unless the user turned it off here
+CREATE TABLE IF NOT EXISTS "notification_preferences" (
+	user_id INT NOT NULL,
+	type VARCHAR(64) NOT NULL,
+	channel VARCHAR(32) NOT NULL,
+	enabled BOOLEAN NOT NULL,
+	updated_at TIMESTAMPTZ NOT NULL DEFAULT(CURRENT_TIMESTAMP),
+
+	PRIMARY KEY (user_id, type, channel),
+	CONSTRAINT fk_notification_preferences_user_id FOREIGN KEY (user_id) REFERENCES "users" (id) ON DELETE CASCADE
+);
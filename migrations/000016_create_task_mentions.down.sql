@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_task_mentions_user_id;
+DROP INDEX IF EXISTS idx_task_mentions_task_id_comment_id_user_id;
+DROP TABLE IF EXISTS "task_mentions";
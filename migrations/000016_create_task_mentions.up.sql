@@ -0,0 +1,15 @@
+-- users mentioned in a task's description, or in one of its comments when comment_id is set
+CREATE TABLE IF NOT EXISTS "task_mentions" (
+	id SERIAL PRIMARY KEY,
+	task_id INT NOT NULL,
+	comment_id INT,
+	user_id INT NOT NULL,
+	created_at TIMESTAMPTZ NOT NULL DEFAULT(CURRENT_TIMESTAMP),
+
+	CONSTRAINT fk_task_mentions_task_id FOREIGN KEY (task_id) REFERENCES "tasks" (id) ON DELETE CASCADE,
+	CONSTRAINT fk_task_mentions_comment_id FOREIGN KEY (comment_id) REFERENCES "task_comments" (id) ON DELETE CASCADE,
+	CONSTRAINT fk_task_mentions_user_id FOREIGN KEY (user_id) REFERENCES "users" (id) ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_task_mentions_task_id_comment_id_user_id ON "task_mentions" (task_id, COALESCE(comment_id, 0), user_id);
+CREATE INDEX IF NOT EXISTS idx_task_mentions_user_id ON "task_mentions" (user_id, task_id);
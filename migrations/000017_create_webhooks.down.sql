@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_webhook_deliveries_next_attempt_at;
+DROP INDEX IF EXISTS idx_webhook_deliveries_webhook_id;
+DROP TABLE IF EXISTS "webhook_deliveries";
+
+DROP INDEX IF EXISTS idx_webhooks_user_id;
+DROP TABLE IF EXISTS "webhooks";
@@ -0,0 +1,37 @@
+CREATE TABLE IF NOT EXISTS "webhooks" (
+	id SERIAL PRIMARY KEY,
+	user_id INT NOT NULL,
+	url TEXT NOT NULL,
+	secret VARCHAR(255) NOT NULL,
+	event_types TEXT[] NOT NULL,
+	is_active BOOLEAN NOT NULL DEFAULT(TRUE),
+	created_at TIMESTAMPTZ NOT NULL DEFAULT(CURRENT_TIMESTAMP),
+	updated_at TIMESTAMPTZ NOT NULL DEFAULT(CURRENT_TIMESTAMP),
+
+	CONSTRAINT fk_webhooks_user_id FOREIGN KEY (user_id) REFERENCES "users" (id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhooks_user_id ON "webhooks" (user_id);
+
+-- deliveries are written in the same transaction as the task change that caused
+-- them, which makes this table the outbox the delivery worker reads from as well
+-- as the log of every attempt
+CREATE TABLE IF NOT EXISTS "webhook_deliveries" (
+	id SERIAL PRIMARY KEY,
+	webhook_id INT NOT NULL,
+	event_type VARCHAR(64) NOT NULL,
+	payload JSONB NOT NULL,
+	status VARCHAR(32) NOT NULL DEFAULT('pending'),
+	attempts INT NOT NULL DEFAULT(0),
+	next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT(CURRENT_TIMESTAMP),
+	last_attempt_at TIMESTAMPTZ,
+	response_status INT,
+	response_body TEXT NOT NULL DEFAULT(''),
+	error TEXT NOT NULL DEFAULT(''),
+	created_at TIMESTAMPTZ NOT NULL DEFAULT(CURRENT_TIMESTAMP),
+
+	CONSTRAINT fk_webhook_deliveries_webhook_id FOREIGN KEY (webhook_id) REFERENCES "webhooks" (id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_webhook_id ON "webhook_deliveries" (webhook_id, id);
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_next_attempt_at ON "webhook_deliveries" (next_attempt_at) WHERE status = 'pending';
@@ -0,0 +1,13 @@
+-- wakes up the event streams of every API instance when task events are
+-- committed. Streams read the new events from task_events themselves, so one
+-- notification per statement is enough.
+CREATE OR REPLACE FUNCTION notify_task_events() RETURNS TRIGGER AS $$
+BEGIN
+	PERFORM pg_notify('task_events', '');
+	RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_task_events_notify
+AFTER INSERT ON "task_events"
+FOR EACH STATEMENT EXECUTE FUNCTION notify_task_events();
@@ -0,0 +1,5 @@
+DROP TRIGGER IF EXISTS trg_tasks_bump_version ON "tasks";
+DROP FUNCTION IF EXISTS bump_task_version();
+
+ALTER TABLE "tasks"
+DROP COLUMN IF EXISTS version;
@@ -0,0 +1,20 @@
+ALTER TABLE "tasks"
+ADD COLUMN IF NOT EXISTS version INT NOT NULL DEFAULT(1);
+
+-- bumps the version of a task whenever it changes, so that clients can tell
+-- whether they are editing its latest version. Comment counts are bookkeeping
+-- and don't count as a change.
+CREATE OR REPLACE FUNCTION bump_task_version() RETURNS TRIGGER AS $$
+BEGIN
+	IF to_jsonb(NEW) - 'version' - 'updated_at' - 'comment_count' IS DISTINCT FROM to_jsonb(OLD) - 'version' - 'updated_at' - 'comment_count' THEN
+		NEW.version := OLD.version + 1;
+	ELSE
+		NEW.version := OLD.version;
+	END IF;
+	RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_tasks_bump_version
+BEFORE UPDATE ON "tasks"
+FOR EACH ROW EXECUTE FUNCTION bump_task_version();
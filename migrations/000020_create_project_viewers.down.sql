@@ -0,0 +1,5 @@
+DROP TRIGGER IF EXISTS trg_project_viewers_notify ON "project_viewers";
+DROP FUNCTION IF EXISTS notify_project_viewers();
+
+DROP INDEX IF EXISTS idx_project_viewers_project_id;
+DROP TABLE IF EXISTS "project_viewers";
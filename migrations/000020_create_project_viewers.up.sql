@@ -0,0 +1,28 @@
+-- who is viewing a project over a websocket, kept per connection. Rows are
+-- refreshed while the connection is open, rows that weren't refreshed in a
+-- while belong to connections that were lost and are cleaned up.
+CREATE UNLOGGED TABLE IF NOT EXISTS "project_viewers" (
+	session_id VARCHAR(64) NOT NULL,
+	project_id INT NOT NULL,
+	user_id INT NOT NULL,
+	last_seen_at TIMESTAMPTZ NOT NULL DEFAULT(CURRENT_TIMESTAMP),
+
+	PRIMARY KEY (session_id, project_id),
+	CONSTRAINT fk_project_viewers_project_id FOREIGN KEY (project_id) REFERENCES "projects" (id) ON DELETE CASCADE,
+	CONSTRAINT fk_project_viewers_user_id FOREIGN KEY (user_id) REFERENCES "users" (id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_project_viewers_project_id ON "project_viewers" (project_id);
+
+-- wakes up the websockets of every API instance when someone starts or stops
+-- viewing a project
+CREATE OR REPLACE FUNCTION notify_project_viewers() RETURNS TRIGGER AS $$
+BEGIN
+	PERFORM pg_notify('project_viewers', '');
+	RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_project_viewers_notify
+AFTER INSERT OR DELETE ON "project_viewers"
+FOR EACH STATEMENT EXECUTE FUNCTION notify_project_viewers();
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_tasks_tags;
+
+ALTER TABLE "tasks"
+DROP CONSTRAINT IF EXISTS check_tasks_priority,
+DROP COLUMN IF EXISTS tags,
+DROP COLUMN IF EXISTS priority,
+DROP COLUMN IF EXISTS due_at;
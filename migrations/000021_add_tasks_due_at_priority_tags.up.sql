@@ -0,0 +1,7 @@
+ALTER TABLE "tasks"
+ADD COLUMN IF NOT EXISTS due_at TIMESTAMPTZ,
+ADD COLUMN IF NOT EXISTS priority VARCHAR(16) NOT NULL DEFAULT('none'),
+ADD COLUMN IF NOT EXISTS tags TEXT[] NOT NULL DEFAULT('{}'),
+ADD CONSTRAINT check_tasks_priority CHECK (priority IN ('none', 'low', 'medium', 'high', 'urgent'));
+
+CREATE INDEX IF NOT EXISTS idx_tasks_tags ON "tasks" USING GIN (tags);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_imports_unfinished;
+DROP INDEX IF EXISTS idx_imports_user_id;
+DROP TABLE IF EXISTS "imports";
@@ -0,0 +1,26 @@
+-- an import keeps the parsed tasks until the import job has created them, with
+-- project_ids mapping the names of the projects it created to their ids so a
+-- job that is picked up again doesn't create them twice
+CREATE TABLE IF NOT EXISTS "imports" (
+	id SERIAL PRIMARY KEY,
+	user_id INT NOT NULL,
+	workspace_id INT NOT NULL,
+	format VARCHAR(32) NOT NULL,
+	status VARCHAR(32) NOT NULL DEFAULT('pending'),
+	data JSONB NOT NULL,
+	project_ids JSONB NOT NULL DEFAULT('{}'),
+	total INT NOT NULL,
+	processed INT NOT NULL DEFAULT(0),
+	error TEXT NOT NULL DEFAULT(''),
+	leased_until TIMESTAMPTZ,
+	created_at TIMESTAMPTZ NOT NULL DEFAULT(CURRENT_TIMESTAMP),
+	updated_at TIMESTAMPTZ NOT NULL DEFAULT(CURRENT_TIMESTAMP),
+	completed_at TIMESTAMPTZ,
+
+	CONSTRAINT fk_imports_user_id FOREIGN KEY (user_id) REFERENCES "users" (id) ON DELETE CASCADE,
+	CONSTRAINT fk_imports_workspace_id FOREIGN KEY (workspace_id) REFERENCES "workspaces" (id) ON DELETE CASCADE,
+	CONSTRAINT chk_imports_status CHECK (status IN ('pending', 'running', 'completed', 'failed'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_imports_user_id ON "imports" (user_id);
+CREATE INDEX IF NOT EXISTS idx_imports_unfinished ON "imports" (id) WHERE status IN ('pending', 'running');
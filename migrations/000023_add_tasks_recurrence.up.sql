@@ -0,0 +1,3 @@
+-- recurrence is an iCalendar RRULE, empty for tasks that don't repeat
+ALTER TABLE "tasks"
+ADD COLUMN IF NOT EXISTS recurrence TEXT NOT NULL DEFAULT('');
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_tasks_due_at;
+DROP INDEX IF EXISTS idx_calendar_feeds_token_hash;
+DROP TABLE IF EXISTS "calendar_feeds";
@@ -0,0 +1,15 @@
+-- a secret url a user can subscribe to their tasks in a workspace with, only
+-- the hash of its token is stored
+CREATE TABLE IF NOT EXISTS "calendar_feeds" (
+	user_id INT NOT NULL,
+	workspace_id INT NOT NULL,
+	token_hash VARCHAR(64) NOT NULL,
+	created_at TIMESTAMPTZ NOT NULL DEFAULT(CURRENT_TIMESTAMP),
+
+	PRIMARY KEY (user_id, workspace_id),
+	CONSTRAINT fk_calendar_feeds_user_id FOREIGN KEY (user_id) REFERENCES "users" (id) ON DELETE CASCADE,
+	CONSTRAINT fk_calendar_feeds_workspace_id FOREIGN KEY (workspace_id) REFERENCES "workspaces" (id) ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_calendar_feeds_token_hash ON "calendar_feeds" (token_hash);
+CREATE INDEX IF NOT EXISTS idx_tasks_due_at ON "tasks" (workspace_id, due_at) WHERE due_at IS NOT NULL AND deleted_at IS NULL;
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_tasks_workspace_id_uid;
+
+ALTER TABLE "tasks"
+DROP COLUMN IF EXISTS uid;
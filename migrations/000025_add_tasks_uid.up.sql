@@ -0,0 +1,6 @@
+-- uid is the iCalendar UID of tasks created by calendar clients, which name
+-- them after it
+ALTER TABLE "tasks"
+ADD COLUMN IF NOT EXISTS uid VARCHAR(255);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_tasks_workspace_id_uid ON "tasks" (workspace_id, uid) WHERE uid IS NOT NULL;
@@ -0,0 +1,6 @@
+-- parent_id makes a task a subtask of another, subtasks of a purged task are
+-- kept as tasks of their own
+ALTER TABLE "tasks"
+ADD COLUMN IF NOT EXISTS parent_id INT REFERENCES "tasks"(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_tasks_parent_id ON "tasks" (parent_id);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_task_templates_user_id;
+DROP TABLE IF EXISTS "task_templates";
@@ -0,0 +1,16 @@
+-- a template keeps a task and its subtasks as a tree, with due dates as days
+-- after the date the template is instantiated for
+CREATE TABLE IF NOT EXISTS "task_templates" (
+	id SERIAL PRIMARY KEY,
+	user_id INT NOT NULL,
+	workspace_id INT NOT NULL,
+	name VARCHAR(255) NOT NULL,
+	task JSONB NOT NULL,
+	created_at TIMESTAMPTZ NOT NULL DEFAULT(CURRENT_TIMESTAMP),
+	updated_at TIMESTAMPTZ NOT NULL DEFAULT(CURRENT_TIMESTAMP),
+
+	CONSTRAINT fk_task_templates_user_id FOREIGN KEY (user_id) REFERENCES "users" (id) ON DELETE CASCADE,
+	CONSTRAINT fk_task_templates_workspace_id FOREIGN KEY (workspace_id) REFERENCES "workspaces" (id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_task_templates_user_id ON "task_templates" (user_id, workspace_id);
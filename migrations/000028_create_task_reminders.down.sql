@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_tasks_due_at_pending;
+DROP TABLE IF EXISTS "task_reminders";
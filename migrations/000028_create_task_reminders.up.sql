@@ -0,0 +1,17 @@
+-- reminders belong with the notifications of 000015, but they follow 000027
+-- since they need the due dates added in 000021, and migrations that have
+-- already run can't be renumbered.
+--
+-- a reminder is sent once for each due date a task has, so pushing a task
+-- back reminds its users again
+CREATE TABLE IF NOT EXISTS "task_reminders" (
+	task_id INT NOT NULL,
+	due_at TIMESTAMPTZ NOT NULL,
+	created_at TIMESTAMPTZ NOT NULL DEFAULT(CURRENT_TIMESTAMP),
+
+	PRIMARY KEY (task_id, due_at),
+	CONSTRAINT fk_task_reminders_task_id FOREIGN KEY (task_id) REFERENCES "tasks" (id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_tasks_due_at_pending ON "tasks" (due_at)
+WHERE due_at IS NOT NULL AND NOT is_completed AND deleted_at IS NULL AND archived_at IS NULL;
@@ -0,0 +1,2 @@
+DROP FUNCTION IF EXISTS visible_tasks(INT, INT);
+DROP FUNCTION IF EXISTS task_visible_to(INT, INT, INT, INT);
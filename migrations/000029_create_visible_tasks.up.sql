@@ -0,0 +1,16 @@
+-- task_visible_to tells whether viewer_id can see a task of the workspace.
+-- Tasks outside of projects are only visible to their creator, project tasks
+-- to the project's members and the workspace's admins.
+CREATE OR REPLACE FUNCTION task_visible_to(task_workspace_id INT, task_project_id INT, task_user_id INT, viewer_id INT) RETURNS BOOLEAN AS $$
+	SELECT CASE
+		WHEN task_project_id IS NULL THEN task_user_id = viewer_id
+		ELSE EXISTS (SELECT 1 FROM "project_members" WHERE project_id = task_project_id AND user_id = viewer_id)
+			OR EXISTS (SELECT 1 FROM "workspace_members" WHERE workspace_id = task_workspace_id AND user_id = viewer_id AND role IN ('admin', 'owner'))
+	END;
+$$ LANGUAGE sql STABLE;
+
+-- visible_tasks returns the tasks of the workspace viewer_id can see
+CREATE OR REPLACE FUNCTION visible_tasks(viewer_workspace_id INT, viewer_id INT) RETURNS SETOF "tasks" AS $$
+	SELECT * FROM "tasks"
+	WHERE workspace_id = viewer_workspace_id AND task_visible_to(workspace_id, project_id, user_id, viewer_id);
+$$ LANGUAGE sql STABLE;
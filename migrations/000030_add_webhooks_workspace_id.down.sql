@@ -0,0 +1,10 @@
+DROP INDEX IF EXISTS idx_webhooks_workspace_id;
+CREATE INDEX IF NOT EXISTS idx_webhooks_user_id ON "webhooks" (user_id);
+
+-- the copies made for other workspaces would send every event twice
+DELETE FROM "webhooks" AS h
+USING "workspaces" AS w
+WHERE w.id = h.workspace_id AND w.personal_user_id IS DISTINCT FROM h.user_id;
+
+ALTER TABLE "webhooks"
+DROP COLUMN IF EXISTS workspace_id;
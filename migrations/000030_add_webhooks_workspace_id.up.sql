@@ -0,0 +1,23 @@
+-- webhooks only receive the events of the workspace they were created in.
+-- Existing webhooks received the events of every workspace their user could
+-- see, so they are copied into each of those workspaces.
+ALTER TABLE "webhooks"
+ADD COLUMN workspace_id INT;
+
+UPDATE "webhooks" AS h
+SET workspace_id = w.id
+FROM "workspaces" AS w
+WHERE w.personal_user_id = h.user_id;
+
+INSERT INTO "webhooks" (user_id, workspace_id, url, secret, event_types, is_active)
+SELECT h.user_id, m.workspace_id, h.url, h.secret, h.event_types, h.is_active
+FROM "webhooks" AS h
+JOIN "workspace_members" AS m ON m.user_id = h.user_id AND m.workspace_id <> h.workspace_id;
+
+-- leaving a workspace removes the webhooks made in it
+ALTER TABLE "webhooks"
+ALTER COLUMN workspace_id SET NOT NULL,
+ADD CONSTRAINT fk_webhooks_workspace_member FOREIGN KEY (workspace_id, user_id) REFERENCES "workspace_members" (workspace_id, user_id) ON DELETE CASCADE;
+
+DROP INDEX IF EXISTS idx_webhooks_user_id;
+CREATE INDEX IF NOT EXISTS idx_webhooks_workspace_id ON "webhooks" (workspace_id, user_id);
@@ -0,0 +1,26 @@
+-- tasks go back to the statuses of the same name in their user's personal workspace
+UPDATE "tasks" AS t
+SET status_id = p.id
+FROM "task_statuses" AS s, "task_statuses" AS p, "workspaces" AS w
+WHERE s.id = t.status_id AND w.personal_user_id = s.user_id AND w.id <> s.workspace_id
+	AND p.user_id = s.user_id AND p.workspace_id = w.id AND p.name = s.name;
+
+-- or to the first one of the same category for statuses made in other workspaces
+UPDATE "tasks" AS t
+SET status_id = (
+	SELECT p.id FROM "task_statuses" AS p
+	WHERE p.user_id = s.user_id AND p.workspace_id = w.id AND p.category = s.category
+	ORDER BY p.position, p.id
+	LIMIT 1
+)
+FROM "task_statuses" AS s, "workspaces" AS w
+WHERE s.id = t.status_id AND w.personal_user_id = s.user_id AND w.id <> s.workspace_id;
+
+DELETE FROM "task_statuses" AS s
+USING "workspaces" AS w
+WHERE w.id = s.workspace_id AND w.personal_user_id IS DISTINCT FROM s.user_id;
+
+ALTER TABLE "task_statuses"
+DROP CONSTRAINT unique_task_statuses_name,
+ADD CONSTRAINT unique_task_statuses_name UNIQUE (user_id, name),
+DROP COLUMN IF EXISTS workspace_id;
@@ -0,0 +1,31 @@
+-- statuses belong to a user within a workspace, like the tasks using them.
+-- Existing statuses move to their user's personal workspace and are copied
+-- into every other workspace the user is a member of or has tasks using them in.
+ALTER TABLE "task_statuses"
+ADD COLUMN workspace_id INT;
+
+UPDATE "task_statuses" AS s
+SET workspace_id = w.id
+FROM "workspaces" AS w
+WHERE w.personal_user_id = s.user_id;
+
+INSERT INTO "task_statuses" (user_id, workspace_id, name, category, position)
+SELECT s.user_id, p.workspace_id, s.name, s.category, s.position
+FROM "task_statuses" AS s
+JOIN (
+	SELECT user_id, workspace_id FROM "workspace_members"
+	UNION
+	SELECT ts.user_id, t.workspace_id FROM "tasks" AS t JOIN "task_statuses" AS ts ON ts.id = t.status_id
+) AS p ON p.user_id = s.user_id AND p.workspace_id <> s.workspace_id;
+
+UPDATE "tasks" AS t
+SET status_id = c.id
+FROM "task_statuses" AS s, "task_statuses" AS c
+WHERE s.id = t.status_id AND s.workspace_id <> t.workspace_id
+	AND c.user_id = s.user_id AND c.workspace_id = t.workspace_id AND c.name = s.name;
+
+ALTER TABLE "task_statuses"
+ALTER COLUMN workspace_id SET NOT NULL,
+ADD CONSTRAINT fk_task_statuses_workspace_id FOREIGN KEY (workspace_id) REFERENCES "workspaces" (id) ON DELETE CASCADE,
+DROP CONSTRAINT unique_task_statuses_name,
+ADD CONSTRAINT unique_task_statuses_name UNIQUE (workspace_id, user_id, name);
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_task_events_xact_id;
+
+ALTER TABLE "task_events"
+DROP COLUMN IF EXISTS xact_id;
@@ -0,0 +1,10 @@
+-- events are read in the order of the transactions that recorded them, see
+-- GetTaskEventWatermark. The events recorded so far have all committed, in
+-- the order of their ids, so they come before any new ones.
+ALTER TABLE "task_events"
+ADD COLUMN xact_id BIGINT NOT NULL DEFAULT 0;
+
+ALTER TABLE "task_events"
+ALTER COLUMN xact_id SET DEFAULT (pg_current_xact_id()::text::bigint);
+
+CREATE INDEX IF NOT EXISTS idx_task_events_xact_id ON "task_events" (xact_id, id);
@@ -0,0 +1,13 @@
+DROP INDEX IF EXISTS idx_task_events_workspace_id;
+CREATE INDEX IF NOT EXISTS idx_task_events_xact_id ON "task_events" (xact_id, id);
+
+DELETE FROM "task_events"
+WHERE viewer_id IS NOT NULL;
+
+ALTER TABLE "task_events"
+DROP CONSTRAINT IF EXISTS fk_task_events_viewer_id,
+DROP CONSTRAINT IF EXISTS fk_task_events_workspace_id,
+DROP COLUMN IF EXISTS viewer_id,
+DROP COLUMN IF EXISTS previous_project_id,
+DROP COLUMN IF EXISTS project_id,
+DROP COLUMN IF EXISTS workspace_id;
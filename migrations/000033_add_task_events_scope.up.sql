@@ -0,0 +1,31 @@
+-- events record where their task was when they were recorded, so who they
+-- are sent to doesn't depend on the task as it is now. previous_project_id is
+-- the project the task was in before the change, its members are sent the
+-- event too so that they learn the task was moved away from them.
+-- viewer_id is set on events that are only about, and for, a single user,
+-- such as them no longer being able to see the task.
+ALTER TABLE "task_events"
+ADD COLUMN workspace_id INT,
+ADD COLUMN project_id INT,
+ADD COLUMN previous_project_id INT,
+ADD COLUMN viewer_id INT;
+
+UPDATE "task_events" AS e
+SET workspace_id = t.workspace_id, project_id = t.project_id, previous_project_id = t.project_id
+FROM "tasks" AS t
+WHERE t.id = e.task_id;
+
+-- the tasks of the remaining events were purged, they were only sent to
+-- their creator
+UPDATE "task_events" AS e
+SET workspace_id = w.id
+FROM "workspaces" AS w
+WHERE e.workspace_id IS NULL AND w.personal_user_id = e.user_id;
+
+ALTER TABLE "task_events"
+ALTER COLUMN workspace_id SET NOT NULL,
+ADD CONSTRAINT fk_task_events_workspace_id FOREIGN KEY (workspace_id) REFERENCES "workspaces" (id) ON DELETE CASCADE,
+ADD CONSTRAINT fk_task_events_viewer_id FOREIGN KEY (viewer_id) REFERENCES "users" (id) ON DELETE CASCADE;
+
+DROP INDEX IF EXISTS idx_task_events_xact_id;
+CREATE INDEX IF NOT EXISTS idx_task_events_workspace_id ON "task_events" (workspace_id, xact_id, id);